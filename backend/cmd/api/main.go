@@ -14,6 +14,9 @@ import (
 	"github.com/kyleaupton/arrflix/internal/http"
 	downloadworker "github.com/kyleaupton/arrflix/internal/jobs/download"
 	importworker "github.com/kyleaupton/arrflix/internal/jobs/import"
+	metadataworker "github.com/kyleaupton/arrflix/internal/jobs/metadata"
+	requestsworker "github.com/kyleaupton/arrflix/internal/jobs/requests"
+	searchworker "github.com/kyleaupton/arrflix/internal/jobs/search"
 	"github.com/kyleaupton/arrflix/internal/logger"
 	"github.com/kyleaupton/arrflix/internal/repo"
 	"github.com/kyleaupton/arrflix/internal/service"
@@ -69,12 +72,18 @@ func main() {
 		}
 	}()
 
-	// Download and import workers
+	// Download, import, search, metadata and requests workers
 	workerCtx, workerCancel := context.WithCancel(context.Background())
 	dlWorker := downloadworker.New(repo, downloaderManager, logg, broker)
-	impWorker := importworker.New(repo, downloaderManager, logg, broker)
+	impWorker := importworker.New(repo, downloaderManager, services.Settings, services.Media, services.AutoSearch, logg, broker)
+	searchWorker := searchworker.New(services.AutoSearch, logg)
+	metadataWorker := metadataworker.New(services.Metadata, logg)
+	requestsWorker := requestsworker.New(services.MediaRequests, logg)
 	go dlWorker.Run(workerCtx)
 	go impWorker.Run(workerCtx)
+	go searchWorker.Run(workerCtx)
+	go metadataWorker.Run(workerCtx)
+	go requestsWorker.Run(workerCtx)
 
 	// Graceful shutdown
 	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
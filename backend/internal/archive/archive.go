@@ -0,0 +1,258 @@
+// Package archive detects and extracts the RAR and ZIP archives releases
+// often arrive in. ZIPs are extracted in-process; RARs use the unrar CLI
+// binary.
+package archive
+
+import (
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/rs/zerolog"
+)
+
+var (
+	// ErrPassword is returned for encrypted archives. Releases that need a
+	// password are almost always fakes.
+	ErrPassword = errors.New("archive is password protected")
+	// ErrCorrupt is returned when an archive fails its checksums or a volume
+	// is missing.
+	ErrCorrupt = errors.New("archive is corrupt or incomplete")
+)
+
+var (
+	partVolume = regexp.MustCompile(`(?i)\.part(\d+)\.rar$`)
+	oldVolume  = regexp.MustCompile(`(?i)\.r\d{2}$`)
+)
+
+// IsArchive reports whether p is a volume of a RAR or ZIP archive.
+func IsArchive(p string) bool {
+	ext := strings.ToLower(filepath.Ext(p))
+	return ext == ".rar" || ext == ".zip" || oldVolume.MatchString(p)
+}
+
+// IsFirstVolume reports whether p is the volume extraction starts from:
+// "x.zip", "x.rar" or "x.part01.rar". Later volumes ("x.part02.rar",
+// "x.r00") are read through the first.
+func IsFirstVolume(p string) bool {
+	if m := partVolume.FindStringSubmatch(p); m != nil {
+		n, _ := strconv.Atoi(m[1])
+		return n == 1
+	}
+	ext := strings.ToLower(filepath.Ext(p))
+	return ext == ".rar" || ext == ".zip"
+}
+
+// SetName returns the name shared by every volume of the archive p belongs
+// to, without directory or volume suffix.
+func SetName(p string) string {
+	base := filepath.Base(p)
+	if loc := partVolume.FindStringIndex(base); loc != nil {
+		return base[:loc[0]]
+	}
+	return strings.TrimSuffix(base, filepath.Ext(base))
+}
+
+// Extractor unpacks archives.
+type Extractor struct {
+	// unrarPath is the path to the unrar binary
+	unrarPath string
+	// logger for error reporting
+	log zerolog.Logger
+}
+
+// NewExtractor creates a new extractor.
+// By default, it looks for unrar in the PATH.
+func NewExtractor(log zerolog.Logger) *Extractor {
+	return &Extractor{
+		unrarPath: "unrar",
+		log:       log,
+	}
+}
+
+// WithUnrarPath sets a custom path to the unrar binary.
+func (e *Extractor) WithUnrarPath(path string) *Extractor {
+	e.unrarPath = path
+	return e
+}
+
+// Extract unpacks the archive whose first volume is path into dest.
+// progress, when set, is called with the percentage done as it grows.
+func (e *Extractor) Extract(ctx context.Context, path, dest string, progress func(percent int)) error {
+	if progress == nil {
+		progress = func(int) {}
+	}
+	if err := os.MkdirAll(dest, 0o755); err != nil {
+		return fmt.Errorf("create staging dir: %w", err)
+	}
+	if strings.EqualFold(filepath.Ext(path), ".zip") {
+		return extractZip(ctx, path, dest, progress)
+	}
+	return e.extractRar(ctx, path, dest, progress)
+}
+
+// unrar exit codes, see unrar's errhnd.hpp
+const (
+	unrarCRCError    = 3
+	unrarOpenError   = 6
+	unrarNoFiles     = 10
+	unrarBadPassword = 11
+)
+
+var percentRe = regexp.MustCompile(`(\d{1,3})%`)
+
+func (e *Extractor) extractRar(ctx context.Context, path, dest string, progress func(int)) error {
+	// x: keep paths, -o+: overwrite (a retry finishes a partial extraction),
+	// -p-: never prompt for a password, -y: assume yes
+	cmd := exec.CommandContext(ctx, e.unrarPath, "x", "-o+", "-p-", "-y", path, dest+string(filepath.Separator))
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return fmt.Errorf("unrar stdout: %w", err)
+	}
+	if err := cmd.Start(); err != nil {
+		if errors.Is(err, exec.ErrNotFound) {
+			return fmt.Errorf("unrar not found, install it to import RAR releases: %w", err)
+		}
+		return fmt.Errorf("start unrar: %w", err)
+	}
+
+	// unrar redraws its percentage with backspaces; read it as it comes
+	var output strings.Builder
+	last := -1
+	scanner := bufio.NewScanner(stdout)
+	scanner.Split(splitProgress)
+	for scanner.Scan() {
+		line := scanner.Text()
+		output.WriteString(line)
+		output.WriteByte('\n')
+		for _, m := range percentRe.FindAllStringSubmatch(line, -1) {
+			if p, _ := strconv.Atoi(m[1]); p > last && p <= 100 {
+				last = p
+				progress(p)
+			}
+		}
+	}
+
+	err = cmd.Wait()
+	if err == nil {
+		return nil
+	}
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	msg := strings.TrimSpace(stderr.String())
+	lower := strings.ToLower(msg + output.String())
+	var exitErr *exec.ExitError
+	code := -1
+	if errors.As(err, &exitErr) {
+		code = exitErr.ExitCode()
+	}
+	switch {
+	case code == unrarBadPassword || strings.Contains(lower, "password") || strings.Contains(lower, "encrypted"):
+		return ErrPassword
+	case code == unrarCRCError || code == unrarOpenError || code == unrarNoFiles || strings.Contains(lower, "cannot find volume"):
+		return fmt.Errorf("%w: %s", ErrCorrupt, msg)
+	}
+	e.log.Debug().Str("path", path).Str("output", output.String()).Msg("unrar failed")
+	return fmt.Errorf("unrar: %w: %s", err, msg)
+}
+
+// splitProgress splits unrar output on newlines, carriage returns and
+// backspaces.
+func splitProgress(data []byte, atEOF bool) (int, []byte, error) {
+	for i, b := range data {
+		if b == '\n' || b == '\r' || b == '\b' {
+			return i + 1, data[:i], nil
+		}
+	}
+	if atEOF && len(data) > 0 {
+		return len(data), data, nil
+	}
+	return 0, nil, nil
+}
+
+func extractZip(ctx context.Context, path, dest string, progress func(int)) error {
+	r, err := zip.OpenReader(path)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrCorrupt, err)
+	}
+	defer r.Close()
+
+	var total, done uint64
+	for _, f := range r.File {
+		if f.Flags&0x1 != 0 {
+			return ErrPassword
+		}
+		total += f.UncompressedSize64
+	}
+
+	last := -1
+	for _, f := range r.File {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		target := filepath.Join(dest, filepath.FromSlash(f.Name))
+		if rel, err := filepath.Rel(dest, target); err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			return fmt.Errorf("%w: entry %q escapes the staging dir", ErrCorrupt, f.Name)
+		}
+		if f.FileInfo().IsDir() {
+			if err := os.MkdirAll(target, 0o755); err != nil {
+				return fmt.Errorf("mkdir: %w", err)
+			}
+			continue
+		}
+		n, err := extractZipFile(f, target)
+		if err != nil {
+			return err
+		}
+		done += n
+		if total > 0 {
+			if p := int(done * 100 / total); p > last {
+				last = p
+				progress(p)
+			}
+		}
+	}
+	return nil
+}
+
+func extractZipFile(f *zip.File, target string) (uint64, error) {
+	if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+		return 0, fmt.Errorf("mkdir: %w", err)
+	}
+	in, err := f.Open()
+	if err != nil {
+		return 0, fmt.Errorf("%w: %v", ErrCorrupt, err)
+	}
+	defer in.Close()
+
+	out, err := os.Create(target)
+	if err != nil {
+		return 0, fmt.Errorf("create %s: %w", f.Name, err)
+	}
+	n, copyErr := io.Copy(out, in)
+	closeErr := out.Close()
+	if errors.Is(copyErr, zip.ErrChecksum) {
+		return 0, fmt.Errorf("%w: %s: %v", ErrCorrupt, f.Name, copyErr)
+	}
+	if copyErr != nil {
+		return 0, fmt.Errorf("extract %s: %w", f.Name, copyErr)
+	}
+	if closeErr != nil {
+		return 0, fmt.Errorf("close %s: %w", f.Name, closeErr)
+	}
+	return uint64(n), nil
+}
@@ -0,0 +1,102 @@
+package archive
+
+import (
+	"archive/zip"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/rs/zerolog"
+)
+
+func TestVolumes(t *testing.T) {
+	tests := []struct {
+		path    string
+		archive bool
+		first   bool
+		set     string
+	}{
+		{path: "/dl/Movie.2020.part01.rar", archive: true, first: true, set: "Movie.2020"},
+		{path: "/dl/Movie.2020.part1.rar", archive: true, first: true, set: "Movie.2020"},
+		{path: "/dl/Movie.2020.part02.rar", archive: true, first: false, set: "Movie.2020"},
+		{path: "/dl/movie.rar", archive: true, first: true, set: "movie"},
+		{path: "/dl/movie.r00", archive: true, first: false, set: "movie"},
+		{path: "/dl/movie.R17", archive: true, first: false, set: "movie"},
+		{path: "/dl/movie.zip", archive: true, first: true, set: "movie"},
+		{path: "/dl/movie.mkv", archive: false, first: false, set: "movie"},
+	}
+	for _, tt := range tests {
+		if got := IsArchive(tt.path); got != tt.archive {
+			t.Errorf("IsArchive(%q) = %v, want %v", tt.path, got, tt.archive)
+		}
+		if got := IsFirstVolume(tt.path); got != tt.first {
+			t.Errorf("IsFirstVolume(%q) = %v, want %v", tt.path, got, tt.first)
+		}
+		if got := SetName(tt.path); got != tt.set {
+			t.Errorf("SetName(%q) = %q, want %q", tt.path, got, tt.set)
+		}
+	}
+}
+
+func writeZip(t *testing.T, path string, files map[string]string) {
+	t.Helper()
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	zw := zip.NewWriter(f)
+	for name, content := range files {
+		w, err := zw.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := w.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestExtractZip(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "Movie.2020.zip")
+	writeZip(t, path, map[string]string{
+		"Movie.2020/Movie.2020.mkv": "video",
+		"Movie.2020/Movie.2020.srt": "subs",
+	})
+
+	dest := filepath.Join(dir, "staging")
+	var progress []int
+	err := NewExtractor(zerolog.Nop()).Extract(context.Background(), path, dest, func(p int) { progress = append(progress, p) })
+	if err != nil {
+		t.Fatalf("Extract() error = %v", err)
+	}
+	b, err := os.ReadFile(filepath.Join(dest, "Movie.2020", "Movie.2020.mkv"))
+	if err != nil || string(b) != "video" {
+		t.Errorf("extracted video = %q, %v", b, err)
+	}
+	if len(progress) == 0 || progress[len(progress)-1] != 100 {
+		t.Errorf("progress = %v, want it to end at 100", progress)
+	}
+}
+
+func TestExtractZipEscape(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "evil.zip")
+	writeZip(t, path, map[string]string{"../evil.mkv": "video"})
+
+	err := NewExtractor(zerolog.Nop()).Extract(context.Background(), path, filepath.Join(dir, "staging"), nil)
+	if !errors.Is(err, ErrCorrupt) {
+		t.Errorf("Extract() error = %v, want ErrCorrupt", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "evil.mkv")); !os.IsNotExist(err) {
+		t.Errorf("entry written outside staging dir, err = %v", err)
+	}
+}
@@ -0,0 +1,149 @@
+// Package blocklist identifies releases that must not be grabbed again and
+// records failed downloads and imports against them.
+package blocklist
+
+import (
+	"context"
+	"encoding/base32"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	dbgen "github.com/kyleaupton/arrflix/internal/db/sqlc"
+	"github.com/kyleaupton/arrflix/internal/indexer"
+	"github.com/kyleaupton/arrflix/internal/repo"
+)
+
+// Reasons a release was blocklisted.
+const (
+	ReasonDownloadFailed     = "download_failed"
+	ReasonImportFailed       = "import_failed"
+	ReasonVerificationFailed = "verification_failed"
+	ReasonManual             = "manual"
+)
+
+// Release identifies a release to check against the blocklist.
+type Release struct {
+	IndexerID int64
+	GUID      string
+	Title     string
+	InfoHash  string
+}
+
+// Entry is the part of a blocklist entry used for matching.
+type Entry struct {
+	InfoHash        string
+	IndexerID       int64
+	GUID            string
+	NormalizedTitle string
+}
+
+// Matches reports whether the entry blocks the release. Releases match by
+// info hash, by indexer and GUID, or by normalized title.
+func (e Entry) Matches(r Release) bool {
+	if e.InfoHash != "" && strings.EqualFold(e.InfoHash, NormalizeInfoHash(r.InfoHash)) {
+		return true
+	}
+	if e.GUID != "" && e.IndexerID == r.IndexerID && e.GUID == r.GUID {
+		return true
+	}
+	if e.NormalizedTitle != "" && e.NormalizedTitle == indexer.NormalizeTitle(r.Title) {
+		return true
+	}
+	return false
+}
+
+// EntryFromRow converts a blocklist row for matching.
+func EntryFromRow(row dbgen.Blocklist) Entry {
+	e := Entry{NormalizedTitle: row.NormalizedTitle}
+	if row.InfoHash != nil {
+		e.InfoHash = *row.InfoHash
+	}
+	if row.IndexerID != nil {
+		e.IndexerID = *row.IndexerID
+	}
+	if row.Guid != nil {
+		e.GUID = *row.Guid
+	}
+	return e
+}
+
+// IndexerGUIDKey is the key FindBlocklistMatches uses for (indexer_id, guid).
+func IndexerGUIDKey(indexerID int64, guid string) string {
+	return fmt.Sprintf("%d:%s", indexerID, guid)
+}
+
+// NormalizeInfoHash lowercases a hex info hash and converts base32 hashes to hex.
+// Anything that isn't a valid v1 info hash is returned lowercased.
+func NormalizeInfoHash(hash string) string {
+	hash = strings.TrimSpace(hash)
+	if len(hash) == 32 {
+		if b, err := base32.StdEncoding.DecodeString(strings.ToUpper(hash)); err == nil {
+			return hex.EncodeToString(b)
+		}
+	}
+	return strings.ToLower(hash)
+}
+
+// InfoHashFromLink extracts the info hash from a magnet link.
+func InfoHashFromLink(link string) string {
+	if !strings.HasPrefix(link, "magnet:") {
+		return ""
+	}
+	u, err := url.Parse(link)
+	if err != nil {
+		return ""
+	}
+	for _, xt := range u.Query()["xt"] {
+		if hash, ok := strings.CutPrefix(xt, "urn:btih:"); ok {
+			return NormalizeInfoHash(hash)
+		}
+	}
+	return ""
+}
+
+// AddForDownloadJob blocklists the release a download job grabbed. The info
+// hash is taken from the persisted search result when available, otherwise
+// from the job's magnet link. A job is blocklisted at most once; an existing
+// entry for the job is returned as is.
+func AddForDownloadJob(ctx context.Context, r *repo.Repository, job dbgen.DownloadJob, reason, message string) (dbgen.Blocklist, error) {
+	if existing, err := r.GetBlocklistEntryByDownloadJob(ctx, job.ID); err == nil {
+		return existing, nil
+	}
+
+	infoHash := InfoHashFromLink(job.CandidateLink)
+	if res, err := r.GetLatestSearchResult(ctx, job.IndexerID, job.Guid); err == nil {
+		var c struct {
+			InfoHash string `json:"infoHash"`
+		}
+		if json.Unmarshal(res.Candidate, &c) == nil && c.InfoHash != "" {
+			infoHash = NormalizeInfoHash(c.InfoHash)
+		}
+	}
+
+	indexerID := job.IndexerID
+	guid := job.Guid
+	protocol := job.Protocol
+	params := dbgen.CreateBlocklistEntryParams{
+		IndexerID:       &indexerID,
+		Guid:            &guid,
+		Title:           job.CandidateTitle,
+		NormalizedTitle: indexer.NormalizeTitle(job.CandidateTitle),
+		Protocol:        &protocol,
+		Reason:          reason,
+		MediaItemID:     job.MediaItemID,
+		DownloadJobID:   job.ID,
+		ExpiresAt:       pgtype.Timestamptz{},
+	}
+	if infoHash != "" {
+		params.InfoHash = &infoHash
+	}
+	if message != "" {
+		params.Message = &message
+	}
+
+	return r.CreateBlocklistEntry(ctx, params)
+}
@@ -0,0 +1,74 @@
+package blocklist
+
+import "testing"
+
+func TestInfoHashFromLink(t *testing.T) {
+	tests := []struct {
+		link string
+		want string
+	}{
+		{"magnet:?xt=urn:btih:ABCDEF0123456789ABCDEF0123456789ABCDEF01&dn=Movie", "abcdef0123456789abcdef0123456789abcdef01"},
+		{"magnet:?dn=Movie&xt=urn:btih:abcdef0123456789abcdef0123456789abcdef01&tr=udp://t", "abcdef0123456789abcdef0123456789abcdef01"},
+		{"magnet:?xt=urn:btih:VPG66AJDIVTYTK6N54ASGRLHRGV433YB", "abcdef0123456789abcdef0123456789abcdef01"},
+		{"https://indexer.example/download/123", ""},
+		{"magnet:?dn=NoHash", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.link, func(t *testing.T) {
+			if got := InfoHashFromLink(tt.link); got != tt.want {
+				t.Errorf("InfoHashFromLink(%q) = %q, want %q", tt.link, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNormalizeInfoHash(t *testing.T) {
+	tests := []struct {
+		hash string
+		want string
+	}{
+		{"ABCDEF0123456789ABCDEF0123456789ABCDEF01", "abcdef0123456789abcdef0123456789abcdef01"},
+		{"  abcdef0123456789abcdef0123456789abcdef01 ", "abcdef0123456789abcdef0123456789abcdef01"},
+		{"VPG66AJDIVTYTK6N54ASGRLHRGV433YB", "abcdef0123456789abcdef0123456789abcdef01"},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.hash, func(t *testing.T) {
+			if got := NormalizeInfoHash(tt.hash); got != tt.want {
+				t.Errorf("NormalizeInfoHash(%q) = %q, want %q", tt.hash, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEntryMatches(t *testing.T) {
+	release := Release{
+		IndexerID: 3,
+		GUID:      "https://indexer.example/details/42",
+		Title:     "Movie.Name.2020.1080p.WEB-DL-GRP",
+		InfoHash:  "ABCDEF0123456789ABCDEF0123456789ABCDEF01",
+	}
+
+	tests := []struct {
+		name  string
+		entry Entry
+		want  bool
+	}{
+		{"info hash", Entry{InfoHash: "abcdef0123456789abcdef0123456789abcdef01"}, true},
+		{"indexer and guid", Entry{IndexerID: 3, GUID: "https://indexer.example/details/42"}, true},
+		{"guid on other indexer", Entry{IndexerID: 4, GUID: "https://indexer.example/details/42"}, false},
+		{"normalized title", Entry{NormalizedTitle: "movie name 2020 1080p web dl grp"}, true},
+		{"different release", Entry{InfoHash: "ffff", NormalizedTitle: "movie name 2020 720p"}, false},
+		{"empty entry", Entry{}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.entry.Matches(release); got != tt.want {
+				t.Errorf("Matches() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,7 @@
+-- External IDs (IMDb, TVDB) fetched from TMDB and cached on media items for ID-based indexer search
+
+ALTER TABLE media_item ADD COLUMN IF NOT EXISTS imdb_id TEXT;
+ALTER TABLE media_item ADD COLUMN IF NOT EXISTS tvdb_id BIGINT;
+
+CREATE INDEX IF NOT EXISTS idx_media_item_imdb ON media_item (imdb_id);
+CREATE INDEX IF NOT EXISTS idx_media_item_tvdb ON media_item (tvdb_id);
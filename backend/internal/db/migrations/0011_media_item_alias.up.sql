@@ -0,0 +1,13 @@
+-- Custom title aliases per media item, used alongside TMDB alternative titles when searching indexers.
+-- excluded = true hides a TMDB-provided title from searches instead of adding one.
+
+CREATE TABLE IF NOT EXISTS media_item_alias (
+  id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+  media_item_id UUID NOT NULL REFERENCES media_item(id) ON DELETE CASCADE,
+  title TEXT NOT NULL,
+  excluded BOOLEAN NOT NULL DEFAULT false,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  UNIQUE (media_item_id, title)
+);
+
+CREATE INDEX IF NOT EXISTS idx_media_item_alias_media ON media_item_alias (media_item_id);
@@ -0,0 +1,32 @@
+-- Persisted candidate searches so results survive restarts, are shared across replicas,
+-- and can be reopened and enqueued later. Sessions expire per search.result_retention_hours.
+
+CREATE TABLE IF NOT EXISTS search_session (
+  id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+  media_type TEXT NOT NULL CHECK (media_type IN ('movie','series')),
+  tmdb_id BIGINT NOT NULL,
+  season_number INT,
+  episode_number INT,
+  query TEXT NOT NULL,
+  sources JSONB,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  expires_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_search_session_media ON search_session (media_type, tmdb_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_search_session_expires ON search_session (expires_at);
+
+CREATE TABLE IF NOT EXISTS search_result (
+  id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+  session_id UUID NOT NULL REFERENCES search_session(id) ON DELETE CASCADE,
+  position INT NOT NULL,
+  indexer_id BIGINT NOT NULL,
+  guid TEXT NOT NULL,
+  title TEXT NOT NULL,
+  candidate JSONB NOT NULL,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  UNIQUE (session_id, indexer_id, guid)
+);
+
+CREATE INDEX IF NOT EXISTS idx_search_result_session ON search_result (session_id, position);
+CREATE INDEX IF NOT EXISTS idx_search_result_indexer_guid ON search_result (indexer_id, guid);
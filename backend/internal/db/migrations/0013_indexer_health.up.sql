@@ -0,0 +1,26 @@
+-- Per-indexer search outcomes. indexer_health holds the current state used for backoff;
+-- indexer_search_event keeps recent history for the status endpoint and reliability stats.
+
+CREATE TABLE IF NOT EXISTS indexer_health (
+  indexer_id BIGINT PRIMARY KEY,
+  indexer_name TEXT NOT NULL,
+  consecutive_failures INT NOT NULL DEFAULT 0,
+  last_success_at TIMESTAMPTZ,
+  last_failure_at TIMESTAMPTZ,
+  last_error TEXT,
+  disabled_until TIMESTAMPTZ,
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE TABLE IF NOT EXISTS indexer_search_event (
+  id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+  indexer_id BIGINT NOT NULL,
+  query TEXT NOT NULL,
+  duration_ms INT NOT NULL,
+  result_count INT NOT NULL,
+  error TEXT,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_indexer_search_event_indexer ON indexer_search_event (indexer_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_indexer_search_event_created ON indexer_search_event (created_at);
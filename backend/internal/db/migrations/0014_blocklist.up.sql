@@ -0,0 +1,43 @@
+-- Releases that must not be grabbed again. A candidate matches an entry by info hash,
+-- by (indexer_id, guid), or by normalized title. expires_at NULL means the entry is permanent.
+
+CREATE TABLE IF NOT EXISTS blocklist (
+  id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+  info_hash TEXT,
+  indexer_id BIGINT,
+  guid TEXT,
+  title TEXT NOT NULL,
+  normalized_title TEXT NOT NULL,
+  protocol TEXT CHECK (protocol IS NULL OR protocol IN ('torrent', 'usenet')),
+  reason TEXT NOT NULL CHECK (reason IN ('download_failed', 'import_failed', 'manual')),
+  message TEXT,
+  media_item_id UUID REFERENCES media_item(id) ON DELETE CASCADE,
+  download_job_id UUID REFERENCES download_job(id) ON DELETE SET NULL,
+  expires_at TIMESTAMPTZ,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_blocklist_info_hash ON blocklist (info_hash) WHERE info_hash IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_blocklist_indexer_guid ON blocklist (indexer_id, guid) WHERE guid IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_blocklist_normalized_title ON blocklist (normalized_title);
+CREATE INDEX IF NOT EXISTS idx_blocklist_created ON blocklist (created_at DESC);
+
+-- Record blocklisting in the job audit logs
+ALTER TABLE download_job_event DROP CONSTRAINT IF EXISTS download_job_event_event_type_check;
+ALTER TABLE download_job_event ADD CONSTRAINT download_job_event_event_type_check CHECK (event_type IN (
+  'created',
+  'status_changed',
+  'error',
+  'retry_scheduled',
+  'blocklisted'
+));
+
+ALTER TABLE import_task_event DROP CONSTRAINT IF EXISTS import_task_event_event_type_check;
+ALTER TABLE import_task_event ADD CONSTRAINT import_task_event_event_type_check CHECK (event_type IN (
+  'created',
+  'status_changed',
+  'error',
+  'retry_scheduled',
+  'reimport_requested',
+  'blocklisted'
+));
@@ -0,0 +1,6 @@
+-- Anime-style absolute episode numbers, mapped from TMDB's "Absolute" episode group
+-- or the regular seasons in order. Fansub releases are numbered this way.
+
+ALTER TABLE media_episode ADD COLUMN IF NOT EXISTS absolute_number INT;
+
+CREATE INDEX IF NOT EXISTS idx_media_episode_absolute ON media_episode (absolute_number) WHERE absolute_number IS NOT NULL;
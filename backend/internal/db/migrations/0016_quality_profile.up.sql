@@ -0,0 +1,54 @@
+-- Quality profiles: an ordered list of qualities (least preferred first), each a single quality
+-- or a named group of equal qualities, with per-item size limits in MB per minute of runtime.
+-- Upgrades stop once the library file reaches the cutoff item.
+--   items: [{"name": "WEB 1080p", "qualities": ["WEBRip-1080p", "WEBDL-1080p"], "allowed": true, "minSize": 5, "maxSize": 100}]
+
+CREATE TABLE IF NOT EXISTS quality_profile (
+  id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+  name TEXT NOT NULL,
+  items JSONB NOT NULL DEFAULT '[]',
+  cutoff TEXT NOT NULL,
+  upgrades_allowed BOOLEAN NOT NULL DEFAULT true,
+  "default" BOOLEAN NOT NULL DEFAULT false,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS uq_quality_profile_name_ci ON quality_profile (lower(name));
+CREATE UNIQUE INDEX IF NOT EXISTS uq_quality_profile_default ON quality_profile ("default") WHERE "default" = true;
+
+-- Profile resolution: media item, then its library, then the default profile
+ALTER TABLE library ADD COLUMN IF NOT EXISTS quality_profile_id UUID REFERENCES quality_profile(id) ON DELETE SET NULL;
+ALTER TABLE media_item ADD COLUMN IF NOT EXISTS quality_profile_id UUID REFERENCES quality_profile(id) ON DELETE SET NULL;
+
+-- Quality of the imported file (release.Quality name), used to decide on upgrades.
+-- NULL for files imported before this migration; the quality is then parsed from the path.
+ALTER TABLE media_file ADD COLUMN IF NOT EXISTS quality TEXT;
+
+-- Default profile: every quality in Sonarr's order except Unknown, upgrading up to WEBDL-1080p
+INSERT INTO quality_profile (name, items, cutoff, "default")
+VALUES ('Any', '[
+  {"name": "Unknown", "qualities": ["Unknown"], "allowed": false},
+  {"name": "SDTV", "qualities": ["SDTV"], "allowed": true},
+  {"name": "WEBRip-480p", "qualities": ["WEBRip-480p"], "allowed": true},
+  {"name": "WEBDL-480p", "qualities": ["WEBDL-480p"], "allowed": true},
+  {"name": "DVD", "qualities": ["DVD"], "allowed": true},
+  {"name": "Bluray-480p", "qualities": ["Bluray-480p"], "allowed": true},
+  {"name": "Bluray-576p", "qualities": ["Bluray-576p"], "allowed": true},
+  {"name": "HDTV-720p", "qualities": ["HDTV-720p"], "allowed": true},
+  {"name": "HDTV-1080p", "qualities": ["HDTV-1080p"], "allowed": true},
+  {"name": "Raw-HD", "qualities": ["Raw-HD"], "allowed": true},
+  {"name": "WEBRip-720p", "qualities": ["WEBRip-720p"], "allowed": true},
+  {"name": "WEBDL-720p", "qualities": ["WEBDL-720p"], "allowed": true},
+  {"name": "Bluray-720p", "qualities": ["Bluray-720p"], "allowed": true},
+  {"name": "WEBRip-1080p", "qualities": ["WEBRip-1080p"], "allowed": true},
+  {"name": "WEBDL-1080p", "qualities": ["WEBDL-1080p"], "allowed": true},
+  {"name": "Bluray-1080p", "qualities": ["Bluray-1080p"], "allowed": true},
+  {"name": "Bluray-1080p Remux", "qualities": ["Bluray-1080p Remux"], "allowed": true},
+  {"name": "HDTV-2160p", "qualities": ["HDTV-2160p"], "allowed": true},
+  {"name": "WEBRip-2160p", "qualities": ["WEBRip-2160p"], "allowed": true},
+  {"name": "WEBDL-2160p", "qualities": ["WEBDL-2160p"], "allowed": true},
+  {"name": "Bluray-2160p", "qualities": ["Bluray-2160p"], "allowed": true},
+  {"name": "Bluray-2160p Remux", "qualities": ["Bluray-2160p Remux"], "allowed": true}
+]'::jsonb, 'WEBDL-1080p', true)
+ON CONFLICT DO NOTHING;
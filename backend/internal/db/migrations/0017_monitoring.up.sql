@@ -0,0 +1,14 @@
+-- Monitoring: which movies, series, seasons and episodes should be searched for when missing.
+-- A series episode is wanted only when the series, its season and the episode are all monitored.
+-- monitor_new_seasons monitors seasons TMDB adds after the series was monitored.
+-- library_id is the library monitored items are downloaded into; NULL uses the default library.
+
+ALTER TABLE media_item ADD COLUMN IF NOT EXISTS monitored BOOLEAN NOT NULL DEFAULT false;
+ALTER TABLE media_item ADD COLUMN IF NOT EXISTS monitor_new_seasons BOOLEAN NOT NULL DEFAULT false;
+ALTER TABLE media_item ADD COLUMN IF NOT EXISTS library_id UUID REFERENCES library(id) ON DELETE SET NULL;
+
+ALTER TABLE media_season ADD COLUMN IF NOT EXISTS monitored BOOLEAN NOT NULL DEFAULT false;
+ALTER TABLE media_episode ADD COLUMN IF NOT EXISTS monitored BOOLEAN NOT NULL DEFAULT false;
+
+CREATE INDEX IF NOT EXISTS idx_media_item_monitored ON media_item (monitored) WHERE monitored = true;
+CREATE INDEX IF NOT EXISTS idx_media_episode_monitored ON media_episode (season_id) WHERE monitored = true;
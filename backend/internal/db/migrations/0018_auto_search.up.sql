@@ -0,0 +1,46 @@
+-- Automatic search: monitored movies and aired episodes without a file are searched periodically
+-- and the best acceptable release is grabbed. Every search records a decision for auditing.
+-- last_searched_at spaces out retries of titles that found nothing (autosearch.retry_hours).
+
+ALTER TABLE media_item ADD COLUMN IF NOT EXISTS last_searched_at TIMESTAMPTZ;
+ALTER TABLE media_episode ADD COLUMN IF NOT EXISTS last_searched_at TIMESTAMPTZ;
+
+-- Policies can score and reject candidates during automatic search
+ALTER TABLE action DROP CONSTRAINT IF EXISTS action_type_check;
+ALTER TABLE action ADD CONSTRAINT action_type_check CHECK (type IN (
+  'set_downloader',
+  'set_library',
+  'set_name_template',
+  'stop_processing',
+  'add_score',
+  'reject'
+));
+
+CREATE TABLE IF NOT EXISTS auto_search_decision (
+  id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+  media_item_id UUID NOT NULL REFERENCES media_item(id) ON DELETE CASCADE,
+  media_type TEXT NOT NULL CHECK (media_type IN ('movie','series')),
+  tmdb_id BIGINT NOT NULL,
+  season_number INT,
+  episode_number INT,
+  trigger TEXT NOT NULL CHECK (trigger IN ('scheduled', 'manual')),
+  outcome TEXT NOT NULL CHECK (outcome IN (
+    'grabbed',     -- A candidate was enqueued
+    'rejected',    -- Results were found but none were acceptable
+    'no_results',  -- The search returned nothing
+    'failed'       -- The search or enqueue failed
+  )),
+  reason TEXT,
+  search_session_id UUID REFERENCES search_session(id) ON DELETE SET NULL,
+  candidate_count INT NOT NULL DEFAULT 0,
+  chosen_title TEXT,
+  chosen_indexer_id BIGINT,
+  chosen_guid TEXT,
+  download_job_id UUID REFERENCES download_job(id) ON DELETE SET NULL,
+  candidates JSONB, -- per-candidate verdicts, best first
+  trace JSONB,      -- evaluation trace of the chosen candidate
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_auto_search_decision_created ON auto_search_decision (created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_auto_search_decision_media ON auto_search_decision (media_item_id, created_at DESC);
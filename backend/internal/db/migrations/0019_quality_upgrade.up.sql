@@ -0,0 +1,23 @@
+-- Quality upgrades: monitored items whose file is below their profile's cutoff are searched
+-- for a better release. Importing the upgrade moves the old file aside (under .replaced in
+-- the library root) and points the existing media_file at the new one. The import that
+-- replaced a file records what it replaced, so history keeps both.
+
+ALTER TABLE media_file_import ADD COLUMN IF NOT EXISTS replaced_path TEXT;    -- Library path of the previous file
+ALTER TABLE media_file_import ADD COLUMN IF NOT EXISTS replaced_quality TEXT; -- Quality of the previous file
+ALTER TABLE media_file_import ADD COLUMN IF NOT EXISTS archived_path TEXT;    -- Where the previous file was moved
+
+-- Automatic searches for an upgrade, rather than a missing file
+ALTER TABLE auto_search_decision ADD COLUMN IF NOT EXISTS upgrade BOOLEAN NOT NULL DEFAULT false;
+
+-- Import tasks log the file they replaced
+ALTER TABLE import_task_event DROP CONSTRAINT IF EXISTS import_task_event_event_type_check;
+ALTER TABLE import_task_event ADD CONSTRAINT import_task_event_event_type_check CHECK (event_type IN (
+  'created',
+  'status_changed',
+  'error',
+  'retry_scheduled',
+  'reimport_requested',
+  'blocklisted',
+  'file_replaced'
+));
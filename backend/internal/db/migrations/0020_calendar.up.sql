@@ -0,0 +1,16 @@
+-- Release calendar: movie release dates and episode air dates are pulled from TMDB for library
+-- items, and served as JSON and as an iCal feed. Calendar apps can't log in, so each user gets
+-- a secret feed token instead.
+
+ALTER TABLE media_item ADD COLUMN IF NOT EXISTS release_date DATE;          -- Theatrical release
+ALTER TABLE media_item ADD COLUMN IF NOT EXISTS digital_release_date DATE;
+ALTER TABLE media_item ADD COLUMN IF NOT EXISTS physical_release_date DATE;
+ALTER TABLE media_item ADD COLUMN IF NOT EXISTS dates_synced_at TIMESTAMPTZ; -- Last pull from TMDB
+
+CREATE INDEX IF NOT EXISTS idx_media_episode_air_date ON media_episode (air_date);
+
+CREATE TABLE IF NOT EXISTS calendar_token (
+  user_id UUID PRIMARY KEY REFERENCES app_user(id) ON DELETE CASCADE,
+  token TEXT NOT NULL UNIQUE,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
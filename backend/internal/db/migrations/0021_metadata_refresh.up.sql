@@ -0,0 +1,12 @@
+-- Metadata refresh: library items are refreshed from TMDB on a schedule (and on demand). Movies
+-- get their title, year and release dates; series get their title, year, seasons and episodes,
+-- reconciled so renumbered and removed episodes keep their files. This replaces the calendar's
+-- release date sync, so its timestamp and setting are renamed.
+
+ALTER TABLE media_item RENAME COLUMN dates_synced_at TO metadata_refreshed_at;
+
+UPDATE app_setting SET key = 'metadata.refresh_hours'
+WHERE key = 'calendar.refresh_hours'
+  AND NOT EXISTS (SELECT 1 FROM app_setting WHERE key = 'metadata.refresh_hours');
+
+DELETE FROM app_setting WHERE key = 'calendar.refresh_hours';
@@ -0,0 +1,38 @@
+-- Media requests: users ask for a movie, a series or specific seasons, and users with
+-- requests.approve decide. Approval monitors the title and queues a search; the request then
+-- follows the resulting download jobs and import tasks until everything asked for is on disk.
+
+CREATE TABLE IF NOT EXISTS media_request (
+  id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+  user_id UUID NOT NULL REFERENCES app_user(id) ON DELETE CASCADE,
+
+  media_type TEXT NOT NULL CHECK (media_type IN ('movie', 'series')),
+  tmdb_id BIGINT NOT NULL,
+  title TEXT NOT NULL,
+  year INT,
+  -- Requested season numbers; empty means the whole series (always empty for movies)
+  seasons INT[] NOT NULL DEFAULT '{}',
+
+  status TEXT NOT NULL DEFAULT 'pending' CHECK (status IN (
+    'pending',      -- Waiting for a decision
+    'approved',     -- Approved, monitored and searched; nothing grabbed yet
+    'declined',     -- Declined (terminal)
+    'downloading',  -- A download job for the title is running
+    'importing',    -- Downloaded files are being imported
+    'available'     -- Everything requested is on disk (terminal)
+  )),
+
+  decided_by UUID REFERENCES app_user(id) ON DELETE SET NULL,
+  decided_at TIMESTAMPTZ,
+  decision_comment TEXT,
+
+  media_item_id UUID REFERENCES media_item(id) ON DELETE SET NULL,
+  available_at TIMESTAMPTZ,
+
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_media_request_user ON media_request(user_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_media_request_status ON media_request(status);
+CREATE INDEX IF NOT EXISTS idx_media_request_title ON media_request(media_type, tmdb_id);
@@ -0,0 +1,56 @@
+-- Request quotas and auto-approval rules. Quotas limit how many movies and seasons a user can
+-- request in a rolling window, per role with per-user overrides. Auto-approval rules are
+-- evaluated per role in priority order over the request's fields; the first match decides
+-- whether a request is approved right away or waits for a decision.
+
+-- Seasons a request covers (all seasons but specials at request time for a whole series), and
+-- the quality profile the requester asked for
+ALTER TABLE media_request
+  ADD COLUMN IF NOT EXISTS season_count INT NOT NULL DEFAULT 0,
+  ADD COLUMN IF NOT EXISTS quality_profile_id UUID REFERENCES quality_profile(id) ON DELETE SET NULL;
+
+UPDATE media_request SET season_count = cardinality(seasons) WHERE media_type = 'series';
+
+CREATE INDEX IF NOT EXISTS idx_media_request_user_created ON media_request(user_id, created_at)
+  WHERE status <> 'declined';
+
+-- A user's own quota replaces those of their roles. Across roles the most generous limit
+-- applies. NULL limits are unlimited.
+CREATE TABLE IF NOT EXISTS request_quota (
+  subject_type grant_subject NOT NULL,
+  subject_id UUID NOT NULL,
+  movie_limit INT CHECK (movie_limit IS NULL OR movie_limit >= 0),
+  season_limit INT CHECK (season_limit IS NULL OR season_limit >= 0),
+  window_days INT NOT NULL DEFAULT 7 CHECK (window_days > 0),
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  PRIMARY KEY (subject_type, subject_id)
+);
+
+-- A rule without a condition always matches
+CREATE TABLE IF NOT EXISTS request_rule (
+  id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+  role_id UUID NOT NULL REFERENCES role(id) ON DELETE CASCADE,
+  name TEXT NOT NULL,
+  enabled BOOLEAN NOT NULL DEFAULT true,
+  priority INT NOT NULL DEFAULT 0,
+  left_operand TEXT,
+  operator TEXT CHECK (operator IS NULL OR operator IN ('==', '!=', '>', '>=', '<', '<=', 'contains', 'in', 'not in')),
+  right_operand TEXT,
+  action TEXT NOT NULL CHECK (action IN ('approve', 'require_approval')),
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  CONSTRAINT request_rule_condition_check CHECK (
+    (left_operand IS NULL AND operator IS NULL AND right_operand IS NULL) OR
+    (left_operand IS NOT NULL AND operator IS NOT NULL AND right_operand IS NOT NULL)
+  )
+);
+
+CREATE INDEX IF NOT EXISTS idx_request_rule_role ON request_rule(role_id, priority DESC);
+
+-- Admins and managers auto-approve their own requests
+INSERT INTO request_rule (role_id, name, priority, action)
+SELECT r.id, 'Auto-approve', 0, 'approve'
+FROM role r
+WHERE r.name IN ('admin', 'manager')
+  AND NOT EXISTS (SELECT 1 FROM request_rule rr WHERE rr.role_id = r.id);
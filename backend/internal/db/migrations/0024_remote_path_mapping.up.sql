@@ -0,0 +1,15 @@
+-- Remote path mappings: a downloader may see the same storage under a different path than
+-- Arrflix does (qBittorrent reports /downloads/... where Arrflix mounts /data/torrents/...).
+-- Paths the downloader reports under remote_path are read under local_path instead.
+
+CREATE TABLE IF NOT EXISTS remote_path_mapping (
+  id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+  downloader_id UUID NOT NULL REFERENCES downloader(id) ON DELETE CASCADE,
+  remote_path TEXT NOT NULL,
+  local_path TEXT NOT NULL,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  UNIQUE (downloader_id, remote_path)
+);
+
+CREATE INDEX IF NOT EXISTS idx_remote_path_mapping_downloader ON remote_path_mapping(downloader_id);
@@ -0,0 +1,19 @@
+-- Import modes: how a library takes in files from a download.
+--   hardlink: hardlink, copy when source and library are on different filesystems
+--   reflink:  copy-on-write clone (btrfs, XFS), copy when the filesystem can't clone
+--   move:     rename, copy then delete across filesystems
+--   symlink:  symlink the library file to the download
+--   copy:     always a full copy
+ALTER TABLE library ADD COLUMN IF NOT EXISTS import_mode TEXT NOT NULL DEFAULT 'hardlink'
+  CHECK (import_mode IN ('hardlink','reflink','move','symlink','copy'));
+
+-- Record the method that was actually used
+ALTER TABLE import_task DROP CONSTRAINT IF EXISTS import_task_import_method_check;
+ALTER TABLE import_task ADD CONSTRAINT import_task_import_method_check CHECK (
+  import_method IS NULL OR import_method IN ('hardlink','reflink','move','symlink','copy')
+);
+
+ALTER TABLE media_file_import DROP CONSTRAINT IF EXISTS media_file_import_method_check;
+ALTER TABLE media_file_import ADD CONSTRAINT media_file_import_method_check CHECK (
+  method IN ('hardlink','reflink','move','symlink','copy','scan','manual_match')
+);
@@ -0,0 +1,35 @@
+-- Extra files (subtitles, NFOs, artwork) imported alongside a video.
+-- Libraries opt in and whitelist extensions; extras belong to their media_file
+-- so deleting the file drops them too.
+ALTER TABLE library ADD COLUMN IF NOT EXISTS import_extra_files BOOLEAN NOT NULL DEFAULT false;
+ALTER TABLE library ADD COLUMN IF NOT EXISTS extra_file_extensions TEXT[] NOT NULL DEFAULT '{srt,ass,ssa,sub,idx,vtt,nfo}';
+
+CREATE TABLE IF NOT EXISTS media_file_extra (
+  id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+  media_file_id UUID NOT NULL REFERENCES media_file(id) ON DELETE CASCADE,
+  type TEXT NOT NULL CHECK (type IN ('subtitle','nfo','artwork','other')),
+  path TEXT NOT NULL,           -- Relative to the library root, like media_file.path
+  language TEXT,                -- ISO 639-1, subtitles only
+  forced BOOLEAN NOT NULL DEFAULT false,
+  sdh BOOLEAN NOT NULL DEFAULT false,
+  source_path TEXT,
+  method TEXT NOT NULL CHECK (method IN ('hardlink','reflink','move','symlink','copy')),
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  CHECK (path !~ '^/'),
+  UNIQUE (media_file_id, path)
+);
+
+CREATE INDEX IF NOT EXISTS idx_media_file_extra_media_file ON media_file_extra (media_file_id);
+
+-- Import tasks log the extras they brought along
+ALTER TABLE import_task_event DROP CONSTRAINT IF EXISTS import_task_event_event_type_check;
+ALTER TABLE import_task_event ADD CONSTRAINT import_task_event_event_type_check CHECK (event_type IN (
+  'created',
+  'status_changed',
+  'error',
+  'retry_scheduled',
+  'reimport_requested',
+  'blocklisted',
+  'file_replaced',
+  'extras_imported'
+));
@@ -0,0 +1,15 @@
+-- Import tasks log the extraction of packed (RAR/ZIP) releases
+ALTER TABLE import_task_event DROP CONSTRAINT IF EXISTS import_task_event_event_type_check;
+ALTER TABLE import_task_event ADD CONSTRAINT import_task_event_event_type_check CHECK (event_type IN (
+  'created',
+  'status_changed',
+  'error',
+  'retry_scheduled',
+  'reimport_requested',
+  'blocklisted',
+  'file_replaced',
+  'extras_imported',
+  'extraction_started',
+  'extraction_progress',
+  'extraction_completed'
+));
@@ -0,0 +1,25 @@
+-- Releases whose files fail import verification (fakes, truncated files) are
+-- blocklisted with their own reason, and may queue a new search
+ALTER TABLE blocklist DROP CONSTRAINT IF EXISTS blocklist_reason_check;
+ALTER TABLE blocklist ADD CONSTRAINT blocklist_reason_check CHECK (reason IN (
+  'download_failed',
+  'import_failed',
+  'verification_failed',
+  'manual'
+));
+
+ALTER TABLE import_task_event DROP CONSTRAINT IF EXISTS import_task_event_event_type_check;
+ALTER TABLE import_task_event ADD CONSTRAINT import_task_event_event_type_check CHECK (event_type IN (
+  'created',
+  'status_changed',
+  'error',
+  'retry_scheduled',
+  'reimport_requested',
+  'blocklisted',
+  'file_replaced',
+  'extras_imported',
+  'extraction_started',
+  'extraction_progress',
+  'extraction_completed',
+  'search_queued'
+));
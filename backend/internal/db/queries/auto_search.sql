@@ -0,0 +1,180 @@
+-- Wanted items
+
+-- name: ListWantedMovies :many
+-- Monitored movies with no file on disk and no download in flight, least
+-- recently searched first. Movies searched since searched_before are skipped.
+-- A completed download still counts while its import tasks are running.
+select mi.* from media_item mi
+where mi.type = 'movie' and mi.monitored
+  and (mi.last_searched_at is null or mi.last_searched_at < sqlc.arg(searched_before))
+  and not exists (
+    select 1 from media_file mf
+    left join media_file_state mfs on mf.id = mfs.media_file_id
+    where mf.media_item_id = mi.id and coalesce(mfs.file_exists, true)
+  )
+  and not exists (
+    select 1 from download_job dj
+    where dj.media_item_id = mi.id
+      and (dj.status in ('created', 'enqueued', 'downloading')
+        or (dj.status = 'completed' and exists (
+          select 1 from import_task it
+          where it.download_job_id = dj.id and it.status in ('pending', 'in_progress')
+        )))
+  )
+order by mi.last_searched_at asc nulls first, mi.created_at asc
+limit sqlc.arg(row_limit)::int;
+
+-- name: ListWantedEpisodes :many
+-- Aired episodes monitored along with their season and series, with no file on
+-- disk and no download in flight for the episode, its season or the series,
+-- least recently searched first. season_pack is set when every episode of the
+-- season has aired and is wanted, so a single season search can cover them.
+with wanted as (
+  select
+    me.id as episode_id,
+    mi.id as media_item_id,
+    mi.tmdb_id,
+    mi.title,
+    ms.id as season_id,
+    ms.season_number,
+    me.episode_number,
+    me.last_searched_at
+  from media_episode me
+  join media_season ms on ms.id = me.season_id
+  join media_item mi on mi.id = ms.media_item_id
+  where mi.type = 'series' and mi.monitored and ms.monitored and me.monitored
+    and me.air_date <= current_date
+    and not exists (
+      select 1 from media_file mf
+      left join media_file_state mfs on mf.id = mfs.media_file_id
+      where mf.episode_id = me.id and coalesce(mfs.file_exists, true)
+    )
+    and not exists (
+      select 1 from download_job dj
+      where dj.media_item_id = mi.id
+        and (dj.episode_id = me.id
+          or (dj.episode_id is null and (dj.season_id = ms.id or dj.season_id is null)))
+        and (dj.status in ('created', 'enqueued', 'downloading')
+          or (dj.status = 'completed' and exists (
+            select 1 from import_task it
+            where it.download_job_id = dj.id and it.status in ('pending', 'in_progress')
+          )))
+    )
+)
+select
+  w.episode_id,
+  w.media_item_id,
+  w.tmdb_id,
+  w.title,
+  w.season_id,
+  w.season_number,
+  w.episode_number,
+  (w.season_number > 0 and not exists (
+    select 1 from media_episode e
+    where e.season_id = w.season_id
+      and not exists (select 1 from wanted w2 where w2.episode_id = e.id)
+  ))::boolean as season_pack
+from wanted w
+where w.last_searched_at is null or w.last_searched_at < sqlc.arg(searched_before)
+order by w.last_searched_at asc nulls first, w.season_number asc, w.episode_number asc
+limit sqlc.arg(row_limit)::int;
+
+-- Upgrade candidates
+
+-- name: ListUpgradeMovies :many
+-- Monitored movies with a file on disk and no download in flight, least
+-- recently searched first. Whether the file is below its profile's cutoff is
+-- decided by the caller.
+select mi.* from media_item mi
+where mi.type = 'movie' and mi.monitored
+  and (mi.last_searched_at is null or mi.last_searched_at < sqlc.arg(searched_before))
+  and exists (
+    select 1 from media_file mf
+    left join media_file_state mfs on mf.id = mfs.media_file_id
+    where mf.media_item_id = mi.id and coalesce(mfs.file_exists, true)
+  )
+  and not exists (
+    select 1 from download_job dj
+    where dj.media_item_id = mi.id
+      and (dj.status in ('created', 'enqueued', 'downloading')
+        or (dj.status = 'completed' and exists (
+          select 1 from import_task it
+          where it.download_job_id = dj.id and it.status in ('pending', 'in_progress')
+        )))
+  )
+order by mi.last_searched_at asc nulls first, mi.created_at asc
+limit sqlc.arg(row_limit)::int;
+
+-- name: ListUpgradeEpisodes :many
+-- Episodes monitored along with their season and series, with a file on disk
+-- and no download in flight for the episode, its season or the series, least
+-- recently searched first. Whether the file is below its profile's cutoff is
+-- decided by the caller.
+select
+  me.id as episode_id,
+  mi.id as media_item_id,
+  mi.tmdb_id,
+  mi.title,
+  ms.season_number,
+  me.episode_number
+from media_episode me
+join media_season ms on ms.id = me.season_id
+join media_item mi on mi.id = ms.media_item_id
+where mi.type = 'series' and mi.monitored and ms.monitored and me.monitored
+  and (me.last_searched_at is null or me.last_searched_at < sqlc.arg(searched_before))
+  and exists (
+    select 1 from media_file mf
+    left join media_file_state mfs on mf.id = mfs.media_file_id
+    where mf.episode_id = me.id and coalesce(mfs.file_exists, true)
+  )
+  and not exists (
+    select 1 from download_job dj
+    where dj.media_item_id = mi.id
+      and (dj.episode_id = me.id
+        or (dj.episode_id is null and (dj.season_id = ms.id or dj.season_id is null)))
+      and (dj.status in ('created', 'enqueued', 'downloading')
+        or (dj.status = 'completed' and exists (
+          select 1 from import_task it
+          where it.download_job_id = dj.id and it.status in ('pending', 'in_progress')
+        )))
+  )
+order by me.last_searched_at asc nulls first, ms.season_number asc, me.episode_number asc
+limit sqlc.arg(row_limit)::int;
+
+-- name: SetMediaItemSearchedAt :exec
+update media_item set last_searched_at = now() where id = $1;
+
+-- name: SetEpisodesSearchedAt :exec
+update media_episode set last_searched_at = now() where id = any(sqlc.arg(ids)::uuid[]);
+
+-- Decisions
+
+-- name: CreateAutoSearchDecision :one
+insert into auto_search_decision (
+  media_item_id, media_type, tmdb_id, season_number, episode_number, trigger, outcome, reason,
+  search_session_id, candidate_count, chosen_title, chosen_indexer_id, chosen_guid, download_job_id,
+  candidates, trace, upgrade
+)
+values (
+  sqlc.arg(media_item_id), sqlc.arg(media_type), sqlc.arg(tmdb_id), sqlc.narg(season_number), sqlc.narg(episode_number),
+  sqlc.arg(trigger), sqlc.arg(outcome), sqlc.narg(reason), sqlc.arg(search_session_id), sqlc.arg(candidate_count),
+  sqlc.narg(chosen_title), sqlc.narg(chosen_indexer_id), sqlc.narg(chosen_guid), sqlc.arg(download_job_id),
+  sqlc.arg(candidates), sqlc.arg(trace), sqlc.arg(upgrade)
+)
+returning *;
+
+-- name: GetAutoSearchDecision :one
+select * from auto_search_decision
+where id = $1;
+
+-- name: ListAutoSearchDecisionsPaginated :many
+select * from auto_search_decision
+where (sqlc.narg(media_item_id)::uuid is null or media_item_id = sqlc.narg(media_item_id))
+  and (sqlc.narg(outcome)::text is null or outcome = sqlc.narg(outcome))
+order by created_at desc
+limit sqlc.arg(page_size)::int offset sqlc.arg(offset_val)::int;
+
+-- name: CountAutoSearchDecisions :one
+select count(*) from auto_search_decision
+where (sqlc.narg(media_item_id)::uuid is null or media_item_id = sqlc.narg(media_item_id))
+  and (sqlc.narg(outcome)::text is null or outcome = sqlc.narg(outcome));
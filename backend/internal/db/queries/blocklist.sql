@@ -0,0 +1,44 @@
+-- name: CreateBlocklistEntry :one
+insert into blocklist (info_hash, indexer_id, guid, title, normalized_title, protocol, reason, message, media_item_id, download_job_id, expires_at)
+values (sqlc.narg(info_hash), sqlc.narg(indexer_id), sqlc.narg(guid), sqlc.arg(title), sqlc.arg(normalized_title), sqlc.narg(protocol), sqlc.arg(reason), sqlc.narg(message), sqlc.arg(media_item_id), sqlc.arg(download_job_id), sqlc.arg(expires_at))
+returning *;
+
+-- name: GetBlocklistEntry :one
+select * from blocklist
+where id = $1;
+
+-- name: ListBlocklistEntriesPaginated :many
+select * from blocklist
+where (sqlc.narg(media_item_id)::uuid is null or media_item_id = sqlc.narg(media_item_id))
+order by created_at desc
+limit sqlc.arg(page_size)::int offset sqlc.arg(offset_val)::int;
+
+-- name: CountBlocklistEntries :one
+select count(*) from blocklist
+where (sqlc.narg(media_item_id)::uuid is null or media_item_id = sqlc.narg(media_item_id));
+
+-- name: UpdateBlocklistEntry :one
+update blocklist
+set message = sqlc.narg(message),
+    expires_at = sqlc.arg(expires_at)
+where id = sqlc.arg(id)
+returning *;
+
+-- name: DeleteBlocklistEntry :exec
+delete from blocklist where id = $1;
+
+-- name: FindBlocklistMatches :many
+select * from blocklist
+where (expires_at is null or expires_at > now())
+  and (
+    info_hash = any(sqlc.arg(info_hashes)::text[])
+    or (indexer_id::text || ':' || guid) = any(sqlc.arg(indexer_guids)::text[])
+    or normalized_title = any(sqlc.arg(normalized_titles)::text[])
+  )
+order by created_at desc;
+
+-- name: GetBlocklistEntryByDownloadJob :one
+select * from blocklist
+where download_job_id = $1
+order by created_at desc
+limit 1;
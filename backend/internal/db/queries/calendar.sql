@@ -0,0 +1,76 @@
+-- Release dates
+
+-- name: SetMediaItemReleaseDates :exec
+update media_item
+set release_date = sqlc.arg(release_date),
+    digital_release_date = sqlc.arg(digital_release_date),
+    physical_release_date = sqlc.arg(physical_release_date)
+where id = sqlc.arg(id);
+
+-- Calendar
+
+-- name: ListCalendarEpisodes :many
+-- Episodes airing between start_date and end_date, inclusive, and whether they
+-- have a file on disk.
+select
+  me.id as episode_id,
+  mi.id as media_item_id,
+  mi.tmdb_id,
+  mi.title,
+  ms.season_number,
+  me.episode_number,
+  me.title as episode_title,
+  me.air_date,
+  (mi.monitored and ms.monitored and me.monitored)::boolean as monitored,
+  exists (
+    select 1 from media_file mf
+    left join media_file_state mfs on mf.id = mfs.media_file_id
+    where mf.episode_id = me.id and coalesce(mfs.file_exists, true)
+  )::boolean as has_file
+from media_episode me
+join media_season ms on ms.id = me.season_id
+join media_item mi on mi.id = ms.media_item_id
+where mi.type = 'series'
+  and me.air_date between sqlc.arg(start_date) and sqlc.arg(end_date)
+order by me.air_date asc, mi.title asc, ms.season_number asc, me.episode_number asc;
+
+-- name: ListCalendarMovies :many
+-- Movies with a theatrical, digital or physical release between start_date and
+-- end_date, inclusive, and whether they have a file on disk.
+select
+  mi.id as media_item_id,
+  mi.tmdb_id,
+  mi.title,
+  mi.year,
+  mi.release_date,
+  mi.digital_release_date,
+  mi.physical_release_date,
+  mi.monitored,
+  exists (
+    select 1 from media_file mf
+    left join media_file_state mfs on mf.id = mfs.media_file_id
+    where mf.media_item_id = mi.id and coalesce(mfs.file_exists, true)
+  )::boolean as has_file
+from media_item mi
+where mi.type = 'movie'
+  and (mi.release_date between sqlc.arg(start_date) and sqlc.arg(end_date)
+    or mi.digital_release_date between sqlc.arg(start_date) and sqlc.arg(end_date)
+    or mi.physical_release_date between sqlc.arg(start_date) and sqlc.arg(end_date))
+order by mi.title asc;
+
+-- Feed tokens
+
+-- name: GetCalendarToken :one
+select * from calendar_token
+where user_id = $1;
+
+-- name: GetCalendarTokenByToken :one
+select * from calendar_token
+where token = $1;
+
+-- name: UpsertCalendarToken :one
+insert into calendar_token (user_id, token)
+values (sqlc.arg(user_id), sqlc.arg(token))
+on conflict (user_id)
+do update set token = excluded.token, created_at = now()
+returning *;
@@ -160,6 +160,9 @@ SELECT
   ms.season_number,
   l.name AS library_name,
   l.root_path AS library_root_path,
+  l.import_mode AS library_import_mode,
+  l.import_extra_files AS library_import_extra_files,
+  l.extra_file_extensions AS library_extra_file_extensions,
   nt.template AS name_template,
   nt.movie_dir_template,
   nt.series_show_template,
@@ -0,0 +1,71 @@
+-- name: ListIndexerHealth :many
+select * from indexer_health
+order by indexer_name asc;
+
+-- name: GetIndexerHealth :one
+select * from indexer_health
+where indexer_id = $1;
+
+-- name: ListDisabledIndexerIDs :many
+select indexer_id from indexer_health
+where disabled_until > now();
+
+-- name: RecordIndexerSuccess :one
+insert into indexer_health (indexer_id, indexer_name, consecutive_failures, last_success_at)
+values (sqlc.arg(indexer_id), sqlc.arg(indexer_name), 0, now())
+on conflict (indexer_id)
+do update set indexer_name = excluded.indexer_name,
+              consecutive_failures = 0,
+              last_success_at = now(),
+              disabled_until = null,
+              updated_at = now()
+returning *;
+
+-- name: RecordIndexerFailure :one
+insert into indexer_health (indexer_id, indexer_name, consecutive_failures, last_failure_at, last_error)
+values (sqlc.arg(indexer_id), sqlc.arg(indexer_name), 1, now(), sqlc.arg(last_error))
+on conflict (indexer_id)
+do update set indexer_name = excluded.indexer_name,
+              consecutive_failures = indexer_health.consecutive_failures + 1,
+              last_failure_at = now(),
+              last_error = excluded.last_error,
+              updated_at = now()
+returning *;
+
+-- name: SetIndexerDisabledUntil :exec
+update indexer_health
+set disabled_until = sqlc.narg(disabled_until),
+    updated_at = now()
+where indexer_id = sqlc.arg(indexer_id);
+
+-- name: ResetIndexerHealth :one
+update indexer_health
+set consecutive_failures = 0,
+    disabled_until = null,
+    updated_at = now()
+where indexer_id = $1
+returning *;
+
+-- name: CreateIndexerSearchEvent :exec
+insert into indexer_search_event (indexer_id, query, duration_ms, result_count, error)
+values (sqlc.arg(indexer_id), sqlc.arg(query), sqlc.arg(duration_ms), sqlc.arg(result_count), sqlc.narg(error));
+
+-- name: ListIndexerSearchEvents :many
+select * from indexer_search_event
+where indexer_id = $1
+order by created_at desc
+limit sqlc.arg(limit_val);
+
+-- name: ListIndexerSearchStats :many
+select
+  indexer_id,
+  count(*)::bigint as searches,
+  count(*) filter (where error is not null)::bigint as failures,
+  coalesce(avg(duration_ms), 0)::bigint as avg_duration_ms
+from indexer_search_event
+where created_at >= sqlc.arg(since)
+group by indexer_id;
+
+-- name: DeleteIndexerSearchEventsBefore :exec
+delete from indexer_search_event
+where created_at < sqlc.arg(before_time);
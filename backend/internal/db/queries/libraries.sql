@@ -7,8 +7,8 @@ select * from library
 where id = $1;
 
 -- name: CreateLibrary :one
-insert into library (name, type, root_path, enabled, "default")
-values (sqlc.arg(name), sqlc.arg(type), sqlc.arg(root_path), sqlc.arg(enabled), sqlc.arg(is_default))
+insert into library (name, type, root_path, enabled, "default", quality_profile_id, import_mode, import_extra_files, extra_file_extensions)
+values (sqlc.arg(name), sqlc.arg(type), sqlc.arg(root_path), sqlc.arg(enabled), sqlc.arg(is_default), sqlc.narg(quality_profile_id), sqlc.arg(import_mode), sqlc.arg(import_extra_files), sqlc.arg(extra_file_extensions))
 returning *;
 
 -- name: UpdateLibrary :one
@@ -18,6 +18,10 @@ set name = sqlc.arg(name),
     root_path = sqlc.arg(root_path),
     enabled = sqlc.arg(enabled),
     "default" = sqlc.arg(is_default),
+    quality_profile_id = sqlc.narg(quality_profile_id),
+    import_mode = sqlc.arg(import_mode),
+    import_extra_files = sqlc.arg(import_extra_files),
+    extra_file_extensions = sqlc.arg(extra_file_extensions),
     updated_at = now()
 where id = sqlc.arg(id)
 returning *;
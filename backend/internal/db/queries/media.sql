@@ -39,6 +39,21 @@ set title = $2,
 where id = $1
 returning *;
 
+-- name: UpdateMediaItemExternalIDs :one
+update media_item
+set imdb_id = sqlc.narg(imdb_id),
+    tvdb_id = sqlc.narg(tvdb_id),
+    updated_at = now()
+where id = sqlc.arg(id)
+returning *;
+
+-- name: SetMediaItemQualityProfile :one
+update media_item
+set quality_profile_id = sqlc.narg(quality_profile_id),
+    updated_at = now()
+where id = sqlc.arg(id)
+returning *;
+
 -- name: DeleteMediaItem :exec
 delete from media_item where id = $1;
 
@@ -61,7 +76,7 @@ where media_item_id = $1 and season_number = $2;
 insert into media_season (media_item_id, season_number, air_date)
 values (sqlc.arg(media_item_id), sqlc.arg(season_number), sqlc.arg(air_date))
 on conflict (media_item_id, season_number)
-do update set air_date = excluded.air_date
+do update set air_date = coalesce(excluded.air_date, media_season.air_date)
 returning *;
 
 -- Episodes
@@ -80,15 +95,84 @@ select * from media_episode
 where season_id = $1 and episode_number = $2;
 
 -- name: UpsertEpisode :one
+-- Metadata that isn't known (NULL) leaves the stored value alone on conflict.
 insert into media_episode (season_id, episode_number, title, air_date, tmdb_id, tvdb_id)
 values (sqlc.arg(season_id), sqlc.arg(episode_number), sqlc.arg(title), sqlc.arg(air_date), sqlc.arg(tmdb_id), sqlc.arg(tvdb_id))
 on conflict (season_id, episode_number)
-do update set title = excluded.title,
-              air_date = excluded.air_date,
-              tmdb_id = excluded.tmdb_id,
-              tvdb_id = excluded.tvdb_id
+do update set title = coalesce(excluded.title, media_episode.title),
+              air_date = coalesce(excluded.air_date, media_episode.air_date),
+              tmdb_id = coalesce(excluded.tmdb_id, media_episode.tmdb_id),
+              tvdb_id = coalesce(excluded.tvdb_id, media_episode.tvdb_id)
+returning *;
+
+-- name: SetEpisodeAbsoluteNumber :one
+-- Creates the episode if needed; other metadata is left alone on conflict.
+insert into media_episode (season_id, episode_number, absolute_number)
+values (sqlc.arg(season_id), sqlc.arg(episode_number), sqlc.arg(absolute_number))
+on conflict (season_id, episode_number)
+do update set absolute_number = excluded.absolute_number
+returning *;
+
+-- name: ListAbsoluteEpisodeNumbers :many
+select ms.season_number, me.episode_number, me.absolute_number
+from media_episode me
+join media_season ms on me.season_id = ms.id
+where ms.media_item_id = $1 and me.absolute_number is not null
+order by me.absolute_number;
+
+-- Monitoring
+
+-- name: SetMediaItemMonitoring :one
+update media_item
+set monitored = sqlc.arg(monitored),
+    monitor_new_seasons = sqlc.arg(monitor_new_seasons),
+    library_id = sqlc.narg(library_id),
+    updated_at = now()
+where id = sqlc.arg(id)
+returning *;
+
+-- name: SetSeasonMonitored :one
+update media_season
+set monitored = $2
+where id = $1
+returning *;
+
+-- name: SetSeasonEpisodesMonitored :exec
+update media_episode
+set monitored = $2
+where season_id = $1;
+
+-- name: SetEpisodeMonitored :one
+update media_episode
+set monitored = $2
+where id = $1
 returning *;
 
+-- name: ListMonitoringCounts :many
+-- An episode is missing when it is monitored along with its season and series,
+-- has aired, and has no file on disk. Episodes without an air date haven't aired.
+select
+  mi.id as media_item_id,
+  (count(me.id) filter (where mi.monitored and ms.monitored and me.monitored))::int as monitored_episodes,
+  (count(me.id) filter (
+    where mi.monitored and ms.monitored and me.monitored and me.air_date <= current_date
+      and not exists (
+        select 1 from media_file mf
+        left join media_file_state mfs on mf.id = mfs.media_file_id
+        where mf.episode_id = me.id and coalesce(mfs.file_exists, true)
+      )
+  ))::int as missing_episodes,
+  exists (
+    select 1 from media_file mf
+    left join media_file_state mfs on mf.id = mfs.media_file_id
+    where mf.media_item_id = mi.id and coalesce(mfs.file_exists, true)
+  ) as has_file
+from media_item mi
+left join media_season ms on ms.media_item_id = mi.id
+left join media_episode me on me.season_id = ms.id
+where mi.id = any(sqlc.arg(ids)::uuid[])
+group by mi.id;
+
 -- Files (removed season_id and status)
 
 -- name: GetMediaFile :one
@@ -98,8 +182,15 @@ select * from media_file where id = $1;
 select * from media_file where library_id = $1 and path = $2;
 
 -- name: CreateMediaFile :one
-insert into media_file (library_id, media_item_id, episode_id, path)
-values (sqlc.arg(library_id), sqlc.arg(media_item_id), sqlc.arg(episode_id), sqlc.arg(path))
+insert into media_file (library_id, media_item_id, episode_id, path, quality)
+values (sqlc.arg(library_id), sqlc.arg(media_item_id), sqlc.arg(episode_id), sqlc.arg(path), sqlc.narg(quality))
+returning *;
+
+-- name: ReplaceMediaFile :one
+-- Points a media file at the file that replaced it, keeping its import history.
+update media_file
+set path = sqlc.arg(path), quality = sqlc.narg(quality)
+where id = sqlc.arg(id)
 returning *;
 
 -- name: DeleteMediaFile :exec
@@ -113,6 +204,7 @@ select
   mf.episode_id,
   mf.path,
   mf.created_at,
+  mf.quality,
   ms.id as season_id,
   ms.season_number,
   me.episode_number,
@@ -135,7 +227,9 @@ select
   me.air_date,
   mf.id as file_id,
   mf.library_id,
-  mfs.file_exists
+  mfs.file_exists,
+  ms.monitored as season_monitored,
+  me.monitored
 from media_episode me
 join media_season ms on me.season_id = ms.id
 join media_item mi on ms.media_item_id = mi.id
@@ -216,8 +310,14 @@ limit sqlc.arg(limit_val);
 -- Media File Import queries
 
 -- name: CreateMediaFileImport :one
-insert into media_file_import (media_file_id, import_task_id, method, source_path, dest_path, success, error_message)
-values (sqlc.arg(media_file_id), sqlc.arg(import_task_id), sqlc.arg(method), sqlc.arg(source_path), sqlc.arg(dest_path), sqlc.arg(success), sqlc.arg(error_message))
+insert into media_file_import (
+  media_file_id, import_task_id, method, source_path, dest_path, success, error_message,
+  replaced_path, replaced_quality, archived_path
+)
+values (
+  sqlc.arg(media_file_id), sqlc.arg(import_task_id), sqlc.arg(method), sqlc.arg(source_path), sqlc.arg(dest_path), sqlc.arg(success), sqlc.arg(error_message),
+  sqlc.narg(replaced_path), sqlc.narg(replaced_quality), sqlc.narg(archived_path)
+)
 returning *;
 
 -- name: GetMediaFileImport :one
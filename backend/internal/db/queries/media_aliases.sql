@@ -0,0 +1,15 @@
+-- name: ListMediaItemAliases :many
+select * from media_item_alias
+where media_item_id = $1
+order by created_at asc;
+
+-- name: UpsertMediaItemAlias :one
+insert into media_item_alias (media_item_id, title, excluded)
+values (sqlc.arg(media_item_id), sqlc.arg(title), sqlc.arg(excluded))
+on conflict (media_item_id, title)
+do update set excluded = excluded.excluded
+returning *;
+
+-- name: DeleteMediaItemAlias :exec
+delete from media_item_alias
+where id = sqlc.arg(id) and media_item_id = sqlc.arg(media_item_id);
@@ -0,0 +1,12 @@
+-- name: ListMediaFileExtras :many
+select * from media_file_extra
+where media_file_id = $1
+order by type, path;
+
+-- name: CreateMediaFileExtra :one
+insert into media_file_extra (media_file_id, type, path, language, forced, sdh, source_path, method)
+values (sqlc.arg(media_file_id), sqlc.arg(type), sqlc.arg(path), sqlc.narg(language), sqlc.arg(forced), sqlc.arg(sdh), sqlc.narg(source_path), sqlc.arg(method))
+returning *;
+
+-- name: DeleteMediaFileExtra :exec
+delete from media_file_extra where id = $1;
@@ -0,0 +1,160 @@
+-- name: CreateMediaRequest :one
+insert into media_request (user_id, media_type, tmdb_id, title, year, seasons, season_count, quality_profile_id)
+values (sqlc.arg(user_id), sqlc.arg(media_type), sqlc.arg(tmdb_id), sqlc.arg(title), sqlc.narg(year), sqlc.arg(seasons)::int[],
+  sqlc.arg(season_count), sqlc.narg(quality_profile_id))
+returning *;
+
+-- name: GetMediaRequest :one
+select
+  r.*,
+  u.username as requested_by,
+  d.username as decided_by_username
+from media_request r
+join app_user u on u.id = r.user_id
+left join app_user d on d.id = r.decided_by
+where r.id = sqlc.arg(id);
+
+-- name: ListMediaRequests :many
+-- Newest first. user_id limits the list to one requester.
+select
+  r.*,
+  u.username as requested_by,
+  d.username as decided_by_username
+from media_request r
+join app_user u on u.id = r.user_id
+left join app_user d on d.id = r.decided_by
+where (sqlc.narg(user_id)::uuid is null or r.user_id = sqlc.narg(user_id))
+  and (sqlc.narg(status)::text is null or r.status = sqlc.narg(status))
+order by r.created_at desc
+limit sqlc.arg(page_size)::int offset sqlc.arg(offset_val)::int;
+
+-- name: CountMediaRequests :one
+select count(*)
+from media_request r
+where (sqlc.narg(user_id)::uuid is null or r.user_id = sqlc.narg(user_id))
+  and (sqlc.narg(status)::text is null or r.status = sqlc.narg(status));
+
+-- name: CountOpenMediaRequestsForUser :one
+-- Requests of a user that are neither declined nor available.
+select count(*)
+from media_request
+where user_id = sqlc.arg(user_id)
+  and status not in ('declined', 'available');
+
+-- name: GetUserRequestUsage :one
+-- Movies and seasons a user requested since the start of each quota window.
+-- Declined requests don't count.
+select
+  (count(*) filter (where media_type = 'movie' and created_at >= sqlc.arg(movies_since)))::int as movies,
+  (coalesce(sum(season_count) filter (where media_type = 'series' and created_at >= sqlc.arg(seasons_since)), 0))::int as seasons
+from media_request
+where user_id = sqlc.arg(user_id)
+  and status <> 'declined';
+
+-- name: ListOpenMediaRequestsForTitle :many
+select id, seasons
+from media_request
+where user_id = sqlc.arg(user_id)
+  and media_type = sqlc.arg(media_type)
+  and tmdb_id = sqlc.arg(tmdb_id)
+  and status not in ('declined', 'available');
+
+-- name: ListUserMediaRequestStatuses :many
+-- The latest request status of a user per title, for feed cards.
+select distinct on (media_type, tmdb_id) media_type, tmdb_id, status
+from media_request
+where user_id = sqlc.arg(user_id)
+order by media_type, tmdb_id, created_at desc;
+
+-- name: DecideMediaRequest :one
+-- Approves or declines a pending request. Returns no rows once it has been decided.
+update media_request
+set status = sqlc.arg(status),
+    decided_by = sqlc.arg(decided_by),
+    decided_at = now(),
+    decision_comment = sqlc.narg(decision_comment),
+    media_item_id = sqlc.narg(media_item_id),
+    updated_at = now()
+where id = sqlc.arg(id)
+  and status = 'pending'
+returning *;
+
+-- name: SetMediaRequestStatus :exec
+update media_request
+set status = sqlc.arg(status),
+    available_at = case when sqlc.arg(status) = 'available' then now() else available_at end,
+    updated_at = now()
+where id = sqlc.arg(id);
+
+-- name: GetTitleAvailability :one
+-- How much of a movie or the given seasons of a series is out and on disk.
+-- No seasons means every season above zero. A movie counts as one item that
+-- has aired once it is in the library.
+select
+  mi.id as media_item_id,
+  (case when mi.type = 'movie' then 1 else (
+    select count(*)
+    from media_episode me
+    join media_season ms on ms.id = me.season_id
+    where ms.media_item_id = mi.id
+      and (case when cardinality(sqlc.arg(seasons)::int[]) = 0 then ms.season_number > 0
+        else ms.season_number = any(sqlc.arg(seasons)::int[]) end)
+      and me.air_date is not null and me.air_date <= current_date
+  ) end)::int as aired,
+  (case when mi.type = 'movie' then (
+    case when exists (
+      select 1 from media_file mf
+      left join media_file_state mfs on mf.id = mfs.media_file_id
+      where mf.media_item_id = mi.id and coalesce(mfs.file_exists, true)
+    ) then 1 else 0 end
+  ) else (
+    select count(*)
+    from media_episode me
+    join media_season ms on ms.id = me.season_id
+    where ms.media_item_id = mi.id
+      and (case when cardinality(sqlc.arg(seasons)::int[]) = 0 then ms.season_number > 0
+        else ms.season_number = any(sqlc.arg(seasons)::int[]) end)
+      and me.air_date is not null and me.air_date <= current_date
+      and exists (
+        select 1 from media_file mf
+        left join media_file_state mfs on mf.id = mfs.media_file_id
+        where mf.episode_id = me.id and coalesce(mfs.file_exists, true)
+      )
+  ) end)::int as on_disk
+from media_item mi
+where mi.type = sqlc.arg(media_type)
+  and mi.tmdb_id = sqlc.arg(tmdb_id);
+
+-- name: ListActiveMediaRequests :many
+-- Approved requests still in flight, with whether a download job or import
+-- task is running for what they asked for.
+select
+  r.id,
+  r.media_type,
+  r.tmdb_id,
+  r.seasons,
+  r.status,
+  exists (
+    select 1 from download_job dj
+    left join media_season ms on ms.id = dj.season_id
+    left join media_episode me on me.id = dj.episode_id
+    left join media_season mes on mes.id = me.season_id
+    where dj.media_item_id = r.media_item_id
+      and dj.status in ('created', 'enqueued', 'downloading')
+      and (cardinality(r.seasons) = 0
+        or coalesce(ms.season_number, mes.season_number) is null
+        or coalesce(ms.season_number, mes.season_number) = any(r.seasons))
+  )::boolean as downloading,
+  exists (
+    select 1 from import_task it
+    left join media_episode me on me.id = it.episode_id
+    left join media_season ms on ms.id = me.season_id
+    where it.media_item_id = r.media_item_id
+      and it.status in ('pending', 'in_progress')
+      and (cardinality(r.seasons) = 0
+        or ms.season_number is null
+        or ms.season_number = any(r.seasons))
+  )::boolean as importing
+from media_request r
+where r.status in ('approved', 'downloading', 'importing')
+order by r.created_at asc;
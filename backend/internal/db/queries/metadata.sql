@@ -0,0 +1,71 @@
+-- Refresh schedule
+
+-- name: ListMetadataRefreshItems :many
+-- Library items whose metadata was last refreshed before refreshed_before,
+-- least recently refreshed first.
+select * from media_item
+where tmdb_id is not null
+  and (metadata_refreshed_at is null or metadata_refreshed_at < sqlc.arg(refreshed_before))
+order by metadata_refreshed_at asc nulls first, created_at asc
+limit sqlc.arg(row_limit)::int;
+
+-- name: SetMediaItemMetadataRefreshed :exec
+update media_item set metadata_refreshed_at = now() where id = $1;
+
+-- Episode reconciliation
+
+-- name: ListEpisodesForMedia :many
+select me.id, me.season_id, ms.season_number, me.episode_number, me.tmdb_id, me.tvdb_id, me.monitored
+from media_episode me
+join media_season ms on ms.id = me.season_id
+where ms.media_item_id = $1
+order by ms.season_number asc, me.episode_number asc;
+
+-- name: MoveEpisode :exec
+update media_episode
+set season_id = sqlc.arg(season_id), episode_number = sqlc.arg(episode_number)
+where id = sqlc.arg(id);
+
+-- name: SetEpisodeTvdbID :exec
+update media_episode set tvdb_id = $2 where id = $1;
+
+-- name: EpisodeInUse :one
+-- Whether a file, download job or import task points at the episode.
+select (
+  exists (select 1 from media_file where episode_id = $1)
+  or exists (select 1 from download_job where episode_id = $1)
+  or exists (select 1 from import_task where episode_id = $1)
+)::boolean as in_use;
+
+-- name: ReassignEpisodeFiles :exec
+update media_file set episode_id = sqlc.arg(to_episode_id)
+where episode_id = sqlc.arg(from_episode_id);
+
+-- name: ReassignEpisodeDownloadJobs :exec
+update download_job set episode_id = sqlc.arg(to_episode_id), updated_at = now()
+where episode_id = sqlc.arg(from_episode_id);
+
+-- name: ReassignEpisodeImportTasks :exec
+update import_task set episode_id = sqlc.arg(to_episode_id), updated_at = now()
+where episode_id = sqlc.arg(from_episode_id);
+
+-- name: RealignDownloadJobSeasons :exec
+-- Points download jobs of a series' episodes at the season the episode now
+-- belongs to.
+update download_job dj
+set season_id = me.season_id, updated_at = now()
+from media_episode me
+join media_season ms on ms.id = me.season_id
+where dj.episode_id = me.id
+  and ms.media_item_id = $1
+  and dj.season_id is distinct from me.season_id;
+
+-- name: DeleteEpisode :exec
+delete from media_episode where id = $1;
+
+-- name: DeleteSeasonIfUnused :exec
+-- Deletes a season that has no episodes and no download jobs.
+delete from media_season ms
+where ms.id = $1
+  and not exists (select 1 from media_episode where season_id = ms.id)
+  and not exists (select 1 from download_job where season_id = ms.id);
@@ -0,0 +1,36 @@
+-- name: ListQualityProfiles :many
+select * from quality_profile
+order by name asc;
+
+-- name: GetQualityProfile :one
+select * from quality_profile
+where id = $1;
+
+-- name: GetDefaultQualityProfile :one
+select * from quality_profile
+where "default" = true;
+
+-- name: CreateQualityProfile :one
+insert into quality_profile (name, items, cutoff, upgrades_allowed, "default")
+values (sqlc.arg(name), sqlc.arg(items), sqlc.arg(cutoff), sqlc.arg(upgrades_allowed), sqlc.arg(is_default))
+returning *;
+
+-- name: UpdateQualityProfile :one
+update quality_profile
+set name = sqlc.arg(name),
+    items = sqlc.arg(items),
+    cutoff = sqlc.arg(cutoff),
+    upgrades_allowed = sqlc.arg(upgrades_allowed),
+    "default" = sqlc.arg(is_default),
+    updated_at = now()
+where id = sqlc.arg(id)
+returning *;
+
+-- name: UnsetDefaultQualityProfiles :exec
+update quality_profile
+set "default" = false,
+    updated_at = now()
+where "default" = true and id <> $1;
+
+-- name: DeleteQualityProfile :exec
+delete from quality_profile where id = $1;
@@ -0,0 +1,33 @@
+-- Remote path mappings
+
+-- name: ListRemotePathMappings :many
+select m.*, d.name as downloader_name
+from remote_path_mapping m
+join downloader d on d.id = m.downloader_id
+order by d.name asc, m.remote_path asc;
+
+-- name: ListRemotePathMappingsForDownloader :many
+select * from remote_path_mapping
+where downloader_id = sqlc.arg(downloader_id);
+
+-- name: GetRemotePathMapping :one
+select * from remote_path_mapping
+where id = sqlc.arg(id);
+
+-- name: CreateRemotePathMapping :one
+insert into remote_path_mapping (downloader_id, remote_path, local_path)
+values (sqlc.arg(downloader_id), sqlc.arg(remote_path), sqlc.arg(local_path))
+returning *;
+
+-- name: UpdateRemotePathMapping :one
+update remote_path_mapping
+set downloader_id = sqlc.arg(downloader_id),
+    remote_path = sqlc.arg(remote_path),
+    local_path = sqlc.arg(local_path),
+    updated_at = now()
+where id = sqlc.arg(id)
+returning *;
+
+-- name: DeleteRemotePathMapping :exec
+delete from remote_path_mapping
+where id = sqlc.arg(id);
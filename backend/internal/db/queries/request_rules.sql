@@ -0,0 +1,74 @@
+-- Quotas
+
+-- name: ListRequestQuotas :many
+select * from request_quota
+order by subject_type, created_at;
+
+-- name: ListRequestQuotasForUser :many
+-- The user's own quota and those of the user's roles.
+select q.*
+from request_quota q
+where (q.subject_type = 'user' and q.subject_id = sqlc.arg(user_id))
+  or (q.subject_type = 'role' and q.subject_id in (
+    select ur.role_id from user_role ur where ur.user_id = sqlc.arg(user_id)
+  ));
+
+-- name: UpsertRequestQuota :one
+insert into request_quota (subject_type, subject_id, movie_limit, season_limit, window_days)
+values (sqlc.arg(subject_type), sqlc.arg(subject_id), sqlc.narg(movie_limit), sqlc.narg(season_limit), sqlc.arg(window_days))
+on conflict (subject_type, subject_id) do update
+set movie_limit = excluded.movie_limit,
+    season_limit = excluded.season_limit,
+    window_days = excluded.window_days,
+    updated_at = now()
+returning *;
+
+-- name: DeleteRequestQuota :exec
+delete from request_quota
+where subject_type = sqlc.arg(subject_type) and subject_id = sqlc.arg(subject_id);
+
+-- Auto-approval rules
+
+-- name: ListRequestRules :many
+select rr.*, r.name as role_name
+from request_rule rr
+join role r on r.id = rr.role_id
+order by r.name, rr.priority desc, rr.created_at;
+
+-- name: ListRequestRulesForUser :many
+-- Enabled rules of the user's roles, highest priority first.
+select rr.*, r.name as role_name
+from request_rule rr
+join role r on r.id = rr.role_id
+join user_role ur on ur.role_id = rr.role_id
+where ur.user_id = sqlc.arg(user_id)
+  and rr.enabled
+order by rr.priority desc, rr.created_at;
+
+-- name: GetRequestRule :one
+select * from request_rule
+where id = sqlc.arg(id);
+
+-- name: CreateRequestRule :one
+insert into request_rule (role_id, name, enabled, priority, left_operand, operator, right_operand, action)
+values (sqlc.arg(role_id), sqlc.arg(name), sqlc.arg(enabled), sqlc.arg(priority),
+  sqlc.narg(left_operand), sqlc.narg(operator), sqlc.narg(right_operand), sqlc.arg(action))
+returning *;
+
+-- name: UpdateRequestRule :one
+update request_rule
+set role_id = sqlc.arg(role_id),
+    name = sqlc.arg(name),
+    enabled = sqlc.arg(enabled),
+    priority = sqlc.arg(priority),
+    left_operand = sqlc.narg(left_operand),
+    operator = sqlc.narg(operator),
+    right_operand = sqlc.narg(right_operand),
+    action = sqlc.arg(action),
+    updated_at = now()
+where id = sqlc.arg(id)
+returning *;
+
+-- name: DeleteRequestRule :exec
+delete from request_rule
+where id = sqlc.arg(id);
@@ -6,4 +6,23 @@ WHERE ur.user_id = $1;
 -- name: AssignRole :exec
 INSERT INTO user_role (user_id, role_id)
 VALUES ($1, $2)
-ON CONFLICT DO NOTHING;
\ No newline at end of file
+ON CONFLICT DO NOTHING;
+
+-- name: UserHasPermission :one
+-- Whether a global grant allows the permission to the user, directly or via
+-- one of the user's roles, with no deny grant overriding it.
+WITH grants AS (
+  SELECT g.effect
+  FROM permission_grant g
+  WHERE g.permission_key = sqlc.arg(permission_key)
+    AND g.resource_type IS NULL AND g.resource_id IS NULL
+    AND (
+      (g.subject_type = 'user' AND g.subject_id = sqlc.arg(user_id))
+      OR (g.subject_type = 'role' AND g.subject_id IN (
+        SELECT ur.role_id FROM user_role ur WHERE ur.user_id = sqlc.arg(user_id)
+      ))
+    )
+)
+SELECT
+  EXISTS (SELECT 1 FROM grants WHERE effect = 'allow')
+  AND NOT EXISTS (SELECT 1 FROM grants WHERE effect = 'deny');
@@ -0,0 +1,57 @@
+-- name: CreateSearchSession :one
+insert into search_session (media_type, tmdb_id, season_number, episode_number, query, sources, expires_at)
+values (sqlc.arg(media_type), sqlc.arg(tmdb_id), sqlc.narg(season_number), sqlc.narg(episode_number), sqlc.arg(query), sqlc.arg(sources), sqlc.arg(expires_at))
+returning *;
+
+-- name: GetSearchSession :one
+select * from search_session
+where id = $1;
+
+-- name: ListSearchSessions :many
+select
+  ss.*,
+  (select count(*) from search_result sr where sr.session_id = ss.id)::bigint as result_count
+from search_session ss
+where ss.media_type = sqlc.arg(media_type)
+  and ss.tmdb_id = sqlc.arg(tmdb_id)
+  and ss.expires_at > now()
+order by ss.created_at desc
+limit sqlc.arg(limit_val);
+
+-- name: InsertSearchResults :exec
+insert into search_result (session_id, position, indexer_id, guid, title, candidate)
+select sqlc.arg(session_id), r.position, r.indexer_id, r.guid, r.title, r.candidate
+from unnest(
+  sqlc.arg(positions)::int[],
+  sqlc.arg(indexer_ids)::bigint[],
+  sqlc.arg(guids)::text[],
+  sqlc.arg(titles)::text[],
+  sqlc.arg(candidates)::jsonb[]
+) as r(position, indexer_id, guid, title, candidate)
+on conflict (session_id, indexer_id, guid) do nothing;
+
+-- name: ListSearchResults :many
+select * from search_result
+where session_id = $1
+order by position asc;
+
+-- name: GetSearchResultInSession :one
+select sr.*, ss.expires_at
+from search_result sr
+join search_session ss on ss.id = sr.session_id
+where sr.session_id = sqlc.arg(session_id)
+  and sr.indexer_id = sqlc.arg(indexer_id)
+  and sr.guid = sqlc.arg(guid);
+
+-- name: GetLatestSearchResult :one
+select sr.*, ss.expires_at
+from search_result sr
+join search_session ss on ss.id = sr.session_id
+where sr.indexer_id = sqlc.arg(indexer_id)
+  and sr.guid = sqlc.arg(guid)
+order by ss.created_at desc
+limit 1;
+
+-- name: DeleteExpiredSearchSessions :exec
+delete from search_session
+where expires_at <= now();
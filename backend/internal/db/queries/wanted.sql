@@ -0,0 +1,147 @@
+-- Missing
+
+-- name: ListWantedMissing :many
+-- Movies and episodes with no file on disk, newest first. aired filters on
+-- whether the air date (theatrical release for movies) has passed; without a
+-- date an item hasn't aired. library_id matches the library an item is
+-- monitored into or holds any of its files.
+with missing as (
+  select
+    'movie'::text as media_type,
+    mi.id as media_item_id,
+    null::uuid as episode_id,
+    mi.tmdb_id,
+    mi.title,
+    mi.year,
+    mi.library_id,
+    null::int as season_number,
+    null::int as episode_number,
+    null::text as episode_title,
+    mi.release_date as air_date,
+    mi.monitored,
+    mi.last_searched_at
+  from media_item mi
+  where mi.type = 'movie'
+    and not exists (
+      select 1 from media_file mf
+      left join media_file_state mfs on mf.id = mfs.media_file_id
+      where mf.media_item_id = mi.id and coalesce(mfs.file_exists, true)
+    )
+  union all
+  select
+    'series'::text,
+    mi.id,
+    me.id,
+    mi.tmdb_id,
+    mi.title,
+    mi.year,
+    mi.library_id,
+    ms.season_number,
+    me.episode_number,
+    me.title,
+    me.air_date,
+    (mi.monitored and ms.monitored and me.monitored),
+    me.last_searched_at
+  from media_episode me
+  join media_season ms on ms.id = me.season_id
+  join media_item mi on mi.id = ms.media_item_id
+  where mi.type = 'series'
+    and not exists (
+      select 1 from media_file mf
+      left join media_file_state mfs on mf.id = mfs.media_file_id
+      where mf.episode_id = me.id and coalesce(mfs.file_exists, true)
+    )
+)
+select * from missing m
+where (sqlc.narg(media_type)::text is null or m.media_type = sqlc.narg(media_type))
+  and (sqlc.narg(library_id)::uuid is null or m.library_id = sqlc.narg(library_id)
+    or exists (select 1 from media_file mf where mf.media_item_id = m.media_item_id and mf.library_id = sqlc.narg(library_id)))
+  and (sqlc.narg(series_tmdb_id)::bigint is null or (m.media_type = 'series' and m.tmdb_id = sqlc.narg(series_tmdb_id)))
+  and (sqlc.arg(include_unmonitored)::boolean or m.monitored)
+  and (sqlc.narg(aired)::boolean is null
+    or sqlc.narg(aired) = (m.air_date is not null and m.air_date <= current_date))
+order by m.air_date desc nulls last, m.title asc, m.season_number asc, m.episode_number asc
+limit sqlc.arg(page_size)::int offset sqlc.arg(offset_val)::int;
+
+-- name: CountWantedMissing :one
+with missing as (
+  select
+    'movie'::text as media_type,
+    mi.id as media_item_id,
+    mi.tmdb_id,
+    mi.library_id,
+    mi.release_date as air_date,
+    mi.monitored
+  from media_item mi
+  where mi.type = 'movie'
+    and not exists (
+      select 1 from media_file mf
+      left join media_file_state mfs on mf.id = mfs.media_file_id
+      where mf.media_item_id = mi.id and coalesce(mfs.file_exists, true)
+    )
+  union all
+  select
+    'series'::text,
+    mi.id,
+    mi.tmdb_id,
+    mi.library_id,
+    me.air_date,
+    (mi.monitored and ms.monitored and me.monitored)
+  from media_episode me
+  join media_season ms on ms.id = me.season_id
+  join media_item mi on mi.id = ms.media_item_id
+  where mi.type = 'series'
+    and not exists (
+      select 1 from media_file mf
+      left join media_file_state mfs on mf.id = mfs.media_file_id
+      where mf.episode_id = me.id and coalesce(mfs.file_exists, true)
+    )
+)
+select count(*) from missing m
+where (sqlc.narg(media_type)::text is null or m.media_type = sqlc.narg(media_type))
+  and (sqlc.narg(library_id)::uuid is null or m.library_id = sqlc.narg(library_id)
+    or exists (select 1 from media_file mf where mf.media_item_id = m.media_item_id and mf.library_id = sqlc.narg(library_id)))
+  and (sqlc.narg(series_tmdb_id)::bigint is null or (m.media_type = 'series' and m.tmdb_id = sqlc.narg(series_tmdb_id)))
+  and (sqlc.arg(include_unmonitored)::boolean or m.monitored)
+  and (sqlc.narg(aired)::boolean is null
+    or sqlc.narg(aired) = (m.air_date is not null and m.air_date <= current_date));
+
+-- Cutoff unmet
+
+-- name: ListWantedCutoffFiles :many
+-- Files on disk of movies and episodes, one row per file, with the quality
+-- profile that applies: the item's own, else its library's, else the file's
+-- library's. NULL means the default profile. Whether a file is below its
+-- profile's cutoff is decided by the caller.
+select
+  mi.type as media_type,
+  mi.id as media_item_id,
+  me.id as episode_id,
+  mi.tmdb_id,
+  mi.title,
+  mi.year,
+  mf.library_id,
+  ms.season_number,
+  me.episode_number,
+  me.title as episode_title,
+  coalesce(me.air_date, mi.release_date) as air_date,
+  (mi.monitored and coalesce(ms.monitored, true) and coalesce(me.monitored, true))::boolean as monitored,
+  coalesce(me.last_searched_at, mi.last_searched_at) as last_searched_at,
+  mf.path,
+  mf.quality,
+  coalesce(mi.quality_profile_id, case when il.id is not null then il.quality_profile_id else fl.quality_profile_id end) as quality_profile_id
+from media_file mf
+join media_item mi on mi.id = mf.media_item_id
+join library fl on fl.id = mf.library_id
+left join library il on il.id = mi.library_id
+left join media_episode me on me.id = mf.episode_id
+left join media_season ms on ms.id = me.season_id
+left join media_file_state mfs on mf.id = mfs.media_file_id
+where coalesce(mfs.file_exists, true)
+  and (mi.type = 'movie' or me.id is not null)
+  and (sqlc.narg(media_type)::text is null or mi.type = sqlc.narg(media_type))
+  and (sqlc.narg(library_id)::uuid is null or mf.library_id = sqlc.narg(library_id) or mi.library_id = sqlc.narg(library_id))
+  and (sqlc.narg(series_tmdb_id)::bigint is null or (mi.type = 'series' and mi.tmdb_id = sqlc.narg(series_tmdb_id)))
+  and (sqlc.arg(include_unmonitored)::boolean
+    or (mi.monitored and coalesce(ms.monitored, true) and coalesce(me.monitored, true)))
+order by mi.title asc, ms.season_number asc nulls first, me.episode_number asc nulls first;
@@ -362,6 +362,9 @@ SELECT
   ms.season_number,
   l.name AS library_name,
   l.root_path AS library_root_path,
+  l.import_mode AS library_import_mode,
+  l.import_extra_files AS library_import_extra_files,
+  l.extra_file_extensions AS library_extra_file_extensions,
   nt.template AS name_template,
   nt.movie_dir_template,
   nt.series_show_template,
@@ -378,40 +381,43 @@ WHERE it.id = $1
 `
 
 type GetImportTaskWithDetailsRow struct {
-	ID                   pgtype.UUID `json:"id"`
-	Status               string      `json:"status"`
-	DownloadJobID        pgtype.UUID `json:"download_job_id"`
-	SourcePath           string      `json:"source_path"`
-	PreviousTaskID       pgtype.UUID `json:"previous_task_id"`
-	MediaType            string      `json:"media_type"`
-	MediaItemID          pgtype.UUID `json:"media_item_id"`
-	EpisodeID            pgtype.UUID `json:"episode_id"`
-	LibraryID            pgtype.UUID `json:"library_id"`
-	NameTemplateID       pgtype.UUID `json:"name_template_id"`
-	DestPath             *string     `json:"dest_path"`
-	ImportMethod         *string     `json:"import_method"`
-	MediaFileID          pgtype.UUID `json:"media_file_id"`
-	AttemptCount         int32       `json:"attempt_count"`
-	MaxAttempts          int32       `json:"max_attempts"`
-	NextRunAt            time.Time   `json:"next_run_at"`
-	LastError            *string     `json:"last_error"`
-	ErrorCategory        *string     `json:"error_category"`
-	CreatedAt            time.Time   `json:"created_at"`
-	UpdatedAt            time.Time   `json:"updated_at"`
-	MediaTitle           string      `json:"media_title"`
-	MediaYear            *int32      `json:"media_year"`
-	MediaTmdbID          *int64      `json:"media_tmdb_id"`
-	MediaItemType        string      `json:"media_item_type"`
-	EpisodeNumber        *int32      `json:"episode_number"`
-	EpisodeTitle         *string     `json:"episode_title"`
-	SeasonNumber         *int32      `json:"season_number"`
-	LibraryName          string      `json:"library_name"`
-	LibraryRootPath      string      `json:"library_root_path"`
-	NameTemplate         string      `json:"name_template"`
-	MovieDirTemplate     *string     `json:"movie_dir_template"`
-	SeriesShowTemplate   *string     `json:"series_show_template"`
-	SeriesSeasonTemplate *string     `json:"series_season_template"`
-	CandidateTitle       *string     `json:"candidate_title"`
+	ID                         pgtype.UUID `json:"id"`
+	Status                     string      `json:"status"`
+	DownloadJobID              pgtype.UUID `json:"download_job_id"`
+	SourcePath                 string      `json:"source_path"`
+	PreviousTaskID             pgtype.UUID `json:"previous_task_id"`
+	MediaType                  string      `json:"media_type"`
+	MediaItemID                pgtype.UUID `json:"media_item_id"`
+	EpisodeID                  pgtype.UUID `json:"episode_id"`
+	LibraryID                  pgtype.UUID `json:"library_id"`
+	NameTemplateID             pgtype.UUID `json:"name_template_id"`
+	DestPath                   *string     `json:"dest_path"`
+	ImportMethod               *string     `json:"import_method"`
+	MediaFileID                pgtype.UUID `json:"media_file_id"`
+	AttemptCount               int32       `json:"attempt_count"`
+	MaxAttempts                int32       `json:"max_attempts"`
+	NextRunAt                  time.Time   `json:"next_run_at"`
+	LastError                  *string     `json:"last_error"`
+	ErrorCategory              *string     `json:"error_category"`
+	CreatedAt                  time.Time   `json:"created_at"`
+	UpdatedAt                  time.Time   `json:"updated_at"`
+	MediaTitle                 string      `json:"media_title"`
+	MediaYear                  *int32      `json:"media_year"`
+	MediaTmdbID                *int64      `json:"media_tmdb_id"`
+	MediaItemType              string      `json:"media_item_type"`
+	EpisodeNumber              *int32      `json:"episode_number"`
+	EpisodeTitle               *string     `json:"episode_title"`
+	SeasonNumber               *int32      `json:"season_number"`
+	LibraryName                string      `json:"library_name"`
+	LibraryRootPath            string      `json:"library_root_path"`
+	LibraryImportMode          string      `json:"library_import_mode"`
+	LibraryImportExtraFiles    bool        `json:"library_import_extra_files"`
+	LibraryExtraFileExtensions []string    `json:"library_extra_file_extensions"`
+	NameTemplate               string      `json:"name_template"`
+	MovieDirTemplate           *string     `json:"movie_dir_template"`
+	SeriesShowTemplate         *string     `json:"series_show_template"`
+	SeriesSeasonTemplate       *string     `json:"series_season_template"`
+	CandidateTitle             *string     `json:"candidate_title"`
 }
 
 // Get import task with related media info and name template
@@ -448,6 +454,9 @@ func (q *Queries) GetImportTaskWithDetails(ctx context.Context, id pgtype.UUID)
 		&i.SeasonNumber,
 		&i.LibraryName,
 		&i.LibraryRootPath,
+		&i.LibraryImportMode,
+		&i.LibraryImportExtraFiles,
+		&i.LibraryExtraFileExtensions,
 		&i.NameTemplate,
 		&i.MovieDirTemplate,
 		&i.SeriesShowTemplate,
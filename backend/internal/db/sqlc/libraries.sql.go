@@ -12,17 +12,21 @@ import (
 )
 
 const createLibrary = `-- name: CreateLibrary :one
-insert into library (name, type, root_path, enabled, "default")
-values ($1, $2, $3, $4, $5)
-returning id, name, type, root_path, enabled, "default", created_at, updated_at
+insert into library (name, type, root_path, enabled, "default", quality_profile_id, import_mode, import_extra_files, extra_file_extensions)
+values ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+returning id, name, type, root_path, enabled, "default", created_at, updated_at, quality_profile_id, import_mode, import_extra_files, extra_file_extensions
 `
 
 type CreateLibraryParams struct {
-	Name      string `json:"name"`
-	Type      string `json:"type"`
-	RootPath  string `json:"root_path"`
-	Enabled   bool   `json:"enabled"`
-	IsDefault bool   `json:"is_default"`
+	Name                string      `json:"name"`
+	Type                string      `json:"type"`
+	RootPath            string      `json:"root_path"`
+	Enabled             bool        `json:"enabled"`
+	IsDefault           bool        `json:"is_default"`
+	QualityProfileID    pgtype.UUID `json:"quality_profile_id"`
+	ImportMode          string      `json:"import_mode"`
+	ImportExtraFiles    bool        `json:"import_extra_files"`
+	ExtraFileExtensions []string    `json:"extra_file_extensions"`
 }
 
 func (q *Queries) CreateLibrary(ctx context.Context, arg CreateLibraryParams) (Library, error) {
@@ -32,6 +36,10 @@ func (q *Queries) CreateLibrary(ctx context.Context, arg CreateLibraryParams) (L
 		arg.RootPath,
 		arg.Enabled,
 		arg.IsDefault,
+		arg.QualityProfileID,
+		arg.ImportMode,
+		arg.ImportExtraFiles,
+		arg.ExtraFileExtensions,
 	)
 	var i Library
 	err := row.Scan(
@@ -43,6 +51,10 @@ func (q *Queries) CreateLibrary(ctx context.Context, arg CreateLibraryParams) (L
 		&i.Default,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.QualityProfileID,
+		&i.ImportMode,
+		&i.ImportExtraFiles,
+		&i.ExtraFileExtensions,
 	)
 	return i, err
 }
@@ -57,7 +69,7 @@ func (q *Queries) DeleteLibrary(ctx context.Context, id pgtype.UUID) error {
 }
 
 const getDefaultLibrary = `-- name: GetDefaultLibrary :one
-select id, name, type, root_path, enabled, "default", created_at, updated_at from library
+select id, name, type, root_path, enabled, "default", created_at, updated_at, quality_profile_id, import_mode, import_extra_files, extra_file_extensions from library
 where type = $1 and "default" = true
 `
 
@@ -73,12 +85,16 @@ func (q *Queries) GetDefaultLibrary(ctx context.Context, type_ string) (Library,
 		&i.Default,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.QualityProfileID,
+		&i.ImportMode,
+		&i.ImportExtraFiles,
+		&i.ExtraFileExtensions,
 	)
 	return i, err
 }
 
 const getLibrary = `-- name: GetLibrary :one
-select id, name, type, root_path, enabled, "default", created_at, updated_at from library
+select id, name, type, root_path, enabled, "default", created_at, updated_at, quality_profile_id, import_mode, import_extra_files, extra_file_extensions from library
 where id = $1
 `
 
@@ -94,12 +110,16 @@ func (q *Queries) GetLibrary(ctx context.Context, id pgtype.UUID) (Library, erro
 		&i.Default,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.QualityProfileID,
+		&i.ImportMode,
+		&i.ImportExtraFiles,
+		&i.ExtraFileExtensions,
 	)
 	return i, err
 }
 
 const listLibraries = `-- name: ListLibraries :many
-select id, name, type, root_path, enabled, "default", created_at, updated_at from library
+select id, name, type, root_path, enabled, "default", created_at, updated_at, quality_profile_id, import_mode, import_extra_files, extra_file_extensions from library
 order by name asc
 `
 
@@ -121,6 +141,10 @@ func (q *Queries) ListLibraries(ctx context.Context) ([]Library, error) {
 			&i.Default,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.QualityProfileID,
+			&i.ImportMode,
+			&i.ImportExtraFiles,
+			&i.ExtraFileExtensions,
 		); err != nil {
 			return nil, err
 		}
@@ -139,18 +163,26 @@ set name = $1,
     root_path = $3,
     enabled = $4,
     "default" = $5,
+    quality_profile_id = $6,
+    import_mode = $7,
+    import_extra_files = $8,
+    extra_file_extensions = $9,
     updated_at = now()
-where id = $6
-returning id, name, type, root_path, enabled, "default", created_at, updated_at
+where id = $10
+returning id, name, type, root_path, enabled, "default", created_at, updated_at, quality_profile_id, import_mode, import_extra_files, extra_file_extensions
 `
 
 type UpdateLibraryParams struct {
-	Name      string      `json:"name"`
-	Type      string      `json:"type"`
-	RootPath  string      `json:"root_path"`
-	Enabled   bool        `json:"enabled"`
-	IsDefault bool        `json:"is_default"`
-	ID        pgtype.UUID `json:"id"`
+	Name                string      `json:"name"`
+	Type                string      `json:"type"`
+	RootPath            string      `json:"root_path"`
+	Enabled             bool        `json:"enabled"`
+	IsDefault           bool        `json:"is_default"`
+	QualityProfileID    pgtype.UUID `json:"quality_profile_id"`
+	ImportMode          string      `json:"import_mode"`
+	ImportExtraFiles    bool        `json:"import_extra_files"`
+	ExtraFileExtensions []string    `json:"extra_file_extensions"`
+	ID                  pgtype.UUID `json:"id"`
 }
 
 func (q *Queries) UpdateLibrary(ctx context.Context, arg UpdateLibraryParams) (Library, error) {
@@ -160,6 +192,10 @@ func (q *Queries) UpdateLibrary(ctx context.Context, arg UpdateLibraryParams) (L
 		arg.RootPath,
 		arg.Enabled,
 		arg.IsDefault,
+		arg.QualityProfileID,
+		arg.ImportMode,
+		arg.ImportExtraFiles,
+		arg.ExtraFileExtensions,
 		arg.ID,
 	)
 	var i Library
@@ -172,6 +208,10 @@ func (q *Queries) UpdateLibrary(ctx context.Context, arg UpdateLibraryParams) (L
 		&i.Default,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.QualityProfileID,
+		&i.ImportMode,
+		&i.ImportExtraFiles,
+		&i.ExtraFileExtensions,
 	)
 	return i, err
 }
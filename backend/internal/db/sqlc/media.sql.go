@@ -45,9 +45,9 @@ func (q *Queries) CountUnmatchedFiles(ctx context.Context, libraryID pgtype.UUID
 }
 
 const createMediaFile = `-- name: CreateMediaFile :one
-insert into media_file (library_id, media_item_id, episode_id, path)
-values ($1, $2, $3, $4)
-returning id, library_id, media_item_id, episode_id, path, created_at
+insert into media_file (library_id, media_item_id, episode_id, path, quality)
+values ($1, $2, $3, $4, $5)
+returning id, library_id, media_item_id, episode_id, path, created_at, quality
 `
 
 type CreateMediaFileParams struct {
@@ -55,6 +55,7 @@ type CreateMediaFileParams struct {
 	MediaItemID pgtype.UUID `json:"media_item_id"`
 	EpisodeID   pgtype.UUID `json:"episode_id"`
 	Path        string      `json:"path"`
+	Quality     *string     `json:"quality"`
 }
 
 func (q *Queries) CreateMediaFile(ctx context.Context, arg CreateMediaFileParams) (MediaFile, error) {
@@ -63,6 +64,7 @@ func (q *Queries) CreateMediaFile(ctx context.Context, arg CreateMediaFileParams
 		arg.MediaItemID,
 		arg.EpisodeID,
 		arg.Path,
+		arg.Quality,
 	)
 	var i MediaFile
 	err := row.Scan(
@@ -72,25 +74,34 @@ func (q *Queries) CreateMediaFile(ctx context.Context, arg CreateMediaFileParams
 		&i.EpisodeID,
 		&i.Path,
 		&i.CreatedAt,
+		&i.Quality,
 	)
 	return i, err
 }
 
 const createMediaFileImport = `-- name: CreateMediaFileImport :one
-
-insert into media_file_import (media_file_id, import_task_id, method, source_path, dest_path, success, error_message)
-values ($1, $2, $3, $4, $5, $6, $7)
-returning id, media_file_id, import_task_id, method, source_path, dest_path, attempted_at, success, error_message
+insert into media_file_import (
+  media_file_id, import_task_id, method, source_path, dest_path, success, error_message,
+  replaced_path, replaced_quality, archived_path
+)
+values (
+  $1, $2, $3, $4, $5, $6, $7,
+  $8, $9, $10
+)
+returning id, media_file_id, import_task_id, method, source_path, dest_path, attempted_at, success, error_message, replaced_path, replaced_quality, archived_path
 `
 
 type CreateMediaFileImportParams struct {
-	MediaFileID  pgtype.UUID `json:"media_file_id"`
-	ImportTaskID pgtype.UUID `json:"import_task_id"`
-	Method       string      `json:"method"`
-	SourcePath   *string     `json:"source_path"`
-	DestPath     string      `json:"dest_path"`
-	Success      bool        `json:"success"`
-	ErrorMessage *string     `json:"error_message"`
+	MediaFileID     pgtype.UUID `json:"media_file_id"`
+	ImportTaskID    pgtype.UUID `json:"import_task_id"`
+	Method          string      `json:"method"`
+	SourcePath      *string     `json:"source_path"`
+	DestPath        string      `json:"dest_path"`
+	Success         bool        `json:"success"`
+	ErrorMessage    *string     `json:"error_message"`
+	ReplacedPath    *string     `json:"replaced_path"`
+	ReplacedQuality *string     `json:"replaced_quality"`
+	ArchivedPath    *string     `json:"archived_path"`
 }
 
 // Media File Import queries
@@ -103,6 +114,9 @@ func (q *Queries) CreateMediaFileImport(ctx context.Context, arg CreateMediaFile
 		arg.DestPath,
 		arg.Success,
 		arg.ErrorMessage,
+		arg.ReplacedPath,
+		arg.ReplacedQuality,
+		arg.ArchivedPath,
 	)
 	var i MediaFileImport
 	err := row.Scan(
@@ -115,6 +129,9 @@ func (q *Queries) CreateMediaFileImport(ctx context.Context, arg CreateMediaFile
 		&i.AttemptedAt,
 		&i.Success,
 		&i.ErrorMessage,
+		&i.ReplacedPath,
+		&i.ReplacedQuality,
+		&i.ArchivedPath,
 	)
 	return i, err
 }
@@ -148,7 +165,7 @@ func (q *Queries) CreateMediaFileState(ctx context.Context, arg CreateMediaFileS
 const createMediaItem = `-- name: CreateMediaItem :one
 insert into media_item (type, title, year, tmdb_id)
 values ($1, $2, $3, $4)
-returning id, type, title, year, tmdb_id, created_at, updated_at
+returning id, type, title, year, tmdb_id, created_at, updated_at, imdb_id, tvdb_id, quality_profile_id, monitored, monitor_new_seasons, library_id, last_searched_at, release_date, digital_release_date, physical_release_date, metadata_refreshed_at
 `
 
 type CreateMediaItemParams struct {
@@ -174,6 +191,17 @@ func (q *Queries) CreateMediaItem(ctx context.Context, arg CreateMediaItemParams
 		&i.TmdbID,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.ImdbID,
+		&i.TvdbID,
+		&i.QualityProfileID,
+		&i.Monitored,
+		&i.MonitorNewSeasons,
+		&i.LibraryID,
+		&i.LastSearchedAt,
+		&i.ReleaseDate,
+		&i.DigitalReleaseDate,
+		&i.PhysicalReleaseDate,
+		&i.MetadataRefreshedAt,
 	)
 	return i, err
 }
@@ -276,7 +304,7 @@ func (q *Queries) DismissUnmatchedFile(ctx context.Context, id pgtype.UUID) (Unm
 }
 
 const getEpisode = `-- name: GetEpisode :one
-select id, season_id, episode_number, title, air_date, tmdb_id, tvdb_id, created_at from media_episode
+select id, season_id, episode_number, title, air_date, tmdb_id, tvdb_id, created_at, absolute_number, monitored, last_searched_at from media_episode
 where id = $1
 `
 
@@ -292,12 +320,15 @@ func (q *Queries) GetEpisode(ctx context.Context, id pgtype.UUID) (MediaEpisode,
 		&i.TmdbID,
 		&i.TvdbID,
 		&i.CreatedAt,
+		&i.AbsoluteNumber,
+		&i.Monitored,
+		&i.LastSearchedAt,
 	)
 	return i, err
 }
 
 const getEpisodeByNumber = `-- name: GetEpisodeByNumber :one
-select id, season_id, episode_number, title, air_date, tmdb_id, tvdb_id, created_at from media_episode
+select id, season_id, episode_number, title, air_date, tmdb_id, tvdb_id, created_at, absolute_number, monitored, last_searched_at from media_episode
 where season_id = $1 and episode_number = $2
 `
 
@@ -318,13 +349,16 @@ func (q *Queries) GetEpisodeByNumber(ctx context.Context, arg GetEpisodeByNumber
 		&i.TmdbID,
 		&i.TvdbID,
 		&i.CreatedAt,
+		&i.AbsoluteNumber,
+		&i.Monitored,
+		&i.LastSearchedAt,
 	)
 	return i, err
 }
 
 const getMediaFile = `-- name: GetMediaFile :one
 
-select id, library_id, media_item_id, episode_id, path, created_at from media_file where id = $1
+select id, library_id, media_item_id, episode_id, path, created_at, quality from media_file where id = $1
 `
 
 // Files (removed season_id and status)
@@ -338,12 +372,13 @@ func (q *Queries) GetMediaFile(ctx context.Context, id pgtype.UUID) (MediaFile,
 		&i.EpisodeID,
 		&i.Path,
 		&i.CreatedAt,
+		&i.Quality,
 	)
 	return i, err
 }
 
 const getMediaFileByLibraryAndPath = `-- name: GetMediaFileByLibraryAndPath :one
-select id, library_id, media_item_id, episode_id, path, created_at from media_file where library_id = $1 and path = $2
+select id, library_id, media_item_id, episode_id, path, created_at, quality from media_file where library_id = $1 and path = $2
 `
 
 type GetMediaFileByLibraryAndPathParams struct {
@@ -361,12 +396,13 @@ func (q *Queries) GetMediaFileByLibraryAndPath(ctx context.Context, arg GetMedia
 		&i.EpisodeID,
 		&i.Path,
 		&i.CreatedAt,
+		&i.Quality,
 	)
 	return i, err
 }
 
 const getMediaFileImport = `-- name: GetMediaFileImport :one
-select id, media_file_id, import_task_id, method, source_path, dest_path, attempted_at, success, error_message from media_file_import where id = $1
+select id, media_file_id, import_task_id, method, source_path, dest_path, attempted_at, success, error_message, replaced_path, replaced_quality, archived_path from media_file_import where id = $1
 `
 
 func (q *Queries) GetMediaFileImport(ctx context.Context, id pgtype.UUID) (MediaFileImport, error) {
@@ -382,6 +418,9 @@ func (q *Queries) GetMediaFileImport(ctx context.Context, id pgtype.UUID) (Media
 		&i.AttemptedAt,
 		&i.Success,
 		&i.ErrorMessage,
+		&i.ReplacedPath,
+		&i.ReplacedQuality,
+		&i.ArchivedPath,
 	)
 	return i, err
 }
@@ -403,7 +442,7 @@ func (q *Queries) GetMediaFileState(ctx context.Context, mediaFileID pgtype.UUID
 }
 
 const getMediaItem = `-- name: GetMediaItem :one
-select id, type, title, year, tmdb_id, created_at, updated_at from media_item
+select id, type, title, year, tmdb_id, created_at, updated_at, imdb_id, tvdb_id, quality_profile_id, monitored, monitor_new_seasons, library_id, last_searched_at, release_date, digital_release_date, physical_release_date, metadata_refreshed_at from media_item
 where id = $1
 `
 
@@ -418,12 +457,23 @@ func (q *Queries) GetMediaItem(ctx context.Context, id pgtype.UUID) (MediaItem,
 		&i.TmdbID,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.ImdbID,
+		&i.TvdbID,
+		&i.QualityProfileID,
+		&i.Monitored,
+		&i.MonitorNewSeasons,
+		&i.LibraryID,
+		&i.LastSearchedAt,
+		&i.ReleaseDate,
+		&i.DigitalReleaseDate,
+		&i.PhysicalReleaseDate,
+		&i.MetadataRefreshedAt,
 	)
 	return i, err
 }
 
 const getMediaItemByTmdbID = `-- name: GetMediaItemByTmdbID :one
-select id, type, title, year, tmdb_id, created_at, updated_at from media_item
+select id, type, title, year, tmdb_id, created_at, updated_at, imdb_id, tvdb_id, quality_profile_id, monitored, monitor_new_seasons, library_id, last_searched_at, release_date, digital_release_date, physical_release_date, metadata_refreshed_at from media_item
 where tmdb_id = $1
 `
 
@@ -438,12 +488,23 @@ func (q *Queries) GetMediaItemByTmdbID(ctx context.Context, tmdbID *int64) (Medi
 		&i.TmdbID,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.ImdbID,
+		&i.TvdbID,
+		&i.QualityProfileID,
+		&i.Monitored,
+		&i.MonitorNewSeasons,
+		&i.LibraryID,
+		&i.LastSearchedAt,
+		&i.ReleaseDate,
+		&i.DigitalReleaseDate,
+		&i.PhysicalReleaseDate,
+		&i.MetadataRefreshedAt,
 	)
 	return i, err
 }
 
 const getMediaItemByTmdbIDAndType = `-- name: GetMediaItemByTmdbIDAndType :one
-select id, type, title, year, tmdb_id, created_at, updated_at from media_item
+select id, type, title, year, tmdb_id, created_at, updated_at, imdb_id, tvdb_id, quality_profile_id, monitored, monitor_new_seasons, library_id, last_searched_at, release_date, digital_release_date, physical_release_date, metadata_refreshed_at from media_item
 where tmdb_id = $1 and type = $2
 `
 
@@ -463,6 +524,17 @@ func (q *Queries) GetMediaItemByTmdbIDAndType(ctx context.Context, arg GetMediaI
 		&i.TmdbID,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.ImdbID,
+		&i.TvdbID,
+		&i.QualityProfileID,
+		&i.Monitored,
+		&i.MonitorNewSeasons,
+		&i.LibraryID,
+		&i.LastSearchedAt,
+		&i.ReleaseDate,
+		&i.DigitalReleaseDate,
+		&i.PhysicalReleaseDate,
+		&i.MetadataRefreshedAt,
 	)
 	return i, err
 }
@@ -498,7 +570,7 @@ func (q *Queries) GetMediaItemsByTmdbIDs(ctx context.Context, arg GetMediaItemsB
 }
 
 const getSeason = `-- name: GetSeason :one
-select id, media_item_id, season_number, air_date, created_at from media_season
+select id, media_item_id, season_number, air_date, created_at, monitored from media_season
 where id = $1
 `
 
@@ -511,12 +583,13 @@ func (q *Queries) GetSeason(ctx context.Context, id pgtype.UUID) (MediaSeason, e
 		&i.SeasonNumber,
 		&i.AirDate,
 		&i.CreatedAt,
+		&i.Monitored,
 	)
 	return i, err
 }
 
 const getSeasonByNumber = `-- name: GetSeasonByNumber :one
-select id, media_item_id, season_number, air_date, created_at from media_season
+select id, media_item_id, season_number, air_date, created_at, monitored from media_season
 where media_item_id = $1 and season_number = $2
 `
 
@@ -534,6 +607,7 @@ func (q *Queries) GetSeasonByNumber(ctx context.Context, arg GetSeasonByNumberPa
 		&i.SeasonNumber,
 		&i.AirDate,
 		&i.CreatedAt,
+		&i.Monitored,
 	)
 	return i, err
 }
@@ -583,6 +657,44 @@ func (q *Queries) GetUnmatchedFileByPath(ctx context.Context, arg GetUnmatchedFi
 	return i, err
 }
 
+const listAbsoluteEpisodeNumbers = `-- name: ListAbsoluteEpisodeNumbers :many
+select ms.season_number, me.episode_number, me.absolute_number
+from media_episode me
+join media_season ms on me.season_id = ms.id
+where ms.media_item_id = $1 and me.absolute_number is not null
+order by me.absolute_number
+`
+
+type ListAbsoluteEpisodeNumbersRow struct {
+	SeasonNumber   int32  `json:"season_number"`
+	EpisodeNumber  int32  `json:"episode_number"`
+	AbsoluteNumber *int32 `json:"absolute_number"`
+}
+
+func (q *Queries) ListAbsoluteEpisodeNumbers(ctx context.Context, mediaItemID pgtype.UUID) ([]ListAbsoluteEpisodeNumbersRow, error) {
+	rows, err := q.db.Query(ctx, listAbsoluteEpisodeNumbers, mediaItemID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListAbsoluteEpisodeNumbersRow
+	for rows.Next() {
+		var i ListAbsoluteEpisodeNumbersRow
+		if err := rows.Scan(
+			&i.SeasonNumber,
+			&i.EpisodeNumber,
+			&i.AbsoluteNumber,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listEpisodeAvailabilityForSeries = `-- name: ListEpisodeAvailabilityForSeries :many
 select
   ms.season_number,
@@ -592,7 +704,9 @@ select
   me.air_date,
   mf.id as file_id,
   mf.library_id,
-  mfs.file_exists
+  mfs.file_exists,
+  ms.monitored as season_monitored,
+  me.monitored
 from media_episode me
 join media_season ms on me.season_id = ms.id
 join media_item mi on ms.media_item_id = mi.id
@@ -603,14 +717,16 @@ order by ms.season_number, me.episode_number
 `
 
 type ListEpisodeAvailabilityForSeriesRow struct {
-	SeasonNumber  int32       `json:"season_number"`
-	EpisodeNumber int32       `json:"episode_number"`
-	EpisodeID     pgtype.UUID `json:"episode_id"`
-	Title         *string     `json:"title"`
-	AirDate       pgtype.Date `json:"air_date"`
-	FileID        pgtype.UUID `json:"file_id"`
-	LibraryID     pgtype.UUID `json:"library_id"`
-	FileExists    *bool       `json:"file_exists"`
+	SeasonNumber    int32       `json:"season_number"`
+	EpisodeNumber   int32       `json:"episode_number"`
+	EpisodeID       pgtype.UUID `json:"episode_id"`
+	Title           *string     `json:"title"`
+	AirDate         pgtype.Date `json:"air_date"`
+	FileID          pgtype.UUID `json:"file_id"`
+	LibraryID       pgtype.UUID `json:"library_id"`
+	FileExists      *bool       `json:"file_exists"`
+	SeasonMonitored bool        `json:"season_monitored"`
+	Monitored       bool        `json:"monitored"`
 }
 
 func (q *Queries) ListEpisodeAvailabilityForSeries(ctx context.Context, id pgtype.UUID) ([]ListEpisodeAvailabilityForSeriesRow, error) {
@@ -631,6 +747,8 @@ func (q *Queries) ListEpisodeAvailabilityForSeries(ctx context.Context, id pgtyp
 			&i.FileID,
 			&i.LibraryID,
 			&i.FileExists,
+			&i.SeasonMonitored,
+			&i.Monitored,
 		); err != nil {
 			return nil, err
 		}
@@ -644,7 +762,7 @@ func (q *Queries) ListEpisodeAvailabilityForSeries(ctx context.Context, id pgtyp
 
 const listEpisodesForSeason = `-- name: ListEpisodesForSeason :many
 
-select id, season_id, episode_number, title, air_date, tmdb_id, tvdb_id, created_at from media_episode
+select id, season_id, episode_number, title, air_date, tmdb_id, tvdb_id, created_at, absolute_number, monitored, last_searched_at from media_episode
 where season_id = $1
 order by episode_number asc
 `
@@ -668,6 +786,9 @@ func (q *Queries) ListEpisodesForSeason(ctx context.Context, seasonID pgtype.UUI
 			&i.TmdbID,
 			&i.TvdbID,
 			&i.CreatedAt,
+			&i.AbsoluteNumber,
+			&i.Monitored,
+			&i.LastSearchedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -680,7 +801,7 @@ func (q *Queries) ListEpisodesForSeason(ctx context.Context, seasonID pgtype.UUI
 }
 
 const listFailedImports = `-- name: ListFailedImports :many
-select id, media_file_id, import_task_id, method, source_path, dest_path, attempted_at, success, error_message from media_file_import
+select id, media_file_id, import_task_id, method, source_path, dest_path, attempted_at, success, error_message, replaced_path, replaced_quality, archived_path from media_file_import
 where success = false
 order by attempted_at desc
 limit $1
@@ -705,6 +826,9 @@ func (q *Queries) ListFailedImports(ctx context.Context, limitVal int32) ([]Medi
 			&i.AttemptedAt,
 			&i.Success,
 			&i.ErrorMessage,
+			&i.ReplacedPath,
+			&i.ReplacedQuality,
+			&i.ArchivedPath,
 		); err != nil {
 			return nil, err
 		}
@@ -717,7 +841,7 @@ func (q *Queries) ListFailedImports(ctx context.Context, limitVal int32) ([]Medi
 }
 
 const listFilesNeedingVerification = `-- name: ListFilesNeedingVerification :many
-select mf.id, mf.library_id, mf.media_item_id, mf.episode_id, mf.path, mf.created_at, mfs.file_exists, mfs.file_size, mfs.last_verified_at
+select mf.id, mf.library_id, mf.media_item_id, mf.episode_id, mf.path, mf.created_at, mf.quality, mfs.file_exists, mfs.file_size, mfs.last_verified_at
 from media_file mf
 join media_file_state mfs on mf.id = mfs.media_file_id
 where mfs.last_verified_at < $1
@@ -737,6 +861,7 @@ type ListFilesNeedingVerificationRow struct {
 	EpisodeID      pgtype.UUID `json:"episode_id"`
 	Path           string      `json:"path"`
 	CreatedAt      time.Time   `json:"created_at"`
+	Quality        *string     `json:"quality"`
 	FileExists     bool        `json:"file_exists"`
 	FileSize       *int64      `json:"file_size"`
 	LastVerifiedAt time.Time   `json:"last_verified_at"`
@@ -758,6 +883,7 @@ func (q *Queries) ListFilesNeedingVerification(ctx context.Context, arg ListFile
 			&i.EpisodeID,
 			&i.Path,
 			&i.CreatedAt,
+			&i.Quality,
 			&i.FileExists,
 			&i.FileSize,
 			&i.LastVerifiedAt,
@@ -773,7 +899,7 @@ func (q *Queries) ListFilesNeedingVerification(ctx context.Context, arg ListFile
 }
 
 const listImportsForImportTask = `-- name: ListImportsForImportTask :many
-select id, media_file_id, import_task_id, method, source_path, dest_path, attempted_at, success, error_message from media_file_import
+select id, media_file_id, import_task_id, method, source_path, dest_path, attempted_at, success, error_message, replaced_path, replaced_quality, archived_path from media_file_import
 where import_task_id = $1
 order by attempted_at desc
 `
@@ -797,6 +923,9 @@ func (q *Queries) ListImportsForImportTask(ctx context.Context, importTaskID pgt
 			&i.AttemptedAt,
 			&i.Success,
 			&i.ErrorMessage,
+			&i.ReplacedPath,
+			&i.ReplacedQuality,
+			&i.ArchivedPath,
 		); err != nil {
 			return nil, err
 		}
@@ -809,7 +938,7 @@ func (q *Queries) ListImportsForImportTask(ctx context.Context, importTaskID pgt
 }
 
 const listImportsForMediaFile = `-- name: ListImportsForMediaFile :many
-select id, media_file_id, import_task_id, method, source_path, dest_path, attempted_at, success, error_message from media_file_import
+select id, media_file_id, import_task_id, method, source_path, dest_path, attempted_at, success, error_message, replaced_path, replaced_quality, archived_path from media_file_import
 where media_file_id = $1
 order by attempted_at desc
 `
@@ -833,6 +962,9 @@ func (q *Queries) ListImportsForMediaFile(ctx context.Context, mediaFileID pgtyp
 			&i.AttemptedAt,
 			&i.Success,
 			&i.ErrorMessage,
+			&i.ReplacedPath,
+			&i.ReplacedQuality,
+			&i.ArchivedPath,
 		); err != nil {
 			return nil, err
 		}
@@ -852,6 +984,7 @@ select
   mf.episode_id,
   mf.path,
   mf.created_at,
+  mf.quality,
   ms.id as season_id,
   ms.season_number,
   me.episode_number,
@@ -873,6 +1006,7 @@ type ListMediaFilesForItemRow struct {
 	EpisodeID      pgtype.UUID        `json:"episode_id"`
 	Path           string             `json:"path"`
 	CreatedAt      time.Time          `json:"created_at"`
+	Quality        *string            `json:"quality"`
 	SeasonID       pgtype.UUID        `json:"season_id"`
 	SeasonNumber   *int32             `json:"season_number"`
 	EpisodeNumber  *int32             `json:"episode_number"`
@@ -897,6 +1031,7 @@ func (q *Queries) ListMediaFilesForItem(ctx context.Context, mediaItemID pgtype.
 			&i.EpisodeID,
 			&i.Path,
 			&i.CreatedAt,
+			&i.Quality,
 			&i.SeasonID,
 			&i.SeasonNumber,
 			&i.EpisodeNumber,
@@ -916,7 +1051,7 @@ func (q *Queries) ListMediaFilesForItem(ctx context.Context, mediaItemID pgtype.
 
 const listMediaItems = `-- name: ListMediaItems :many
 
-select id, type, title, year, tmdb_id, created_at, updated_at from media_item
+select id, type, title, year, tmdb_id, created_at, updated_at, imdb_id, tvdb_id, quality_profile_id, monitored, monitor_new_seasons, library_id, last_searched_at, release_date, digital_release_date, physical_release_date, metadata_refreshed_at from media_item
 order by created_at desc
 `
 
@@ -938,6 +1073,17 @@ func (q *Queries) ListMediaItems(ctx context.Context) ([]MediaItem, error) {
 			&i.TmdbID,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.ImdbID,
+			&i.TvdbID,
+			&i.QualityProfileID,
+			&i.Monitored,
+			&i.MonitorNewSeasons,
+			&i.LibraryID,
+			&i.LastSearchedAt,
+			&i.ReleaseDate,
+			&i.DigitalReleaseDate,
+			&i.PhysicalReleaseDate,
+			&i.MetadataRefreshedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -951,7 +1097,7 @@ func (q *Queries) ListMediaItems(ctx context.Context) ([]MediaItem, error) {
 
 const listMediaItemsPaginated = `-- name: ListMediaItemsPaginated :many
 
-SELECT id, type, title, year, tmdb_id, created_at, updated_at FROM media_item
+SELECT id, type, title, year, tmdb_id, created_at, updated_at, imdb_id, tvdb_id, quality_profile_id, monitored, monitor_new_seasons, library_id, last_searched_at, release_date, digital_release_date, physical_release_date, metadata_refreshed_at FROM media_item
 WHERE
     ($1::text IS NULL OR type = $1) AND
     ($2::text IS NULL OR title ILIKE '%' || $2 || '%')
@@ -1000,6 +1146,17 @@ func (q *Queries) ListMediaItemsPaginated(ctx context.Context, arg ListMediaItem
 			&i.TmdbID,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.ImdbID,
+			&i.TvdbID,
+			&i.QualityProfileID,
+			&i.Monitored,
+			&i.MonitorNewSeasons,
+			&i.LibraryID,
+			&i.LastSearchedAt,
+			&i.ReleaseDate,
+			&i.DigitalReleaseDate,
+			&i.PhysicalReleaseDate,
+			&i.MetadataRefreshedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -1012,7 +1169,7 @@ func (q *Queries) ListMediaItemsPaginated(ctx context.Context, arg ListMediaItem
 }
 
 const listMissingFiles = `-- name: ListMissingFiles :many
-select mf.id, mf.library_id, mf.media_item_id, mf.episode_id, mf.path, mf.created_at, mfs.file_size, mfs.last_verified_at
+select mf.id, mf.library_id, mf.media_item_id, mf.episode_id, mf.path, mf.created_at, mf.quality, mfs.file_size, mfs.last_verified_at
 from media_file mf
 join media_file_state mfs on mf.id = mfs.media_file_id
 where mfs.file_exists = false
@@ -1026,6 +1183,7 @@ type ListMissingFilesRow struct {
 	EpisodeID      pgtype.UUID `json:"episode_id"`
 	Path           string      `json:"path"`
 	CreatedAt      time.Time   `json:"created_at"`
+	Quality        *string     `json:"quality"`
 	FileSize       *int64      `json:"file_size"`
 	LastVerifiedAt time.Time   `json:"last_verified_at"`
 }
@@ -1046,6 +1204,7 @@ func (q *Queries) ListMissingFiles(ctx context.Context) ([]ListMissingFilesRow,
 			&i.EpisodeID,
 			&i.Path,
 			&i.CreatedAt,
+			&i.Quality,
 			&i.FileSize,
 			&i.LastVerifiedAt,
 		); err != nil {
@@ -1059,8 +1218,66 @@ func (q *Queries) ListMissingFiles(ctx context.Context) ([]ListMissingFilesRow,
 	return items, nil
 }
 
+const listMonitoringCounts = `-- name: ListMonitoringCounts :many
+select
+  mi.id as media_item_id,
+  (count(me.id) filter (where mi.monitored and ms.monitored and me.monitored))::int as monitored_episodes,
+  (count(me.id) filter (
+    where mi.monitored and ms.monitored and me.monitored and me.air_date <= current_date
+      and not exists (
+        select 1 from media_file mf
+        left join media_file_state mfs on mf.id = mfs.media_file_id
+        where mf.episode_id = me.id and coalesce(mfs.file_exists, true)
+      )
+  ))::int as missing_episodes,
+  exists (
+    select 1 from media_file mf
+    left join media_file_state mfs on mf.id = mfs.media_file_id
+    where mf.media_item_id = mi.id and coalesce(mfs.file_exists, true)
+  ) as has_file
+from media_item mi
+left join media_season ms on ms.media_item_id = mi.id
+left join media_episode me on me.season_id = ms.id
+where mi.id = any($1::uuid[])
+group by mi.id
+`
+
+type ListMonitoringCountsRow struct {
+	MediaItemID       pgtype.UUID `json:"media_item_id"`
+	MonitoredEpisodes int32       `json:"monitored_episodes"`
+	MissingEpisodes   int32       `json:"missing_episodes"`
+	HasFile           bool        `json:"has_file"`
+}
+
+// An episode is missing when it is monitored along with its season and series,
+// has aired, and has no file on disk. Episodes without an air date haven't aired.
+func (q *Queries) ListMonitoringCounts(ctx context.Context, ids []pgtype.UUID) ([]ListMonitoringCountsRow, error) {
+	rows, err := q.db.Query(ctx, listMonitoringCounts, ids)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListMonitoringCountsRow
+	for rows.Next() {
+		var i ListMonitoringCountsRow
+		if err := rows.Scan(
+			&i.MediaItemID,
+			&i.MonitoredEpisodes,
+			&i.MissingEpisodes,
+			&i.HasFile,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listRecentImports = `-- name: ListRecentImports :many
-select id, media_file_id, import_task_id, method, source_path, dest_path, attempted_at, success, error_message from media_file_import
+select id, media_file_id, import_task_id, method, source_path, dest_path, attempted_at, success, error_message, replaced_path, replaced_quality, archived_path from media_file_import
 order by attempted_at desc
 limit $1
 `
@@ -1084,6 +1301,9 @@ func (q *Queries) ListRecentImports(ctx context.Context, limitVal int32) ([]Medi
 			&i.AttemptedAt,
 			&i.Success,
 			&i.ErrorMessage,
+			&i.ReplacedPath,
+			&i.ReplacedQuality,
+			&i.ArchivedPath,
 		); err != nil {
 			return nil, err
 		}
@@ -1097,7 +1317,7 @@ func (q *Queries) ListRecentImports(ctx context.Context, limitVal int32) ([]Medi
 
 const listSeasonsForMedia = `-- name: ListSeasonsForMedia :many
 
-select id, media_item_id, season_number, air_date, created_at from media_season
+select id, media_item_id, season_number, air_date, created_at, monitored from media_season
 where media_item_id = $1
 order by season_number asc
 `
@@ -1118,6 +1338,7 @@ func (q *Queries) ListSeasonsForMedia(ctx context.Context, mediaItemID pgtype.UU
 			&i.SeasonNumber,
 			&i.AirDate,
 			&i.CreatedAt,
+			&i.Monitored,
 		); err != nil {
 			return nil, err
 		}
@@ -1242,6 +1463,35 @@ func (q *Queries) ListUnmatchedFilesPaginated(ctx context.Context, arg ListUnmat
 	return items, nil
 }
 
+const replaceMediaFile = `-- name: ReplaceMediaFile :one
+update media_file
+set path = $1, quality = $2
+where id = $3
+returning id, library_id, media_item_id, episode_id, path, created_at, quality
+`
+
+type ReplaceMediaFileParams struct {
+	Path    string      `json:"path"`
+	Quality *string     `json:"quality"`
+	ID      pgtype.UUID `json:"id"`
+}
+
+// Points a media file at the file that replaced it, keeping its import history.
+func (q *Queries) ReplaceMediaFile(ctx context.Context, arg ReplaceMediaFileParams) (MediaFile, error) {
+	row := q.db.QueryRow(ctx, replaceMediaFile, arg.Path, arg.Quality, arg.ID)
+	var i MediaFile
+	err := row.Scan(
+		&i.ID,
+		&i.LibraryID,
+		&i.MediaItemID,
+		&i.EpisodeID,
+		&i.Path,
+		&i.CreatedAt,
+		&i.Quality,
+	)
+	return i, err
+}
+
 const resolveUnmatchedFile = `-- name: ResolveUnmatchedFile :one
 update unmatched_file
 set resolved_at = now(),
@@ -1271,6 +1521,200 @@ func (q *Queries) ResolveUnmatchedFile(ctx context.Context, arg ResolveUnmatched
 	return i, err
 }
 
+const setEpisodeAbsoluteNumber = `-- name: SetEpisodeAbsoluteNumber :one
+insert into media_episode (season_id, episode_number, absolute_number)
+values ($1, $2, $3)
+on conflict (season_id, episode_number)
+do update set absolute_number = excluded.absolute_number
+returning id, season_id, episode_number, title, air_date, tmdb_id, tvdb_id, created_at, absolute_number, monitored, last_searched_at
+`
+
+type SetEpisodeAbsoluteNumberParams struct {
+	SeasonID       pgtype.UUID `json:"season_id"`
+	EpisodeNumber  int32       `json:"episode_number"`
+	AbsoluteNumber *int32      `json:"absolute_number"`
+}
+
+// Creates the episode if needed; other metadata is left alone on conflict.
+func (q *Queries) SetEpisodeAbsoluteNumber(ctx context.Context, arg SetEpisodeAbsoluteNumberParams) (MediaEpisode, error) {
+	row := q.db.QueryRow(ctx, setEpisodeAbsoluteNumber, arg.SeasonID, arg.EpisodeNumber, arg.AbsoluteNumber)
+	var i MediaEpisode
+	err := row.Scan(
+		&i.ID,
+		&i.SeasonID,
+		&i.EpisodeNumber,
+		&i.Title,
+		&i.AirDate,
+		&i.TmdbID,
+		&i.TvdbID,
+		&i.CreatedAt,
+		&i.AbsoluteNumber,
+		&i.Monitored,
+		&i.LastSearchedAt,
+	)
+	return i, err
+}
+
+const setEpisodeMonitored = `-- name: SetEpisodeMonitored :one
+update media_episode
+set monitored = $2
+where id = $1
+returning id, season_id, episode_number, title, air_date, tmdb_id, tvdb_id, created_at, absolute_number, monitored, last_searched_at
+`
+
+type SetEpisodeMonitoredParams struct {
+	ID        pgtype.UUID `json:"id"`
+	Monitored bool        `json:"monitored"`
+}
+
+func (q *Queries) SetEpisodeMonitored(ctx context.Context, arg SetEpisodeMonitoredParams) (MediaEpisode, error) {
+	row := q.db.QueryRow(ctx, setEpisodeMonitored, arg.ID, arg.Monitored)
+	var i MediaEpisode
+	err := row.Scan(
+		&i.ID,
+		&i.SeasonID,
+		&i.EpisodeNumber,
+		&i.Title,
+		&i.AirDate,
+		&i.TmdbID,
+		&i.TvdbID,
+		&i.CreatedAt,
+		&i.AbsoluteNumber,
+		&i.Monitored,
+		&i.LastSearchedAt,
+	)
+	return i, err
+}
+
+const setMediaItemMonitoring = `-- name: SetMediaItemMonitoring :one
+update media_item
+set monitored = $1,
+    monitor_new_seasons = $2,
+    library_id = $3,
+    updated_at = now()
+where id = $4
+returning id, type, title, year, tmdb_id, created_at, updated_at, imdb_id, tvdb_id, quality_profile_id, monitored, monitor_new_seasons, library_id, last_searched_at, release_date, digital_release_date, physical_release_date, metadata_refreshed_at
+`
+
+type SetMediaItemMonitoringParams struct {
+	Monitored         bool        `json:"monitored"`
+	MonitorNewSeasons bool        `json:"monitor_new_seasons"`
+	LibraryID         pgtype.UUID `json:"library_id"`
+	ID                pgtype.UUID `json:"id"`
+}
+
+func (q *Queries) SetMediaItemMonitoring(ctx context.Context, arg SetMediaItemMonitoringParams) (MediaItem, error) {
+	row := q.db.QueryRow(ctx, setMediaItemMonitoring,
+		arg.Monitored,
+		arg.MonitorNewSeasons,
+		arg.LibraryID,
+		arg.ID,
+	)
+	var i MediaItem
+	err := row.Scan(
+		&i.ID,
+		&i.Type,
+		&i.Title,
+		&i.Year,
+		&i.TmdbID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.ImdbID,
+		&i.TvdbID,
+		&i.QualityProfileID,
+		&i.Monitored,
+		&i.MonitorNewSeasons,
+		&i.LibraryID,
+		&i.LastSearchedAt,
+		&i.ReleaseDate,
+		&i.DigitalReleaseDate,
+		&i.PhysicalReleaseDate,
+		&i.MetadataRefreshedAt,
+	)
+	return i, err
+}
+
+const setMediaItemQualityProfile = `-- name: SetMediaItemQualityProfile :one
+update media_item
+set quality_profile_id = $1,
+    updated_at = now()
+where id = $2
+returning id, type, title, year, tmdb_id, created_at, updated_at, imdb_id, tvdb_id, quality_profile_id, monitored, monitor_new_seasons, library_id, last_searched_at, release_date, digital_release_date, physical_release_date, metadata_refreshed_at
+`
+
+type SetMediaItemQualityProfileParams struct {
+	QualityProfileID pgtype.UUID `json:"quality_profile_id"`
+	ID               pgtype.UUID `json:"id"`
+}
+
+func (q *Queries) SetMediaItemQualityProfile(ctx context.Context, arg SetMediaItemQualityProfileParams) (MediaItem, error) {
+	row := q.db.QueryRow(ctx, setMediaItemQualityProfile, arg.QualityProfileID, arg.ID)
+	var i MediaItem
+	err := row.Scan(
+		&i.ID,
+		&i.Type,
+		&i.Title,
+		&i.Year,
+		&i.TmdbID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.ImdbID,
+		&i.TvdbID,
+		&i.QualityProfileID,
+		&i.Monitored,
+		&i.MonitorNewSeasons,
+		&i.LibraryID,
+		&i.LastSearchedAt,
+		&i.ReleaseDate,
+		&i.DigitalReleaseDate,
+		&i.PhysicalReleaseDate,
+		&i.MetadataRefreshedAt,
+	)
+	return i, err
+}
+
+const setSeasonEpisodesMonitored = `-- name: SetSeasonEpisodesMonitored :exec
+update media_episode
+set monitored = $2
+where season_id = $1
+`
+
+type SetSeasonEpisodesMonitoredParams struct {
+	SeasonID  pgtype.UUID `json:"season_id"`
+	Monitored bool        `json:"monitored"`
+}
+
+func (q *Queries) SetSeasonEpisodesMonitored(ctx context.Context, arg SetSeasonEpisodesMonitoredParams) error {
+	_, err := q.db.Exec(ctx, setSeasonEpisodesMonitored, arg.SeasonID, arg.Monitored)
+	return err
+}
+
+const setSeasonMonitored = `-- name: SetSeasonMonitored :one
+update media_season
+set monitored = $2
+where id = $1
+returning id, media_item_id, season_number, air_date, created_at, monitored
+`
+
+type SetSeasonMonitoredParams struct {
+	ID        pgtype.UUID `json:"id"`
+	Monitored bool        `json:"monitored"`
+}
+
+func (q *Queries) SetSeasonMonitored(ctx context.Context, arg SetSeasonMonitoredParams) (MediaSeason, error) {
+	row := q.db.QueryRow(ctx, setSeasonMonitored, arg.ID, arg.Monitored)
+	var i MediaSeason
+	err := row.Scan(
+		&i.ID,
+		&i.MediaItemID,
+		&i.SeasonNumber,
+		&i.AirDate,
+		&i.CreatedAt,
+		&i.Monitored,
+	)
+	return i, err
+}
+
 const updateMediaFileState = `-- name: UpdateMediaFileState :one
 update media_file_state
 set file_exists = $1,
@@ -1305,7 +1749,7 @@ set title = $2,
     tmdb_id = $4,
     updated_at = now()
 where id = $1
-returning id, type, title, year, tmdb_id, created_at, updated_at
+returning id, type, title, year, tmdb_id, created_at, updated_at, imdb_id, tvdb_id, quality_profile_id, monitored, monitor_new_seasons, library_id, last_searched_at, release_date, digital_release_date, physical_release_date, metadata_refreshed_at
 `
 
 type UpdateMediaItemParams struct {
@@ -1331,6 +1775,58 @@ func (q *Queries) UpdateMediaItem(ctx context.Context, arg UpdateMediaItemParams
 		&i.TmdbID,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.ImdbID,
+		&i.TvdbID,
+		&i.QualityProfileID,
+		&i.Monitored,
+		&i.MonitorNewSeasons,
+		&i.LibraryID,
+		&i.LastSearchedAt,
+		&i.ReleaseDate,
+		&i.DigitalReleaseDate,
+		&i.PhysicalReleaseDate,
+		&i.MetadataRefreshedAt,
+	)
+	return i, err
+}
+
+const updateMediaItemExternalIDs = `-- name: UpdateMediaItemExternalIDs :one
+update media_item
+set imdb_id = $1,
+    tvdb_id = $2,
+    updated_at = now()
+where id = $3
+returning id, type, title, year, tmdb_id, created_at, updated_at, imdb_id, tvdb_id, quality_profile_id, monitored, monitor_new_seasons, library_id, last_searched_at, release_date, digital_release_date, physical_release_date, metadata_refreshed_at
+`
+
+type UpdateMediaItemExternalIDsParams struct {
+	ImdbID *string     `json:"imdb_id"`
+	TvdbID *int64      `json:"tvdb_id"`
+	ID     pgtype.UUID `json:"id"`
+}
+
+func (q *Queries) UpdateMediaItemExternalIDs(ctx context.Context, arg UpdateMediaItemExternalIDsParams) (MediaItem, error) {
+	row := q.db.QueryRow(ctx, updateMediaItemExternalIDs, arg.ImdbID, arg.TvdbID, arg.ID)
+	var i MediaItem
+	err := row.Scan(
+		&i.ID,
+		&i.Type,
+		&i.Title,
+		&i.Year,
+		&i.TmdbID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.ImdbID,
+		&i.TvdbID,
+		&i.QualityProfileID,
+		&i.Monitored,
+		&i.MonitorNewSeasons,
+		&i.LibraryID,
+		&i.LastSearchedAt,
+		&i.ReleaseDate,
+		&i.DigitalReleaseDate,
+		&i.PhysicalReleaseDate,
+		&i.MetadataRefreshedAt,
 	)
 	return i, err
 }
@@ -1367,11 +1863,11 @@ const upsertEpisode = `-- name: UpsertEpisode :one
 insert into media_episode (season_id, episode_number, title, air_date, tmdb_id, tvdb_id)
 values ($1, $2, $3, $4, $5, $6)
 on conflict (season_id, episode_number)
-do update set title = excluded.title,
-              air_date = excluded.air_date,
-              tmdb_id = excluded.tmdb_id,
-              tvdb_id = excluded.tvdb_id
-returning id, season_id, episode_number, title, air_date, tmdb_id, tvdb_id, created_at
+do update set title = coalesce(excluded.title, media_episode.title),
+              air_date = coalesce(excluded.air_date, media_episode.air_date),
+              tmdb_id = coalesce(excluded.tmdb_id, media_episode.tmdb_id),
+              tvdb_id = coalesce(excluded.tvdb_id, media_episode.tvdb_id)
+returning id, season_id, episode_number, title, air_date, tmdb_id, tvdb_id, created_at, absolute_number, monitored, last_searched_at
 `
 
 type UpsertEpisodeParams struct {
@@ -1383,6 +1879,7 @@ type UpsertEpisodeParams struct {
 	TvdbID        *int64      `json:"tvdb_id"`
 }
 
+// Metadata that isn't known (NULL) leaves the stored value alone on conflict.
 func (q *Queries) UpsertEpisode(ctx context.Context, arg UpsertEpisodeParams) (MediaEpisode, error) {
 	row := q.db.QueryRow(ctx, upsertEpisode,
 		arg.SeasonID,
@@ -1402,6 +1899,9 @@ func (q *Queries) UpsertEpisode(ctx context.Context, arg UpsertEpisodeParams) (M
 		&i.TmdbID,
 		&i.TvdbID,
 		&i.CreatedAt,
+		&i.AbsoluteNumber,
+		&i.Monitored,
+		&i.LastSearchedAt,
 	)
 	return i, err
 }
@@ -1441,7 +1941,7 @@ on conflict (type, tmdb_id)
 do update set title = excluded.title,
               year = excluded.year,
               updated_at = now()
-returning id, type, title, year, tmdb_id, created_at, updated_at
+returning id, type, title, year, tmdb_id, created_at, updated_at, imdb_id, tvdb_id, quality_profile_id, monitored, monitor_new_seasons, library_id, last_searched_at, release_date, digital_release_date, physical_release_date, metadata_refreshed_at
 `
 
 type UpsertMediaItemParams struct {
@@ -1467,6 +1967,17 @@ func (q *Queries) UpsertMediaItem(ctx context.Context, arg UpsertMediaItemParams
 		&i.TmdbID,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.ImdbID,
+		&i.TvdbID,
+		&i.QualityProfileID,
+		&i.Monitored,
+		&i.MonitorNewSeasons,
+		&i.LibraryID,
+		&i.LastSearchedAt,
+		&i.ReleaseDate,
+		&i.DigitalReleaseDate,
+		&i.PhysicalReleaseDate,
+		&i.MetadataRefreshedAt,
 	)
 	return i, err
 }
@@ -1475,8 +1986,8 @@ const upsertSeason = `-- name: UpsertSeason :one
 insert into media_season (media_item_id, season_number, air_date)
 values ($1, $2, $3)
 on conflict (media_item_id, season_number)
-do update set air_date = excluded.air_date
-returning id, media_item_id, season_number, air_date, created_at
+do update set air_date = coalesce(excluded.air_date, media_season.air_date)
+returning id, media_item_id, season_number, air_date, created_at, monitored
 `
 
 type UpsertSeasonParams struct {
@@ -1494,6 +2005,7 @@ func (q *Queries) UpsertSeason(ctx context.Context, arg UpsertSeasonParams) (Med
 		&i.SeasonNumber,
 		&i.AirDate,
 		&i.CreatedAt,
+		&i.Monitored,
 	)
 	return i, err
 }
@@ -187,6 +187,50 @@ type AuthAudit struct {
 	CreatedAt time.Time   `json:"created_at"`
 }
 
+type AutoSearchDecision struct {
+	ID              pgtype.UUID `json:"id"`
+	MediaItemID     pgtype.UUID `json:"media_item_id"`
+	MediaType       string      `json:"media_type"`
+	TmdbID          int64       `json:"tmdb_id"`
+	SeasonNumber    *int32      `json:"season_number"`
+	EpisodeNumber   *int32      `json:"episode_number"`
+	Trigger         string      `json:"trigger"`
+	Outcome         string      `json:"outcome"`
+	Reason          *string     `json:"reason"`
+	SearchSessionID pgtype.UUID `json:"search_session_id"`
+	CandidateCount  int32       `json:"candidate_count"`
+	ChosenTitle     *string     `json:"chosen_title"`
+	ChosenIndexerID *int64      `json:"chosen_indexer_id"`
+	ChosenGuid      *string     `json:"chosen_guid"`
+	DownloadJobID   pgtype.UUID `json:"download_job_id"`
+	Candidates      []byte      `json:"candidates"`
+	Trace           []byte      `json:"trace"`
+	CreatedAt       time.Time   `json:"created_at"`
+	Upgrade         bool        `json:"upgrade"`
+}
+
+type Blocklist struct {
+	ID              pgtype.UUID        `json:"id"`
+	InfoHash        *string            `json:"info_hash"`
+	IndexerID       *int64             `json:"indexer_id"`
+	Guid            *string            `json:"guid"`
+	Title           string             `json:"title"`
+	NormalizedTitle string             `json:"normalized_title"`
+	Protocol        *string            `json:"protocol"`
+	Reason          string             `json:"reason"`
+	Message         *string            `json:"message"`
+	MediaItemID     pgtype.UUID        `json:"media_item_id"`
+	DownloadJobID   pgtype.UUID        `json:"download_job_id"`
+	ExpiresAt       pgtype.Timestamptz `json:"expires_at"`
+	CreatedAt       time.Time          `json:"created_at"`
+}
+
+type CalendarToken struct {
+	UserID    pgtype.UUID `json:"user_id"`
+	Token     string      `json:"token"`
+	CreatedAt time.Time   `json:"created_at"`
+}
+
 type DownloadJob struct {
 	ID                   pgtype.UUID `json:"id"`
 	Status               string      `json:"status"`
@@ -275,26 +319,54 @@ type ImportTaskEvent struct {
 	CreatedAt    time.Time   `json:"created_at"`
 }
 
+type IndexerHealth struct {
+	IndexerID           int64              `json:"indexer_id"`
+	IndexerName         string             `json:"indexer_name"`
+	ConsecutiveFailures int32              `json:"consecutive_failures"`
+	LastSuccessAt       pgtype.Timestamptz `json:"last_success_at"`
+	LastFailureAt       pgtype.Timestamptz `json:"last_failure_at"`
+	LastError           *string            `json:"last_error"`
+	DisabledUntil       pgtype.Timestamptz `json:"disabled_until"`
+	UpdatedAt           time.Time          `json:"updated_at"`
+}
+
+type IndexerSearchEvent struct {
+	ID          pgtype.UUID `json:"id"`
+	IndexerID   int64       `json:"indexer_id"`
+	Query       string      `json:"query"`
+	DurationMs  int32       `json:"duration_ms"`
+	ResultCount int32       `json:"result_count"`
+	Error       *string     `json:"error"`
+	CreatedAt   time.Time   `json:"created_at"`
+}
+
 type Library struct {
-	ID        pgtype.UUID `json:"id"`
-	Name      string      `json:"name"`
-	Type      string      `json:"type"`
-	RootPath  string      `json:"root_path"`
-	Enabled   bool        `json:"enabled"`
-	Default   bool        `json:"default"`
-	CreatedAt time.Time   `json:"created_at"`
-	UpdatedAt time.Time   `json:"updated_at"`
+	ID                  pgtype.UUID `json:"id"`
+	Name                string      `json:"name"`
+	Type                string      `json:"type"`
+	RootPath            string      `json:"root_path"`
+	Enabled             bool        `json:"enabled"`
+	Default             bool        `json:"default"`
+	CreatedAt           time.Time   `json:"created_at"`
+	UpdatedAt           time.Time   `json:"updated_at"`
+	QualityProfileID    pgtype.UUID `json:"quality_profile_id"`
+	ImportMode          string      `json:"import_mode"`
+	ImportExtraFiles    bool        `json:"import_extra_files"`
+	ExtraFileExtensions []string    `json:"extra_file_extensions"`
 }
 
 type MediaEpisode struct {
-	ID            pgtype.UUID `json:"id"`
-	SeasonID      pgtype.UUID `json:"season_id"`
-	EpisodeNumber int32       `json:"episode_number"`
-	Title         *string     `json:"title"`
-	AirDate       pgtype.Date `json:"air_date"`
-	TmdbID        *int64      `json:"tmdb_id"`
-	TvdbID        *int64      `json:"tvdb_id"`
-	CreatedAt     time.Time   `json:"created_at"`
+	ID             pgtype.UUID        `json:"id"`
+	SeasonID       pgtype.UUID        `json:"season_id"`
+	EpisodeNumber  int32              `json:"episode_number"`
+	Title          *string            `json:"title"`
+	AirDate        pgtype.Date        `json:"air_date"`
+	TmdbID         *int64             `json:"tmdb_id"`
+	TvdbID         *int64             `json:"tvdb_id"`
+	CreatedAt      time.Time          `json:"created_at"`
+	AbsoluteNumber *int32             `json:"absolute_number"`
+	Monitored      bool               `json:"monitored"`
+	LastSearchedAt pgtype.Timestamptz `json:"last_searched_at"`
 }
 
 type MediaFile struct {
@@ -304,18 +376,35 @@ type MediaFile struct {
 	EpisodeID   pgtype.UUID `json:"episode_id"`
 	Path        string      `json:"path"`
 	CreatedAt   time.Time   `json:"created_at"`
+	Quality     *string     `json:"quality"`
+}
+
+type MediaFileExtra struct {
+	ID          pgtype.UUID `json:"id"`
+	MediaFileID pgtype.UUID `json:"media_file_id"`
+	Type        string      `json:"type"`
+	Path        string      `json:"path"`
+	Language    *string     `json:"language"`
+	Forced      bool        `json:"forced"`
+	Sdh         bool        `json:"sdh"`
+	SourcePath  *string     `json:"source_path"`
+	Method      string      `json:"method"`
+	CreatedAt   time.Time   `json:"created_at"`
 }
 
 type MediaFileImport struct {
-	ID           pgtype.UUID `json:"id"`
-	MediaFileID  pgtype.UUID `json:"media_file_id"`
-	ImportTaskID pgtype.UUID `json:"import_task_id"`
-	Method       string      `json:"method"`
-	SourcePath   *string     `json:"source_path"`
-	DestPath     string      `json:"dest_path"`
-	AttemptedAt  time.Time   `json:"attempted_at"`
-	Success      bool        `json:"success"`
-	ErrorMessage *string     `json:"error_message"`
+	ID              pgtype.UUID `json:"id"`
+	MediaFileID     pgtype.UUID `json:"media_file_id"`
+	ImportTaskID    pgtype.UUID `json:"import_task_id"`
+	Method          string      `json:"method"`
+	SourcePath      *string     `json:"source_path"`
+	DestPath        string      `json:"dest_path"`
+	AttemptedAt     time.Time   `json:"attempted_at"`
+	Success         bool        `json:"success"`
+	ErrorMessage    *string     `json:"error_message"`
+	ReplacedPath    *string     `json:"replaced_path"`
+	ReplacedQuality *string     `json:"replaced_quality"`
+	ArchivedPath    *string     `json:"archived_path"`
 }
 
 type MediaFileState struct {
@@ -326,13 +415,52 @@ type MediaFileState struct {
 }
 
 type MediaItem struct {
-	ID        pgtype.UUID `json:"id"`
-	Type      string      `json:"type"`
-	Title     string      `json:"title"`
-	Year      *int32      `json:"year"`
-	TmdbID    *int64      `json:"tmdb_id"`
-	CreatedAt time.Time   `json:"created_at"`
-	UpdatedAt time.Time   `json:"updated_at"`
+	ID                  pgtype.UUID        `json:"id"`
+	Type                string             `json:"type"`
+	Title               string             `json:"title"`
+	Year                *int32             `json:"year"`
+	TmdbID              *int64             `json:"tmdb_id"`
+	CreatedAt           time.Time          `json:"created_at"`
+	UpdatedAt           time.Time          `json:"updated_at"`
+	ImdbID              *string            `json:"imdb_id"`
+	TvdbID              *int64             `json:"tvdb_id"`
+	QualityProfileID    pgtype.UUID        `json:"quality_profile_id"`
+	Monitored           bool               `json:"monitored"`
+	MonitorNewSeasons   bool               `json:"monitor_new_seasons"`
+	LibraryID           pgtype.UUID        `json:"library_id"`
+	LastSearchedAt      pgtype.Timestamptz `json:"last_searched_at"`
+	ReleaseDate         pgtype.Date        `json:"release_date"`
+	DigitalReleaseDate  pgtype.Date        `json:"digital_release_date"`
+	PhysicalReleaseDate pgtype.Date        `json:"physical_release_date"`
+	MetadataRefreshedAt pgtype.Timestamptz `json:"metadata_refreshed_at"`
+}
+
+type MediaItemAlias struct {
+	ID          pgtype.UUID `json:"id"`
+	MediaItemID pgtype.UUID `json:"media_item_id"`
+	Title       string      `json:"title"`
+	Excluded    bool        `json:"excluded"`
+	CreatedAt   time.Time   `json:"created_at"`
+}
+
+type MediaRequest struct {
+	ID               pgtype.UUID        `json:"id"`
+	UserID           pgtype.UUID        `json:"user_id"`
+	MediaType        string             `json:"media_type"`
+	TmdbID           int64              `json:"tmdb_id"`
+	Title            string             `json:"title"`
+	Year             *int32             `json:"year"`
+	Seasons          []int32            `json:"seasons"`
+	Status           string             `json:"status"`
+	DecidedBy        pgtype.UUID        `json:"decided_by"`
+	DecidedAt        pgtype.Timestamptz `json:"decided_at"`
+	DecisionComment  *string            `json:"decision_comment"`
+	MediaItemID      pgtype.UUID        `json:"media_item_id"`
+	AvailableAt      pgtype.Timestamptz `json:"available_at"`
+	CreatedAt        time.Time          `json:"created_at"`
+	UpdatedAt        time.Time          `json:"updated_at"`
+	SeasonCount      int32              `json:"season_count"`
+	QualityProfileID pgtype.UUID        `json:"quality_profile_id"`
 }
 
 type MediaSeason struct {
@@ -341,6 +469,7 @@ type MediaSeason struct {
 	SeasonNumber int32       `json:"season_number"`
 	AirDate      pgtype.Date `json:"air_date"`
 	CreatedAt    time.Time   `json:"created_at"`
+	Monitored    bool        `json:"monitored"`
 }
 
 type NameTemplate struct {
@@ -383,6 +512,50 @@ type Policy struct {
 	UpdatedAt   time.Time   `json:"updated_at"`
 }
 
+type QualityProfile struct {
+	ID              pgtype.UUID `json:"id"`
+	Name            string      `json:"name"`
+	Items           []byte      `json:"items"`
+	Cutoff          string      `json:"cutoff"`
+	UpgradesAllowed bool        `json:"upgrades_allowed"`
+	Default         bool        `json:"default"`
+	CreatedAt       time.Time   `json:"created_at"`
+	UpdatedAt       time.Time   `json:"updated_at"`
+}
+
+type RemotePathMapping struct {
+	ID           pgtype.UUID `json:"id"`
+	DownloaderID pgtype.UUID `json:"downloader_id"`
+	RemotePath   string      `json:"remote_path"`
+	LocalPath    string      `json:"local_path"`
+	CreatedAt    time.Time   `json:"created_at"`
+	UpdatedAt    time.Time   `json:"updated_at"`
+}
+
+type RequestQuota struct {
+	SubjectType GrantSubject `json:"subject_type"`
+	SubjectID   pgtype.UUID  `json:"subject_id"`
+	MovieLimit  *int32       `json:"movie_limit"`
+	SeasonLimit *int32       `json:"season_limit"`
+	WindowDays  int32        `json:"window_days"`
+	CreatedAt   time.Time    `json:"created_at"`
+	UpdatedAt   time.Time    `json:"updated_at"`
+}
+
+type RequestRule struct {
+	ID           pgtype.UUID `json:"id"`
+	RoleID       pgtype.UUID `json:"role_id"`
+	Name         string      `json:"name"`
+	Enabled      bool        `json:"enabled"`
+	Priority     int32       `json:"priority"`
+	LeftOperand  *string     `json:"left_operand"`
+	Operator     *string     `json:"operator"`
+	RightOperand *string     `json:"right_operand"`
+	Action       string      `json:"action"`
+	CreatedAt    time.Time   `json:"created_at"`
+	UpdatedAt    time.Time   `json:"updated_at"`
+}
+
 type Role struct {
 	ID          pgtype.UUID `json:"id"`
 	Name        string      `json:"name"`
@@ -401,6 +574,29 @@ type Rule struct {
 	UpdatedAt    time.Time   `json:"updated_at"`
 }
 
+type SearchResult struct {
+	ID        pgtype.UUID `json:"id"`
+	SessionID pgtype.UUID `json:"session_id"`
+	Position  int32       `json:"position"`
+	IndexerID int64       `json:"indexer_id"`
+	Guid      string      `json:"guid"`
+	Title     string      `json:"title"`
+	Candidate []byte      `json:"candidate"`
+	CreatedAt time.Time   `json:"created_at"`
+}
+
+type SearchSession struct {
+	ID            pgtype.UUID `json:"id"`
+	MediaType     string      `json:"media_type"`
+	TmdbID        int64       `json:"tmdb_id"`
+	SeasonNumber  *int32      `json:"season_number"`
+	EpisodeNumber *int32      `json:"episode_number"`
+	Query         string      `json:"query"`
+	Sources       []byte      `json:"sources"`
+	CreatedAt     time.Time   `json:"created_at"`
+	ExpiresAt     time.Time   `json:"expires_at"`
+}
+
 type UnmatchedFile struct {
 	ID                  pgtype.UUID        `json:"id"`
 	LibraryID           pgtype.UUID        `json:"library_id"`
@@ -58,3 +58,35 @@ func (q *Queries) ListUserRoles(ctx context.Context, userID pgtype.UUID) ([]Role
 	}
 	return items, nil
 }
+
+const userHasPermission = `-- name: UserHasPermission :one
+WITH grants AS (
+  SELECT g.effect
+  FROM permission_grant g
+  WHERE g.permission_key = $1
+    AND g.resource_type IS NULL AND g.resource_id IS NULL
+    AND (
+      (g.subject_type = 'user' AND g.subject_id = $2)
+      OR (g.subject_type = 'role' AND g.subject_id IN (
+        SELECT ur.role_id FROM user_role ur WHERE ur.user_id = $2
+      ))
+    )
+)
+SELECT
+  EXISTS (SELECT 1 FROM grants WHERE effect = 'allow')
+  AND NOT EXISTS (SELECT 1 FROM grants WHERE effect = 'deny')
+`
+
+type UserHasPermissionParams struct {
+	PermissionKey string      `json:"permission_key"`
+	UserID        pgtype.UUID `json:"user_id"`
+}
+
+// Whether a global grant allows the permission to the user, directly or via
+// one of the user's roles, with no deny grant overriding it.
+func (q *Queries) UserHasPermission(ctx context.Context, arg UserHasPermissionParams) (bool, error) {
+	row := q.db.QueryRow(ctx, userHasPermission, arg.PermissionKey, arg.UserID)
+	var column_1 bool
+	err := row.Scan(&column_1)
+	return column_1, err
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"sort"
 
+	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/kyleaupton/arrflix/internal/model"
 	"github.com/kyleaupton/arrflix/internal/repo"
 )
@@ -34,10 +35,11 @@ func NewComposer(
 	}
 }
 
-// BuildFeed constructs the complete feed with hero and rows
-func (c *Composer) BuildFeed(ctx context.Context) (*model.Feed, error) {
+// BuildFeed constructs the complete feed with hero and rows for a user
+func (c *Composer) BuildFeed(ctx context.Context, userID pgtype.UUID) (*model.Feed, error) {
 	// Initialize global dedupe set
 	seen := make(map[string]bool)
+	requests := c.requestStatuses(ctx, userID)
 
 	// 1. Select hero via strategy
 	hero, heroKey, err := c.heroStrategy.SelectHero(ctx)
@@ -61,7 +63,7 @@ func (c *Composer) BuildFeed(ctx context.Context) (*model.Feed, error) {
 	shownIntents := make([]model.RowIntent, 0)
 
 	for _, def := range orderedRows {
-		row, err := c.buildRow(ctx, def, seen)
+		row, err := c.buildRow(ctx, def, seen, requests)
 		if err != nil {
 			// Log error and skip this row
 			continue
@@ -82,7 +84,7 @@ func (c *Composer) BuildFeed(ctx context.Context) (*model.Feed, error) {
 }
 
 // buildRow processes a single row definition
-func (c *Composer) buildRow(ctx context.Context, def model.RowDefinition, seen map[string]bool) (model.FeedRow, error) {
+func (c *Composer) buildRow(ctx context.Context, def model.RowDefinition, seen map[string]bool, requests map[string]model.MediaRequestStatus) (model.FeedRow, error) {
 	// Fetch candidates from all sources
 	candidates := make([]model.Title, 0)
 	for _, sourceConfig := range def.Sources {
@@ -114,8 +116,8 @@ func (c *Composer) buildRow(ctx context.Context, def model.RowDefinition, seen m
 		seen[item.TitleKey()] = true
 	}
 
-	// Hydrate with user overlay (IsInLibrary, IsDownloading)
-	hydrated := c.hydrateUserOverlay(ctx, selected)
+	// Hydrate with user overlay (IsInLibrary, IsDownloading, RequestStatus)
+	hydrated := c.hydrateUserOverlay(ctx, selected, requests)
 
 	return model.FeedRow{
 		ID:       string(def.Intent),
@@ -213,7 +215,7 @@ func (c *Composer) applyRanking(items []model.Title, strategy model.RankingStrat
 }
 
 // hydrateUserOverlay adds user-specific state to titles
-func (c *Composer) hydrateUserOverlay(ctx context.Context, titles []model.Title) []model.HydratedTitle {
+func (c *Composer) hydrateUserOverlay(ctx context.Context, titles []model.Title, requests map[string]model.MediaRequestStatus) []model.HydratedTitle {
 	hydrated := make([]model.HydratedTitle, len(titles))
 
 	for i, t := range titles {
@@ -221,12 +223,31 @@ func (c *Composer) hydrateUserOverlay(ctx context.Context, titles []model.Title)
 			Title:         t,
 			IsInLibrary:   c.isInLibrary(ctx, t.TmdbID, t.MediaType),
 			IsDownloading: c.hasActiveDownloads(ctx, t.TmdbID, t.MediaType),
+			RequestStatus: requests[t.TitleKey()],
 		}
 	}
 
 	return hydrated
 }
 
+// requestStatuses maps the user's titles, by title key, to the status of
+// their latest request for each
+func (c *Composer) requestStatuses(ctx context.Context, userID pgtype.UUID) map[string]model.MediaRequestStatus {
+	statuses := make(map[string]model.MediaRequestStatus)
+	if !userID.Valid {
+		return statuses
+	}
+	rows, err := c.repo.ListUserMediaRequestStatuses(ctx, userID)
+	if err != nil {
+		return statuses // Feed renders without request state
+	}
+	for _, row := range rows {
+		key := model.Title{MediaType: model.MediaType(row.MediaType), TmdbID: row.TmdbID}.TitleKey()
+		statuses[key] = model.MediaRequestStatus(row.Status)
+	}
+	return statuses
+}
+
 // isInLibrary checks if a title is in the user's library
 func (c *Composer) isInLibrary(ctx context.Context, tmdbID int64, typ model.MediaType) bool {
 	_, err := c.repo.GetMediaItemByTmdbIDAndType(ctx, tmdbID, string(typ))
@@ -2002,10 +2002,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/model.DownloadCandidate"
-                            }
+                            "$ref": "#/definitions/model.DownloadCandidatesResponse"
                         }
                     },
                     "400": {
@@ -3066,10 +3063,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/model.DownloadCandidate"
-                            }
+                            "$ref": "#/definitions/model.DownloadCandidatesResponse"
                         }
                     },
                     "400": {
@@ -6025,6 +6019,26 @@ const docTemplate = `{
                 }
             }
         },
+        "model.DownloadCandidatesResponse": {
+            "type": "object",
+            "properties": {
+                "candidates": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.DownloadCandidate"
+                    }
+                },
+                "session": {
+                    "$ref": "#/definitions/model.SearchSession"
+                },
+                "sources": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.SearchSourceReport"
+                    }
+                }
+            }
+        },
         "model.EnumValue": {
             "type": "object",
             "required": [
@@ -7302,6 +7316,55 @@ const docTemplate = `{
                 }
             }
         },
+        "model.SearchSession": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "episode": {
+                    "type": "integer"
+                },
+                "expiresAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "mediaType": {
+                    "$ref": "#/definitions/model.MediaType"
+                },
+                "query": {
+                    "type": "string"
+                },
+                "resultCount": {
+                    "type": "integer"
+                },
+                "season": {
+                    "type": "integer"
+                },
+                "tmdbId": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.SearchSourceReport": {
+            "type": "object",
+            "properties": {
+                "durationMs": {
+                    "type": "integer"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "resultCount": {
+                    "type": "integer"
+                }
+            }
+        },
         "model.SearchResponse": {
             "type": "object",
             "required": [
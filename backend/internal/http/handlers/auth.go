@@ -8,6 +8,7 @@ import (
 	"strings"
 
 	"github.com/golang-jwt/jwt/v4"
+	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/kyleaupton/arrflix/internal/config"
 	"github.com/kyleaupton/arrflix/internal/logger"
@@ -249,10 +250,20 @@ func (h *Auth) Me(c echo.Context) error {
 		return c.JSON(http.StatusUnauthorized, map[string]string{"error": "invalid token subject"})
 	}
 
-	// For now, just echo back minimal info from token; optionally you can fetch from DB later
-	return c.JSON(http.StatusOK, map[string]interface{}{
+	res := map[string]interface{}{
 		"sub":   sub,
 		"email": claims["email"],
 		"name":  claims["name"],
-	})
+	}
+
+	// Quota usage for requesting media
+	var userID pgtype.UUID
+	if err := userID.Scan(sub); err == nil {
+		if usage, err := h.svc.RequestRules.Usage(c.Request().Context(), userID); err == nil {
+			res["requestQuota"] = usage
+		} else {
+			h.log.Warn().Err(err).Msg("Failed to get request quota usage")
+		}
+	}
+	return c.JSON(http.StatusOK, res)
 }
@@ -0,0 +1,171 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/kyleaupton/arrflix/internal/model"
+	"github.com/kyleaupton/arrflix/internal/service"
+	"github.com/labstack/echo/v4"
+)
+
+type AutoSearch struct{ svc *service.Services }
+
+func NewAutoSearch(s *service.Services) *AutoSearch { return &AutoSearch{svc: s} }
+
+func (h *AutoSearch) RegisterProtected(v1 *echo.Group) {
+	v1.POST("/auto-search/run", h.Run)
+	v1.GET("/auto-search/decisions", h.ListDecisions)
+	v1.GET("/auto-search/decisions/:id", h.GetDecision)
+
+	v1.POST("/movie/:id/auto-search", h.SearchMovie)
+	v1.POST("/series/:id/auto-search", h.SearchSeries)
+}
+
+// Run starts an automatic search for missing monitored items
+// @Summary Run automatic search now
+// @Description Searches wanted movies and episodes in the background, ignoring the schedule
+// @Tags    auto-search
+// @Produce json
+// @Success 202 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router  /v1/auto-search/run [post]
+func (h *AutoSearch) Run(c echo.Context) error {
+	if err := h.svc.AutoSearch.Start(model.AutoSearchTriggerManual); err != nil {
+		if errors.Is(err, service.ErrAutoSearchRunning) {
+			return c.JSON(http.StatusConflict, map[string]string{"error": err.Error()})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusAccepted, map[string]string{"status": "started"})
+}
+
+// ListDecisions lists automatic search decisions
+// @Summary List automatic search decisions
+// @Tags    auto-search
+// @Produce json
+// @Param   mediaItemId query string false "Filter by media item ID"
+// @Param   outcome query string false "Filter by outcome (grabbed, rejected, no_results, failed)"
+// @Param   page query int false "Page number (default 1)"
+// @Param   pageSize query int false "Page size (default 20)"
+// @Success 200 {object} model.PaginatedAutoSearchDecisionResponse
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router  /v1/auto-search/decisions [get]
+func (h *AutoSearch) ListDecisions(c echo.Context) error {
+	page, pageSize := 1, 20
+	if pageStr := c.QueryParam("page"); pageStr != "" {
+		if p, err := strconv.Atoi(pageStr); err == nil && p > 0 {
+			page = p
+		}
+	}
+	if pageSizeStr := c.QueryParam("pageSize"); pageSizeStr != "" {
+		if ps, err := strconv.Atoi(pageSizeStr); err == nil && ps > 0 && ps <= 100 {
+			pageSize = ps
+		}
+	}
+
+	var mediaItemID pgtype.UUID
+	if idStr := c.QueryParam("mediaItemId"); idStr != "" {
+		if err := mediaItemID.Scan(idStr); err != nil {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid media item id"})
+		}
+	}
+	var outcome *string
+	if o := c.QueryParam("outcome"); o != "" {
+		outcome = &o
+	}
+
+	resp, err := h.svc.AutoSearch.ListDecisions(c.Request().Context(), mediaItemID, outcome, page, pageSize)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusOK, resp)
+}
+
+// GetDecision returns an automatic search decision with its candidates and trace
+// @Summary Get automatic search decision
+// @Tags    auto-search
+// @Produce json
+// @Param   id path string true "Decision ID"
+// @Success 200 {object} model.AutoSearchDecision
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router  /v1/auto-search/decisions/{id} [get]
+func (h *AutoSearch) GetDecision(c echo.Context) error {
+	var id pgtype.UUID
+	if err := id.Scan(c.Param("id")); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid id"})
+	}
+
+	decision, err := h.svc.AutoSearch.GetDecision(c.Request().Context(), id)
+	if err != nil {
+		if errors.Is(err, service.ErrAutoSearchDecisionNotFound) {
+			return c.JSON(http.StatusNotFound, map[string]string{"error": err.Error()})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusOK, decision)
+}
+
+// SearchMovie searches for a movie and grabs the best acceptable release
+// @Summary Automatically search a movie
+// @Tags    auto-search
+// @Produce json
+// @Param   id path int true "Movie ID (TMDB ID)"
+// @Success 200 {object} model.AutoSearchDecision
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router  /v1/movie/{id}/auto-search [post]
+func (h *AutoSearch) SearchMovie(c echo.Context) error {
+	tmdbID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid id"})
+	}
+
+	decision, err := h.svc.AutoSearch.SearchMovie(c.Request().Context(), tmdbID, model.AutoSearchTriggerManual)
+	if err != nil {
+		return autoSearchError(c, err)
+	}
+	return c.JSON(http.StatusOK, decision)
+}
+
+// SearchSeries searches for a season pack or an episode and grabs the best acceptable release
+// @Summary Automatically search a season or episode
+// @Tags    auto-search
+// @Accept  json
+// @Produce json
+// @Param   id path int true "Series ID (TMDB ID)"
+// @Param   payload body model.AutoSearchRequest true "Season, and optionally episode"
+// @Success 200 {object} model.AutoSearchDecision
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router  /v1/series/{id}/auto-search [post]
+func (h *AutoSearch) SearchSeries(c echo.Context) error {
+	tmdbID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid id"})
+	}
+	var req model.AutoSearchRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid body"})
+	}
+
+	decision, err := h.svc.AutoSearch.SearchSeries(c.Request().Context(), tmdbID, req.Season, req.Episode, model.AutoSearchTriggerManual)
+	if err != nil {
+		return autoSearchError(c, err)
+	}
+	return c.JSON(http.StatusOK, decision)
+}
+
+func autoSearchError(c echo.Context, err error) error {
+	switch {
+	case errors.Is(err, service.ErrAutoSearchInvalid):
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	case errors.Is(err, service.ErrMediaNotInLibrary):
+		return c.JSON(http.StatusNotFound, map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+}
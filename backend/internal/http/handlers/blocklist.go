@@ -0,0 +1,173 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/kyleaupton/arrflix/internal/model"
+	"github.com/kyleaupton/arrflix/internal/service"
+	"github.com/labstack/echo/v4"
+)
+
+type Blocklist struct{ svc *service.Services }
+
+func NewBlocklist(s *service.Services) *Blocklist { return &Blocklist{svc: s} }
+
+func (h *Blocklist) RegisterProtected(v1 *echo.Group) {
+	v1.GET("/blocklist", h.List)
+	v1.POST("/blocklist", h.Create)
+	v1.GET("/blocklist/:id", h.Get)
+	v1.PUT("/blocklist/:id", h.Update)
+	v1.DELETE("/blocklist/:id", h.Delete)
+}
+
+// List blocklisted releases
+// @Summary List blocklisted releases
+// @Tags    blocklist
+// @Produce json
+// @Param   mediaItemId query string false "Filter by media item ID"
+// @Param   page query int false "Page number (default 1)"
+// @Param   pageSize query int false "Page size (default 20)"
+// @Success 200 {object} model.PaginatedBlocklistResponse
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router  /v1/blocklist [get]
+func (h *Blocklist) List(c echo.Context) error {
+	page, pageSize := 1, 20
+	if pageStr := c.QueryParam("page"); pageStr != "" {
+		if p, err := strconv.Atoi(pageStr); err == nil && p > 0 {
+			page = p
+		}
+	}
+	if pageSizeStr := c.QueryParam("pageSize"); pageSizeStr != "" {
+		if ps, err := strconv.Atoi(pageSizeStr); err == nil && ps > 0 && ps <= 100 {
+			pageSize = ps
+		}
+	}
+
+	var mediaItemID pgtype.UUID
+	if idStr := c.QueryParam("mediaItemId"); idStr != "" {
+		if err := mediaItemID.Scan(idStr); err != nil {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid media item id"})
+		}
+	}
+
+	resp, err := h.svc.Blocklist.List(c.Request().Context(), mediaItemID, page, pageSize)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusOK, resp)
+}
+
+// Get a blocklist entry
+// @Summary Get blocklist entry
+// @Tags    blocklist
+// @Produce json
+// @Param   id path string true "Blocklist entry ID"
+// @Success 200 {object} model.BlocklistEntry
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router  /v1/blocklist/{id} [get]
+func (h *Blocklist) Get(c echo.Context) error {
+	var id pgtype.UUID
+	if err := id.Scan(c.Param("id")); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid id"})
+	}
+
+	entry, err := h.svc.Blocklist.Get(c.Request().Context(), id)
+	if err != nil {
+		if errors.Is(err, service.ErrBlocklistEntryNotFound) {
+			return c.JSON(http.StatusNotFound, map[string]string{"error": err.Error()})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusOK, entry)
+}
+
+// Create blocklists a release manually
+// @Summary Blocklist a release
+// @Description Blocklist the release grabbed by a download job, or a release identified by info hash or indexer and GUID
+// @Tags    blocklist
+// @Accept  json
+// @Produce json
+// @Param   payload body model.CreateBlocklistEntryRequest true "Release to blocklist"
+// @Success 201 {object} model.BlocklistEntry
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router  /v1/blocklist [post]
+func (h *Blocklist) Create(c echo.Context) error {
+	var req model.CreateBlocklistEntryRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid body"})
+	}
+
+	entry, err := h.svc.Blocklist.Create(c.Request().Context(), req)
+	if err != nil {
+		if errors.Is(err, service.ErrBlocklistEntryInvalid) {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+		}
+		if errors.Is(err, service.ErrBlocklistJobNotFound) {
+			return c.JSON(http.StatusNotFound, map[string]string{"error": err.Error()})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusCreated, entry)
+}
+
+// Update a blocklist entry
+// @Summary Update blocklist entry
+// @Tags    blocklist
+// @Accept  json
+// @Produce json
+// @Param   id path string true "Blocklist entry ID"
+// @Param   payload body model.UpdateBlocklistEntryRequest true "Message and expiry"
+// @Success 200 {object} model.BlocklistEntry
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router  /v1/blocklist/{id} [put]
+func (h *Blocklist) Update(c echo.Context) error {
+	var id pgtype.UUID
+	if err := id.Scan(c.Param("id")); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid id"})
+	}
+
+	var req model.UpdateBlocklistEntryRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid body"})
+	}
+
+	entry, err := h.svc.Blocklist.Update(c.Request().Context(), id, req)
+	if err != nil {
+		if errors.Is(err, service.ErrBlocklistEntryNotFound) {
+			return c.JSON(http.StatusNotFound, map[string]string{"error": err.Error()})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusOK, entry)
+}
+
+// Delete removes a release from the blocklist
+// @Summary Delete blocklist entry
+// @Tags    blocklist
+// @Param   id path string true "Blocklist entry ID"
+// @Success 204 {string} string ""
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router  /v1/blocklist/{id} [delete]
+func (h *Blocklist) Delete(c echo.Context) error {
+	var id pgtype.UUID
+	if err := id.Scan(c.Param("id")); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid id"})
+	}
+
+	if err := h.svc.Blocklist.Delete(c.Request().Context(), id); err != nil {
+		if errors.Is(err, service.ErrBlocklistEntryNotFound) {
+			return c.JSON(http.StatusNotFound, map[string]string{"error": err.Error()})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	return c.NoContent(http.StatusNoContent)
+}
@@ -0,0 +1,148 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/kyleaupton/arrflix/internal/service"
+	"github.com/labstack/echo/v4"
+)
+
+// defaultCalendarDays is the span of a calendar request without an end.
+const defaultCalendarDays = 30
+
+type Calendar struct{ svc *service.Services }
+
+func NewCalendar(s *service.Services) *Calendar { return &Calendar{svc: s} }
+
+// RegisterPublic registers the iCal feed, which authenticates with its own
+// token since calendar apps can't send a bearer token.
+func (h *Calendar) RegisterPublic(v1 *echo.Group) {
+	v1.GET("/calendar/feed.ics", h.Feed)
+}
+
+func (h *Calendar) RegisterProtected(v1 *echo.Group) {
+	v1.GET("/calendar", h.List)
+	v1.GET("/calendar/feed", h.GetFeed)
+	v1.POST("/calendar/feed/regenerate", h.RegenerateFeed)
+}
+
+// List returns the calendar
+// @Summary List calendar
+// @Description Episode air dates and movie theatrical, digital and physical release dates of library items, by date
+// @Tags    calendar
+// @Produce json
+// @Param   start query string false "First day, YYYY-MM-DD (default today)"
+// @Param   end query string false "Last day, YYYY-MM-DD (default 30 days after start)"
+// @Success 200 {array} model.CalendarEntry
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router  /v1/calendar [get]
+func (h *Calendar) List(c echo.Context) error {
+	now := time.Now()
+	start := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	if s := c.QueryParam("start"); s != "" {
+		t, err := time.Parse(time.DateOnly, s)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid start, expected YYYY-MM-DD"})
+		}
+		start = t
+	}
+	end := start.AddDate(0, 0, defaultCalendarDays)
+	if s := c.QueryParam("end"); s != "" {
+		t, err := time.Parse(time.DateOnly, s)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid end, expected YYYY-MM-DD"})
+		}
+		end = t
+	}
+
+	entries, err := h.svc.Calendar.List(c.Request().Context(), start, end)
+	if err != nil {
+		if errors.Is(err, service.ErrCalendarRange) {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusOK, entries)
+}
+
+// GetFeed returns the current user's iCal feed
+// @Summary Get calendar feed
+// @Description Returns the token and path of the current user's iCal feed, creating the token on first use
+// @Tags    calendar
+// @Produce json
+// @Success 200 {object} model.CalendarFeed
+// @Failure 401 {object} map[string]string
+// @Router  /v1/calendar/feed [get]
+func (h *Calendar) GetFeed(c echo.Context) error {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": "unauthorized"})
+	}
+	feed, err := h.svc.Calendar.FeedToken(c.Request().Context(), userID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusOK, feed)
+}
+
+// RegenerateFeed replaces the current user's iCal feed token
+// @Summary Regenerate calendar feed token
+// @Description Replaces the feed token; calendar apps subscribed with the old one stop updating
+// @Tags    calendar
+// @Produce json
+// @Success 200 {object} model.CalendarFeed
+// @Failure 401 {object} map[string]string
+// @Router  /v1/calendar/feed/regenerate [post]
+func (h *Calendar) RegenerateFeed(c echo.Context) error {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": "unauthorized"})
+	}
+	feed, err := h.svc.Calendar.RegenerateFeedToken(c.Request().Context(), userID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusOK, feed)
+}
+
+// Feed serves the iCal feed
+// @Summary Calendar iCal feed
+// @Description The calendar from 30 days ago to a year ahead as an iCal feed, for calendar apps
+// @Tags    calendar
+// @Produce text/calendar
+// @Param   token query string true "Calendar feed token"
+// @Success 200 {string} string
+// @Failure 401 {object} map[string]string
+// @Router  /v1/calendar/feed.ics [get]
+func (h *Calendar) Feed(c echo.Context) error {
+	body, err := h.svc.Calendar.Feed(c.Request().Context(), c.QueryParam("token"))
+	if err != nil {
+		if errors.Is(err, service.ErrCalendarTokenInvalid) {
+			return c.JSON(http.StatusUnauthorized, map[string]string{"error": err.Error()})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	return c.Blob(http.StatusOK, "text/calendar; charset=utf-8", body)
+}
+
+// currentUserID returns the user the JWT middleware authenticated.
+func currentUserID(c echo.Context) (pgtype.UUID, bool) {
+	var userID pgtype.UUID
+	claims, ok := c.Get("claims").(jwt.MapClaims)
+	if !ok {
+		return userID, false
+	}
+	sub, ok := claims["sub"].(string)
+	if !ok {
+		return userID, false
+	}
+	if err := userID.Scan(sub); err != nil {
+		return userID, false
+	}
+	return userID, true
+}
@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"strconv"
 
+	"github.com/jackc/pgx/v5/pgtype"
 	dbgen "github.com/kyleaupton/arrflix/internal/db/sqlc"
 	"github.com/kyleaupton/arrflix/internal/model"
 	"github.com/kyleaupton/arrflix/internal/service"
@@ -25,6 +26,10 @@ func (h *DownloadCandidates) RegisterProtected(v1 *echo.Group) {
 	v1.GET("/series/:id/candidates", h.GetSeriesDownloadCandidates)
 	v1.POST("/series/:id/candidate/preview", h.PreviewSeriesCandidate)
 	v1.POST("/series/:id/candidate/download", h.DownloadSeriesCandidate)
+
+	v1.GET("/movie/:id/searches", h.ListMovieSearchSessions)
+	v1.GET("/series/:id/searches", h.ListSeriesSearchSessions)
+	v1.GET("/searches/:sessionId", h.GetSearchSession)
 }
 
 // GetDownloadCandidates searches for download candidates for a movie
@@ -32,7 +37,8 @@ func (h *DownloadCandidates) RegisterProtected(v1 *echo.Group) {
 // @Tags    download-candidates
 // @Produce json
 // @Param   id path int true "Movie ID (TMDB ID)"
-// @Success 200 {array} model.DownloadCandidate
+// @Param   strict query bool false "Drop releases that don't match the title and year"
+// @Success 200 {object} model.DownloadCandidatesResponse
 // @Failure 400 {object} map[string]string
 // @Failure 500 {object} map[string]string
 // @Router  /v1/movie/{id}/candidates [get]
@@ -43,7 +49,7 @@ func (h *DownloadCandidates) GetDownloadCandidates(c echo.Context) error {
 	}
 
 	ctx := c.Request().Context()
-	candidates, err := h.svc.DownloadCandidates.SearchDownloadCandidates(ctx, movieID)
+	candidates, err := h.svc.DownloadCandidates.SearchDownloadCandidates(ctx, movieID, strictParam(c))
 	if err != nil {
 		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
 	}
@@ -58,7 +64,8 @@ func (h *DownloadCandidates) GetDownloadCandidates(c echo.Context) error {
 // @Param   id path int true "Series ID (TMDB ID)"
 // @Param   season query int false "Season number"
 // @Param   episode query int false "Episode number"
-// @Success 200 {array} model.DownloadCandidate
+// @Param   strict query bool false "Drop releases that don't match the title, season and episode"
+// @Success 200 {object} model.DownloadCandidatesResponse
 // @Failure 400 {object} map[string]string
 // @Failure 500 {object} map[string]string
 // @Router  /v1/series/{id}/candidates [get]
@@ -83,7 +90,7 @@ func (h *DownloadCandidates) GetSeriesDownloadCandidates(c echo.Context) error {
 	}
 
 	ctx := c.Request().Context()
-	candidates, err := h.svc.DownloadCandidates.SearchSeriesDownloadCandidates(ctx, seriesID, season, episode)
+	candidates, err := h.svc.DownloadCandidates.SearchSeriesDownloadCandidates(ctx, seriesID, season, episode, strictParam(c))
 	if err != nil {
 		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
 	}
@@ -91,8 +98,88 @@ func (h *DownloadCandidates) GetSeriesDownloadCandidates(c echo.Context) error {
 	return c.JSON(http.StatusOK, candidates)
 }
 
+// strictParam reports whether the strict query flag is set.
+func strictParam(c echo.Context) bool {
+	strict, _ := strconv.ParseBool(c.QueryParam("strict"))
+	return strict
+}
+
+// ListMovieSearchSessions lists recent searches for a movie that can be reopened
+// @Summary List saved candidate searches for a movie
+// @Tags    download-candidates
+// @Produce json
+// @Param   id path int true "Movie ID (TMDB ID)"
+// @Success 200 {array} model.SearchSession
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router  /v1/movie/{id}/searches [get]
+func (h *DownloadCandidates) ListMovieSearchSessions(c echo.Context) error {
+	return h.listSearchSessions(c, model.MediaTypeMovie)
+}
+
+// ListSeriesSearchSessions lists recent searches for a series that can be reopened
+// @Summary List saved candidate searches for a series
+// @Tags    download-candidates
+// @Produce json
+// @Param   id path int true "Series ID (TMDB ID)"
+// @Success 200 {array} model.SearchSession
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router  /v1/series/{id}/searches [get]
+func (h *DownloadCandidates) ListSeriesSearchSessions(c echo.Context) error {
+	return h.listSearchSessions(c, model.MediaTypeSeries)
+}
+
+func (h *DownloadCandidates) listSearchSessions(c echo.Context, mediaType model.MediaType) error {
+	tmdbID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid id"})
+	}
+
+	sessions, err := h.svc.DownloadCandidates.ListSearchSessions(c.Request().Context(), mediaType, tmdbID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, sessions)
+}
+
+// GetSearchSession reopens a saved candidate search
+// @Summary Get a saved candidate search
+// @Tags    download-candidates
+// @Produce json
+// @Param   sessionId path string true "Search session ID"
+// @Success 200 {object} model.DownloadCandidatesResponse
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 410 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router  /v1/searches/{sessionId} [get]
+func (h *DownloadCandidates) GetSearchSession(c echo.Context) error {
+	var id pgtype.UUID
+	if err := id.Scan(c.Param("sessionId")); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid session id"})
+	}
+
+	resp, err := h.svc.DownloadCandidates.GetSearchSession(c.Request().Context(), id)
+	if err != nil {
+		if errors.Is(err, service.ErrSearchSessionNotFound) {
+			return c.JSON(http.StatusNotFound, map[string]string{"error": err.Error()})
+		}
+		if errors.Is(err, service.ErrCandidateExpired) {
+			return c.JSON(http.StatusGone, map[string]string{"error": err.Error()})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
 // EnqueueCandidateRequest is the request body for enqueueing a candidate
 type EnqueueCandidateRequest struct {
+	// SessionID selects the search the candidate came from; when omitted the
+	// most recent search that returned the release is used.
+	SessionID string `json:"sessionId,omitempty"`
 	IndexerID int64  `json:"indexerId"`
 	GUID      string `json:"guid"`
 	Season    *int   `json:"season,omitempty"`
@@ -127,7 +214,7 @@ func (h *DownloadCandidates) PreviewCandidate(c echo.Context) error {
 	}
 
 	ctx := c.Request().Context()
-	trace, err := h.svc.DownloadCandidates.EvaluateCandidate(ctx, movieID, req.IndexerID, req.GUID)
+	trace, err := h.svc.DownloadCandidates.EvaluateCandidate(ctx, movieID, req.SessionID, req.IndexerID, req.GUID)
 	if err != nil {
 		if errors.Is(err, service.ErrCandidateNotFound) || errors.Is(err, service.ErrCandidateExpired) {
 			return c.JSON(http.StatusNotFound, map[string]string{"error": err.Error()})
@@ -166,7 +253,7 @@ func (h *DownloadCandidates) PreviewSeriesCandidate(c echo.Context) error {
 	}
 
 	ctx := c.Request().Context()
-	trace, err := h.svc.DownloadCandidates.EvaluateCandidate(ctx, seriesID, req.IndexerID, req.GUID)
+	trace, err := h.svc.DownloadCandidates.EvaluateCandidate(ctx, seriesID, req.SessionID, req.IndexerID, req.GUID)
 	if err != nil {
 		if errors.Is(err, service.ErrCandidateNotFound) || errors.Is(err, service.ErrCandidateExpired) {
 			return c.JSON(http.StatusNotFound, map[string]string{"error": err.Error()})
@@ -187,6 +274,7 @@ func (h *DownloadCandidates) PreviewSeriesCandidate(c echo.Context) error {
 // @Success 200 {object} handlers.DownloadCandidateResponse
 // @Failure 400 {object} map[string]string
 // @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
 // @Failure 500 {object} map[string]string
 // @Router  /v1/movie/{id}/candidate/download [post]
 func (h *DownloadCandidates) DownloadCandidate(c echo.Context) error {
@@ -205,11 +293,14 @@ func (h *DownloadCandidates) DownloadCandidate(c echo.Context) error {
 	}
 
 	ctx := c.Request().Context()
-	trace, job, err := h.svc.DownloadCandidates.EnqueueCandidate(ctx, movieID, req.IndexerID, req.GUID)
+	trace, job, err := h.svc.DownloadCandidates.EnqueueCandidate(ctx, movieID, req.SessionID, req.IndexerID, req.GUID)
 	if err != nil {
 		if errors.Is(err, service.ErrCandidateNotFound) || errors.Is(err, service.ErrCandidateExpired) {
 			return c.JSON(http.StatusNotFound, map[string]string{"error": err.Error()})
 		}
+		if errors.Is(err, service.ErrCandidateBlocklisted) {
+			return c.JSON(http.StatusConflict, map[string]string{"error": err.Error()})
+		}
 		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
 	}
 
@@ -229,6 +320,7 @@ func (h *DownloadCandidates) DownloadCandidate(c echo.Context) error {
 // @Success 200 {object} handlers.DownloadCandidateResponse
 // @Failure 400 {object} map[string]string
 // @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
 // @Failure 500 {object} map[string]string
 // @Router  /v1/series/{id}/candidate/download [post]
 func (h *DownloadCandidates) DownloadSeriesCandidate(c echo.Context) error {
@@ -247,11 +339,14 @@ func (h *DownloadCandidates) DownloadSeriesCandidate(c echo.Context) error {
 	}
 
 	ctx := c.Request().Context()
-	trace, job, err := h.svc.DownloadCandidates.EnqueueSeriesCandidate(ctx, seriesID, req.IndexerID, req.GUID, req.Season, req.Episode)
+	trace, job, err := h.svc.DownloadCandidates.EnqueueSeriesCandidate(ctx, seriesID, req.SessionID, req.IndexerID, req.GUID, req.Season, req.Episode)
 	if err != nil {
 		if errors.Is(err, service.ErrCandidateNotFound) || errors.Is(err, service.ErrCandidateExpired) {
 			return c.JSON(http.StatusNotFound, map[string]string{"error": err.Error()})
 		}
+		if errors.Is(err, service.ErrCandidateBlocklisted) {
+			return c.JSON(http.StatusConflict, map[string]string{"error": err.Error()})
+		}
 		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
 	}
 
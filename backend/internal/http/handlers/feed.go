@@ -24,7 +24,8 @@ func (h *Feed) RegisterProtected(v1 *echo.Group) {
 // @Router  /v1/home [get]
 func (h *Feed) GetFeed(c echo.Context) error {
 	ctx := c.Request().Context()
-	feed, err := h.svc.Feed.GetFeed(ctx)
+	userID, _ := currentUserID(c)
+	feed, err := h.svc.Feed.GetFeed(ctx, userID)
 	if err != nil {
 		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "failed to get feed"})
 	}
@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"strconv"
 	"time"
@@ -26,6 +27,9 @@ func (h *Indexers) RegisterProtected(v1 *echo.Group) {
 	v1.POST("/indexer/:id/test", h.TestSaved)
 	v1.POST("/indexer/test", h.TestUnsaved)
 	v1.POST("/indexers/testall", h.TestAll)
+	v1.GET("/indexers/status", h.ListStatus)
+	v1.GET("/indexer/:id/status", h.GetStatus)
+	v1.POST("/indexer/:id/status/reset", h.ResetStatus)
 }
 
 // ListConfigured returns only configured indexers
@@ -264,3 +268,68 @@ func (h *Indexers) TestAll(c echo.Context) error {
 
 	return c.JSON(http.StatusOK, results)
 }
+
+// ListStatus returns search health for all indexers
+// @Summary List indexer health
+// @Tags    indexers
+// @Produce json
+// @Success 200 {array} model.IndexerStatus
+// @Failure 500 {object} map[string]string
+// @Router  /v1/indexers/status [get]
+func (h *Indexers) ListStatus(c echo.Context) error {
+	statuses, err := h.svc.IndexerHealth.ListStatus(c.Request().Context())
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusOK, statuses)
+}
+
+// GetStatus returns search health and recent history for an indexer
+// @Summary Get indexer health
+// @Tags    indexers
+// @Produce json
+// @Param   id path int true "Indexer ID"
+// @Success 200 {object} model.IndexerStatusDetail
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router  /v1/indexer/{id}/status [get]
+func (h *Indexers) GetStatus(c echo.Context) error {
+	indexerID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid indexer ID"})
+	}
+
+	detail, err := h.svc.IndexerHealth.GetStatus(c.Request().Context(), indexerID)
+	if err != nil {
+		if errors.Is(err, service.ErrIndexerHealthNotFound) {
+			return c.JSON(http.StatusNotFound, map[string]string{"error": err.Error()})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusOK, detail)
+}
+
+// ResetStatus clears an indexer's failures and re-enables it
+// @Summary Reset indexer health
+// @Tags    indexers
+// @Param   id path int true "Indexer ID"
+// @Success 204
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router  /v1/indexer/{id}/status/reset [post]
+func (h *Indexers) ResetStatus(c echo.Context) error {
+	indexerID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid indexer ID"})
+	}
+
+	if err := h.svc.IndexerHealth.Reset(c.Request().Context(), indexerID); err != nil {
+		if errors.Is(err, service.ErrIndexerHealthNotFound) {
+			return c.JSON(http.StatusNotFound, map[string]string{"error": err.Error()})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	return c.NoContent(http.StatusNoContent)
+}
@@ -33,6 +33,12 @@ type librarySwagger struct {
 	Default   bool   `json:"default"`
 	CreatedAt string `json:"created_at"`
 	UpdatedAt string `json:"updated_at"`
+
+	QualityProfileID *string `json:"quality_profile_id"`
+	ImportMode       string  `json:"import_mode"`
+
+	ImportExtraFiles    bool     `json:"import_extra_files"`
+	ExtraFileExtensions []string `json:"extra_file_extensions"`
 }
 
 // LibraryCreateRequest payload
@@ -42,6 +48,20 @@ type LibraryCreateRequest struct {
 	RootPath string `json:"root_path"`
 	Enabled  bool   `json:"enabled"`
 	Default  bool   `json:"default"`
+
+	// QualityProfileID is used for items in this library that have no
+	// profile of their own; null falls back to the default profile.
+	QualityProfileID *string `json:"quality_profile_id"`
+
+	// ImportMode is how files are taken in from downloads: hardlink,
+	// reflink, move, symlink or copy. Empty means hardlink.
+	ImportMode string `json:"import_mode"`
+
+	// ImportExtraFiles imports subtitles, NFOs and artwork that come with a
+	// video. ExtraFileExtensions whitelists them, e.g. ["srt", "nfo", "jpg"];
+	// null uses the default list.
+	ImportExtraFiles    bool     `json:"import_extra_files"`
+	ExtraFileExtensions []string `json:"extra_file_extensions"`
 }
 
 // LibraryUpdateRequest payload
@@ -51,6 +71,20 @@ type LibraryUpdateRequest struct {
 	RootPath string `json:"root_path"`
 	Enabled  bool   `json:"enabled"`
 	Default  bool   `json:"default"`
+
+	// QualityProfileID is used for items in this library that have no
+	// profile of their own; null falls back to the default profile.
+	QualityProfileID *string `json:"quality_profile_id"`
+
+	// ImportMode is how files are taken in from downloads: hardlink,
+	// reflink, move, symlink or copy. Empty means hardlink.
+	ImportMode string `json:"import_mode"`
+
+	// ImportExtraFiles imports subtitles, NFOs and artwork that come with a
+	// video. ExtraFileExtensions whitelists them, e.g. ["srt", "nfo", "jpg"];
+	// null uses the default list.
+	ImportExtraFiles    bool     `json:"import_extra_files"`
+	ExtraFileExtensions []string `json:"extra_file_extensions"`
 }
 
 // List libraries
@@ -82,8 +116,22 @@ func (h *Libraries) Create(c echo.Context) error {
 	if err := c.Bind(&req); err != nil {
 		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid body"})
 	}
+	profileID, err := parseOptionalUUID(req.QualityProfileID)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid quality_profile_id"})
+	}
 	ctx := c.Request().Context()
-	lib, err := h.svc.Libraries.Create(ctx, req.Name, req.Type, req.RootPath, req.Enabled, req.Default)
+	lib, err := h.svc.Libraries.Create(ctx, service.LibraryParams{
+		Name:                req.Name,
+		Type:                req.Type,
+		RootPath:            req.RootPath,
+		Enabled:             req.Enabled,
+		Default:             req.Default,
+		QualityProfileID:    profileID,
+		ImportMode:          req.ImportMode,
+		ImportExtraFiles:    req.ImportExtraFiles,
+		ExtraFileExtensions: req.ExtraFileExtensions,
+	})
 	if err != nil {
 		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
 	}
@@ -128,8 +176,22 @@ func (h *Libraries) Update(c echo.Context) error {
 	if err := id.Scan(c.Param("id")); err != nil {
 		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid id"})
 	}
+	profileID, err := parseOptionalUUID(req.QualityProfileID)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid quality_profile_id"})
+	}
 	ctx := c.Request().Context()
-	lib, err := h.svc.Libraries.Update(ctx, id, req.Name, req.Type, req.RootPath, req.Enabled, req.Default)
+	lib, err := h.svc.Libraries.Update(ctx, id, service.LibraryParams{
+		Name:                req.Name,
+		Type:                req.Type,
+		RootPath:            req.RootPath,
+		Enabled:             req.Enabled,
+		Default:             req.Default,
+		QualityProfileID:    profileID,
+		ImportMode:          req.ImportMode,
+		ImportExtraFiles:    req.ImportExtraFiles,
+		ExtraFileExtensions: req.ExtraFileExtensions,
+	})
 	if err != nil {
 		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
 	}
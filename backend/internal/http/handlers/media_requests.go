@@ -0,0 +1,213 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/kyleaupton/arrflix/internal/model"
+	"github.com/kyleaupton/arrflix/internal/service"
+	"github.com/labstack/echo/v4"
+)
+
+type MediaRequests struct{ svc *service.Services }
+
+func NewMediaRequests(s *service.Services) *MediaRequests { return &MediaRequests{svc: s} }
+
+func (h *MediaRequests) RegisterProtected(v1 *echo.Group) {
+	v1.GET("/requests", h.List)
+	v1.POST("/requests", h.Create)
+	v1.GET("/requests/:id", h.Get)
+	v1.POST("/requests/:id/approve", h.Approve)
+	v1.POST("/requests/:id/decline", h.Decline)
+}
+
+// List lists media requests
+// @Summary List requests
+// @Description Newest first. Users with requests.approve see everyone's requests, other users their own.
+// @Tags    requests
+// @Produce json
+// @Param   status query string false "pending, approved, declined, downloading, importing or available"
+// @Param   page query int false "Page number (default 1)"
+// @Param   pageSize query int false "Page size (default 20)"
+// @Success 200 {object} model.PaginatedMediaRequestsResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router  /v1/requests [get]
+func (h *MediaRequests) List(c echo.Context) error {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": "unauthorized"})
+	}
+	var status *string
+	switch s := model.MediaRequestStatus(c.QueryParam("status")); s {
+	case "":
+	case model.MediaRequestPending, model.MediaRequestApproved, model.MediaRequestDeclined,
+		model.MediaRequestDownloading, model.MediaRequestImporting, model.MediaRequestAvailable:
+		v := string(s)
+		status = &v
+	default:
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid status"})
+	}
+
+	page, pageSize := wantedPage(c)
+	res, err := h.svc.MediaRequests.List(c.Request().Context(), userID, status, page, pageSize)
+	if err != nil {
+		return mediaRequestError(c, err)
+	}
+	return c.JSON(http.StatusOK, res)
+}
+
+// Create requests a movie, a series or some of its seasons
+// @Summary Create request
+// @Description Requires requests.create. Omitting seasons requests the whole series. Requests over the user's quota fail with 429; the user's request rules may approve the request right away.
+// @Tags    requests
+// @Accept  json
+// @Produce json
+// @Param   payload body model.CreateMediaRequest true "Request"
+// @Success 201 {object} model.MediaRequest
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 429 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router  /v1/requests [post]
+func (h *MediaRequests) Create(c echo.Context) error {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": "unauthorized"})
+	}
+	var req model.CreateMediaRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid request body"})
+	}
+
+	res, err := h.svc.MediaRequests.Create(c.Request().Context(), userID, req)
+	if err != nil {
+		return mediaRequestError(c, err)
+	}
+	return c.JSON(http.StatusCreated, res)
+}
+
+// Get returns a media request
+// @Summary Get request
+// @Tags    requests
+// @Produce json
+// @Param   id path string true "Request ID"
+// @Success 200 {object} model.MediaRequest
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router  /v1/requests/{id} [get]
+func (h *MediaRequests) Get(c echo.Context) error {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": "unauthorized"})
+	}
+	var id pgtype.UUID
+	if err := id.Scan(c.Param("id")); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid id"})
+	}
+
+	res, err := h.svc.MediaRequests.Get(c.Request().Context(), userID, id)
+	if err != nil {
+		return mediaRequestError(c, err)
+	}
+	return c.JSON(http.StatusOK, res)
+}
+
+// Approve approves a pending request
+// @Summary Approve request
+// @Description Requires requests.approve. Monitors the title and queues a search for it. libraryId and qualityProfileId apply to titles that aren't monitored yet.
+// @Tags    requests
+// @Accept  json
+// @Produce json
+// @Param   id path string true "Request ID"
+// @Param   payload body model.MediaRequestDecision false "Decision"
+// @Success 200 {object} model.MediaRequest
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router  /v1/requests/{id}/approve [post]
+func (h *MediaRequests) Approve(c echo.Context) error {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": "unauthorized"})
+	}
+	var id pgtype.UUID
+	if err := id.Scan(c.Param("id")); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid id"})
+	}
+	var decision model.MediaRequestDecision
+	if err := c.Bind(&decision); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid request body"})
+	}
+	libraryID, err := parseOptionalUUID(decision.LibraryID)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid libraryId"})
+	}
+	profileID, err := parseOptionalUUID(decision.QualityProfileID)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid qualityProfileId"})
+	}
+
+	res, err := h.svc.MediaRequests.Approve(c.Request().Context(), userID, id, decision.Comment, libraryID, profileID)
+	if err != nil {
+		return mediaRequestError(c, err)
+	}
+	return c.JSON(http.StatusOK, res)
+}
+
+// Decline declines a pending request
+// @Summary Decline request
+// @Description Requires requests.approve
+// @Tags    requests
+// @Accept  json
+// @Produce json
+// @Param   id path string true "Request ID"
+// @Param   payload body model.MediaRequestDecision false "Decision"
+// @Success 200 {object} model.MediaRequest
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router  /v1/requests/{id}/decline [post]
+func (h *MediaRequests) Decline(c echo.Context) error {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": "unauthorized"})
+	}
+	var id pgtype.UUID
+	if err := id.Scan(c.Param("id")); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid id"})
+	}
+	var decision model.MediaRequestDecision
+	if err := c.Bind(&decision); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid request body"})
+	}
+
+	res, err := h.svc.MediaRequests.Decline(c.Request().Context(), userID, id, decision.Comment)
+	if err != nil {
+		return mediaRequestError(c, err)
+	}
+	return c.JSON(http.StatusOK, res)
+}
+
+func mediaRequestError(c echo.Context, err error) error {
+	switch {
+	case errors.Is(err, service.ErrMediaRequestInvalid), errors.Is(err, service.ErrMonitorInvalid),
+		errors.Is(err, service.ErrSeasonNotFound):
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	case errors.Is(err, service.ErrMediaRequestForbidden):
+		return c.JSON(http.StatusForbidden, map[string]string{"error": err.Error()})
+	case errors.Is(err, service.ErrMediaRequestNotFound):
+		return c.JSON(http.StatusNotFound, map[string]string{"error": err.Error()})
+	case errors.Is(err, service.ErrMediaRequestDuplicate), errors.Is(err, service.ErrMediaRequestAvailable),
+		errors.Is(err, service.ErrMediaRequestDecided):
+		return c.JSON(http.StatusConflict, map[string]string{"error": err.Error()})
+	case errors.Is(err, service.ErrMediaRequestLimit), errors.Is(err, service.ErrMediaRequestQuota):
+		return c.JSON(http.StatusTooManyRequests, map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+}
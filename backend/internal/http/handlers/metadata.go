@@ -0,0 +1,85 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/kyleaupton/arrflix/internal/model"
+	"github.com/kyleaupton/arrflix/internal/service"
+	"github.com/labstack/echo/v4"
+)
+
+type Metadata struct{ svc *service.Services }
+
+func NewMetadata(s *service.Services) *Metadata { return &Metadata{svc: s} }
+
+func (h *Metadata) RegisterProtected(v1 *echo.Group) {
+	v1.POST("/metadata/refresh", h.RefreshStale)
+
+	v1.POST("/movie/:id/refresh", h.RefreshMovie)
+	v1.POST("/series/:id/refresh", h.RefreshSeries)
+}
+
+// RefreshStale refreshes library items with stale metadata now
+// @Summary Refresh stale metadata
+// @Description Refreshes titles, release dates, seasons and episodes from TMDB for library items whose metadata is stale
+// @Tags    metadata
+// @Produce json
+// @Success 200 {object} map[string]int
+// @Failure 409 {object} map[string]string
+// @Router  /v1/metadata/refresh [post]
+func (h *Metadata) RefreshStale(c echo.Context) error {
+	refreshed, err := h.svc.Metadata.RefreshStale(c.Request().Context())
+	if err != nil {
+		if errors.Is(err, service.ErrMetadataRefreshRunning) {
+			return c.JSON(http.StatusConflict, map[string]string{"error": err.Error()})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusOK, map[string]int{"refreshed": refreshed})
+}
+
+// RefreshMovie refreshes a movie from TMDB
+// @Summary Refresh movie metadata
+// @Description Updates the title, year and release dates of a library movie from TMDB
+// @Tags    metadata
+// @Produce json
+// @Param   id path int true "Movie ID (TMDB ID)"
+// @Success 200 {object} model.MetadataRefresh
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router  /v1/movie/{id}/refresh [post]
+func (h *Metadata) RefreshMovie(c echo.Context) error {
+	return h.refresh(c, model.MediaTypeMovie)
+}
+
+// RefreshSeries refreshes a series from TMDB
+// @Summary Refresh series metadata
+// @Description Updates the title and year of a library series from TMDB and reconciles its seasons and episodes, keeping files linked through renumbering
+// @Tags    metadata
+// @Produce json
+// @Param   id path int true "Series ID (TMDB ID)"
+// @Success 200 {object} model.MetadataRefresh
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router  /v1/series/{id}/refresh [post]
+func (h *Metadata) RefreshSeries(c echo.Context) error {
+	return h.refresh(c, model.MediaTypeSeries)
+}
+
+func (h *Metadata) refresh(c echo.Context, mediaType model.MediaType) error {
+	tmdbID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid id"})
+	}
+
+	result, err := h.svc.Metadata.Refresh(c.Request().Context(), mediaType, tmdbID)
+	if err != nil {
+		if errors.Is(err, service.ErrMetadataNotInLibrary) {
+			return c.JSON(http.StatusNotFound, map[string]string{"error": err.Error()})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,197 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/kyleaupton/arrflix/internal/model"
+	"github.com/kyleaupton/arrflix/internal/service"
+	"github.com/labstack/echo/v4"
+)
+
+type Monitoring struct{ svc *service.Services }
+
+func NewMonitoring(s *service.Services) *Monitoring { return &Monitoring{svc: s} }
+
+func (h *Monitoring) RegisterProtected(v1 *echo.Group) {
+	v1.GET("/movie/:id/monitoring", h.GetMovie)
+	v1.PUT("/movie/:id/monitoring", h.MonitorMovie)
+
+	v1.GET("/series/:id/monitoring", h.GetSeries)
+	v1.PUT("/series/:id/monitoring", h.MonitorSeries)
+	v1.PUT("/series/:id/season/:season/monitoring", h.SetSeasonMonitored)
+	v1.PUT("/series/:id/season/:season/episode/:episode/monitoring", h.SetEpisodeMonitored)
+}
+
+// GetMovie returns the monitoring state of a movie
+// @Summary Get movie monitoring
+// @Tags    monitoring
+// @Produce json
+// @Param   id path int true "Movie ID (TMDB ID)"
+// @Success 200 {object} model.Monitoring
+// @Failure 400 {object} map[string]string
+// @Router  /v1/movie/{id}/monitoring [get]
+func (h *Monitoring) GetMovie(c echo.Context) error {
+	return h.get(c, model.MediaTypeMovie)
+}
+
+// GetSeries returns the monitoring state of a series and its seasons
+// @Summary Get series monitoring
+// @Tags    monitoring
+// @Produce json
+// @Param   id path int true "Series ID (TMDB ID)"
+// @Success 200 {object} model.Monitoring
+// @Failure 400 {object} map[string]string
+// @Router  /v1/series/{id}/monitoring [get]
+func (h *Monitoring) GetSeries(c echo.Context) error {
+	return h.get(c, model.MediaTypeSeries)
+}
+
+// MonitorMovie monitors or unmonitors a movie, adding it to the library
+// @Summary Monitor movie
+// @Tags    monitoring
+// @Accept  json
+// @Produce json
+// @Param   id path int true "Movie ID (TMDB ID)"
+// @Param   payload body model.MonitorRequest true "Monitoring"
+// @Success 200 {object} model.Monitoring
+// @Failure 400 {object} map[string]string
+// @Router  /v1/movie/{id}/monitoring [put]
+func (h *Monitoring) MonitorMovie(c echo.Context) error {
+	return h.monitor(c, model.MediaTypeMovie)
+}
+
+// MonitorSeries monitors or unmonitors a series and its seasons, adding it
+// and its episodes to the library
+// @Summary Monitor series
+// @Tags    monitoring
+// @Accept  json
+// @Produce json
+// @Param   id path int true "Series ID (TMDB ID)"
+// @Param   payload body model.MonitorRequest true "Monitoring"
+// @Success 200 {object} model.Monitoring
+// @Failure 400 {object} map[string]string
+// @Router  /v1/series/{id}/monitoring [put]
+func (h *Monitoring) MonitorSeries(c echo.Context) error {
+	return h.monitor(c, model.MediaTypeSeries)
+}
+
+// SetSeasonMonitored monitors or unmonitors a season and its episodes
+// @Summary Monitor season
+// @Tags    monitoring
+// @Accept  json
+// @Produce json
+// @Param   id path int true "Series ID (TMDB ID)"
+// @Param   season path int true "Season number"
+// @Param   payload body model.SetMonitoredRequest true "Monitored"
+// @Success 200 {object} model.Monitoring
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router  /v1/series/{id}/season/{season}/monitoring [put]
+func (h *Monitoring) SetSeasonMonitored(c echo.Context) error {
+	tmdbID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid id"})
+	}
+	season, err := strconv.Atoi(c.Param("season"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid season"})
+	}
+	var req model.SetMonitoredRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid body"})
+	}
+
+	out, err := h.svc.Monitoring.SetSeasonMonitored(c.Request().Context(), tmdbID, season, req.Monitored)
+	if err != nil {
+		return monitoringError(c, err)
+	}
+	return c.JSON(http.StatusOK, out)
+}
+
+// SetEpisodeMonitored monitors or unmonitors a single episode
+// @Summary Monitor episode
+// @Tags    monitoring
+// @Accept  json
+// @Produce json
+// @Param   id path int true "Series ID (TMDB ID)"
+// @Param   season path int true "Season number"
+// @Param   episode path int true "Episode number"
+// @Param   payload body model.SetMonitoredRequest true "Monitored"
+// @Success 200 {object} model.Monitoring
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router  /v1/series/{id}/season/{season}/episode/{episode}/monitoring [put]
+func (h *Monitoring) SetEpisodeMonitored(c echo.Context) error {
+	tmdbID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid id"})
+	}
+	season, err := strconv.Atoi(c.Param("season"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid season"})
+	}
+	episode, err := strconv.Atoi(c.Param("episode"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid episode"})
+	}
+	var req model.SetMonitoredRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid body"})
+	}
+
+	out, err := h.svc.Monitoring.SetEpisodeMonitored(c.Request().Context(), tmdbID, season, episode, req.Monitored)
+	if err != nil {
+		return monitoringError(c, err)
+	}
+	return c.JSON(http.StatusOK, out)
+}
+
+func (h *Monitoring) get(c echo.Context, mediaType model.MediaType) error {
+	tmdbID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid id"})
+	}
+	out, err := h.svc.Monitoring.Get(c.Request().Context(), mediaType, tmdbID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusOK, out)
+}
+
+func (h *Monitoring) monitor(c echo.Context, mediaType model.MediaType) error {
+	tmdbID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid id"})
+	}
+	var req model.MonitorRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid body"})
+	}
+	libraryID, err := parseOptionalUUID(req.LibraryID)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid libraryId"})
+	}
+	profileID, err := parseOptionalUUID(req.QualityProfileID)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid qualityProfileId"})
+	}
+
+	out, err := h.svc.Monitoring.Monitor(c.Request().Context(), mediaType, tmdbID, req, libraryID, profileID)
+	if err != nil {
+		return monitoringError(c, err)
+	}
+	return c.JSON(http.StatusOK, out)
+}
+
+func monitoringError(c echo.Context, err error) error {
+	switch {
+	case errors.Is(err, service.ErrMonitorInvalid):
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	case errors.Is(err, service.ErrSeasonNotFound), errors.Is(err, service.ErrEpisodeNotFound),
+		errors.Is(err, service.ErrMediaNotInLibrary):
+		return c.JSON(http.StatusNotFound, map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+}
@@ -0,0 +1,252 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/kyleaupton/arrflix/internal/model"
+	"github.com/kyleaupton/arrflix/internal/service"
+	"github.com/labstack/echo/v4"
+)
+
+type QualityProfiles struct{ svc *service.Services }
+
+func NewQualityProfiles(s *service.Services) *QualityProfiles {
+	return &QualityProfiles{svc: s}
+}
+
+func (h *QualityProfiles) RegisterProtected(v1 *echo.Group) {
+	v1.GET("/quality-profiles", h.List)
+	v1.POST("/quality-profiles", h.Create)
+	v1.GET("/quality-profiles/:id", h.Get)
+	v1.PUT("/quality-profiles/:id", h.Update)
+	v1.DELETE("/quality-profiles/:id", h.Delete)
+
+	v1.GET("/movie/:id/quality-profile", h.GetMovieProfile)
+	v1.PUT("/movie/:id/quality-profile", h.SetMovieProfile)
+	v1.GET("/series/:id/quality-profile", h.GetSeriesProfile)
+	v1.PUT("/series/:id/quality-profile", h.SetSeriesProfile)
+}
+
+// List quality profiles
+// @Summary List quality profiles
+// @Tags    quality-profiles
+// @Produce json
+// @Success 200 {array} model.QualityProfile
+// @Failure 500 {object} map[string]string
+// @Router  /v1/quality-profiles [get]
+func (h *QualityProfiles) List(c echo.Context) error {
+	out, err := h.svc.QualityProfiles.List(c.Request().Context())
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "failed to list"})
+	}
+	return c.JSON(http.StatusOK, out)
+}
+
+// Create quality profile
+// @Summary Create quality profile
+// @Tags    quality-profiles
+// @Accept  json
+// @Produce json
+// @Param   payload body model.QualityProfileRequest true "Quality profile"
+// @Success 201 {object} model.QualityProfile
+// @Failure 400 {object} map[string]string
+// @Router  /v1/quality-profiles [post]
+func (h *QualityProfiles) Create(c echo.Context) error {
+	var req model.QualityProfileRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid body"})
+	}
+	p, err := h.svc.QualityProfiles.Create(c.Request().Context(), req)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusCreated, p)
+}
+
+// Get quality profile
+// @Summary Get quality profile
+// @Tags    quality-profiles
+// @Produce json
+// @Param   id path string true "Quality profile ID"
+// @Success 200 {object} model.QualityProfile
+// @Failure 404 {object} map[string]string
+// @Router  /v1/quality-profiles/{id} [get]
+func (h *QualityProfiles) Get(c echo.Context) error {
+	var id pgtype.UUID
+	if err := id.Scan(c.Param("id")); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid id"})
+	}
+	p, err := h.svc.QualityProfiles.Get(c.Request().Context(), id)
+	if err != nil {
+		return c.JSON(http.StatusNotFound, map[string]string{"error": "not found"})
+	}
+	return c.JSON(http.StatusOK, p)
+}
+
+// Update quality profile
+// @Summary Update quality profile
+// @Tags    quality-profiles
+// @Accept  json
+// @Produce json
+// @Param   id path string true "Quality profile ID"
+// @Param   payload body model.QualityProfileRequest true "Quality profile"
+// @Success 200 {object} model.QualityProfile
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router  /v1/quality-profiles/{id} [put]
+func (h *QualityProfiles) Update(c echo.Context) error {
+	var id pgtype.UUID
+	if err := id.Scan(c.Param("id")); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid id"})
+	}
+	var req model.QualityProfileRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid body"})
+	}
+	p, err := h.svc.QualityProfiles.Update(c.Request().Context(), id, req)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return c.JSON(http.StatusNotFound, map[string]string{"error": "not found"})
+		}
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusOK, p)
+}
+
+// Delete quality profile
+// @Summary Delete quality profile
+// @Tags    quality-profiles
+// @Param   id path string true "Quality profile ID"
+// @Success 204 {string} string ""
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router  /v1/quality-profiles/{id} [delete]
+func (h *QualityProfiles) Delete(c echo.Context) error {
+	var id pgtype.UUID
+	if err := id.Scan(c.Param("id")); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid id"})
+	}
+	if err := h.svc.QualityProfiles.Delete(c.Request().Context(), id); err != nil {
+		switch {
+		case errors.Is(err, pgx.ErrNoRows):
+			return c.JSON(http.StatusNotFound, map[string]string{"error": "not found"})
+		case errors.Is(err, service.ErrQualityProfileDefault):
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "failed to delete"})
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+// GetMovieProfile returns the quality profile that applies to a movie
+// @Summary Get the quality profile for a movie
+// @Tags    quality-profiles
+// @Produce json
+// @Param   id path int true "Movie ID (TMDB ID)"
+// @Success 200 {object} model.MediaQualityProfile
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router  /v1/movie/{id}/quality-profile [get]
+func (h *QualityProfiles) GetMovieProfile(c echo.Context) error {
+	return h.getForMedia(c, model.MediaTypeMovie)
+}
+
+// GetSeriesProfile returns the quality profile that applies to a series
+// @Summary Get the quality profile for a series
+// @Tags    quality-profiles
+// @Produce json
+// @Param   id path int true "Series ID (TMDB ID)"
+// @Success 200 {object} model.MediaQualityProfile
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router  /v1/series/{id}/quality-profile [get]
+func (h *QualityProfiles) GetSeriesProfile(c echo.Context) error {
+	return h.getForMedia(c, model.MediaTypeSeries)
+}
+
+// SetMovieProfile assigns a quality profile to a movie in the library
+// @Summary Set the quality profile for a movie
+// @Tags    quality-profiles
+// @Accept  json
+// @Produce json
+// @Param   id path int true "Movie ID (TMDB ID)"
+// @Param   payload body model.SetQualityProfileRequest true "Quality profile; null uses the library's"
+// @Success 200 {object} model.MediaQualityProfile
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router  /v1/movie/{id}/quality-profile [put]
+func (h *QualityProfiles) SetMovieProfile(c echo.Context) error {
+	return h.setForMedia(c, model.MediaTypeMovie)
+}
+
+// SetSeriesProfile assigns a quality profile to a series in the library
+// @Summary Set the quality profile for a series
+// @Tags    quality-profiles
+// @Accept  json
+// @Produce json
+// @Param   id path int true "Series ID (TMDB ID)"
+// @Param   payload body model.SetQualityProfileRequest true "Quality profile; null uses the library's"
+// @Success 200 {object} model.MediaQualityProfile
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router  /v1/series/{id}/quality-profile [put]
+func (h *QualityProfiles) SetSeriesProfile(c echo.Context) error {
+	return h.setForMedia(c, model.MediaTypeSeries)
+}
+
+func (h *QualityProfiles) getForMedia(c echo.Context, mediaType model.MediaType) error {
+	tmdbID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid id"})
+	}
+	p, err := h.svc.QualityProfiles.ForMedia(c.Request().Context(), mediaType, tmdbID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return c.JSON(http.StatusNotFound, map[string]string{"error": "no quality profile applies"})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusOK, p)
+}
+
+func (h *QualityProfiles) setForMedia(c echo.Context, mediaType model.MediaType) error {
+	tmdbID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid id"})
+	}
+	var req model.SetQualityProfileRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid body"})
+	}
+	profileID, err := parseOptionalUUID(req.QualityProfileID)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid qualityProfileId"})
+	}
+
+	p, err := h.svc.QualityProfiles.SetForMedia(c.Request().Context(), mediaType, tmdbID, profileID)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrMediaNotInLibrary):
+			return c.JSON(http.StatusNotFound, map[string]string{"error": err.Error()})
+		case errors.Is(err, pgx.ErrNoRows):
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "quality profile not found"})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusOK, p)
+}
+
+// parseOptionalUUID parses a nullable ID from a request body. nil and ""
+// give an invalid (NULL) UUID.
+func parseOptionalUUID(s *string) (pgtype.UUID, error) {
+	var id pgtype.UUID
+	if s == nil || *s == "" {
+		return id, nil
+	}
+	err := id.Scan(*s)
+	return id, err
+}
@@ -0,0 +1,181 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/kyleaupton/arrflix/internal/downloader"
+	"github.com/kyleaupton/arrflix/internal/model"
+	"github.com/kyleaupton/arrflix/internal/service"
+	"github.com/labstack/echo/v4"
+)
+
+type RemotePathMappings struct {
+	svc               *service.Services
+	downloaderManager *downloader.Manager
+}
+
+func NewRemotePathMappings(s *service.Services, manager *downloader.Manager) *RemotePathMappings {
+	return &RemotePathMappings{svc: s, downloaderManager: manager}
+}
+
+func (h *RemotePathMappings) RegisterProtected(v1 *echo.Group) {
+	v1.GET("/remote-path-mappings", h.List)
+	v1.POST("/remote-path-mappings", h.Create)
+	v1.GET("/remote-path-mappings/:id", h.Get)
+	v1.PUT("/remote-path-mappings/:id", h.Update)
+	v1.DELETE("/remote-path-mappings/:id", h.Delete)
+	v1.POST("/downloaders/:id/path-check", h.Check)
+}
+
+// List lists remote path mappings
+// @Summary List remote path mappings
+// @Tags    remote-path-mappings
+// @Produce json
+// @Success 200 {array} model.RemotePathMapping
+// @Failure 500 {object} map[string]string
+// @Router  /v1/remote-path-mappings [get]
+func (h *RemotePathMappings) List(c echo.Context) error {
+	out, err := h.svc.RemotePathMappings.List(c.Request().Context())
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusOK, out)
+}
+
+// Create creates a remote path mapping
+// @Summary Create remote path mapping
+// @Description Paths the downloader reports under remotePath are read under localPath. Paths match by whole components.
+// @Tags    remote-path-mappings
+// @Accept  json
+// @Produce json
+// @Param   payload body model.RemotePathMappingRequest true "Mapping"
+// @Success 201 {object} model.RemotePathMapping
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router  /v1/remote-path-mappings [post]
+func (h *RemotePathMappings) Create(c echo.Context) error {
+	var req model.RemotePathMappingRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid request body"})
+	}
+	out, err := h.svc.RemotePathMappings.Create(c.Request().Context(), req)
+	if err != nil {
+		return remotePathMappingError(c, err)
+	}
+	return c.JSON(http.StatusCreated, out)
+}
+
+// Get returns a remote path mapping
+// @Summary Get remote path mapping
+// @Tags    remote-path-mappings
+// @Produce json
+// @Param   id path string true "Mapping ID"
+// @Success 200 {object} model.RemotePathMapping
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router  /v1/remote-path-mappings/{id} [get]
+func (h *RemotePathMappings) Get(c echo.Context) error {
+	var id pgtype.UUID
+	if err := id.Scan(c.Param("id")); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid id"})
+	}
+	out, err := h.svc.RemotePathMappings.Get(c.Request().Context(), id)
+	if err != nil {
+		return remotePathMappingError(c, err)
+	}
+	return c.JSON(http.StatusOK, out)
+}
+
+// Update updates a remote path mapping
+// @Summary Update remote path mapping
+// @Tags    remote-path-mappings
+// @Accept  json
+// @Produce json
+// @Param   id path string true "Mapping ID"
+// @Param   payload body model.RemotePathMappingRequest true "Mapping"
+// @Success 200 {object} model.RemotePathMapping
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router  /v1/remote-path-mappings/{id} [put]
+func (h *RemotePathMappings) Update(c echo.Context) error {
+	var id pgtype.UUID
+	if err := id.Scan(c.Param("id")); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid id"})
+	}
+	var req model.RemotePathMappingRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid request body"})
+	}
+	out, err := h.svc.RemotePathMappings.Update(c.Request().Context(), id, req)
+	if err != nil {
+		return remotePathMappingError(c, err)
+	}
+	return c.JSON(http.StatusOK, out)
+}
+
+// Delete deletes a remote path mapping
+// @Summary Delete remote path mapping
+// @Tags    remote-path-mappings
+// @Param   id path string true "Mapping ID"
+// @Success 204 {string} string ""
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router  /v1/remote-path-mappings/{id} [delete]
+func (h *RemotePathMappings) Delete(c echo.Context) error {
+	var id pgtype.UUID
+	if err := id.Scan(c.Param("id")); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid id"})
+	}
+	if err := h.svc.RemotePathMappings.Delete(c.Request().Context(), id); err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+// Check checks a downloader's paths against its remote path mappings
+// @Summary Check downloader paths
+// @Description Asks the downloader for its most recently added item and reports whether Arrflix can see its files after mapping.
+// @Tags    remote-path-mappings
+// @Produce json
+// @Param   id path string true "Downloader ID"
+// @Success 200 {object} model.RemotePathCheck
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router  /v1/downloaders/{id}/path-check [post]
+func (h *RemotePathMappings) Check(c echo.Context) error {
+	var id pgtype.UUID
+	if err := id.Scan(c.Param("id")); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid id"})
+	}
+	ctx := c.Request().Context()
+	if _, err := h.svc.Downloaders.Get(ctx, id); err != nil {
+		return c.JSON(http.StatusNotFound, map[string]string{"error": "not found"})
+	}
+	client, err := h.downloaderManager.GetClientByID(ctx, id.String())
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "failed to get downloader client: " + err.Error()})
+	}
+
+	checkCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	out, err := h.svc.RemotePathMappings.Check(checkCtx, id, client)
+	if err != nil {
+		return remotePathMappingError(c, err)
+	}
+	return c.JSON(http.StatusOK, out)
+}
+
+func remotePathMappingError(c echo.Context, err error) error {
+	switch {
+	case errors.Is(err, service.ErrRemotePathMappingInvalid), errors.Is(err, service.ErrRemotePathCheck):
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	case errors.Is(err, service.ErrRemotePathMappingNotFound):
+		return c.JSON(http.StatusNotFound, map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+}
@@ -0,0 +1,265 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/kyleaupton/arrflix/internal/model"
+	"github.com/kyleaupton/arrflix/internal/service"
+	"github.com/labstack/echo/v4"
+)
+
+type RequestRules struct{ svc *service.Services }
+
+func NewRequestRules(s *service.Services) *RequestRules { return &RequestRules{svc: s} }
+
+func (h *RequestRules) RegisterProtected(v1 *echo.Group) {
+	v1.GET("/request-quotas", h.ListQuotas)
+	v1.PUT("/request-quotas/:subjectType/:subjectId", h.SetQuota)
+	v1.DELETE("/request-quotas/:subjectType/:subjectId", h.DeleteQuota)
+
+	v1.GET("/request-rules", h.ListRules)
+	v1.POST("/request-rules", h.CreateRule)
+	v1.GET("/request-rules/fields", h.GetFields)
+	v1.GET("/request-rules/:id", h.GetRule)
+	v1.PUT("/request-rules/:id", h.UpdateRule)
+	v1.DELETE("/request-rules/:id", h.DeleteRule)
+}
+
+// ListQuotas lists request quotas
+// @Summary List request quotas
+// @Description Users without requests.approve only see the quotas that apply to them.
+// @Tags    request-rules
+// @Produce json
+// @Success 200 {array} model.RequestQuota
+// @Failure 401 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router  /v1/request-quotas [get]
+func (h *RequestRules) ListQuotas(c echo.Context) error {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": "unauthorized"})
+	}
+	out, err := h.svc.RequestRules.ListQuotas(c.Request().Context(), userID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusOK, out)
+}
+
+// SetQuota sets the request quota of a role or user
+// @Summary Set request quota
+// @Description Requires requests.approve. A user's own quota replaces those of their roles. Null limits are unlimited.
+// @Tags    request-rules
+// @Accept  json
+// @Produce json
+// @Param   subjectType path string true "role or user"
+// @Param   subjectId path string true "Role or user ID"
+// @Param   payload body model.RequestQuotaRequest true "Quota"
+// @Success 200 {object} model.RequestQuota
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router  /v1/request-quotas/{subjectType}/{subjectId} [put]
+func (h *RequestRules) SetQuota(c echo.Context) error {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": "unauthorized"})
+	}
+	var subjectID pgtype.UUID
+	if err := subjectID.Scan(c.Param("subjectId")); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid subjectId"})
+	}
+	var req model.RequestQuotaRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid request body"})
+	}
+
+	out, err := h.svc.RequestRules.SetQuota(c.Request().Context(), userID, c.Param("subjectType"), subjectID, req)
+	if err != nil {
+		return requestRuleError(c, err)
+	}
+	return c.JSON(http.StatusOK, out)
+}
+
+// DeleteQuota removes the request quota of a role or user
+// @Summary Delete request quota
+// @Description Requires requests.approve
+// @Tags    request-rules
+// @Param   subjectType path string true "role or user"
+// @Param   subjectId path string true "Role or user ID"
+// @Success 204 {string} string ""
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router  /v1/request-quotas/{subjectType}/{subjectId} [delete]
+func (h *RequestRules) DeleteQuota(c echo.Context) error {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": "unauthorized"})
+	}
+	var subjectID pgtype.UUID
+	if err := subjectID.Scan(c.Param("subjectId")); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid subjectId"})
+	}
+	if err := h.svc.RequestRules.DeleteQuota(c.Request().Context(), userID, c.Param("subjectType"), subjectID); err != nil {
+		return requestRuleError(c, err)
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+// ListRules lists request rules
+// @Summary List request rules
+// @Description Rules of every role, highest priority first
+// @Tags    request-rules
+// @Produce json
+// @Success 200 {array} model.RequestRule
+// @Failure 500 {object} map[string]string
+// @Router  /v1/request-rules [get]
+func (h *RequestRules) ListRules(c echo.Context) error {
+	out, err := h.svc.RequestRules.ListRules(c.Request().Context())
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusOK, out)
+}
+
+// CreateRule creates a request rule
+// @Summary Create request rule
+// @Description Requires requests.approve
+// @Tags    request-rules
+// @Accept  json
+// @Produce json
+// @Param   payload body model.RequestRuleRequest true "Rule"
+// @Success 201 {object} model.RequestRule
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router  /v1/request-rules [post]
+func (h *RequestRules) CreateRule(c echo.Context) error {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": "unauthorized"})
+	}
+	var req model.RequestRuleRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid request body"})
+	}
+	out, err := h.svc.RequestRules.CreateRule(c.Request().Context(), userID, req)
+	if err != nil {
+		return requestRuleError(c, err)
+	}
+	return c.JSON(http.StatusCreated, out)
+}
+
+// GetRule returns a request rule
+// @Summary Get request rule
+// @Tags    request-rules
+// @Produce json
+// @Param   id path string true "Rule ID"
+// @Success 200 {object} model.RequestRule
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router  /v1/request-rules/{id} [get]
+func (h *RequestRules) GetRule(c echo.Context) error {
+	var id pgtype.UUID
+	if err := id.Scan(c.Param("id")); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid id"})
+	}
+	out, err := h.svc.RequestRules.GetRule(c.Request().Context(), id)
+	if err != nil {
+		return requestRuleError(c, err)
+	}
+	return c.JSON(http.StatusOK, out)
+}
+
+// UpdateRule updates a request rule
+// @Summary Update request rule
+// @Description Requires requests.approve
+// @Tags    request-rules
+// @Accept  json
+// @Produce json
+// @Param   id path string true "Rule ID"
+// @Param   payload body model.RequestRuleRequest true "Rule"
+// @Success 200 {object} model.RequestRule
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router  /v1/request-rules/{id} [put]
+func (h *RequestRules) UpdateRule(c echo.Context) error {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": "unauthorized"})
+	}
+	var id pgtype.UUID
+	if err := id.Scan(c.Param("id")); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid id"})
+	}
+	var req model.RequestRuleRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid request body"})
+	}
+	out, err := h.svc.RequestRules.UpdateRule(c.Request().Context(), userID, id, req)
+	if err != nil {
+		return requestRuleError(c, err)
+	}
+	return c.JSON(http.StatusOK, out)
+}
+
+// DeleteRule deletes a request rule
+// @Summary Delete request rule
+// @Description Requires requests.approve
+// @Tags    request-rules
+// @Param   id path string true "Rule ID"
+// @Success 204 {string} string ""
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router  /v1/request-rules/{id} [delete]
+func (h *RequestRules) DeleteRule(c echo.Context) error {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": "unauthorized"})
+	}
+	var id pgtype.UUID
+	if err := id.Scan(c.Param("id")); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid id"})
+	}
+	if err := h.svc.RequestRules.DeleteRule(c.Request().Context(), userID, id); err != nil {
+		return requestRuleError(c, err)
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+// GetFields returns the fields request rules can compare
+// @Summary Get request rule fields
+// @Tags    request-rules
+// @Produce json
+// @Success 200 {array} model.FieldDefinition
+// @Failure 500 {object} map[string]string
+// @Router  /v1/request-rules/fields [get]
+func (h *RequestRules) GetFields(c echo.Context) error {
+	fields, err := h.svc.RequestRules.GetFieldDefinitions(c.Request().Context())
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusOK, fields)
+}
+
+func requestRuleError(c echo.Context, err error) error {
+	switch {
+	case errors.Is(err, service.ErrRequestRuleInvalid), errors.Is(err, service.ErrRequestQuotaInvalid):
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	case errors.Is(err, service.ErrRequestRuleForbidden):
+		return c.JSON(http.StatusForbidden, map[string]string{"error": err.Error()})
+	case errors.Is(err, service.ErrRequestRuleNotFound):
+		return c.JSON(http.StatusNotFound, map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+}
@@ -0,0 +1,172 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/kyleaupton/arrflix/internal/model"
+	"github.com/kyleaupton/arrflix/internal/service"
+	"github.com/labstack/echo/v4"
+)
+
+type TitleAliases struct{ svc *service.Services }
+
+func NewTitleAliases(s *service.Services) *TitleAliases {
+	return &TitleAliases{svc: s}
+}
+
+func (h *TitleAliases) RegisterProtected(v1 *echo.Group) {
+	v1.GET("/movie/:id/aliases", h.ListMovieAliases)
+	v1.POST("/movie/:id/aliases", h.CreateMovieAlias)
+	v1.DELETE("/movie/:id/aliases/:aliasId", h.DeleteMovieAlias)
+
+	v1.GET("/series/:id/aliases", h.ListSeriesAliases)
+	v1.POST("/series/:id/aliases", h.CreateSeriesAlias)
+	v1.DELETE("/series/:id/aliases/:aliasId", h.DeleteSeriesAlias)
+}
+
+// ListMovieAliases lists the titles a movie is searched under
+// @Summary List title aliases for a movie
+// @Tags    title-aliases
+// @Produce json
+// @Param   id path int true "Movie ID (TMDB ID)"
+// @Success 200 {array} model.TitleAlias
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router  /v1/movie/{id}/aliases [get]
+func (h *TitleAliases) ListMovieAliases(c echo.Context) error {
+	return h.list(c, model.MediaTypeMovie)
+}
+
+// ListSeriesAliases lists the titles a series is searched under
+// @Summary List title aliases for a series
+// @Tags    title-aliases
+// @Produce json
+// @Param   id path int true "Series ID (TMDB ID)"
+// @Success 200 {array} model.TitleAlias
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router  /v1/series/{id}/aliases [get]
+func (h *TitleAliases) ListSeriesAliases(c echo.Context) error {
+	return h.list(c, model.MediaTypeSeries)
+}
+
+// CreateMovieAlias adds a custom title alias to a movie
+// @Summary Add a custom title alias to a movie
+// @Tags    title-aliases
+// @Accept  json
+// @Produce json
+// @Param   id path int true "Movie ID (TMDB ID)"
+// @Param   payload body model.CreateTitleAliasRequest true "Alias"
+// @Success 201 {object} model.TitleAlias
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router  /v1/movie/{id}/aliases [post]
+func (h *TitleAliases) CreateMovieAlias(c echo.Context) error {
+	return h.create(c, model.MediaTypeMovie)
+}
+
+// CreateSeriesAlias adds a custom title alias to a series
+// @Summary Add a custom title alias to a series
+// @Tags    title-aliases
+// @Accept  json
+// @Produce json
+// @Param   id path int true "Series ID (TMDB ID)"
+// @Param   payload body model.CreateTitleAliasRequest true "Alias"
+// @Success 201 {object} model.TitleAlias
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router  /v1/series/{id}/aliases [post]
+func (h *TitleAliases) CreateSeriesAlias(c echo.Context) error {
+	return h.create(c, model.MediaTypeSeries)
+}
+
+// DeleteMovieAlias removes a custom title alias from a movie
+// @Summary Delete a custom title alias from a movie
+// @Tags    title-aliases
+// @Param   id path int true "Movie ID (TMDB ID)"
+// @Param   aliasId path string true "Alias ID"
+// @Success 204 {string} string ""
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router  /v1/movie/{id}/aliases/{aliasId} [delete]
+func (h *TitleAliases) DeleteMovieAlias(c echo.Context) error {
+	return h.delete(c, model.MediaTypeMovie)
+}
+
+// DeleteSeriesAlias removes a custom title alias from a series
+// @Summary Delete a custom title alias from a series
+// @Tags    title-aliases
+// @Param   id path int true "Series ID (TMDB ID)"
+// @Param   aliasId path string true "Alias ID"
+// @Success 204 {string} string ""
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router  /v1/series/{id}/aliases/{aliasId} [delete]
+func (h *TitleAliases) DeleteSeriesAlias(c echo.Context) error {
+	return h.delete(c, model.MediaTypeSeries)
+}
+
+func (h *TitleAliases) list(c echo.Context, mediaType model.MediaType) error {
+	tmdbID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid id"})
+	}
+
+	aliases, err := h.svc.TitleAliases.List(c.Request().Context(), mediaType, tmdbID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusOK, aliases)
+}
+
+func (h *TitleAliases) create(c echo.Context, mediaType model.MediaType) error {
+	tmdbID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid id"})
+	}
+
+	var req model.CreateTitleAliasRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid body"})
+	}
+
+	alias, err := h.svc.TitleAliases.Add(c.Request().Context(), mediaType, tmdbID, req.Title, req.Excluded)
+	if err != nil {
+		if errors.Is(err, service.ErrTitleAliasInvalid) {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+
+	id := alias.ID.String()
+	return c.JSON(http.StatusCreated, model.TitleAlias{
+		ID:       &id,
+		Title:    alias.Title,
+		Source:   model.TitleAliasSourceCustom,
+		Excluded: alias.Excluded,
+		Searched: !alias.Excluded,
+	})
+}
+
+func (h *TitleAliases) delete(c echo.Context, mediaType model.MediaType) error {
+	tmdbID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid id"})
+	}
+	var aliasID pgtype.UUID
+	if err := aliasID.Scan(c.Param("aliasId")); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid alias id"})
+	}
+
+	if err := h.svc.TitleAliases.Delete(c.Request().Context(), mediaType, tmdbID, aliasID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return c.JSON(http.StatusNotFound, map[string]string{"error": "not found"})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	return c.NoContent(http.StatusNoContent)
+}
@@ -0,0 +1,164 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/kyleaupton/arrflix/internal/model"
+	"github.com/kyleaupton/arrflix/internal/service"
+	"github.com/labstack/echo/v4"
+)
+
+type Wanted struct{ svc *service.Services }
+
+func NewWanted(s *service.Services) *Wanted { return &Wanted{svc: s} }
+
+func (h *Wanted) RegisterProtected(v1 *echo.Group) {
+	v1.GET("/wanted/missing", h.Missing)
+	v1.GET("/wanted/cutoff", h.Cutoff)
+	v1.POST("/wanted/search", h.Search)
+}
+
+// Missing lists movies and episodes without a file
+// @Summary List missing
+// @Description Movies and episodes with no file on disk, most recently aired first
+// @Tags    wanted
+// @Produce json
+// @Param   mediaType query string false "movie or series"
+// @Param   libraryId query string false "Filter by library ID"
+// @Param   seriesId query int false "Filter by series (TMDB ID)"
+// @Param   airStatus query string false "aired (default), unaired or all"
+// @Param   includeUnmonitored query bool false "Include unmonitored items"
+// @Param   page query int false "Page number (default 1)"
+// @Param   pageSize query int false "Page size (default 20)"
+// @Success 200 {object} model.PaginatedWantedResponse
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router  /v1/wanted/missing [get]
+func (h *Wanted) Missing(c echo.Context) error {
+	filter, err := wantedFilter(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+	aired := true
+	filter.Aired = &aired
+	switch c.QueryParam("airStatus") {
+	case "", "aired":
+	case "unaired":
+		aired = false
+	case "all":
+		filter.Aired = nil
+	default:
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid airStatus, expected aired, unaired or all"})
+	}
+
+	page, pageSize := wantedPage(c)
+	res, err := h.svc.Wanted.Missing(c.Request().Context(), filter, page, pageSize)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusOK, res)
+}
+
+// Cutoff lists movies and episodes below their quality profile's cutoff
+// @Summary List cutoff unmet
+// @Description Movies and episodes whose file is below the cutoff of a quality profile that allows upgrades, by title
+// @Tags    wanted
+// @Produce json
+// @Param   mediaType query string false "movie or series"
+// @Param   libraryId query string false "Filter by library ID"
+// @Param   seriesId query int false "Filter by series (TMDB ID)"
+// @Param   includeUnmonitored query bool false "Include unmonitored items"
+// @Param   page query int false "Page number (default 1)"
+// @Param   pageSize query int false "Page size (default 20)"
+// @Success 200 {object} model.PaginatedWantedResponse
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router  /v1/wanted/cutoff [get]
+func (h *Wanted) Cutoff(c echo.Context) error {
+	filter, err := wantedFilter(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	page, pageSize := wantedPage(c)
+	res, err := h.svc.Wanted.Cutoff(c.Request().Context(), filter, page, pageSize)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusOK, res)
+}
+
+// Search queues searches for the selected wanted items
+// @Summary Search selected
+// @Description Queues an automatic search for each movie, season or episode; results are recorded as automatic search decisions
+// @Tags    wanted
+// @Accept  json
+// @Produce json
+// @Param   payload body model.WantedSearchRequest true "Items to search"
+// @Success 202 {object} model.WantedSearchQueued
+// @Failure 400 {object} map[string]string
+// @Router  /v1/wanted/search [post]
+func (h *Wanted) Search(c echo.Context) error {
+	var req model.WantedSearchRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid request body"})
+	}
+
+	res, err := h.svc.Wanted.Search(req.Items)
+	if err != nil {
+		if errors.Is(err, service.ErrWantedSearchInvalid) || errors.Is(err, service.ErrWantedSearchTooMany) {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusAccepted, res)
+}
+
+// wantedFilter parses the filters shared by the wanted lists.
+func wantedFilter(c echo.Context) (service.WantedFilter, error) {
+	var filter service.WantedFilter
+	switch t := model.MediaType(c.QueryParam("mediaType")); t {
+	case "":
+	case model.MediaTypeMovie, model.MediaTypeSeries:
+		filter.MediaType = &t
+	default:
+		return filter, errors.New("invalid mediaType, expected movie or series")
+	}
+	if idStr := c.QueryParam("libraryId"); idStr != "" {
+		if err := filter.LibraryID.Scan(idStr); err != nil {
+			return filter, errors.New("invalid library id")
+		}
+	}
+	if idStr := c.QueryParam("seriesId"); idStr != "" {
+		id, err := strconv.ParseInt(idStr, 10, 64)
+		if err != nil {
+			return filter, errors.New("invalid series id")
+		}
+		filter.SeriesTmdbID = &id
+	}
+	if s := c.QueryParam("includeUnmonitored"); s != "" {
+		include, err := strconv.ParseBool(s)
+		if err != nil {
+			return filter, errors.New("invalid includeUnmonitored, expected true or false")
+		}
+		filter.IncludeUnmonitored = include
+	}
+	return filter, nil
+}
+
+func wantedPage(c echo.Context) (int, int) {
+	page, pageSize := 1, 20
+	if pageStr := c.QueryParam("page"); pageStr != "" {
+		if p, err := strconv.Atoi(pageStr); err == nil && p > 0 {
+			page = p
+		}
+	}
+	if pageSizeStr := c.QueryParam("pageSize"); pageSizeStr != "" {
+		if ps, err := strconv.Atoi(pageSizeStr); err == nil && ps > 0 && ps <= 100 {
+			pageSize = ps
+		}
+	}
+	return page, pageSize
+}
@@ -26,6 +26,9 @@ func NewServer(cfg config.Config, log *logger.Logger, pool *pgxpool.Pool, servic
 
 	// Handlers
 	auth := handlers.NewAuth(cfg, log, pool, services)
+	autoSearch := handlers.NewAutoSearch(services)
+	blocklist := handlers.NewBlocklist(services)
+	calendar := handlers.NewCalendar(services)
 	downloadCandidates := handlers.NewDownloadCandidates(services)
 	downloadJobs := handlers.NewDownloadJobs(services)
 	importTasks := handlers.NewImportTasks(services)
@@ -37,14 +40,22 @@ func NewServer(cfg config.Config, log *logger.Logger, pool *pgxpool.Pool, servic
 	indexers := handlers.NewIndexers(services)
 	libraries := handlers.NewLibraries(services)
 	media := handlers.NewMedia(services)
+	mediaRequests := handlers.NewMediaRequests(services)
+	metadata := handlers.NewMetadata(services)
+	monitoring := handlers.NewMonitoring(services)
 	nameTemplates := handlers.NewNameTemplates(services)
 	policies := handlers.NewPolicies(services)
+	qualityProfiles := handlers.NewQualityProfiles(services)
+	remotePathMappings := handlers.NewRemotePathMappings(services, downloaderManager)
+	requestRules := handlers.NewRequestRules(services)
 	settings := handlers.NewSettings(services)
 	bootstrap := handlers.NewBootstrap(cfg, services)
 	setup := handlers.NewSetup(services)
+	titleAliases := handlers.NewTitleAliases(services)
 	unmatchedFiles := handlers.NewUnmatchedFiles(services)
 	users := handlers.NewUsers(services)
 	version := handlers.NewVersion(services)
+	wanted := handlers.NewWanted(services)
 
 	// Apply setup mode middleware globally
 	e.Use(middlewares.SetupMode(services))
@@ -56,12 +67,16 @@ func NewServer(cfg config.Config, log *logger.Logger, pool *pgxpool.Pool, servic
 	// Public routes
 	bootstrap.RegisterPublic(v1)
 	auth.RegisterPublic(v1)
+	calendar.RegisterPublic(v1)
 	health.RegisterPublic(e)
 	setup.RegisterPublic(v1)
 	version.RegisterPublic(v1)
 
 	// Protected routes
 	auth.RegisterProtected(protected)
+	autoSearch.RegisterProtected(protected)
+	blocklist.RegisterProtected(protected)
+	calendar.RegisterProtected(protected)
 	downloadCandidates.RegisterProtected(protected)
 	downloadJobs.RegisterProtected(protected)
 	importTasks.RegisterProtected(protected)
@@ -72,11 +87,19 @@ func NewServer(cfg config.Config, log *logger.Logger, pool *pgxpool.Pool, servic
 	indexers.RegisterProtected(protected)
 	libraries.RegisterProtected(protected)
 	media.RegisterProtected(protected)
+	mediaRequests.RegisterProtected(protected)
+	metadata.RegisterProtected(protected)
+	monitoring.RegisterProtected(protected)
 	nameTemplates.RegisterProtected(protected)
 	policies.RegisterProtected(protected)
+	qualityProfiles.RegisterProtected(protected)
+	remotePathMappings.RegisterProtected(protected)
+	requestRules.RegisterProtected(protected)
 	settings.RegisterProtected(protected)
+	titleAliases.RegisterProtected(protected)
 	unmatchedFiles.RegisterProtected(protected)
 	users.RegisterProtected(protected)
+	wanted.RegisterProtected(protected)
 
 	// Dev-only routes
 	if cfg.Env == "dev" {
@@ -0,0 +1,90 @@
+// Package ical writes iCalendar (RFC 5545) feeds of all-day events.
+package ical
+
+import (
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// maxLineOctets is the longest a content line may be before it is folded.
+const maxLineOctets = 75
+
+// Event is an all-day event.
+type Event struct {
+	UID         string // stable across feed refreshes, so apps update rather than duplicate
+	Date        time.Time
+	Summary     string
+	Description string
+}
+
+// Calendar is a named list of events.
+type Calendar struct {
+	Name   string
+	Events []Event
+}
+
+// Encode renders the calendar. stamp is the DTSTAMP of every event, usually
+// the time the feed was generated.
+func (c Calendar) Encode(stamp time.Time) []byte {
+	var b strings.Builder
+	line := func(name, value string) {
+		writeFolded(&b, name+":"+value)
+	}
+
+	line("BEGIN", "VCALENDAR")
+	line("VERSION", "2.0")
+	line("PRODID", "-//Arrflix//Calendar//EN")
+	line("CALSCALE", "GREGORIAN")
+	line("METHOD", "PUBLISH")
+	if c.Name != "" {
+		line("X-WR-CALNAME", escape(c.Name))
+	}
+
+	dtstamp := stamp.UTC().Format("20060102T150405Z")
+	for _, e := range c.Events {
+		line("BEGIN", "VEVENT")
+		line("UID", escape(e.UID))
+		line("DTSTAMP", dtstamp)
+		line("DTSTART;VALUE=DATE", e.Date.Format("20060102"))
+		line("DTEND;VALUE=DATE", e.Date.AddDate(0, 0, 1).Format("20060102"))
+		line("SUMMARY", escape(e.Summary))
+		if e.Description != "" {
+			line("DESCRIPTION", escape(e.Description))
+		}
+		line("TRANSP", "TRANSPARENT")
+		line("END", "VEVENT")
+	}
+
+	line("END", "VCALENDAR")
+	return []byte(b.String())
+}
+
+// escape escapes a TEXT value.
+func escape(s string) string {
+	return strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+	).Replace(s)
+}
+
+// writeFolded writes a content line, folding it into continuation lines of at
+// most maxLineOctets without splitting a UTF-8 character.
+func writeFolded(b *strings.Builder, s string) {
+	limit := maxLineOctets
+	for len(s) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(s[cut]) {
+			cut--
+		}
+		b.WriteString(s[:cut])
+		b.WriteString("\r\n ")
+		s = s[cut:]
+		limit = maxLineOctets - 1 // the leading space counts
+	}
+	b.WriteString(s)
+	b.WriteString("\r\n")
+}
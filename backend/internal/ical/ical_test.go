@@ -0,0 +1,62 @@
+package ical
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestEncode(t *testing.T) {
+	cal := Calendar{
+		Name: "Arrflix",
+		Events: []Event{{
+			UID:         "episode-1@arrflix",
+			Date:        time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC),
+			Summary:     "Show; Part 1, Again",
+			Description: "Line one\nLine two",
+		}},
+	}
+	got := string(cal.Encode(time.Date(2026, 10, 1, 12, 30, 0, 0, time.UTC)))
+
+	for _, want := range []string{
+		"BEGIN:VCALENDAR\r\n",
+		"X-WR-CALNAME:Arrflix\r\n",
+		"UID:episode-1@arrflix\r\n",
+		"DTSTAMP:20261001T123000Z\r\n",
+		"DTSTART;VALUE=DATE:20261018\r\n",
+		"DTEND;VALUE=DATE:20261019\r\n",
+		`SUMMARY:Show\; Part 1\, Again` + "\r\n",
+		`DESCRIPTION:Line one\nLine two` + "\r\n",
+		"END:VCALENDAR\r\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Encode() missing %q in:\n%s", want, got)
+		}
+	}
+}
+
+func TestWriteFolded(t *testing.T) {
+	var b strings.Builder
+	writeFolded(&b, "SUMMARY:"+strings.Repeat("é", 80))
+
+	lines := strings.Split(strings.TrimSuffix(b.String(), "\r\n"), "\r\n")
+	if len(lines) < 2 {
+		t.Fatalf("writeFolded() did not fold: %q", b.String())
+	}
+	var joined strings.Builder
+	for i, line := range lines {
+		if len(line) > maxLineOctets {
+			t.Errorf("line %d is %d octets, want at most %d", i, len(line), maxLineOctets)
+		}
+		if i > 0 {
+			if !strings.HasPrefix(line, " ") {
+				t.Errorf("continuation line %d does not start with a space", i)
+			}
+			line = line[1:]
+		}
+		joined.WriteString(line)
+	}
+	if want := "SUMMARY:" + strings.Repeat("é", 80); joined.String() != want {
+		t.Errorf("unfolded = %q, want %q", joined.String(), want)
+	}
+}
@@ -2,10 +2,12 @@ package identity
 
 import (
 	"errors"
+	"path/filepath"
 	"regexp"
 	"strconv"
 
 	dbgen "github.com/kyleaupton/arrflix/internal/db/sqlc"
+	"github.com/kyleaupton/arrflix/internal/release"
 )
 
 type Identity struct {
@@ -73,21 +75,11 @@ func getIdFromString(str string) (string, string, error) {
 }
 
 func getSeasonAndEpisodeFromPath(path string) (int32, int32, error) {
-	re := regexp.MustCompile(`[sS](\d+)[eE](\d+)`)
-	matches := re.FindStringSubmatch(path)
-	if len(matches) == 0 {
+	info := release.ParseTitleInfo(filepath.Base(path))
+	season, ok := info.Season()
+	if !ok || len(info.Episodes) == 0 {
 		return 0, 0, ErrNoIdentityFound
 	}
 
-	season, err := strconv.ParseInt(matches[1], 10, 32)
-	if err != nil {
-		return 0, 0, err
-	}
-
-	episode, err := strconv.ParseInt(matches[2], 10, 32)
-	if err != nil {
-		return 0, 0, err
-	}
-
-	return int32(season), int32(episode), nil
+	return int32(season), int32(info.Episodes[0]), nil
 }
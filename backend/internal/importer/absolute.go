@@ -0,0 +1,69 @@
+package importer
+
+import (
+	"sort"
+
+	dbgen "github.com/kyleaupton/arrflix/internal/db/sqlc"
+)
+
+// EpisodeNumber identifies an episode by its TMDB season and episode number.
+type EpisodeNumber struct {
+	Season  int
+	Episode int
+}
+
+// AbsoluteEpisodeMap maps anime-style absolute episode numbers to TMDB
+// seasons and episodes.
+type AbsoluteEpisodeMap map[int]EpisodeNumber
+
+// NewAbsoluteEpisodeMap numbers the given episodes 1..n in order. The order
+// comes from a TMDB "Absolute" episode group, or from the regular seasons
+// with specials left out.
+func NewAbsoluteEpisodeMap(order []EpisodeNumber) AbsoluteEpisodeMap {
+	m := make(AbsoluteEpisodeMap, len(order))
+	for i, ep := range order {
+		m[i+1] = ep
+	}
+	return m
+}
+
+// AbsoluteEpisodeMapFromRows builds the map from the absolute numbers stored
+// on a series' episodes.
+func AbsoluteEpisodeMapFromRows(rows []dbgen.ListAbsoluteEpisodeNumbersRow) AbsoluteEpisodeMap {
+	m := make(AbsoluteEpisodeMap, len(rows))
+	for _, row := range rows {
+		if row.AbsoluteNumber == nil {
+			continue
+		}
+		m[int(*row.AbsoluteNumber)] = EpisodeNumber{Season: int(row.SeasonNumber), Episode: int(row.EpisodeNumber)}
+	}
+	return m
+}
+
+// Resolve returns the season and episode for an absolute number.
+func (m AbsoluteEpisodeMap) Resolve(absolute int) (EpisodeNumber, bool) {
+	ep, ok := m[absolute]
+	return ep, ok
+}
+
+// Absolute returns the absolute number of a season episode.
+func (m AbsoluteEpisodeMap) Absolute(season, episode int) (int, bool) {
+	for abs, ep := range m {
+		if ep.Season == season && ep.Episode == episode {
+			return abs, true
+		}
+	}
+	return 0, false
+}
+
+// SeasonAbsolutes returns the absolute numbers of every episode in a season, in order.
+func (m AbsoluteEpisodeMap) SeasonAbsolutes(season int) []int {
+	var out []int
+	for abs, ep := range m {
+		if ep.Season == season {
+			out = append(out, abs)
+		}
+	}
+	sort.Ints(out)
+	return out
+}
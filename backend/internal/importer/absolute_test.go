@@ -0,0 +1,26 @@
+package importer
+
+import (
+	"reflect"
+	"testing"
+
+	dbgen "github.com/kyleaupton/arrflix/internal/db/sqlc"
+)
+
+func TestAbsoluteEpisodeMapFromRows(t *testing.T) {
+	num := func(n int32) *int32 { return &n }
+	rows := []dbgen.ListAbsoluteEpisodeNumbersRow{
+		{SeasonNumber: 1, EpisodeNumber: 1, AbsoluteNumber: num(1)},
+		{SeasonNumber: 1, EpisodeNumber: 2, AbsoluteNumber: num(2)},
+		{SeasonNumber: 2, EpisodeNumber: 1, AbsoluteNumber: num(3)},
+		{SeasonNumber: 0, EpisodeNumber: 1},
+	}
+	want := AbsoluteEpisodeMap{
+		1: {Season: 1, Episode: 1},
+		2: {Season: 1, Episode: 2},
+		3: {Season: 2, Episode: 1},
+	}
+	if got := AbsoluteEpisodeMapFromRows(rows); !reflect.DeepEqual(got, want) {
+		t.Errorf("AbsoluteEpisodeMapFromRows() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,292 @@
+package importer
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ExtraType classifies a file imported alongside a video.
+type ExtraType string
+
+const (
+	ExtraSubtitle ExtraType = "subtitle"
+	ExtraNFO      ExtraType = "nfo"
+	ExtraArtwork  ExtraType = "artwork"
+	ExtraOther    ExtraType = "other"
+)
+
+// DefaultExtraExtensions are the extra files a library imports unless it
+// lists its own. Artwork has to be whitelisted explicitly.
+var DefaultExtraExtensions = []string{"srt", "ass", "ssa", "sub", "idx", "vtt", "nfo"}
+
+var subtitleExts = map[string]bool{
+	".srt": true,
+	".ass": true,
+	".ssa": true,
+	".sub": true,
+	".idx": true,
+	".vtt": true,
+	".sup": true,
+}
+
+var artworkExts = map[string]bool{
+	".jpg":  true,
+	".jpeg": true,
+	".png":  true,
+}
+
+// subtitleDirs hold a release's subtitles, either directly or in one folder
+// per episode.
+var subtitleDirs = map[string]bool{
+	"subs":      true,
+	"subtitles": true,
+}
+
+// Extra is a file that came with a video.
+type Extra struct {
+	SourcePath string
+	Type       ExtraType
+
+	// Subtitles only: ISO 639-1 language and flags read from the file name
+	Language string
+	Forced   bool
+	SDH      bool
+}
+
+// NormalizeExtraExtensions lowercases exts and strips leading dots, dropping
+// empty entries and duplicates. Video extensions are rejected, the video is
+// never an extra.
+func NormalizeExtraExtensions(exts []string) ([]string, error) {
+	seen := make(map[string]bool, len(exts))
+	out := make([]string, 0, len(exts))
+	for _, e := range exts {
+		e = strings.ToLower(strings.TrimPrefix(strings.TrimSpace(e), "."))
+		if e == "" || seen[e] {
+			continue
+		}
+		if strings.ContainsAny(e, `/\. `) {
+			return nil, fmt.Errorf("invalid extension %q", e)
+		}
+		if videoExts["."+e] {
+			return nil, fmt.Errorf("%q is a video extension", e)
+		}
+		seen[e] = true
+		out = append(out, e)
+	}
+	return out, nil
+}
+
+// FindExtras returns the whitelisted files that belong to the video at
+// videoPath. When the video is the only one in its directory, every extra in
+// the directory and its Subs folder is its own. Otherwise (season packs,
+// shared download folders) an extra must start with the video's name or sit
+// in a Subs folder named after it.
+func FindExtras(videoPath string, exts []string) ([]Extra, error) {
+	allowed := make(map[string]bool, len(exts))
+	for _, e := range exts {
+		allowed["."+e] = true
+	}
+
+	dir := filepath.Dir(videoPath)
+	stem := strings.TrimSuffix(filepath.Base(videoPath), filepath.Ext(videoPath))
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("read source dir: %w", err)
+	}
+	videos := 0
+	for _, e := range entries {
+		if !e.IsDir() && IsVideoPath(e.Name()) && !LooksLikeSample(e.Name()) {
+			videos++
+		}
+	}
+	alone := videos == 1
+
+	var extras []Extra
+	add := func(path string, owned bool) {
+		name := filepath.Base(path)
+		ext := strings.ToLower(filepath.Ext(name))
+		if !allowed[ext] || LooksLikeSample(name) {
+			return
+		}
+		if !owned && !hasPrefixFold(name, stem) {
+			return
+		}
+		extras = append(extras, newExtra(path, stem))
+	}
+
+	for _, e := range entries {
+		path := filepath.Join(dir, e.Name())
+		if !e.IsDir() {
+			add(path, alone)
+			continue
+		}
+		if !subtitleDirs[strings.ToLower(e.Name())] {
+			continue
+		}
+		subs, err := os.ReadDir(path)
+		if err != nil {
+			continue
+		}
+		for _, s := range subs {
+			if !s.IsDir() {
+				add(filepath.Join(path, s.Name()), alone)
+				continue
+			}
+			// Subs/<episode name>/2_English.srt
+			if !strings.EqualFold(s.Name(), stem) {
+				continue
+			}
+			nested, err := os.ReadDir(filepath.Join(path, s.Name()))
+			if err != nil {
+				continue
+			}
+			for _, n := range nested {
+				if !n.IsDir() {
+					add(filepath.Join(path, s.Name(), n.Name()), true)
+				}
+			}
+		}
+	}
+	return extras, nil
+}
+
+func newExtra(path, videoStem string) Extra {
+	ext := strings.ToLower(filepath.Ext(path))
+	e := Extra{SourcePath: path, Type: ExtraOther}
+	switch {
+	case subtitleExts[ext]:
+		e.Type = ExtraSubtitle
+		e.Language, e.Forced, e.SDH = parseSubtitleName(extraSuffix(path, videoStem))
+	case ext == ".nfo":
+		e.Type = ExtraNFO
+	case artworkExts[ext]:
+		e.Type = ExtraArtwork
+	}
+	return e
+}
+
+// extraSuffix is the part of an extra's name after the video's name, or the
+// whole name (without extension) when it doesn't start with it.
+func extraSuffix(path, videoStem string) string {
+	name := filepath.Base(path)
+	name = strings.TrimSuffix(name, filepath.Ext(name))
+	if hasPrefixFold(name, videoStem) {
+		return name[len(videoStem):]
+	}
+	return name
+}
+
+// parseSubtitleName reads the language and flags from the end of a subtitle
+// name: "en.forced", "English.SDH", "2_eng". Tokens are read from the end and
+// stop at the first one that is neither, so titles aren't read as languages.
+func parseSubtitleName(name string) (lang string, forced, sdh bool) {
+	tokens := strings.FieldsFunc(name, func(r rune) bool {
+		return r == '.' || r == '_' || r == '-' || r == ' ' || r == '[' || r == ']' || r == '(' || r == ')'
+	})
+	for i := len(tokens) - 1; i >= 0; i-- {
+		t := strings.ToLower(tokens[i])
+		switch {
+		case t == "forced" || t == "foreign":
+			forced = true
+		case t == "sdh" || t == "hi" || t == "cc":
+			sdh = true
+		case languageCodes[t] != "":
+			if lang == "" {
+				lang = languageCodes[t]
+			}
+		default:
+			return lang, forced, sdh
+		}
+	}
+	return lang, forced, sdh
+}
+
+// ExtraDestPaths returns where each extra goes in the library, next to the
+// video at videoDest. Subtitles and NFOs take the video's name, subtitles
+// with their language and flags; other extras keep their own name, or swap
+// the release name for the video's when they start with it. Clashes within
+// the set get a number.
+func ExtraDestPaths(videoDest, sourceVideo string, extras []Extra) []string {
+	destDir := filepath.Dir(videoDest)
+	destStem := strings.TrimSuffix(videoDest, filepath.Ext(videoDest))
+	srcStem := strings.TrimSuffix(filepath.Base(sourceVideo), filepath.Ext(sourceVideo))
+
+	used := make(map[string]bool, len(extras))
+	out := make([]string, len(extras))
+	for i, e := range extras {
+		ext := strings.ToLower(filepath.Ext(e.SourcePath))
+		var base string
+		switch e.Type {
+		case ExtraSubtitle:
+			base = destStem
+			if e.Language != "" {
+				base += "." + e.Language
+			}
+			if e.Forced {
+				base += ".forced"
+			}
+			if e.SDH {
+				base += ".sdh"
+			}
+		case ExtraNFO:
+			base = destStem
+		default:
+			name := filepath.Base(e.SourcePath)
+			name = strings.TrimSuffix(name, filepath.Ext(name))
+			if hasPrefixFold(name, srcStem) {
+				base = destStem + name[len(srcStem):]
+			} else {
+				base = filepath.Join(destDir, name)
+			}
+		}
+
+		dest := base + ext
+		for n := 1; used[strings.ToLower(dest)]; n++ {
+			dest = fmt.Sprintf("%s.%d%s", base, n, ext)
+		}
+		used[strings.ToLower(dest)] = true
+		out[i] = dest
+	}
+	return out
+}
+
+func hasPrefixFold(s, prefix string) bool {
+	return len(s) >= len(prefix) && strings.EqualFold(s[:len(prefix)], prefix)
+}
+
+// languageCodes maps the language names and ISO 639 codes seen in subtitle
+// file names to ISO 639-1. "hi" is left out, it marks SDH far more often
+// than Hindi.
+var languageCodes = map[string]string{
+	"en": "en", "eng": "en", "english": "en",
+	"fr": "fr", "fre": "fr", "fra": "fr", "french": "fr",
+	"de": "de", "ger": "de", "deu": "de", "german": "de",
+	"es": "es", "spa": "es", "spanish": "es",
+	"it": "it", "ita": "it", "italian": "it",
+	"pt": "pt", "por": "pt", "portuguese": "pt",
+	"nl": "nl", "dut": "nl", "nld": "nl", "dutch": "nl",
+	"sv": "sv", "swe": "sv", "swedish": "sv",
+	"no": "no", "nor": "no", "nb": "no", "nob": "no", "norwegian": "no",
+	"da": "da", "dan": "da", "danish": "da",
+	"fi": "fi", "fin": "fi", "finnish": "fi",
+	"pl": "pl", "pol": "pl", "polish": "pl",
+	"cs": "cs", "cze": "cs", "ces": "cs", "czech": "cs",
+	"hu": "hu", "hun": "hu", "hungarian": "hu",
+	"ro": "ro", "rum": "ro", "ron": "ro", "romanian": "ro",
+	"el": "el", "gre": "el", "ell": "el", "greek": "el",
+	"tr": "tr", "tur": "tr", "turkish": "tr",
+	"ru": "ru", "rus": "ru", "russian": "ru",
+	"uk": "uk", "ukr": "uk", "ukrainian": "uk",
+	"ar": "ar", "ara": "ar", "arabic": "ar",
+	"he": "he", "heb": "he", "hebrew": "he",
+	"hin": "hi", "hindi": "hi",
+	"ja": "ja", "jpn": "ja", "japanese": "ja",
+	"ko": "ko", "kor": "ko", "korean": "ko",
+	"zh": "zh", "chi": "zh", "zho": "zh", "chinese": "zh",
+	"th": "th", "tha": "th", "thai": "th",
+	"vi": "vi", "vie": "vi", "vietnamese": "vi",
+	"id": "id", "ind": "id", "indonesian": "id",
+}
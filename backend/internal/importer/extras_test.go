@@ -0,0 +1,124 @@
+package importer
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestParseSubtitleName(t *testing.T) {
+	tests := []struct {
+		name   string
+		lang   string
+		forced bool
+		sdh    bool
+	}{
+		{name: ".en", lang: "en"},
+		{name: ".eng.forced", lang: "en", forced: true},
+		{name: ".English.SDH", lang: "en", sdh: true},
+		{name: "2_English", lang: "en"},
+		{name: ".pt.forced.sdh", lang: "pt", forced: true, sdh: true},
+		{name: ".hi", sdh: true},
+		{name: "The.French.Dispatch.2021", lang: ""},
+		{name: "", lang: ""},
+	}
+	for _, tt := range tests {
+		lang, forced, sdh := parseSubtitleName(tt.name)
+		if lang != tt.lang || forced != tt.forced || sdh != tt.sdh {
+			t.Errorf("parseSubtitleName(%q) = %q, %v, %v; want %q, %v, %v", tt.name, lang, forced, sdh, tt.lang, tt.forced, tt.sdh)
+		}
+	}
+}
+
+func TestFindExtras(t *testing.T) {
+	touch := func(t *testing.T, paths ...string) {
+		t.Helper()
+		for _, p := range paths {
+			if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+				t.Fatal(err)
+			}
+			if err := os.WriteFile(p, []byte("x"), 0o644); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	names := func(extras []Extra, root string) []string {
+		var out []string
+		for _, e := range extras {
+			rel, _ := filepath.Rel(root, e.SourcePath)
+			out = append(out, rel)
+		}
+		return out
+	}
+	exts := []string{"srt", "nfo", "jpg"}
+
+	t.Run("movie folder", func(t *testing.T) {
+		dir := t.TempDir()
+		touch(t,
+			filepath.Join(dir, "Movie.2020.1080p.mkv"),
+			filepath.Join(dir, "Movie.2020.1080p.nfo"),
+			filepath.Join(dir, "poster.jpg"),
+			filepath.Join(dir, "notes.txt"),
+			filepath.Join(dir, "Subs", "2_English.srt"),
+			filepath.Join(dir, "Sample", "sample.srt"),
+		)
+		got, err := FindExtras(filepath.Join(dir, "Movie.2020.1080p.mkv"), exts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := []string{"Movie.2020.1080p.nfo", filepath.Join("Subs", "2_English.srt"), "poster.jpg"}
+		if g := names(got, dir); !reflect.DeepEqual(g, want) {
+			t.Errorf("FindExtras() = %v, want %v", g, want)
+		}
+	})
+
+	t.Run("season pack", func(t *testing.T) {
+		dir := t.TempDir()
+		touch(t,
+			filepath.Join(dir, "Show.S01E01.mkv"),
+			filepath.Join(dir, "Show.S01E01.en.srt"),
+			filepath.Join(dir, "Show.S01E02.mkv"),
+			filepath.Join(dir, "Show.S01E02.en.srt"),
+			filepath.Join(dir, "Subs", "Show.S01E01", "3_English.srt"),
+			filepath.Join(dir, "Subs", "Show.S01E02", "3_English.srt"),
+			filepath.Join(dir, "poster.jpg"),
+		)
+		got, err := FindExtras(filepath.Join(dir, "Show.S01E01.mkv"), exts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := []string{"Show.S01E01.en.srt", filepath.Join("Subs", "Show.S01E01", "3_English.srt")}
+		if g := names(got, dir); !reflect.DeepEqual(g, want) {
+			t.Errorf("FindExtras() = %v, want %v", g, want)
+		}
+		for _, e := range got {
+			if e.Type != ExtraSubtitle || e.Language != "en" {
+				t.Errorf("extra %s = %+v, want an English subtitle", e.SourcePath, e)
+			}
+		}
+	})
+}
+
+func TestExtraDestPaths(t *testing.T) {
+	extras := []Extra{
+		{SourcePath: "/dl/Movie.2020/Movie.2020.en.srt", Type: ExtraSubtitle, Language: "en"},
+		{SourcePath: "/dl/Movie.2020/Subs/2_English.srt", Type: ExtraSubtitle, Language: "en"},
+		{SourcePath: "/dl/Movie.2020/Movie.2020.en.forced.srt", Type: ExtraSubtitle, Language: "en", Forced: true},
+		{SourcePath: "/dl/Movie.2020/Movie.2020.nfo", Type: ExtraNFO},
+		{SourcePath: "/dl/Movie.2020/poster.jpg", Type: ExtraArtwork},
+		{SourcePath: "/dl/Movie.2020/Movie.2020-fanart.jpg", Type: ExtraArtwork},
+	}
+	got := ExtraDestPaths("Movie (2020)/Movie (2020).mkv", "/dl/Movie.2020/Movie.2020.mkv", extras)
+	want := []string{
+		"Movie (2020)/Movie (2020).en.srt",
+		"Movie (2020)/Movie (2020).en.1.srt",
+		"Movie (2020)/Movie (2020).en.forced.srt",
+		"Movie (2020)/Movie (2020).nfo",
+		"Movie (2020)/poster.jpg",
+		"Movie (2020)/Movie (2020)-fanart.jpg",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ExtraDestPaths() = %v, want %v", got, want)
+	}
+}
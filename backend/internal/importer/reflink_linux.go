@@ -0,0 +1,14 @@
+package importer
+
+import (
+	"os"
+
+	"golang.org/x/sys/unix"
+)
+
+// cloneFile makes dst a copy-on-write clone of src with FICLONE. It fails
+// when the filesystem can't share extents or the files are on different
+// filesystems.
+func cloneFile(dst, src *os.File) error {
+	return unix.IoctlFileClone(int(dst.Fd()), int(src.Fd()))
+}
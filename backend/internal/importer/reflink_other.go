@@ -0,0 +1,13 @@
+//go:build !linux
+
+package importer
+
+import (
+	"errors"
+	"os"
+)
+
+// cloneFile is only supported on Linux; elsewhere reflink imports copy.
+func cloneFile(dst, src *os.File) error {
+	return errors.ErrUnsupported
+}
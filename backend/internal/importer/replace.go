@@ -0,0 +1,79 @@
+package importer
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// ReplacedDir is the directory, relative to a library root, that holds files
+// replaced by an upgrade. Library scans skip it.
+const ReplacedDir = ".replaced"
+
+// replacedLayout names the directory under ReplacedDir that holds the files
+// archived at one time, so pruning doesn't depend on file timestamps.
+const replacedLayout = "20060102-150405"
+
+// ArchiveReplaced moves the library file at rel (relative to root) aside
+// into ReplacedDir/<time>/, keeping its relative path. An earlier archive of
+// the same path in the same second gets a counter suffix. Returns the
+// archived path relative to root.
+func ArchiveReplaced(root, rel string, now time.Time) (string, error) {
+	archived := filepath.Join(ReplacedDir, now.UTC().Format(replacedLayout), rel)
+	ext := filepath.Ext(archived)
+	base := strings.TrimSuffix(archived, ext)
+	for n := 2; ; n++ {
+		if _, err := os.Lstat(filepath.Join(root, archived)); os.IsNotExist(err) {
+			break
+		}
+		archived = fmt.Sprintf("%s.%d%s", base, n, ext)
+	}
+
+	dst := filepath.Join(root, archived)
+	if err := os.MkdirAll(filepath.Dir(dst), 0o755); err != nil {
+		return "", fmt.Errorf("mkdir archive dir: %w", err)
+	}
+	if err := os.Rename(filepath.Join(root, rel), dst); err != nil {
+		return "", fmt.Errorf("archive replaced file: %w", err)
+	}
+	return archived, nil
+}
+
+// PruneReplaced removes the archives in root's ReplacedDir that were made
+// before the given time, going by their directory names, then ReplacedDir
+// itself if that leaves it empty. Directories that aren't archive times are
+// left alone. Returns how many files were removed.
+func PruneReplaced(root string, before time.Time) (int, error) {
+	dir := filepath.Join(root, ReplacedDir)
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("prune %s: %w", dir, err)
+	}
+
+	removed := 0
+	for _, e := range entries {
+		archivedAt, err := time.Parse(replacedLayout, e.Name())
+		if !e.IsDir() || err != nil || !archivedAt.Before(before) {
+			continue
+		}
+		path := filepath.Join(dir, e.Name())
+		_ = filepath.WalkDir(path, func(_ string, d fs.DirEntry, err error) error {
+			if err == nil && !d.IsDir() {
+				removed++
+			}
+			return nil
+		})
+		if err := os.RemoveAll(path); err != nil {
+			return removed, fmt.Errorf("prune %s: %w", path, err)
+		}
+	}
+
+	_ = os.Remove(dir) // only succeeds once empty
+	return removed, nil
+}
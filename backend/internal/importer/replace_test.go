@@ -0,0 +1,123 @@
+package importer
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestArchiveReplaced(t *testing.T) {
+	root := t.TempDir()
+	rel := filepath.Join("Movie (2020)", "Movie (2020) WEBDL-1080p.mkv")
+	write := func(content string) {
+		t.Helper()
+		if err := os.MkdirAll(filepath.Dir(filepath.Join(root, rel)), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(root, rel), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	write("first")
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	got, err := ArchiveReplaced(root, rel, now)
+	if err != nil {
+		t.Fatalf("ArchiveReplaced() error = %v", err)
+	}
+	first := filepath.Join(ReplacedDir, "20260102-030405", rel)
+	if got != first {
+		t.Errorf("ArchiveReplaced() = %q, want %q", got, first)
+	}
+	if _, err := os.Stat(filepath.Join(root, rel)); !os.IsNotExist(err) {
+		t.Errorf("original still exists, err = %v", err)
+	}
+
+	// A second archive of the same path in the same second keeps the first
+	write("second")
+	got, err = ArchiveReplaced(root, rel, now)
+	if err != nil {
+		t.Fatalf("ArchiveReplaced() error = %v", err)
+	}
+	second := filepath.Join(ReplacedDir, "20260102-030405", "Movie (2020)", "Movie (2020) WEBDL-1080p.2.mkv")
+	if got != second {
+		t.Errorf("ArchiveReplaced() = %q, want %q", got, second)
+	}
+
+	// A later one goes in its own directory
+	write("third")
+	got, err = ArchiveReplaced(root, rel, now.Add(time.Hour))
+	if err != nil {
+		t.Fatalf("ArchiveReplaced() error = %v", err)
+	}
+	third := filepath.Join(ReplacedDir, "20260102-040405", rel)
+	if got != third {
+		t.Errorf("ArchiveReplaced() = %q, want %q", got, third)
+	}
+
+	for path, content := range map[string]string{first: "first", second: "second", third: "third"} {
+		b, err := os.ReadFile(filepath.Join(root, path))
+		if err != nil || string(b) != content {
+			t.Errorf("%s = %q, %v; want %q", path, b, err, content)
+		}
+	}
+}
+
+func TestPruneReplaced(t *testing.T) {
+	root := t.TempDir()
+	if n, err := PruneReplaced(root, time.Now()); err != nil || n != 0 {
+		t.Fatalf("PruneReplaced() without %s = %d, %v; want 0, nil", ReplacedDir, n, err)
+	}
+
+	archive := func(rel string, at time.Time) string {
+		t.Helper()
+		if err := os.MkdirAll(filepath.Dir(filepath.Join(root, rel)), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(root, rel), []byte("old"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		archived, err := ArchiveReplaced(root, rel, at)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return archived
+	}
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	older := archive(filepath.Join("Movie (2020)", "Movie (2020) WEBDL-1080p.mkv"), now.AddDate(0, 0, -40))
+	archive(filepath.Join("Movie (2020)", "Movie (2020) WEBDL-1080p.en.srt"), now.AddDate(0, 0, -40))
+	newer := archive(filepath.Join("Movie (2020)", "Movie (2020) Bluray-1080p.mkv"), now.AddDate(0, 0, -5))
+
+	// Touching an archived file doesn't change when it was archived
+	if err := os.Chtimes(filepath.Join(root, older), now, now); err != nil {
+		t.Fatal(err)
+	}
+	// Anything else in the dir isn't ours to remove
+	if err := os.WriteFile(filepath.Join(root, ReplacedDir, "notes.txt"), nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if n, err := PruneReplaced(root, now.AddDate(0, 0, -30)); err != nil || n != 2 {
+		t.Fatalf("PruneReplaced() = %d, %v; want 2, nil", n, err)
+	}
+	if _, err := os.Stat(filepath.Join(root, older)); !os.IsNotExist(err) {
+		t.Errorf("expired archive still exists, err = %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(root, newer)); err != nil {
+		t.Errorf("archive removed early: %v", err)
+	}
+
+	if err := os.Remove(filepath.Join(root, ReplacedDir, "notes.txt")); err != nil {
+		t.Fatal(err)
+	}
+	if n, err := PruneReplaced(root, now); err != nil || n != 1 {
+		t.Fatalf("PruneReplaced() = %d, %v; want 1, nil", n, err)
+	}
+	if _, err := os.Stat(filepath.Join(root, ReplacedDir)); !os.IsNotExist(err) {
+		t.Errorf("%s still exists, err = %v", ReplacedDir, err)
+	}
+	if _, err := os.Stat(filepath.Join(root, "Movie (2020)")); err != nil {
+		t.Errorf("library dir removed: %v", err)
+	}
+}
@@ -4,11 +4,14 @@ import (
 	"path/filepath"
 	"strings"
 
+	"github.com/kyleaupton/arrflix/internal/archive"
 	"github.com/kyleaupton/arrflix/internal/downloader"
 )
 
 // PickMainMovieFile chooses the "main" file for a movie download.
-// Strategy: largest video file by extension, excluding obvious samples.
+// Strategy: largest video file by extension, excluding obvious samples. A
+// download without video gives the first volume of its largest archive set,
+// to be extracted on import.
 func PickMainMovieFile(files []downloader.File) (downloader.File, bool) {
 	var (
 		best    downloader.File
@@ -33,6 +36,11 @@ func PickMainMovieFile(files []downloader.File) (downloader.File, bool) {
 		return best, true
 	}
 
+	// No video: the release may be packed, pick the largest archive
+	if f, ok := pickMainArchive(files); ok {
+		return f, true
+	}
+
 	// Fallback: any largest file (still ignore samples if possible)
 	for _, f := range files {
 		if f.Size <= 0 {
@@ -60,6 +68,48 @@ func PickMainMovieFile(files []downloader.File) (downloader.File, bool) {
 	return best, bestSet
 }
 
+// pickMainArchive returns the first volume of the largest archive set.
+func pickMainArchive(files []downloader.File) (downloader.File, bool) {
+	type set struct {
+		first downloader.File
+		found bool
+		size  int64
+	}
+	sets := make(map[string]*set)
+	var order []string
+	for _, f := range files {
+		if !archive.IsArchive(f.Path) || LooksLikeSample(f.Path) {
+			continue
+		}
+		key := filepath.Join(filepath.Dir(f.Path), archive.SetName(f.Path))
+		st, ok := sets[key]
+		if !ok {
+			st = &set{}
+			sets[key] = st
+			order = append(order, key)
+		}
+		st.size += f.Size
+		if archive.IsFirstVolume(f.Path) {
+			st.first, st.found = f, true
+		}
+	}
+
+	var (
+		best    *set
+		bestSet bool
+	)
+	for _, key := range order {
+		st := sets[key]
+		if st.found && (!bestSet || st.size > best.size) {
+			best, bestSet = st, true
+		}
+	}
+	if !bestSet {
+		return downloader.File{}, false
+	}
+	return best.first, true
+}
+
 func EnsureExt(path, ext string) string {
 	if ext == "" {
 		return path
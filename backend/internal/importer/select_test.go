@@ -0,0 +1,52 @@
+package importer
+
+import (
+	"testing"
+
+	"github.com/kyleaupton/arrflix/internal/downloader"
+)
+
+func TestPickMainMovieFile(t *testing.T) {
+	tests := []struct {
+		name  string
+		files []downloader.File
+		want  string
+	}{
+		{
+			name: "video",
+			files: []downloader.File{
+				{Path: "Movie/Movie.2020.1080p.mkv", Size: 4000},
+				{Path: "Movie/Sample/movie.sample.mkv", Size: 50},
+				{Path: "Movie/Movie.2020.1080p.nfo", Size: 1},
+			},
+			want: "Movie/Movie.2020.1080p.mkv",
+		},
+		{
+			name: "rar set",
+			files: []downloader.File{
+				{Path: "Movie/movie.r00", Size: 100},
+				{Path: "Movie/movie.r01", Size: 100},
+				{Path: "Movie/movie.rar", Size: 100},
+				{Path: "Movie/Subs/subs.rar", Size: 1},
+			},
+			want: "Movie/movie.rar",
+		},
+		{
+			name: "part volumes",
+			files: []downloader.File{
+				{Path: "Movie/Movie.2020.part02.rar", Size: 100},
+				{Path: "Movie/Movie.2020.part01.rar", Size: 100},
+				{Path: "Movie/Movie.2020.nfo", Size: 1},
+			},
+			want: "Movie/Movie.2020.part01.rar",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := PickMainMovieFile(tt.files)
+			if !ok || got.Path != tt.want {
+				t.Errorf("PickMainMovieFile() = %q, %v; want %q", got.Path, ok, tt.want)
+			}
+		})
+	}
+}
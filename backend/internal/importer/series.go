@@ -2,10 +2,10 @@ package importer
 
 import (
 	"path/filepath"
-	"regexp"
-	"strconv"
 
+	"github.com/kyleaupton/arrflix/internal/archive"
 	"github.com/kyleaupton/arrflix/internal/downloader"
+	"github.com/kyleaupton/arrflix/internal/release"
 )
 
 // SeriesInfo contains parsed season and episode numbers.
@@ -14,73 +14,68 @@ type SeriesInfo struct {
 	Episodes []int
 }
 
-var (
-	// Standard S01E01 pattern
-	reStandard = regexp.MustCompile(`(?i)s(\d+)e(\d+)(?:-?e?(\d+))?`)
-	// 1x01 pattern
-	reAlternative = regexp.MustCompile(`(?i)(\d+)x(\d+)`)
-)
-
 // ParseSeriesInfo extracts season and episode information from a filename.
+// Season packs and names without episode numbers are not matched.
 func ParseSeriesInfo(filename string) (SeriesInfo, bool) {
-	// Try standard S01E01
-	if matches := reStandard.FindStringSubmatch(filename); len(matches) >= 3 {
-		season, _ := strconv.Atoi(matches[1])
-		ep1, _ := strconv.Atoi(matches[2])
-		info := SeriesInfo{Season: season, Episodes: []int{ep1}}
+	info := release.ParseTitleInfo(filename)
+	season, ok := info.Season()
+	if !ok || len(info.Episodes) == 0 {
+		return SeriesInfo{}, false
+	}
+	return SeriesInfo{Season: season, Episodes: info.Episodes}, true
+}
 
-		// Multi-episode S01E01E02 or S01E01-E02 or S01E01-02
-		if len(matches) >= 4 && matches[3] != "" {
-			ep2, _ := strconv.Atoi(matches[3])
-			// Add all episodes in range if it looks like a range, or just the second one
-			// For now, let's just add the second one.
-			if ep2 > ep1 && ep2-ep1 < 10 { // sanity check on range
-				for i := ep1 + 1; i <= ep2; i++ {
-					info.Episodes = append(info.Episodes, i)
-				}
-			} else {
-				info.Episodes = append(info.Episodes, ep2)
-			}
+// ResolveSeriesEpisodes returns every season and episode a filename refers
+// to. Absolute numbers (fansub releases) are mapped through abs; they are
+// dropped when abs is nil or doesn't know them.
+func ResolveSeriesEpisodes(filename string, abs AbsoluteEpisodeMap) []EpisodeNumber {
+	info := release.ParseTitleInfo(filename)
+	if season, ok := info.Season(); ok {
+		eps := make([]EpisodeNumber, 0, len(info.Episodes))
+		for _, ep := range info.Episodes {
+			eps = append(eps, EpisodeNumber{Season: season, Episode: ep})
 		}
-		return info, true
+		return eps
 	}
 
-	// Try 1x01
-	if matches := reAlternative.FindStringSubmatch(filename); len(matches) == 3 {
-		season, _ := strconv.Atoi(matches[1])
-		ep, _ := strconv.Atoi(matches[2])
-		return SeriesInfo{Season: season, Episodes: []int{ep}}, true
+	var eps []EpisodeNumber
+	for _, n := range info.AbsoluteEpisodes {
+		if ep, ok := abs.Resolve(n); ok {
+			eps = append(eps, ep)
+		}
 	}
-
-	return SeriesInfo{}, false
+	return eps
 }
 
 // MatchFilesToEpisodes matches downloader files to their corresponding episodes.
-func MatchFilesToEpisodes(files []downloader.File, targetSeason *int, targetEpisode *int) map[int]downloader.File {
+// abs maps absolute episode numbers and may be nil for series without one.
+// Packed episodes match through the first volume of their archive set; an
+// episode found both as a video and packed keeps the video.
+func MatchFilesToEpisodes(files []downloader.File, targetSeason *int, targetEpisode *int, abs AbsoluteEpisodeMap) map[int]downloader.File {
 	matched := make(map[int]downloader.File)
 
 	for _, f := range files {
-		if !IsVideoPath(f.Path) || LooksLikeSample(f.Path) {
+		packed := archive.IsFirstVolume(f.Path)
+		if !(IsVideoPath(f.Path) || packed) || LooksLikeSample(f.Path) {
 			continue
 		}
 
-		filename := filepath.Base(f.Path)
-		info, ok := ParseSeriesInfo(filename)
-		if !ok {
-			// If we are looking for a specific episode and this is the only large video file,
-			// it might be a renamed episode file.
-			continue
+		var episodes []int
+		for _, ep := range ResolveSeriesEpisodes(filepath.Base(f.Path), abs) {
+			// If a target season is specified, it must match.
+			if targetSeason != nil && ep.Season != *targetSeason {
+				continue
+			}
+			episodes = append(episodes, ep.Episode)
 		}
-
-		// If a target season is specified, it must match.
-		if targetSeason != nil && info.Season != *targetSeason {
+		if len(episodes) == 0 {
 			continue
 		}
 
 		// If a target episode is specified, it must be in the parsed episodes.
 		if targetEpisode != nil {
 			found := false
-			for _, ep := range info.Episodes {
+			for _, ep := range episodes {
 				if ep == *targetEpisode {
 					found = true
 					break
@@ -92,9 +87,17 @@ func MatchFilesToEpisodes(files []downloader.File, targetSeason *int, targetEpis
 		}
 
 		// Map each episode found in the file to this file.
-		for _, ep := range info.Episodes {
+		for _, ep := range episodes {
 			// If multiple files match the same episode, keep the largest one.
-			if existing, ok := matched[ep]; !ok || f.Size > existing.Size {
+			existing, ok := matched[ep]
+			switch {
+			case !ok:
+				matched[ep] = f
+			case packed != archive.IsFirstVolume(existing.Path):
+				if !packed {
+					matched[ep] = f
+				}
+			case f.Size > existing.Size:
 				matched[ep] = f
 			}
 		}
@@ -3,6 +3,8 @@ package importer
 import (
 	"reflect"
 	"testing"
+
+	"github.com/kyleaupton/arrflix/internal/downloader"
 )
 
 func TestParseSeriesInfo(t *testing.T) {
@@ -46,3 +48,87 @@ func TestParseSeriesInfo(t *testing.T) {
 	}
 }
 
+// Two 12-episode seasons followed by season 3
+var testAbsoluteMap = NewAbsoluteEpisodeMap([]EpisodeNumber{
+	{1, 1}, {1, 2}, {1, 3}, {1, 4}, {1, 5}, {1, 6}, {1, 7}, {1, 8}, {1, 9}, {1, 10}, {1, 11}, {1, 12},
+	{2, 1}, {2, 2}, {2, 3}, {2, 4}, {2, 5}, {2, 6}, {2, 7}, {2, 8}, {2, 9}, {2, 10}, {2, 11}, {2, 12},
+	{3, 1},
+})
+
+func TestResolveSeriesEpisodes(t *testing.T) {
+	tests := []struct {
+		filename string
+		abs      AbsoluteEpisodeMap
+		want     []EpisodeNumber
+	}{
+		{"Show.S02E03.1080p.mkv", testAbsoluteMap, []EpisodeNumber{{2, 3}}},
+		{"[SubsPlease] Show - 15 (1080p) [ABCD1234].mkv", testAbsoluteMap, []EpisodeNumber{{2, 3}}},
+		{"[Group] Show 12v2 [720p].mkv", testAbsoluteMap, []EpisodeNumber{{1, 12}}},
+		{"[Group] Show - 24-25 [1080p].mkv", testAbsoluteMap, []EpisodeNumber{{2, 12}, {3, 1}}},
+		{"[Group] Show - 99 [1080p].mkv", testAbsoluteMap, nil},
+		{"[SubsPlease] Show - 15 (1080p).mkv", nil, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.filename, func(t *testing.T) {
+			if got := ResolveSeriesEpisodes(tt.filename, tt.abs); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ResolveSeriesEpisodes() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMatchFilesToEpisodesAbsolute(t *testing.T) {
+	files := []downloader.File{
+		{Path: "Show Batch/[SubsPlease] Show - 12 (1080p) [AAAAAAAA].mkv", Size: 100},
+		{Path: "Show Batch/[SubsPlease] Show - 13 (1080p) [BBBBBBBB].mkv", Size: 100},
+		{Path: "Show Batch/[SubsPlease] Show - 14 (1080p) [CCCCCCCC].mkv", Size: 100},
+		{Path: "Show Batch/[SubsPlease] Show - 14v2 (1080p) [DDDDDDDD].mkv", Size: 200},
+		{Path: "Show Batch/[SubsPlease] Show - 14 (1080p) [CCCCCCCC].nfo", Size: 1},
+	}
+
+	season := 2
+	got := MatchFilesToEpisodes(files, &season, nil, testAbsoluteMap)
+	if len(got) != 2 {
+		t.Fatalf("matched %d episodes, want 2: %v", len(got), got)
+	}
+	if got[1].Path != files[1].Path {
+		t.Errorf("episode 1 = %q, want %q", got[1].Path, files[1].Path)
+	}
+	if got[2].Path != files[3].Path {
+		t.Errorf("episode 2 = %q, want the larger v2 file %q", got[2].Path, files[3].Path)
+	}
+
+	episode := 2
+	got = MatchFilesToEpisodes(files, &season, &episode, testAbsoluteMap)
+	if len(got) != 1 || got[2].Path != files[3].Path {
+		t.Errorf("single episode match = %v", got)
+	}
+
+	if got := MatchFilesToEpisodes(files, &season, nil, nil); len(got) != 0 {
+		t.Errorf("matched %v without an absolute map", got)
+	}
+}
+
+func TestMatchFilesToEpisodesPacked(t *testing.T) {
+	files := []downloader.File{
+		{Path: "Show.S01/Show.S01E01/Show.S01E01.720p.part01.rar", Size: 50},
+		{Path: "Show.S01/Show.S01E01/Show.S01E01.720p.part02.rar", Size: 50},
+		{Path: "Show.S01/Show.S01E02/Show.S01E02.720p.rar", Size: 50},
+		{Path: "Show.S01/Show.S01E02/Show.S01E02.720p.r00", Size: 50},
+		{Path: "Show.S01/Show.S01E03.720p.mkv", Size: 40},
+		{Path: "Show.S01/Show.S01E03.720p.rar", Size: 50},
+	}
+
+	season := 1
+	got := MatchFilesToEpisodes(files, &season, nil, nil)
+	want := map[int]string{1: files[0].Path, 2: files[2].Path, 3: files[4].Path}
+	if len(got) != len(want) {
+		t.Fatalf("matched %v, want %v", got, want)
+	}
+	for ep, path := range want {
+		if got[ep].Path != path {
+			t.Errorf("episode %d = %q, want %q", ep, got[ep].Path, path)
+		}
+	}
+}
@@ -0,0 +1,153 @@
+package importer
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"syscall"
+)
+
+// Mode is how a library takes in a file from a download.
+type Mode string
+
+const (
+	// ModeHardlink hardlinks the file, copying it when source and library
+	// are on different filesystems.
+	ModeHardlink Mode = "hardlink"
+	// ModeReflink clones the file copy-on-write (btrfs, XFS), copying it when
+	// the filesystem can't clone.
+	ModeReflink Mode = "reflink"
+	// ModeMove renames the file, copying and deleting it across filesystems.
+	ModeMove Mode = "move"
+	// ModeSymlink points the library file at the download.
+	ModeSymlink Mode = "symlink"
+	// ModeCopy always makes a full copy.
+	ModeCopy Mode = "copy"
+)
+
+// Modes lists the import modes in the order they are offered.
+var Modes = []Mode{ModeHardlink, ModeReflink, ModeMove, ModeSymlink, ModeCopy}
+
+// ParseMode returns the mode named s. An empty string is ModeHardlink.
+func ParseMode(s string) (Mode, error) {
+	if s == "" {
+		return ModeHardlink, nil
+	}
+	for _, m := range Modes {
+		if string(m) == s {
+			return m, nil
+		}
+	}
+	return "", fmt.Errorf("unknown import mode %q", s)
+}
+
+// Filesystem operations, replaced in tests to simulate a source on another
+// filesystem.
+var (
+	link   = os.Link
+	rename = os.Rename
+	clone  = cloneFile
+)
+
+// Transfer puts src at dst using mode, falling back to a copy where the mode
+// allows it. Returns the method that was used: the mode itself, or "copy"
+// after a fallback. A move across filesystems copies and keeps src; the
+// caller removes it once the copy is verified.
+func Transfer(src, dst string, mode Mode) (string, error) {
+	if err := os.MkdirAll(filepath.Dir(dst), 0o755); err != nil {
+		return "", fmt.Errorf("mkdir dest dir: %w", err)
+	}
+
+	switch mode {
+	case ModeHardlink:
+		if err := link(src, dst); err == nil {
+			return string(ModeHardlink), nil
+		}
+	case ModeReflink:
+		if err := atomicWrite(dst, func(out *os.File) error { return cloneFrom(out, src) }); err == nil {
+			return string(ModeReflink), nil
+		}
+	case ModeMove:
+		err := rename(src, dst)
+		if err == nil {
+			return string(ModeMove), nil
+		}
+		if !errors.Is(err, syscall.EXDEV) {
+			return "", fmt.Errorf("move: %w", err)
+		}
+		if err := copyFile(src, dst); err != nil {
+			return "", err
+		}
+		return string(ModeCopy), nil
+	case ModeSymlink:
+		abs, err := filepath.Abs(src)
+		if err != nil {
+			return "", fmt.Errorf("resolve src: %w", err)
+		}
+		if err := os.Symlink(abs, dst); err != nil {
+			return "", fmt.Errorf("symlink: %w", err)
+		}
+		return string(ModeSymlink), nil
+	case ModeCopy:
+	default:
+		return "", fmt.Errorf("unknown import mode %q", mode)
+	}
+
+	// Copy fallback
+	if err := copyFile(src, dst); err != nil {
+		return "", err
+	}
+	return string(ModeCopy), nil
+}
+
+func cloneFrom(out *os.File, src string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return fmt.Errorf("open src: %w", err)
+	}
+	defer in.Close()
+	return clone(out, in)
+}
+
+// copyFile copies src to dst byte for byte.
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return fmt.Errorf("open src: %w", err)
+	}
+	defer in.Close()
+
+	return atomicWrite(dst, func(out *os.File) error {
+		if _, err := io.Copy(out, in); err != nil {
+			return fmt.Errorf("copy: %w", err)
+		}
+		return nil
+	})
+}
+
+// atomicWrite fills a temporary file next to dst with write and renames it
+// into place, so a failed import never leaves a partial file at dst.
+func atomicWrite(dst string, write func(*os.File) error) error {
+	tmp := dst + ".tmp"
+	out, err := os.Create(tmp)
+	if err != nil {
+		return fmt.Errorf("create tmp: %w", err)
+	}
+	writeErr := write(out)
+	closeErr := out.Close()
+	if writeErr != nil {
+		_ = os.Remove(tmp)
+		return writeErr
+	}
+	if closeErr != nil {
+		_ = os.Remove(tmp)
+		return fmt.Errorf("close tmp: %w", closeErr)
+	}
+	if err := os.Rename(tmp, dst); err != nil {
+		_ = os.Remove(tmp)
+		return fmt.Errorf("rename tmp: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,103 @@
+package importer
+
+import (
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+)
+
+// crossDevice makes link, rename and clone fail the way they do when source
+// and destination are on different filesystems.
+func crossDevice(t *testing.T) {
+	t.Helper()
+	origLink, origRename, origClone := link, rename, clone
+	t.Cleanup(func() { link, rename, clone = origLink, origRename, origClone })
+
+	link = func(oldname, newname string) error {
+		return &os.LinkError{Op: "link", Old: oldname, New: newname, Err: syscall.EXDEV}
+	}
+	rename = func(oldname, newname string) error {
+		return &os.LinkError{Op: "rename", Old: oldname, New: newname, Err: syscall.EXDEV}
+	}
+	clone = func(dst, src *os.File) error { return syscall.EXDEV }
+}
+
+func TestTransfer(t *testing.T) {
+	tests := []struct {
+		name        string
+		mode        Mode
+		crossDevice bool
+		want        string
+		sourceGone  bool
+	}{
+		{name: "hardlink", mode: ModeHardlink, want: "hardlink"},
+		{name: "hardlink across filesystems", mode: ModeHardlink, crossDevice: true, want: "copy"},
+		{name: "reflink across filesystems", mode: ModeReflink, crossDevice: true, want: "copy"},
+		{name: "move", mode: ModeMove, want: "move", sourceGone: true},
+		{name: "move across filesystems", mode: ModeMove, crossDevice: true, want: "copy"},
+		{name: "symlink", mode: ModeSymlink, want: "symlink"},
+		{name: "copy", mode: ModeCopy, want: "copy"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.crossDevice {
+				crossDevice(t)
+			}
+			src := filepath.Join(t.TempDir(), "Movie.2020.1080p.WEB-DL.mkv")
+			if err := os.WriteFile(src, []byte("video"), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			dst := filepath.Join(t.TempDir(), "Movie (2020)", "Movie (2020).mkv")
+
+			got, err := Transfer(src, dst, tt.mode)
+			if err != nil {
+				t.Fatalf("Transfer() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Transfer() = %q, want %q", got, tt.want)
+			}
+			if b, err := os.ReadFile(dst); err != nil || string(b) != "video" {
+				t.Errorf("dest = %q, %v; want %q", b, err, "video")
+			}
+			if _, err := os.Stat(dst + ".tmp"); !os.IsNotExist(err) {
+				t.Errorf("temporary file left behind, err = %v", err)
+			}
+			if _, err := os.Stat(src); tt.sourceGone != os.IsNotExist(err) {
+				t.Errorf("source exists = %v, want %v", err == nil, !tt.sourceGone)
+			}
+		})
+	}
+}
+
+func TestTransferReflink(t *testing.T) {
+	src := filepath.Join(t.TempDir(), "a.mkv")
+	if err := os.WriteFile(src, []byte("video"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	dst := filepath.Join(t.TempDir(), "b.mkv")
+
+	// The temp filesystem may or may not support clones, either result is fine
+	got, err := Transfer(src, dst, ModeReflink)
+	if err != nil {
+		t.Fatalf("Transfer() error = %v", err)
+	}
+	if got != "reflink" && got != "copy" {
+		t.Errorf("Transfer() = %q, want reflink or copy", got)
+	}
+	if b, err := os.ReadFile(dst); err != nil || string(b) != "video" {
+		t.Errorf("dest = %q, %v; want %q", b, err, "video")
+	}
+}
+
+func TestParseMode(t *testing.T) {
+	if m, err := ParseMode(""); err != nil || m != ModeHardlink {
+		t.Errorf("ParseMode(\"\") = %q, %v; want hardlink", m, err)
+	}
+	if m, err := ParseMode("reflink"); err != nil || m != ModeReflink {
+		t.Errorf("ParseMode(reflink) = %q, %v; want reflink", m, err)
+	}
+	if _, err := ParseMode("teleport"); err == nil {
+		t.Error("ParseMode(teleport) error = nil, want error")
+	}
+}
@@ -0,0 +1,136 @@
+package importer
+
+import (
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/kyleaupton/arrflix/internal/archive"
+	"github.com/kyleaupton/arrflix/internal/model"
+	"github.com/kyleaupton/arrflix/internal/qualityprofile"
+	"github.com/kyleaupton/arrflix/internal/release"
+)
+
+// ErrVerification marks a file that is not what its release claims to be:
+// an unreadable or renamed file, a fake, a truncated download or a bad copy.
+var ErrVerification = errors.New("import verification failed")
+
+// ErrNoVideo marks a download or archive without a single video file in it.
+var ErrNoVideo = errors.New("no video files found")
+
+// ReleaseFault reports whether err is the release's own fault, so another
+// release of the same item would import where this one didn't. Filesystem and
+// library errors, and an episode missing from a season pack, aren't: the
+// release stays usable.
+func ReleaseFault(err error) bool {
+	return errors.Is(err, ErrVerification) ||
+		errors.Is(err, archive.ErrPassword) ||
+		errors.Is(err, archive.ErrCorrupt) ||
+		errors.Is(err, ErrNoVideo)
+}
+
+// minDurationRatio is the share of the TMDB runtime a video must run for.
+// Theatrical cuts and TMDB's rounded episode runtimes stay well above it;
+// samples, truncated files and 20-minute fakes don't.
+const minDurationRatio = 0.5
+
+// Expectation is what a release promises about its video.
+type Expectation struct {
+	// RuntimeMinutes is the movie's or episode's TMDB runtime, zero when unknown
+	RuntimeMinutes int
+	// Quality is parsed from the release name
+	Quality release.Quality
+}
+
+// VerifyMedia checks the mediainfo read from a video against its release. mi
+// is nil when mediainfo could not read the file at all. Checks without data
+// to go on (no runtime, no duration, no resolution in the name) pass.
+func VerifyMedia(mi *model.MediaInfoFields, want Expectation) error {
+	if mi == nil || (mi.Width == 0 && mi.Height == 0) {
+		return fmt.Errorf("%w: no readable video stream", ErrVerification)
+	}
+
+	if want.RuntimeMinutes > 0 && mi.Duration > 0 {
+		expected := time.Duration(want.RuntimeMinutes) * time.Minute
+		got := time.Duration(mi.Duration) * time.Second
+		if float64(got) < float64(expected)*minDurationRatio {
+			return fmt.Errorf("%w: video runs %s, expected about %s", ErrVerification, got, expected)
+		}
+	}
+
+	// A file above its release's resolution is harmless, one below is a fake
+	claimed := resolutionTier(want.Quality.Resolution())
+	actual := resolutionTier(qualityprofile.ResolutionQuality(mi.Width, mi.Height).Resolution())
+	if claimed > 0 && actual > 0 && actual < claimed {
+		return fmt.Errorf("%w: %dx%d video in a %s release", ErrVerification, mi.Width, mi.Height, want.Quality.Resolution())
+	}
+	return nil
+}
+
+// resolutionTier groups resolutions the way ResolutionQuality reads them from
+// a video's dimensions: SD, 720p, 1080p and 2160p. Unknown is zero.
+func resolutionTier(res string) int {
+	switch release.Resolution(res) {
+	case release.ResSD, release.Res480p, release.Res576p:
+		return 1
+	case release.Res720p:
+		return 2
+	case release.Res1080p, release.Res1440p:
+		return 3
+	case release.Res2160p, release.Res4320p:
+		return 4
+	}
+	return 0
+}
+
+// VerifyCopy checks that dst is a complete copy of src: same size and, with
+// checksum set, the same SHA-256. Mismatches wrap ErrVerification; other
+// errors are I/O failures.
+func VerifyCopy(src, dst string, checksum bool) error {
+	srcInfo, err := os.Stat(src)
+	if err != nil {
+		return fmt.Errorf("stat src: %w", err)
+	}
+	dstInfo, err := os.Stat(dst)
+	if err != nil {
+		return fmt.Errorf("stat dest: %w", err)
+	}
+	if srcInfo.Size() != dstInfo.Size() {
+		return fmt.Errorf("%w: copy is %d bytes, source is %d", ErrVerification, dstInfo.Size(), srcInfo.Size())
+	}
+	if !checksum {
+		return nil
+	}
+
+	srcSum, err := sha256File(src)
+	if err != nil {
+		return err
+	}
+	dstSum, err := sha256File(dst)
+	if err != nil {
+		return err
+	}
+	if srcSum != dstSum {
+		return fmt.Errorf("%w: copy checksum %x does not match source %x", ErrVerification, dstSum, srcSum)
+	}
+	return nil
+}
+
+func sha256File(path string) ([sha256.Size]byte, error) {
+	var sum [sha256.Size]byte
+	f, err := os.Open(path)
+	if err != nil {
+		return sum, fmt.Errorf("open %s: %w", path, err)
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return sum, fmt.Errorf("hash %s: %w", path, err)
+	}
+	copy(sum[:], h.Sum(nil))
+	return sum, nil
+}
@@ -0,0 +1,107 @@
+package importer
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kyleaupton/arrflix/internal/archive"
+	apperrors "github.com/kyleaupton/arrflix/internal/errors"
+	"github.com/kyleaupton/arrflix/internal/model"
+	"github.com/kyleaupton/arrflix/internal/release"
+)
+
+func TestVerifyMedia(t *testing.T) {
+	video := func(width, height int, seconds int64) *model.MediaInfoFields {
+		return &model.MediaInfoFields{Width: width, Height: height, Duration: seconds}
+	}
+	tests := []struct {
+		name    string
+		mi      *model.MediaInfoFields
+		want    Expectation
+		wantErr bool
+	}{
+		{name: "unreadable", mi: nil, wantErr: true},
+		{name: "no video stream", mi: video(0, 0, 0), wantErr: true},
+		{name: "matches", mi: video(1920, 1080, 118*60), want: Expectation{RuntimeMinutes: 120, Quality: release.WEBDL1080p}},
+		{name: "20 minute fake", mi: video(1920, 1080, 20*60), want: Expectation{RuntimeMinutes: 120, Quality: release.WEBDL1080p}, wantErr: true},
+		{name: "episode under TMDB runtime", mi: video(1280, 720, 21*60), want: Expectation{RuntimeMinutes: 30, Quality: release.HDTV720p}},
+		{name: "unknown runtime", mi: video(1920, 1080, 60), want: Expectation{Quality: release.WEBDL1080p}},
+		{name: "no duration", mi: video(1920, 1080, 0), want: Expectation{RuntimeMinutes: 120}},
+		{name: "widescreen 1080p", mi: video(1920, 800, 118*60), want: Expectation{RuntimeMinutes: 120, Quality: release.Bluray1080p}},
+		{name: "fake 2160p", mi: video(1920, 1080, 118*60), want: Expectation{RuntimeMinutes: 120, Quality: release.WEBDL2160p}, wantErr: true},
+		{name: "better than claimed", mi: video(1920, 1080, 44*60), want: Expectation{RuntimeMinutes: 45, Quality: release.HDTV720p}},
+		{name: "unknown quality", mi: video(720, 480, 44*60), want: Expectation{RuntimeMinutes: 45}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := VerifyMedia(tt.mi, tt.want)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("VerifyMedia() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, ErrVerification) {
+				t.Errorf("VerifyMedia() error = %v, want ErrVerification", err)
+			}
+		})
+	}
+}
+
+func TestVerifyCopy(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		p := filepath.Join(dir, name)
+		if err := os.WriteFile(p, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return p
+	}
+	src := write("src.mkv", "video")
+
+	tests := []struct {
+		name     string
+		dst      string
+		checksum bool
+		wantErr  bool
+	}{
+		{name: "identical", dst: write("same.mkv", "video"), checksum: true},
+		{name: "truncated", dst: write("short.mkv", "vid"), wantErr: true},
+		{name: "same size without checksum", dst: write("flipped.mkv", "vidoe")},
+		{name: "same size with checksum", dst: write("flipped2.mkv", "vidoe"), checksum: true, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := VerifyCopy(src, tt.dst, tt.checksum)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("VerifyCopy() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, ErrVerification) {
+				t.Errorf("VerifyCopy() error = %v, want ErrVerification", err)
+			}
+		})
+	}
+}
+
+func TestReleaseFault(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "verification", err: apperrors.AsPermanent(fmt.Errorf("%w: no readable video stream", ErrVerification)), want: true},
+		{name: "archive password", err: apperrors.AsPermanent(fmt.Errorf("extract a.rar: %w", archive.ErrPassword)), want: true},
+		{name: "corrupt archive", err: apperrors.AsPermanent(fmt.Errorf("extract a.rar: %w", archive.ErrCorrupt)), want: true},
+		{name: "no video", err: apperrors.AsPermanent(fmt.Errorf("%w in download", ErrNoVideo)), want: true},
+		{name: "destination exists", err: apperrors.AsPermanent(errors.New("destination already exists: /lib/a.mkv"))},
+		{name: "permission denied", err: fmt.Errorf("link: %w", os.ErrPermission)},
+		{name: "episode missing from pack", err: apperrors.AsPermanent(errors.New("no file matched episode S01E05"))},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ReleaseFault(tt.err); got != tt.want {
+				t.Errorf("ReleaseFault() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,329 @@
+package indexer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+
+	"github.com/kyleaupton/arrflix/internal/logger"
+)
+
+// DefaultSourceTimeout bounds how long a single source may take to answer a search.
+const DefaultSourceTimeout = 30 * time.Second
+
+// NamedSource pairs an IndexerSource with the name used in search reports.
+type NamedSource struct {
+	Name    string
+	Source  IndexerSource
+	Timeout time.Duration // zero uses the composite default
+}
+
+// SourceReport describes how a single source performed during a search.
+type SourceReport struct {
+	Name        string
+	Duration    time.Duration
+	ResultCount int
+	Err         error
+}
+
+// SearchReport is the outcome of a fan-out search: deduplicated results plus
+// per-source timings and errors. Indexers is filled by sources that can
+// break a search down by the indexers behind them.
+type SearchReport struct {
+	Results  []SearchResult
+	Sources  []SourceReport
+	Indexers []IndexerReport
+}
+
+// ReportingSource is implemented by sources that can describe how each
+// underlying backend performed during a search.
+type ReportingSource interface {
+	IndexerSource
+
+	// SearchWithReport performs a search and returns per-source details.
+	// An error is returned only when no source produced results.
+	SearchWithReport(ctx context.Context, query SearchQuery) (SearchReport, error)
+}
+
+// CompositeSource fans searches out to several sources concurrently and
+// merges their results. A failing or slow source does not fail the search.
+type CompositeSource struct {
+	sources []NamedSource
+	timeout time.Duration
+	logger  *logger.Logger
+}
+
+var _ ReportingSource = (*CompositeSource)(nil)
+
+// NewComposite creates a CompositeSource over the given sources.
+func NewComposite(logger *logger.Logger, timeout time.Duration, sources ...NamedSource) *CompositeSource {
+	if timeout <= 0 {
+		timeout = DefaultSourceTimeout
+	}
+	return &CompositeSource{
+		sources: sources,
+		timeout: timeout,
+		logger:  logger,
+	}
+}
+
+// Search queries all sources and returns deduplicated results.
+func (c *CompositeSource) Search(ctx context.Context, query SearchQuery) ([]SearchResult, error) {
+	report, err := c.SearchWithReport(ctx, query)
+	return report.Results, err
+}
+
+// SearchWithReport queries all sources concurrently, each bounded by its own
+// timeout, and merges their results.
+func (c *CompositeSource) SearchWithReport(ctx context.Context, query SearchQuery) (SearchReport, error) {
+	reports := make([]SourceReport, len(c.sources))
+	results := make([][]SearchResult, len(c.sources))
+	indexers := make([][]IndexerReport, len(c.sources))
+
+	var wg sync.WaitGroup
+	for i, src := range c.sources {
+		wg.Add(1)
+		go func(i int, src NamedSource) {
+			defer wg.Done()
+
+			timeout := src.Timeout
+			if timeout <= 0 {
+				timeout = c.timeout
+			}
+			sctx, cancel := context.WithTimeout(ctx, timeout)
+			defer cancel()
+
+			start := time.Now()
+			var res []SearchResult
+			var err error
+			if rs, ok := src.Source.(ReportingSource); ok {
+				var sub SearchReport
+				sub, err = rs.SearchWithReport(sctx, query)
+				res, indexers[i] = sub.Results, sub.Indexers
+			} else {
+				res, err = src.Source.Search(sctx, query)
+			}
+			if err == nil && sctx.Err() != nil {
+				err = sctx.Err()
+			}
+			if errors.Is(err, context.DeadlineExceeded) {
+				err = fmt.Errorf("timed out after %s: %w", timeout, err)
+			}
+
+			reports[i] = SourceReport{
+				Name:        src.Name,
+				Duration:    time.Since(start),
+				ResultCount: len(res),
+				Err:         err,
+			}
+			results[i] = res
+		}(i, src)
+	}
+	wg.Wait()
+
+	var all []SearchResult
+	var errs []error
+	var indexerReports []IndexerReport
+	for i, r := range reports {
+		indexerReports = append(indexerReports, indexers[i]...)
+		if r.Err != nil {
+			c.logger.Warn().
+				Str("source", r.Name).
+				Dur("duration", r.Duration).
+				Err(r.Err).
+				Msg("Indexer source search failed")
+			errs = append(errs, fmt.Errorf("%s: %w", r.Name, r.Err))
+			continue
+		}
+		all = append(all, results[i]...)
+	}
+
+	report := SearchReport{
+		Results:  Dedupe(all),
+		Sources:  reports,
+		Indexers: indexerReports,
+	}
+
+	c.logger.Debug().
+		Str("query", query.Query).
+		Int("sources", len(c.sources)).
+		Int("failed", len(errs)).
+		Int("raw_count", len(all)).
+		Int("deduped_count", len(report.Results)).
+		Msg("Composite search completed")
+
+	if len(errs) > 0 && len(errs) == len(c.sources) {
+		return report, errors.Join(errs...)
+	}
+	return report, nil
+}
+
+// ListIndexers returns the indexers of every reachable source.
+func (c *CompositeSource) ListIndexers(ctx context.Context) ([]IndexerInfo, error) {
+	var all []IndexerInfo
+	var errs []error
+	for _, src := range c.sources {
+		infos, err := src.Source.ListIndexers(ctx)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", src.Name, err))
+			continue
+		}
+		all = append(all, infos...)
+	}
+	if len(errs) > 0 && len(errs) == len(c.sources) {
+		return nil, errors.Join(errs...)
+	}
+	return all, nil
+}
+
+// Test verifies connectivity to every source.
+func (c *CompositeSource) Test(ctx context.Context) error {
+	var errs []error
+	for _, src := range c.sources {
+		if err := src.Source.Test(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", src.Name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Dedupe collapses results describing the same release. Two results match when
+// they share an info hash, or when their normalized titles and sizes are equal.
+// Results are returned in first-seen order with metadata merged from duplicates.
+func Dedupe(results []SearchResult) []SearchResult {
+	out := make([]SearchResult, 0, len(results))
+	index := make(map[string]int, len(results)*2)
+
+	for _, r := range results {
+		keys := dedupeKeys(r)
+
+		pos := -1
+		for _, k := range keys {
+			if i, ok := index[k]; ok {
+				pos = i
+				break
+			}
+		}
+
+		if pos < 0 {
+			pos = len(out)
+			out = append(out, r)
+		} else {
+			out[pos] = mergeResults(out[pos], r)
+		}
+
+		for _, k := range dedupeKeys(out[pos]) {
+			index[k] = pos
+		}
+		for _, k := range keys {
+			index[k] = pos
+		}
+	}
+
+	return out
+}
+
+// dedupeKeys returns the identity keys for a result, strongest first.
+func dedupeKeys(r SearchResult) []string {
+	keys := make([]string, 0, 3)
+	if r.InfoHash != "" {
+		keys = append(keys, "hash:"+strings.ToLower(r.InfoHash))
+	}
+	// The same release from the same indexer, e.g. found by two title queries
+	if r.GUID != "" {
+		keys = append(keys, fmt.Sprintf("guid:%d:%s", r.IndexerID, r.GUID))
+	}
+	if title := NormalizeTitle(r.Title); title != "" && r.Size > 0 {
+		keys = append(keys, fmt.Sprintf("title:%s:%d", title, r.Size))
+	}
+	return keys
+}
+
+// NormalizeTitle lowercases a release title, strips diacritics and collapses
+// punctuation and separators so that "Movie.Name.2020" and "Movie Name (2020)"
+// compare equal, as do "Amélie" and "Amelie".
+func NormalizeTitle(title string) string {
+	var b strings.Builder
+	space := false
+	for _, r := range norm.NFD.String(strings.ToLower(title)) {
+		if unicode.Is(unicode.Mn, r) {
+			continue
+		}
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			if space && b.Len() > 0 {
+				b.WriteByte(' ')
+			}
+			b.WriteRune(r)
+			space = false
+			continue
+		}
+		space = true
+	}
+	return b.String()
+}
+
+// mergeResults keeps the richer of two duplicate results and fills any gaps
+// in it from the other.
+func mergeResults(a, b SearchResult) SearchResult {
+	base, other := a, b
+	if richness(b) > richness(a) {
+		base, other = b, a
+	}
+
+	if base.InfoHash == "" {
+		base.InfoHash = other.InfoHash
+	}
+	if base.Size == 0 {
+		base.Size = other.Size
+	}
+	if base.Seeders == nil || (other.Seeders != nil && *other.Seeders > *base.Seeders) {
+		base.Seeders = other.Seeders
+	}
+	if base.Leechers == nil || (other.Leechers != nil && *other.Leechers > *base.Leechers) {
+		base.Leechers = other.Leechers
+	}
+	if base.PublishDate.IsZero() {
+		base.PublishDate = other.PublishDate
+		base.Age = other.Age
+		base.AgeHours = other.AgeHours
+	}
+	if other.Grabs > base.Grabs {
+		base.Grabs = other.Grabs
+	}
+	if len(base.Categories) == 0 {
+		base.Categories = other.Categories
+	}
+
+	return base
+}
+
+// richness scores how much metadata a result carries.
+func richness(r SearchResult) int {
+	score := 0
+	if r.InfoHash != "" {
+		score++
+	}
+	if r.Size > 0 {
+		score++
+	}
+	if r.Seeders != nil {
+		score++
+	}
+	if r.Leechers != nil {
+		score++
+	}
+	if !r.PublishDate.IsZero() {
+		score++
+	}
+	if r.Grabs > 0 {
+		score++
+	}
+	score += len(r.Categories)
+	return score
+}
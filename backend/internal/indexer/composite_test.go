@@ -0,0 +1,194 @@
+package indexer
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+type fakeSource struct {
+	results []SearchResult
+	err     error
+	delay   time.Duration
+}
+
+func (f *fakeSource) Search(ctx context.Context, query SearchQuery) ([]SearchResult, error) {
+	if f.delay > 0 {
+		select {
+		case <-time.After(f.delay):
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	return f.results, f.err
+}
+
+func (f *fakeSource) ListIndexers(ctx context.Context) ([]IndexerInfo, error) { return nil, f.err }
+
+func (f *fakeSource) Test(ctx context.Context) error { return f.err }
+
+func intPtr(i int) *int { return &i }
+
+func TestNormalizeTitle(t *testing.T) {
+	tests := []struct {
+		title string
+		want  string
+	}{
+		{"Movie.Name.2020.1080p", "movie name 2020 1080p"},
+		{"Movie Name (2020) [1080p]", "movie name 2020 1080p"},
+		{"  Movie_Name-2020  ", "movie name 2020"},
+		{"Amélie", "amelie"},
+		{"Pokémon: Mewtwo Strikes Back", "pokemon mewtwo strikes back"},
+		{"千と千尋の神隠し", "千と千尋の神隠し"},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			if got := NormalizeTitle(tt.title); got != tt.want {
+				t.Errorf("NormalizeTitle(%q) = %q, want %q", tt.title, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDedupe(t *testing.T) {
+	tests := []struct {
+		name      string
+		results   []SearchResult
+		wantCount int
+		check     func(t *testing.T, got []SearchResult)
+	}{
+		{
+			name: "same info hash different case",
+			results: []SearchResult{
+				{IndexerID: 1, GUID: "a", Title: "Movie.2020.1080p", InfoHash: "ABCDEF", Size: 100},
+				{IndexerID: 2, GUID: "b", Title: "Movie 2020 1080p WEB", InfoHash: "abcdef", Size: 200},
+			},
+			wantCount: 1,
+		},
+		{
+			name: "normalized title and size",
+			results: []SearchResult{
+				{IndexerID: 1, GUID: "a", Title: "Movie.2020.1080p", Size: 100},
+				{IndexerID: 2, GUID: "b", Title: "Movie 2020 1080p", Size: 100},
+			},
+			wantCount: 1,
+		},
+		{
+			name: "same title different size",
+			results: []SearchResult{
+				{IndexerID: 1, GUID: "a", Title: "Movie.2020.1080p", Size: 100},
+				{IndexerID: 2, GUID: "b", Title: "Movie.2020.1080p", Size: 101},
+			},
+			wantCount: 2,
+		},
+		{
+			name: "keeps richest metadata",
+			results: []SearchResult{
+				{IndexerID: 1, GUID: "a", Title: "Movie.2020.1080p", Size: 100},
+				{IndexerID: 2, GUID: "b", Title: "Movie 2020 1080p", Size: 100, InfoHash: "abc", Seeders: intPtr(5), Categories: []string{"Movies/HD"}},
+				{IndexerID: 3, GUID: "c", Title: "Movie 2020 1080p", Size: 100, Seeders: intPtr(9), Grabs: 4},
+			},
+			wantCount: 1,
+			check: func(t *testing.T, got []SearchResult) {
+				r := got[0]
+				if r.IndexerID != 2 {
+					t.Errorf("IndexerID = %d, want 2", r.IndexerID)
+				}
+				if r.Seeders == nil || *r.Seeders != 9 {
+					t.Errorf("Seeders = %v, want 9", r.Seeders)
+				}
+				if r.Grabs != 4 {
+					t.Errorf("Grabs = %d, want 4", r.Grabs)
+				}
+			},
+		},
+		{
+			name: "same indexer and guid without hash or size",
+			results: []SearchResult{
+				{IndexerID: 1, GUID: "a", Title: "Movie.2020.1080p"},
+				{IndexerID: 1, GUID: "a", Title: "Movie.2020.1080p"},
+				{IndexerID: 2, GUID: "a", Title: "Movie.2020.1080p"},
+			},
+			wantCount: 2,
+		},
+		{
+			name: "hash links title match",
+			results: []SearchResult{
+				{IndexerID: 1, GUID: "a", Title: "Movie.2020.1080p", Size: 100, InfoHash: "abc"},
+				{IndexerID: 2, GUID: "b", Title: "Other Name", Size: 300, InfoHash: "abc"},
+				{IndexerID: 3, GUID: "c", Title: "Other Name", Size: 300},
+			},
+			wantCount: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Dedupe(tt.results)
+			if len(got) != tt.wantCount {
+				t.Fatalf("Dedupe() returned %d results, want %d", len(got), tt.wantCount)
+			}
+			if tt.check != nil {
+				tt.check(t, got)
+			}
+		})
+	}
+}
+
+func TestCompositeSearchWithReport(t *testing.T) {
+	log := zerolog.Nop()
+
+	ok := &fakeSource{results: []SearchResult{
+		{IndexerID: 1, GUID: "a", Title: "Movie.2020", Size: 100},
+	}}
+	dup := &fakeSource{results: []SearchResult{
+		{IndexerID: 2, GUID: "b", Title: "Movie 2020", Size: 100},
+		{IndexerID: 2, GUID: "c", Title: "Movie 2020 REPACK", Size: 100},
+	}}
+	failing := &fakeSource{err: errors.New("boom")}
+	slow := &fakeSource{delay: time.Second}
+
+	c := NewComposite(&log, time.Second,
+		NamedSource{Name: "ok", Source: ok},
+		NamedSource{Name: "dup", Source: dup},
+		NamedSource{Name: "failing", Source: failing},
+		NamedSource{Name: "slow", Source: slow, Timeout: 20 * time.Millisecond},
+	)
+
+	report, err := c.SearchWithReport(context.Background(), SearchQuery{Query: "movie"})
+	if err != nil {
+		t.Fatalf("SearchWithReport() error = %v", err)
+	}
+	if len(report.Results) != 2 {
+		t.Errorf("got %d results, want 2", len(report.Results))
+	}
+	if len(report.Sources) != 4 {
+		t.Fatalf("got %d source reports, want 4", len(report.Sources))
+	}
+	if report.Sources[1].ResultCount != 2 {
+		t.Errorf("dup ResultCount = %d, want 2", report.Sources[1].ResultCount)
+	}
+	if report.Sources[2].Err == nil {
+		t.Error("expected error for failing source")
+	}
+	if !errors.Is(report.Sources[3].Err, context.DeadlineExceeded) {
+		t.Errorf("slow source error = %v, want deadline exceeded", report.Sources[3].Err)
+	}
+}
+
+func TestCompositeAllSourcesFail(t *testing.T) {
+	log := zerolog.Nop()
+	c := NewComposite(&log, time.Second,
+		NamedSource{Name: "a", Source: &fakeSource{err: errors.New("a down")}},
+		NamedSource{Name: "b", Source: &fakeSource{err: errors.New("b down")}},
+	)
+
+	if _, err := c.Search(context.Background(), SearchQuery{Query: "movie"}); err == nil {
+		t.Error("expected error when every source fails")
+	}
+}
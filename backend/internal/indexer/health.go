@@ -0,0 +1,67 @@
+package indexer
+
+import "time"
+
+const (
+	// BackoffThreshold is the number of consecutive failures after which an
+	// indexer is temporarily disabled.
+	BackoffThreshold = 3
+
+	// BackoffBase is how long an indexer is disabled on reaching the threshold.
+	// Each further failure doubles it, up to BackoffMax.
+	BackoffBase = 5 * time.Minute
+	BackoffMax  = 24 * time.Hour
+)
+
+// IndexerReport describes how a single indexer behind a source performed
+// during a search.
+type IndexerReport struct {
+	IndexerID   int64
+	IndexerName string
+	Duration    time.Duration
+	ResultCount int
+	Err         error
+}
+
+// Backoff returns how long an indexer should be disabled after the given
+// number of consecutive failures. Zero means the indexer stays enabled.
+func Backoff(consecutiveFailures int) time.Duration {
+	if consecutiveFailures < BackoffThreshold {
+		return 0
+	}
+	d := BackoffBase
+	for i := BackoffThreshold; i < consecutiveFailures; i++ {
+		d *= 2
+		if d >= BackoffMax {
+			return BackoffMax
+		}
+	}
+	return d
+}
+
+// MergeReports combines the reports of several queries made for one search,
+// one entry per indexer in first-seen order. An indexer only counts as failed
+// when every query to it failed, so a search made under several titles
+// records one outcome per indexer. Queries run concurrently, so the slowest
+// duration is kept.
+func MergeReports(reports ...[]IndexerReport) []IndexerReport {
+	var out []IndexerReport
+	index := make(map[int64]int)
+	for _, rs := range reports {
+		for _, r := range rs {
+			i, ok := index[r.IndexerID]
+			if !ok {
+				index[r.IndexerID] = len(out)
+				out = append(out, r)
+				continue
+			}
+			m := &out[i]
+			m.Duration = max(m.Duration, r.Duration)
+			m.ResultCount += r.ResultCount
+			if r.Err == nil {
+				m.Err = nil
+			}
+		}
+	}
+	return out
+}
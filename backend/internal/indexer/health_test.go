@@ -0,0 +1,58 @@
+package indexer
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestBackoff(t *testing.T) {
+	tests := []struct {
+		failures int
+		want     time.Duration
+	}{
+		{0, 0},
+		{1, 0},
+		{BackoffThreshold - 1, 0},
+		{BackoffThreshold, 5 * time.Minute},
+		{BackoffThreshold + 1, 10 * time.Minute},
+		{BackoffThreshold + 2, 20 * time.Minute},
+		{BackoffThreshold + 8, 1280 * time.Minute},
+		{BackoffThreshold + 9, 24 * time.Hour},
+		{BackoffThreshold + 100, 24 * time.Hour},
+	}
+
+	for _, tt := range tests {
+		if got := Backoff(tt.failures); got != tt.want {
+			t.Errorf("Backoff(%d) = %s, want %s", tt.failures, got, tt.want)
+		}
+	}
+}
+
+func TestMergeReports(t *testing.T) {
+	failure := errors.New("timeout")
+	primary := []IndexerReport{
+		{IndexerID: 1, IndexerName: "A", Duration: time.Second, ResultCount: 5},
+		{IndexerID: 2, IndexerName: "B", Duration: 3 * time.Second, Err: failure},
+		{IndexerID: 3, IndexerName: "C", Duration: time.Second, Err: failure},
+	}
+	alias := []IndexerReport{
+		{IndexerID: 1, IndexerName: "A", Duration: 2 * time.Second, ResultCount: 2},
+		{IndexerID: 2, IndexerName: "B", Duration: time.Second, ResultCount: 1},
+		{IndexerID: 3, IndexerName: "C", Duration: time.Second, Err: failure},
+	}
+
+	got := MergeReports(primary, alias)
+	if len(got) != 3 {
+		t.Fatalf("MergeReports() returned %d reports, want 3", len(got))
+	}
+	if got[0].ResultCount != 7 || got[0].Duration != 2*time.Second || got[0].Err != nil {
+		t.Errorf("indexer 1 = %+v, want 7 results in 2s without error", got[0])
+	}
+	if got[1].Err != nil || got[1].Duration != 3*time.Second {
+		t.Errorf("indexer 2 = %+v, want success in 3s: one of its queries succeeded", got[1])
+	}
+	if got[2].Err == nil {
+		t.Errorf("indexer 3 = %+v, want failure: every query failed", got[2])
+	}
+}
@@ -2,8 +2,11 @@ package prowlarr
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
+	"sync"
+	"time"
 
 	"golift.io/starr/prowlarr"
 
@@ -11,10 +14,18 @@ import (
 	"github.com/kyleaupton/arrflix/internal/logger"
 )
 
+// indexerListTTL is how long searches reuse the indexer list, so the
+// concurrent title queries of one search list Prowlarr's indexers once.
+const indexerListTTL = 30 * time.Second
+
 // ProwlarrSource implements IndexerSource using Prowlarr as the backend.
 type ProwlarrSource struct {
 	client *prowlarr.Prowlarr
 	logger *logger.Logger
+
+	mu         sync.Mutex
+	indexers   []indexer.IndexerInfo
+	indexersAt time.Time
 }
 
 // New creates a new ProwlarrSource.
@@ -25,8 +36,175 @@ func New(client *prowlarr.Prowlarr, logger *logger.Logger) *ProwlarrSource {
 	}
 }
 
+var _ indexer.ReportingSource = (*ProwlarrSource)(nil)
+
 // Search performs a search query against Prowlarr and returns validated results.
 func (p *ProwlarrSource) Search(ctx context.Context, query indexer.SearchQuery) ([]indexer.SearchResult, error) {
+	report, err := p.SearchWithReport(ctx, query)
+	return report.Results, err
+}
+
+// SearchWithReport searches each enabled indexer separately so latency, errors
+// and result counts can be attributed to individual indexers. Indexers listed
+// in query.ExcludeIndexerIDs are skipped. If the indexer list can't be loaded,
+// a single search across all indexers is made instead.
+func (p *ProwlarrSource) SearchWithReport(ctx context.Context, query indexer.SearchQuery) (indexer.SearchReport, error) {
+	infos, err := p.searchIndexerList(ctx)
+	if err != nil {
+		p.logger.Warn().Err(err).Msg("Failed to list Prowlarr indexers, searching all indexers at once")
+		results, err := p.searchAll(ctx, query)
+		return indexer.SearchReport{Results: results}, err
+	}
+
+	excluded := make(map[int64]bool, len(query.ExcludeIndexerIDs))
+	for _, id := range query.ExcludeIndexerIDs {
+		excluded[id] = true
+	}
+	targets := make([]indexer.IndexerInfo, 0, len(infos))
+	for _, info := range infos {
+		if !info.Enabled {
+			continue
+		}
+		if excluded[info.ID] {
+			p.logger.Debug().Int64("indexer_id", info.ID).Str("indexer", info.Name).Msg("Skipping backed-off indexer")
+			continue
+		}
+		targets = append(targets, info)
+	}
+
+	// Queries carrying external IDs use Prowlarr's typed movie/tvsearch first.
+	// Only when no indexer finds anything by ID is the text search run.
+	var reports []indexer.IndexerReport
+	var results [][]indexer.SearchResult
+	if query.HasIDs() {
+		reports, results = p.searchEach(ctx, query, targets, idSearchInput(query))
+		if countResults(results) == 0 {
+			p.logger.Debug().Str("query", query.Query).Msg("Prowlarr ID search returned no results, falling back to text search")
+			textReports, textResults := p.searchEach(ctx, query, targets, textSearchInput(query))
+			reports, results = withIDSearch(textReports, reports), textResults
+		}
+	} else {
+		reports, results = p.searchEach(ctx, query, targets, textSearchInput(query))
+	}
+
+	var all []indexer.SearchResult
+	var errs []error
+	for i, r := range reports {
+		if r.Err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", r.IndexerName, r.Err))
+		}
+		all = append(all, results[i]...)
+	}
+
+	report := indexer.SearchReport{Results: all, Indexers: reports}
+	if len(all) == 0 && len(errs) > 0 && len(errs) == len(targets) {
+		return report, errors.Join(errs...)
+	}
+	return report, nil
+}
+
+// searchEach runs the search input against each target indexer concurrently,
+// reporting latency, errors and result counts per indexer.
+func (p *ProwlarrSource) searchEach(ctx context.Context, query indexer.SearchQuery, targets []indexer.IndexerInfo, input prowlarr.SearchInput) ([]indexer.IndexerReport, [][]indexer.SearchResult) {
+	reports := make([]indexer.IndexerReport, len(targets))
+	results := make([][]indexer.SearchResult, len(targets))
+
+	var wg sync.WaitGroup
+	for i, info := range targets {
+		wg.Add(1)
+		go func(i int, info indexer.IndexerInfo) {
+			defer wg.Done()
+
+			in := input
+			in.IndexerIDs = []int64{info.ID}
+			start := time.Now()
+			res, err := p.search(ctx, in, query)
+			for j := range res {
+				res[j].IndexerPriority = info.Priority
+			}
+
+			reports[i] = indexer.IndexerReport{
+				IndexerID:   info.ID,
+				IndexerName: info.Name,
+				Duration:    time.Since(start),
+				ResultCount: len(res),
+				Err:         err,
+			}
+			results[i] = res
+		}(i, info)
+	}
+	wg.Wait()
+	return reports, results
+}
+
+// withIDSearch folds the ID search that came before a text fallback into the
+// text search's reports: the time it took, and its error, so a broken ID
+// search still counts against the indexer's health.
+func withIDSearch(text, id []indexer.IndexerReport) []indexer.IndexerReport {
+	for i := range text {
+		text[i].Duration += id[i].Duration
+		if id[i].Err != nil {
+			text[i].Err = errors.Join(fmt.Errorf("id search: %w", id[i].Err), text[i].Err)
+		}
+	}
+	return text
+}
+
+func countResults(results [][]indexer.SearchResult) int {
+	n := 0
+	for _, r := range results {
+		n += len(r)
+	}
+	return n
+}
+
+// searchAll searches all Prowlarr indexers at once, by ID first when the
+// query has external IDs and by text when that finds nothing.
+func (p *ProwlarrSource) searchAll(ctx context.Context, query indexer.SearchQuery) ([]indexer.SearchResult, error) {
+	if query.HasIDs() {
+		results, err := p.search(ctx, idSearchInput(query), query)
+		if err != nil {
+			p.logger.Warn().Err(err).Str("query", query.Query).Msg("Prowlarr ID search failed, falling back to text search")
+		} else if len(results) > 0 {
+			return results, nil
+		}
+	}
+	return p.search(ctx, textSearchInput(query), query)
+}
+
+// idSearchInput builds a typed Prowlarr search using {IdType:value} search terms.
+func idSearchInput(query indexer.SearchQuery) prowlarr.SearchInput {
+	var terms strings.Builder
+	if query.ImdbID != "" {
+		fmt.Fprintf(&terms, "{ImdbId:%s}", query.ImdbID)
+	}
+	if query.TmdbID != 0 {
+		fmt.Fprintf(&terms, "{TmdbId:%d}", query.TmdbID)
+	}
+
+	input := prowlarr.SearchInput{Limit: query.Limit}
+	switch query.MediaType {
+	case indexer.MediaTypeSeries:
+		input.Type = "tvsearch"
+		if query.TvdbID != 0 {
+			fmt.Fprintf(&terms, "{TvdbId:%d}", query.TvdbID)
+		}
+		if query.Season != nil {
+			fmt.Fprintf(&terms, "{Season:%02d}", *query.Season)
+			if query.Episode != nil {
+				fmt.Fprintf(&terms, "{Episode:%02d}", *query.Episode)
+			}
+		}
+	default:
+		input.Type = "movie"
+	}
+	input.Query = terms.String()
+
+	return input
+}
+
+// textSearchInput builds a free-text Prowlarr search.
+func textSearchInput(query indexer.SearchQuery) prowlarr.SearchInput {
 	input := prowlarr.SearchInput{
 		Query: query.Query,
 		Limit: query.Limit,
@@ -40,6 +218,10 @@ func (p *ProwlarrSource) Search(ctx context.Context, query indexer.SearchQuery)
 		input.Type = "search"
 	}
 
+	return input
+}
+
+func (p *ProwlarrSource) search(ctx context.Context, input prowlarr.SearchInput, query indexer.SearchQuery) ([]indexer.SearchResult, error) {
 	results, err := p.client.SearchContext(ctx, input)
 	if err != nil {
 		return nil, fmt.Errorf("prowlarr search: %w", err)
@@ -62,6 +244,9 @@ func (p *ProwlarrSource) Search(ctx context.Context, query indexer.SearchQuery)
 
 	p.logger.Debug().
 		Str("query", query.Query).
+		Str("prowlarr_query", input.Query).
+		Str("type", input.Type).
+		Ints64("indexer_ids", input.IndexerIDs).
 		Int("raw_count", len(results)).
 		Int("valid_count", len(validated)).
 		Msg("Prowlarr search completed")
@@ -69,8 +254,36 @@ func (p *ProwlarrSource) Search(ctx context.Context, query indexer.SearchQuery)
 	return validated, nil
 }
 
-// ListIndexers returns information about all configured indexers.
+// searchIndexerList returns the indexer list, fetched at most once every
+// indexerListTTL. Callers wait on a fetch in progress rather than start their own.
+func (p *ProwlarrSource) searchIndexerList(ctx context.Context) ([]indexer.IndexerInfo, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.indexers != nil && time.Since(p.indexersAt) < indexerListTTL {
+		return p.indexers, nil
+	}
+	infos, err := p.listIndexers(ctx)
+	if err != nil {
+		return nil, err
+	}
+	p.indexers, p.indexersAt = infos, time.Now()
+	return infos, nil
+}
+
+// ListIndexers returns information about all configured indexers. It always
+// asks Prowlarr, and refreshes the list searches use.
 func (p *ProwlarrSource) ListIndexers(ctx context.Context) ([]indexer.IndexerInfo, error) {
+	infos, err := p.listIndexers(ctx)
+	if err != nil {
+		return nil, err
+	}
+	p.mu.Lock()
+	p.indexers, p.indexersAt = infos, time.Now()
+	p.mu.Unlock()
+	return infos, nil
+}
+
+func (p *ProwlarrSource) listIndexers(ctx context.Context) ([]indexer.IndexerInfo, error) {
 	indexers, err := p.client.GetIndexersContext(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("prowlarr get indexers: %w", err)
@@ -83,6 +296,7 @@ func (p *ProwlarrSource) ListIndexers(ctx context.Context) ([]indexer.IndexerInf
 			Name:     idx.Name,
 			Protocol: string(idx.Protocol),
 			Enabled:  idx.Enable,
+			Priority: int(idx.Priority),
 		}
 	}
 
@@ -149,6 +363,7 @@ func (p *ProwlarrSource) mapResult(r *prowlarr.Search) (indexer.SearchResult, er
 		Title:       r.Title,
 		DownloadURL: downloadURL,
 		Protocol:    string(r.Protocol),
+		InfoHash:    r.InfoHash,
 		Size:        r.Size,
 		Seeders:     seeders,
 		Leechers:    leechers,
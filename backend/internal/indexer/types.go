@@ -11,27 +11,47 @@ const (
 )
 
 // SearchQuery represents a search request to an indexer source.
+// When any of the external IDs are set, sources should prefer an ID-based
+// search and fall back to the free-text Query.
 type SearchQuery struct {
 	Query     string
 	MediaType MediaType
 	Season    *int
 	Episode   *int
 	Limit     int
+
+	// External IDs (optional)
+	TmdbID int64
+	ImdbID string
+	TvdbID int64
+
+	// ExcludeIndexerIDs lists indexers to skip, e.g. ones backed off after failures.
+	ExcludeIndexerIDs []int64
+}
+
+// HasIDs reports whether the query carries any external ID.
+func (q SearchQuery) HasIDs() bool {
+	return q.TmdbID != 0 || q.ImdbID != "" || q.TvdbID != 0
 }
 
 // SearchResult represents a validated search result from an indexer.
 // All required fields are guaranteed to be non-empty after validation.
 type SearchResult struct {
 	// Identity (required)
-	IndexerID   int64
-	IndexerName string
-	GUID        string
+	IndexerID       int64
+	IndexerName     string
+	IndexerPriority int // lower is preferred; zero when unknown
+	GUID            string
 
 	// Required - validated at adapter boundary
 	Title       string // MUST be non-empty
 	DownloadURL string // MUST be non-empty
 	Protocol    string // "torrent" or "usenet"
 
+	// InfoHash is the torrent info hash when the indexer exposes one.
+	// Used to recognize the same release across sources.
+	InfoHash string
+
 	// Metadata
 	Size        int64
 	Seeders     *int
@@ -49,4 +69,5 @@ type IndexerInfo struct {
 	Name     string
 	Protocol string
 	Enabled  bool
+	Priority int
 }
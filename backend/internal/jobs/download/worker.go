@@ -11,23 +11,26 @@ import (
 	"time"
 
 	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/kyleaupton/arrflix/internal/blocklist"
 	dbgen "github.com/kyleaupton/arrflix/internal/db/sqlc"
 	"github.com/kyleaupton/arrflix/internal/downloader"
 	apperrors "github.com/kyleaupton/arrflix/internal/errors"
 	"github.com/kyleaupton/arrflix/internal/importer"
 	"github.com/kyleaupton/arrflix/internal/jobs/state"
 	"github.com/kyleaupton/arrflix/internal/logger"
+	"github.com/kyleaupton/arrflix/internal/pathmapping"
 	"github.com/kyleaupton/arrflix/internal/repo"
 	"github.com/kyleaupton/arrflix/internal/sse"
 )
 
 // Worker polls download clients and manages download job lifecycle.
 type Worker struct {
-	repo   *repo.Repository
-	dlm    *downloader.Manager
-	log    *logger.Logger
-	broker *sse.Broker
-	sm     *state.DownloadJobMachine
+	repo       *repo.Repository
+	dlm        *downloader.Manager
+	pathMapper *pathmapping.Mapper
+	log        *logger.Logger
+	broker     *sse.Broker
+	sm         *state.DownloadJobMachine
 
 	pollInterval time.Duration
 	claimLimit   int32
@@ -56,6 +59,7 @@ func New(r *repo.Repository, dlm *downloader.Manager, log *logger.Logger, broker
 	return &Worker{
 		repo:         r,
 		dlm:          dlm,
+		pathMapper:   pathmapping.New(r),
 		log:          log,
 		broker:       broker,
 		sm:           state.NewDownloadJobMachine(),
@@ -211,17 +215,37 @@ func (w *Worker) pollDownload(ctx context.Context, client downloader.Client, job
 	if newStatus == "failed" {
 		w.logEvent(ctx, job.ID, "error", "downloader reported failed status", nil)
 		_, _ = w.repo.MarkDownloadJobFailed(ctx, job.ID, "downloader reported failed status", apperrors.Permanent)
+		w.blocklistRelease(ctx, job, blocklist.ReasonDownloadFailed, "downloader reported failed status")
 		return nil
 	}
 
 	// Spawn import tasks when download completes
 	if newStatus == "completed" && job.Status != "completed" {
-		return w.spawnImportTasks(ctx, client, job, item)
+		err := w.spawnImportTasks(ctx, client, job, item)
+		if err != nil && apperrors.CategoryOf(err) == apperrors.Permanent && importer.ReleaseFault(err) {
+			// Nothing in this release can be imported; don't grab it again.
+			w.blocklistRelease(ctx, job, blocklist.ReasonImportFailed, err.Error())
+		}
+		return err
 	}
 
 	return nil
 }
 
+// blocklistRelease blocklists the release grabbed by a failed job. Errors are
+// logged; they never affect the job itself.
+func (w *Worker) blocklistRelease(ctx context.Context, job dbgen.DownloadJob, reason, message string) {
+	entry, err := blocklist.AddForDownloadJob(ctx, w.repo, job, reason, message)
+	if err != nil {
+		w.log.Warn().Err(err).Str("job_id", job.ID.String()).Msg("failed to blocklist release")
+		return
+	}
+	w.logEvent(ctx, job.ID, "blocklisted", message, map[string]any{
+		"blocklist_id": entry.ID.String(),
+		"reason":       reason,
+	})
+}
+
 func (w *Worker) spawnImportTasks(ctx context.Context, client downloader.Client, job dbgen.DownloadJob, item downloader.Item) error {
 	if job.MediaType == "movie" {
 		return w.spawnMovieImportTask(ctx, client, job, item)
@@ -241,7 +265,7 @@ func (w *Worker) spawnMovieImportTask(ctx context.Context, client downloader.Cli
 	if len(files) > 0 {
 		mainFile, ok := importer.PickMainMovieFile(files)
 		if !ok {
-			return apperrors.AsPermanent(fmt.Errorf("no suitable video files found for import"))
+			return apperrors.AsPermanent(fmt.Errorf("%w for import", importer.ErrNoVideo))
 		}
 		if filepath.IsAbs(mainFile.Path) {
 			sourcePath = mainFile.Path
@@ -258,6 +282,7 @@ func (w *Worker) spawnMovieImportTask(ctx context.Context, client downloader.Cli
 	if sourcePath == "" {
 		return apperrors.AsPermanent(fmt.Errorf("unable to determine source path for import"))
 	}
+	sourcePath = w.pathMapper.Apply(ctx, job.DownloaderID, sourcePath)
 
 	// Create import task
 	task, err := w.repo.CreateImportTask(ctx, dbgen.CreateImportTaskParams{
@@ -323,7 +348,7 @@ func (w *Worker) spawnSeriesImportTasks(ctx context.Context, client downloader.C
 		}
 	}
 
-	matchedFiles := importer.MatchFilesToEpisodes(files, targetSeason, targetEpisode)
+	matchedFiles := importer.MatchFilesToEpisodes(files, targetSeason, targetEpisode, w.absoluteEpisodeMap(ctx, job.MediaItemID))
 	if len(matchedFiles) == 0 {
 		return apperrors.AsPermanent(fmt.Errorf("no files matched target episodes"))
 	}
@@ -334,6 +359,7 @@ func (w *Worker) spawnSeriesImportTasks(ctx context.Context, client downloader.C
 		if !filepath.IsAbs(sourcePath) && item.SavePath != "" {
 			sourcePath = filepath.Join(item.SavePath, f.Path)
 		}
+		sourcePath = w.pathMapper.Apply(ctx, job.DownloaderID, sourcePath)
 
 		// Resolve episode ID for this file
 		episodeID, err := w.resolveEpisodeID(ctx, job.MediaItemID, targetSeason, epNum)
@@ -378,6 +404,17 @@ func (w *Worker) spawnSeriesImportTasks(ctx context.Context, client downloader.C
 	return nil
 }
 
+// absoluteEpisodeMap loads the absolute episode numbering stored for a series
+// when its download was enqueued, for fansub releases.
+func (w *Worker) absoluteEpisodeMap(ctx context.Context, mediaItemID pgtype.UUID) importer.AbsoluteEpisodeMap {
+	rows, err := w.repo.ListAbsoluteEpisodeNumbers(ctx, mediaItemID)
+	if err != nil {
+		w.log.Warn().Err(err).Msg("failed to load absolute episode numbers")
+		return nil
+	}
+	return importer.AbsoluteEpisodeMapFromRows(rows)
+}
+
 func (w *Worker) resolveEpisodeID(ctx context.Context, mediaItemID pgtype.UUID, targetSeason *int, epNum int) (pgtype.UUID, error) {
 	seasonNum := 1
 	if targetSeason != nil {
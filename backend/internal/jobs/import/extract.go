@@ -0,0 +1,145 @@
+package importw
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/kyleaupton/arrflix/internal/archive"
+	dbgen "github.com/kyleaupton/arrflix/internal/db/sqlc"
+	"github.com/kyleaupton/arrflix/internal/downloader"
+	apperrors "github.com/kyleaupton/arrflix/internal/errors"
+	"github.com/kyleaupton/arrflix/internal/importer"
+)
+
+const (
+	// stagingDirName holds extractions next to the download when no
+	// extract path is configured.
+	stagingDirName = ".arrflix-unpack"
+	// stagingComplete marks a finished extraction, so a retry reuses it.
+	stagingComplete = ".complete"
+	// progressStep is how often, in percent, extraction progress is logged.
+	progressStep = 25
+)
+
+// unpack extracts the archive the task points at into its staging dir and
+// returns the dir and the video to import from it.
+func (w *Worker) unpack(ctx context.Context, task dbgen.ImportTask, details dbgen.GetImportTaskWithDetailsRow) (string, string, error) {
+	root := w.settings.GetText(ctx, "import.extract_path")
+	if root == "" {
+		root = filepath.Join(filepath.Dir(task.SourcePath), stagingDirName)
+	}
+	dir := filepath.Join(root, task.ID.String())
+
+	if _, err := os.Stat(filepath.Join(dir, stagingComplete)); err != nil {
+		if err := w.extract(ctx, task, dir); err != nil {
+			return "", "", err
+		}
+	}
+
+	video, err := stagedVideo(dir, details)
+	if err != nil {
+		return "", "", err
+	}
+	return dir, video, nil
+}
+
+func (w *Worker) extract(ctx context.Context, task dbgen.ImportTask, dir string) error {
+	w.log.Info().
+		Str("task_id", task.ID.String()).
+		Str("archive", task.SourcePath).
+		Str("staging_dir", dir).
+		Msg("extracting archive")
+	w.logEvent(ctx, task.ID, "extraction_started", "", map[string]any{
+		"archive":     task.SourcePath,
+		"staging_dir": dir,
+	})
+
+	next := progressStep
+	err := w.extractor.Extract(ctx, task.SourcePath, dir, func(percent int) {
+		if percent < next || percent >= 100 {
+			return
+		}
+		next = percent - percent%progressStep + progressStep
+		w.logEvent(ctx, task.ID, "extraction_progress", "", map[string]any{
+			"percent": percent,
+		})
+		w.publishTaskUpdated(ctx, task)
+	})
+	if err != nil {
+		// Password protected and broken archives won't extract on a retry
+		if errors.Is(err, archive.ErrPassword) || errors.Is(err, archive.ErrCorrupt) {
+			_ = os.RemoveAll(dir)
+			return apperrors.AsPermanent(fmt.Errorf("extract %s: %w", filepath.Base(task.SourcePath), err))
+		}
+		return fmt.Errorf("extract %s: %w", filepath.Base(task.SourcePath), err)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, stagingComplete), nil, 0o644); err != nil {
+		return fmt.Errorf("mark extraction complete: %w", err)
+	}
+	w.logEvent(ctx, task.ID, "extraction_completed", "", map[string]any{
+		"staging_dir": dir,
+	})
+	return nil
+}
+
+// stagedVideo picks the video to import from an extraction: the main movie
+// file, or the file matching the task's episode.
+func stagedVideo(dir string, details dbgen.GetImportTaskWithDetailsRow) (string, error) {
+	var files, videos []downloader.File
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		f := downloader.File{Path: path, Size: info.Size()}
+		files = append(files, f)
+		if importer.IsVideoPath(path) && !importer.LooksLikeSample(path) {
+			videos = append(videos, f)
+		}
+		return nil
+	})
+	if err != nil {
+		return "", fmt.Errorf("read staging dir: %w", err)
+	}
+	if len(videos) == 0 {
+		return "", apperrors.AsPermanent(fmt.Errorf("%w in archive", importer.ErrNoVideo))
+	}
+
+	if details.SeasonNumber == nil || details.EpisodeNumber == nil {
+		f, _ := importer.PickMainMovieFile(videos)
+		return f.Path, nil
+	}
+
+	season, episode := int(*details.SeasonNumber), int(*details.EpisodeNumber)
+	if f, ok := importer.MatchFilesToEpisodes(files, &season, &episode, nil)[episode]; ok && importer.IsVideoPath(f.Path) {
+		return f.Path, nil
+	}
+	// An episode packed on its own may have a video without numbers
+	if len(videos) == 1 {
+		return videos[0].Path, nil
+	}
+	return "", apperrors.AsPermanent(fmt.Errorf("no video for S%02dE%02d found in archive", season, episode))
+}
+
+// cleanupStaging removes a task's extraction once its video is imported,
+// and the staging root next to the download when nothing else is left in it.
+func (w *Worker) cleanupStaging(task dbgen.ImportTask, dir string) {
+	if err := os.RemoveAll(dir); err != nil {
+		w.log.Warn().Err(err).
+			Str("task_id", task.ID.String()).
+			Str("staging_dir", dir).
+			Msg("failed to remove staging dir")
+		return
+	}
+	if parent := filepath.Dir(dir); filepath.Base(parent) == stagingDirName {
+		_ = os.Remove(parent) // fails while other extractions are staged
+	}
+}
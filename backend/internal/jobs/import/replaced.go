@@ -0,0 +1,38 @@
+package importw
+
+import (
+	"context"
+	"time"
+
+	"github.com/kyleaupton/arrflix/internal/importer"
+)
+
+// pruneReplacedIfDue removes library files replaced by upgrades once they
+// have been kept for import.replaced_retention_days. Zero keeps them forever.
+func (w *Worker) pruneReplacedIfDue(ctx context.Context) {
+	if time.Since(w.lastPrune) < w.pruneInterval {
+		return
+	}
+	w.lastPrune = time.Now()
+
+	days := w.settings.GetInt(ctx, "import.replaced_retention_days")
+	if days <= 0 {
+		return
+	}
+	libraries, err := w.repo.ListLibraries(ctx)
+	if err != nil {
+		w.log.Warn().Err(err).Msg("failed to list libraries to prune replaced files")
+		return
+	}
+
+	before := time.Now().AddDate(0, 0, -int(days))
+	for _, lib := range libraries {
+		n, err := importer.PruneReplaced(lib.RootPath, before)
+		if err != nil {
+			w.log.Warn().Err(err).Str("library", lib.Name).Msg("failed to prune replaced files")
+		}
+		if n > 0 {
+			w.log.Info().Str("library", lib.Name).Int("removed", n).Msg("pruned replaced files")
+		}
+	}
+}
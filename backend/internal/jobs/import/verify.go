@@ -0,0 +1,116 @@
+package importw
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+
+	dbgen "github.com/kyleaupton/arrflix/internal/db/sqlc"
+	apperrors "github.com/kyleaupton/arrflix/internal/errors"
+	"github.com/kyleaupton/arrflix/internal/importer"
+	"github.com/kyleaupton/arrflix/internal/model"
+	"github.com/kyleaupton/arrflix/internal/release"
+)
+
+// verifyMedia checks the video mediainfo read against its release before it
+// goes into the library. A file that fails is a permanent error.
+func (w *Worker) verifyMedia(ctx context.Context, task dbgen.ImportTask, details dbgen.GetImportTaskWithDetailsRow, mi *model.MediaInfoFields) error {
+	if !w.settings.GetBool(ctx, "import.verify") {
+		return nil
+	}
+	if mi == nil && !w.mediaInfo.Available() {
+		w.log.Warn().Str("task_id", task.ID.String()).Msg("mediainfo is not installed, skipping import verification")
+		return nil
+	}
+
+	want := importer.Expectation{
+		RuntimeMinutes: w.expectedRuntime(ctx, details),
+		Quality:        claimedQuality(coalesce(details.CandidateTitle), task.SourcePath),
+	}
+	if err := importer.VerifyMedia(mi, want); err != nil {
+		return apperrors.AsPermanent(err)
+	}
+	return nil
+}
+
+// verifyCopy compares a copied file with its source. On a mismatch the copy
+// is removed, along with the upgrade it was part of, and the import is
+// retried: a bad copy is the disk's fault, not the release's.
+func (w *Worker) verifyCopy(ctx context.Context, task dbgen.ImportTask, root, destPath, archivedPath string) error {
+	if !w.settings.GetBool(ctx, "import.verify") {
+		return nil
+	}
+	fullDest := filepath.Join(root, destPath)
+	err := importer.VerifyCopy(task.SourcePath, fullDest, w.settings.GetBool(ctx, "import.verify_checksum"))
+	if err == nil {
+		return nil
+	}
+
+	if rmErr := os.Remove(fullDest); rmErr != nil && !os.IsNotExist(rmErr) {
+		w.log.Warn().Err(rmErr).Str("task_id", task.ID.String()).Str("dest", fullDest).Msg("failed to remove unverified copy")
+	}
+	if archivedPath != "" {
+		w.restoreReplaced(task, root, archivedPath, destPath)
+	}
+	return err
+}
+
+// expectedRuntime returns the TMDB runtime in minutes of the task's movie or
+// episode, or zero when it isn't known.
+func (w *Worker) expectedRuntime(ctx context.Context, details dbgen.GetImportTaskWithDetailsRow) int {
+	if details.MediaTmdbID == nil {
+		return 0
+	}
+	if details.MediaType == string(model.MediaTypeMovie) {
+		return w.runtimes.Runtime(ctx, model.MediaTypeMovie, *details.MediaTmdbID, 0, 0)
+	}
+	if details.SeasonNumber == nil || details.EpisodeNumber == nil {
+		return 0
+	}
+	return w.runtimes.Runtime(ctx, model.MediaTypeSeries, *details.MediaTmdbID, int(*details.SeasonNumber), int(*details.EpisodeNumber))
+}
+
+// claimedQuality is the quality the release claims: the first of names that
+// parses to one.
+func claimedQuality(names ...string) release.Quality {
+	for _, name := range names {
+		if name == "" {
+			continue
+		}
+		if q := release.Parse(filepath.Base(name)).Quality.Quality; q != release.Unknown {
+			return q
+		}
+	}
+	return release.Unknown
+}
+
+// searchAgain queues a search for the item of a task whose release failed
+// verification, so another release replaces the blocklisted one. Manual
+// imports have no release to blocklist and are not searched again.
+func (w *Worker) searchAgain(ctx context.Context, task dbgen.ImportTask) {
+	if !task.DownloadJobID.Valid || !w.settings.GetBool(ctx, "import.search_on_verify_failure") {
+		return
+	}
+	details, err := w.repo.GetImportTaskWithDetails(ctx, task.ID)
+	if err != nil || details.MediaTmdbID == nil {
+		w.log.Warn().Err(err).Str("task_id", task.ID.String()).Msg("failed to load task for a new search")
+		return
+	}
+
+	item := model.WantedSearchItem{
+		MediaType: model.MediaType(details.MediaType),
+		TmdbID:    *details.MediaTmdbID,
+	}
+	if details.SeasonNumber != nil && details.EpisodeNumber != nil {
+		season, episode := int(*details.SeasonNumber), int(*details.EpisodeNumber)
+		item.Season, item.Episode = &season, &episode
+	}
+	if w.searcher.Queue([]model.WantedSearchItem{item}) == 0 {
+		return
+	}
+	w.logEvent(ctx, task.ID, "search_queued", "", map[string]any{
+		"tmdb_id": item.TmdbID,
+		"season":  item.Season,
+		"episode": item.Episode,
+	})
+}
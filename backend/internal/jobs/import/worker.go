@@ -5,6 +5,7 @@ package importw
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"math"
 	"os"
@@ -12,6 +13,8 @@ import (
 	"time"
 
 	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/kyleaupton/arrflix/internal/archive"
+	"github.com/kyleaupton/arrflix/internal/blocklist"
 	dbgen "github.com/kyleaupton/arrflix/internal/db/sqlc"
 	"github.com/kyleaupton/arrflix/internal/downloader"
 	apperrors "github.com/kyleaupton/arrflix/internal/errors"
@@ -21,6 +24,7 @@ import (
 	"github.com/kyleaupton/arrflix/internal/mediainfo"
 	"github.com/kyleaupton/arrflix/internal/model"
 	"github.com/kyleaupton/arrflix/internal/pathmapping"
+	"github.com/kyleaupton/arrflix/internal/qualityprofile"
 	"github.com/kyleaupton/arrflix/internal/release"
 	"github.com/kyleaupton/arrflix/internal/repo"
 	"github.com/kyleaupton/arrflix/internal/sse"
@@ -36,42 +40,73 @@ type Worker struct {
 	broker     *sse.Broker
 	sm         *state.ImportTaskMachine
 	mediaInfo  *mediainfo.Analyzer
-
-	pollInterval time.Duration
-	claimLimit   int32
-	maxAttempts  int
+	extractor  *archive.Extractor
+	settings   Settings
+	runtimes   Runtimes
+	searcher   Searcher
+
+	pollInterval  time.Duration
+	claimLimit    int32
+	maxAttempts   int
+	pruneInterval time.Duration
+	lastPrune     time.Time
 }
 
 // Config holds worker configuration.
 type Config struct {
-	PollInterval time.Duration
-	ClaimLimit   int32
-	MaxAttempts  int
+	PollInterval  time.Duration
+	ClaimLimit    int32
+	MaxAttempts   int
+	PruneInterval time.Duration
 }
 
 // DefaultConfig returns default worker configuration.
 func DefaultConfig() Config {
 	return Config{
-		PollInterval: 2 * time.Second,
-		ClaimLimit:   10,
-		MaxAttempts:  5,
+		PollInterval:  2 * time.Second,
+		ClaimLimit:    10,
+		MaxAttempts:   5,
+		PruneInterval: time.Hour,
 	}
 }
 
+// Settings reads the import settings.
+type Settings interface {
+	GetText(ctx context.Context, key string) string
+	GetBool(ctx context.Context, key string) bool
+	GetInt(ctx context.Context, key string) int64
+}
+
+// Runtimes looks up the TMDB runtime, in minutes, imported videos are
+// checked against.
+type Runtimes interface {
+	Runtime(ctx context.Context, mediaType model.MediaType, tmdbID int64, season, episode int) int
+}
+
+// Searcher queues a new search when a release fails verification.
+type Searcher interface {
+	Queue(items []model.WantedSearchItem) int
+}
+
 // New creates a new import worker.
-func New(r *repo.Repository, dlm *downloader.Manager, log *logger.Logger, broker *sse.Broker) *Worker {
+func New(r *repo.Repository, dlm *downloader.Manager, settings Settings, runtimes Runtimes, searcher Searcher, log *logger.Logger, broker *sse.Broker) *Worker {
 	cfg := DefaultConfig()
 	return &Worker{
-		repo:         r,
-		dlm:          dlm,
-		pathMapper:   pathmapping.New(),
-		log:          log,
-		broker:       broker,
-		sm:           state.NewImportTaskMachine(),
-		mediaInfo:    mediainfo.NewAnalyzer(*log),
-		pollInterval: cfg.PollInterval,
-		claimLimit:   cfg.ClaimLimit,
-		maxAttempts:  cfg.MaxAttempts,
+		repo:          r,
+		dlm:           dlm,
+		pathMapper:    pathmapping.New(r),
+		log:           log,
+		broker:        broker,
+		sm:            state.NewImportTaskMachine(),
+		mediaInfo:     mediainfo.NewAnalyzer(*log),
+		extractor:     archive.NewExtractor(*log),
+		settings:      settings,
+		runtimes:      runtimes,
+		searcher:      searcher,
+		pollInterval:  cfg.PollInterval,
+		claimLimit:    cfg.ClaimLimit,
+		maxAttempts:   cfg.MaxAttempts,
+		pruneInterval: cfg.PruneInterval,
 	}
 }
 
@@ -94,6 +129,8 @@ func (w *Worker) Run(ctx context.Context) {
 }
 
 func (w *Worker) tick(ctx context.Context) {
+	w.pruneReplacedIfDue(ctx)
+
 	// ClaimRunnableImportTasks atomically sets status to in_progress
 	tasks, err := w.repo.ClaimRunnableImportTasks(ctx, w.claimLimit)
 	if err != nil {
@@ -143,7 +180,7 @@ func (w *Worker) processTask(ctx context.Context, task dbgen.ImportTask) error {
 	srcInfo, err := os.Stat(task.SourcePath)
 	if err != nil {
 		if os.IsNotExist(err) {
-			return apperrors.AsPermanent(fmt.Errorf("source file not found: %s", task.SourcePath))
+			return apperrors.AsPermanent(fmt.Errorf("source file not found: %s (check the downloader's remote path mappings)", task.SourcePath))
 		}
 		return fmt.Errorf("stat source: %w", err)
 	}
@@ -151,16 +188,40 @@ func (w *Worker) processTask(ctx context.Context, task dbgen.ImportTask) error {
 		return apperrors.AsPermanent(fmt.Errorf("source is a directory, expected file: %s", task.SourcePath))
 	}
 
+	// Get required data
+	taskDetails, err := w.repo.GetImportTaskWithDetails(ctx, task.ID)
+	if err != nil {
+		return fmt.Errorf("get task details: %w", err)
+	}
+	mode, err := importer.ParseMode(taskDetails.LibraryImportMode)
+	if err != nil {
+		return apperrors.AsPermanent(err)
+	}
+
+	// Packed release: extract it and import the video from the staging dir.
+	// The staged files are ours, so they are always moved into the library.
+	var stagingDir, staged string
+	if archive.IsArchive(task.SourcePath) {
+		stagingDir, staged, err = w.unpack(ctx, task, taskDetails)
+		if err != nil {
+			return err
+		}
+		task.SourcePath = staged
+		mode = importer.ModeMove
+		if srcInfo, err = os.Stat(staged); err != nil {
+			return fmt.Errorf("stat extracted video: %w", err)
+		}
+	}
+
 	// Extract mediainfo from source file for template rendering
 	mi := w.mediaInfo.Analyze(task.SourcePath)
 	if mi == nil {
 		w.log.Warn().Str("path", task.SourcePath).Msg("failed to extract mediainfo, continuing without it")
 	}
 
-	// Get required data
-	taskDetails, err := w.repo.GetImportTaskWithDetails(ctx, task.ID)
-	if err != nil {
-		return fmt.Errorf("get task details: %w", err)
+	// Fakes, truncated files and mislabelled releases stay out of the library
+	if err := w.verifyMedia(ctx, task, taskDetails, mi); err != nil {
+		return err
 	}
 
 	// Compute destination path using name template
@@ -172,10 +233,16 @@ func (w *Worker) processTask(ctx context.Context, task dbgen.ImportTask) error {
 	// Full absolute destination
 	fullDest := filepath.Join(taskDetails.LibraryRootPath, destPath)
 
+	// The library file this import replaces, when the item already has one
+	existing := w.existingFile(ctx, task, destPath)
+	replacing := existing != nil
+	var archivedPath string
+
 	// Check for existing destination
 	if _, err := os.Stat(fullDest); err == nil {
-		// Handle reimport: if this is a reimport, remove old file first
-		if task.PreviousTaskID.Valid {
+		switch {
+		case task.PreviousTaskID.Valid:
+			// Handle reimport: if this is a reimport, remove old file first
 			w.log.Info().
 				Str("task_id", task.ID.String()).
 				Str("dest", fullDest).
@@ -183,17 +250,53 @@ func (w *Worker) processTask(ctx context.Context, task dbgen.ImportTask) error {
 			if err := os.Remove(fullDest); err != nil && !os.IsNotExist(err) {
 				return fmt.Errorf("remove existing dest: %w", err)
 			}
-		} else {
-			// Not a reimport, fail
+			if existing != nil && existing.Path == destPath {
+				replacing = false // same file again, its record is reused
+			}
+		case existing != nil && existing.Path == destPath:
+			// Upgrade to the same name: move the old file aside first
+			archivedPath, err = importer.ArchiveReplaced(taskDetails.LibraryRootPath, destPath, time.Now())
+			if err != nil {
+				return fmt.Errorf("move replaced file aside: %w", err)
+			}
+		default:
+			// Not a reimport or an upgrade, fail
 			return apperrors.AsPermanent(fmt.Errorf("destination already exists: %s", fullDest))
 		}
 	}
 
-	// Perform import (hardlink or copy)
-	method, err := importer.HardlinkOrCopy(task.SourcePath, fullDest)
+	// Find extras before the import, a move takes the video out of its folder.
+	// A video from an archive takes the extras unpacked with it in the staging
+	// dir; the download dir only holds the archive.
+	var extras []importer.Extra
+	if taskDetails.LibraryImportExtraFiles {
+		extrasFrom := task.SourcePath
+		if stagingDir != "" {
+			extrasFrom = staged
+		}
+		extras, err = importer.FindExtras(extrasFrom, taskDetails.LibraryExtraFileExtensions)
+		if err != nil {
+			w.log.Warn().Err(err).Str("task_id", task.ID.String()).Msg("failed to look for extra files")
+		}
+	}
+
+	// Perform import with the library's mode
+	method, err := importer.Transfer(task.SourcePath, fullDest, mode)
 	if err != nil {
+		if archivedPath != "" {
+			w.restoreReplaced(task, taskDetails.LibraryRootPath, archivedPath, destPath)
+		}
 		return fmt.Errorf("import file: %w", err)
 	}
+	if method == string(importer.ModeCopy) {
+		if err := w.verifyCopy(ctx, task, taskDetails.LibraryRootPath, destPath, archivedPath); err != nil {
+			return err
+		}
+		// A move across filesystems copied; the source goes once the copy checks out
+		if mode == importer.ModeMove {
+			w.removeMovedSource(task, task.SourcePath)
+		}
+	}
 
 	w.log.Info().
 		Str("task_id", task.ID.String()).
@@ -201,13 +304,38 @@ func (w *Worker) processTask(ctx context.Context, task dbgen.ImportTask) error {
 		Str("dest", fullDest).
 		Msg("file imported successfully")
 
-	// Create media file record
-	var episodeID *pgtype.UUID
-	if task.EpisodeID.Valid {
-		episodeID = &task.EpisodeID
+	// An upgrade under a new name: the new file is in place, move the old one aside
+	if replacing && existing.Path != destPath {
+		if _, err := os.Stat(filepath.Join(taskDetails.LibraryRootPath, existing.Path)); err == nil {
+			archivedPath, err = importer.ArchiveReplaced(taskDetails.LibraryRootPath, existing.Path, time.Now())
+			if err != nil {
+				w.log.Warn().Err(err).
+					Str("task_id", task.ID.String()).
+					Str("path", existing.Path).
+					Msg("failed to move replaced file aside")
+			}
+		}
+	}
+
+	quality := qualityprofile.FileQuality(coalesce(taskDetails.CandidateTitle), task.SourcePath)
+	if quality == nil && mi != nil {
+		// Nothing to parse, fall back to the resolution mediainfo read
+		if q := qualityprofile.ResolutionQuality(mi.Width, mi.Height); q != release.Unknown {
+			quality = strPtr(q.String())
+		}
 	}
 
-	mediaFile, err := w.repo.CreateMediaFile(ctx, task.LibraryID, task.MediaItemID, episodeID, destPath)
+	// Create media file record, or point the replaced one at the new file
+	var mediaFile dbgen.MediaFile
+	if existing != nil {
+		mediaFile, err = w.repo.ReplaceMediaFile(ctx, existing.ID, destPath, quality)
+	} else {
+		var episodeID *pgtype.UUID
+		if task.EpisodeID.Valid {
+			episodeID = &task.EpisodeID
+		}
+		mediaFile, err = w.repo.CreateMediaFile(ctx, task.LibraryID, task.MediaItemID, episodeID, destPath, quality)
+	}
 	if err != nil {
 		// File was created but record failed - log but don't fail the task
 		w.log.Error().Err(err).
@@ -222,8 +350,16 @@ func (w *Worker) processTask(ctx context.Context, task dbgen.ImportTask) error {
 		_, _ = w.repo.UpsertMediaFileState(ctx, mediaFile.ID, true, &fileSize)
 	}
 
+	// The replaced file's extras are named after it, move them out of the way
+	if mediaFile.ID.Valid {
+		if existing != nil {
+			w.dropExtras(ctx, task, taskDetails.LibraryRootPath, existing.ID)
+		}
+		w.importExtras(ctx, task, taskDetails.LibraryRootPath, mode, mediaFile.ID, destPath, extras)
+	}
+
 	// Record import in media_file_import table
-	_, _ = w.repo.CreateMediaFileImport(ctx, dbgen.CreateMediaFileImportParams{
+	importParams := dbgen.CreateMediaFileImportParams{
 		MediaFileID:  mediaFile.ID,
 		ImportTaskID: task.ID,
 		Method:       method,
@@ -231,7 +367,21 @@ func (w *Worker) processTask(ctx context.Context, task dbgen.ImportTask) error {
 		DestPath:     destPath,
 		Success:      true,
 		ErrorMessage: nil,
-	})
+	}
+	if replacing {
+		importParams.ReplacedPath = &existing.Path
+		importParams.ReplacedQuality = existing.Quality
+		importParams.ArchivedPath = strPtr(archivedPath)
+
+		w.logEvent(ctx, task.ID, "file_replaced", "", map[string]any{
+			"media_file_id":    existing.ID.String(),
+			"replaced_path":    existing.Path,
+			"replaced_quality": coalesce(existing.Quality),
+			"archived_path":    archivedPath,
+			"quality":          coalesce(quality),
+		})
+	}
+	_, _ = w.repo.CreateMediaFileImport(ctx, importParams)
 
 	// Mark task completed
 	_, err = w.repo.SetImportTaskCompleted(ctx, task.ID, destPath, method, mediaFile.ID)
@@ -246,10 +396,145 @@ func (w *Worker) processTask(ctx context.Context, task dbgen.ImportTask) error {
 		"import_method": method,
 	})
 
+	if stagingDir != "" {
+		w.cleanupStaging(task, stagingDir)
+	}
+
 	w.publishTaskUpdated(ctx, task)
 	return nil
 }
 
+// existingFile returns the media file an import replaces: the file the item
+// (or, for series, the episode) already has in the task's library. A file at
+// the destination path is preferred, then one that is on disk.
+func (w *Worker) existingFile(ctx context.Context, task dbgen.ImportTask, destPath string) *dbgen.ListMediaFilesForItemRow {
+	files, err := w.repo.ListMediaFilesForItem(ctx, task.MediaItemID)
+	if err != nil {
+		w.log.Warn().Err(err).Str("task_id", task.ID.String()).Msg("failed to list existing media files")
+		return nil
+	}
+
+	var found *dbgen.ListMediaFilesForItemRow
+	for i, f := range files {
+		if f.LibraryID != task.LibraryID || f.EpisodeID != task.EpisodeID {
+			continue
+		}
+		if f.Path == destPath {
+			return &files[i]
+		}
+		onDisk := f.FileExists == nil || *f.FileExists
+		if found == nil || (onDisk && found.FileExists != nil && !*found.FileExists) {
+			found = &files[i]
+		}
+	}
+	return found
+}
+
+// restoreReplaced moves a file archived for an upgrade back after the import
+// failed.
+func (w *Worker) restoreReplaced(task dbgen.ImportTask, root, archivedPath, destPath string) {
+	if err := os.Rename(filepath.Join(root, archivedPath), filepath.Join(root, destPath)); err != nil {
+		w.log.Error().Err(err).
+			Str("task_id", task.ID.String()).
+			Str("archived_path", archivedPath).
+			Msg("failed to restore replaced file")
+	}
+}
+
+// removeMovedSource deletes the source of a move that had to copy. The import
+// is done by then, so a failure only leaves the download behind.
+func (w *Worker) removeMovedSource(task dbgen.ImportTask, src string) {
+	if err := os.Remove(src); err != nil && !os.IsNotExist(err) {
+		w.log.Warn().Err(err).
+			Str("task_id", task.ID.String()).
+			Str("source", src).
+			Msg("failed to remove moved source")
+	}
+}
+
+// dropExtras removes the extras of a media file whose video was replaced.
+// On reimport they are deleted like the video; on upgrade they are moved
+// aside with it.
+func (w *Worker) dropExtras(ctx context.Context, task dbgen.ImportTask, root string, mediaFileID pgtype.UUID) {
+	extras, err := w.repo.ListMediaFileExtras(ctx, mediaFileID)
+	if err != nil {
+		w.log.Warn().Err(err).Str("task_id", task.ID.String()).Msg("failed to list extra files")
+		return
+	}
+	for _, e := range extras {
+		if task.PreviousTaskID.Valid {
+			err = os.Remove(filepath.Join(root, e.Path))
+			if os.IsNotExist(err) {
+				err = nil
+			}
+		} else if _, statErr := os.Lstat(filepath.Join(root, e.Path)); statErr == nil {
+			_, err = importer.ArchiveReplaced(root, e.Path, time.Now())
+		}
+		if err != nil {
+			w.log.Warn().Err(err).
+				Str("task_id", task.ID.String()).
+				Str("path", e.Path).
+				Msg("failed to remove replaced extra file")
+			continue
+		}
+		if err := w.repo.DeleteMediaFileExtra(ctx, e.ID); err != nil {
+			w.log.Warn().Err(err).Str("task_id", task.ID.String()).Msg("failed to delete extra file record")
+		}
+	}
+}
+
+// importExtras puts the extras found with the video next to it in the
+// library and records them under the media file. A failed extra is logged
+// and skipped, it never fails the import.
+func (w *Worker) importExtras(ctx context.Context, task dbgen.ImportTask, root string, mode importer.Mode, mediaFileID pgtype.UUID, destPath string, extras []importer.Extra) {
+	if len(extras) == 0 {
+		return
+	}
+
+	var imported []string
+	for i, dest := range importer.ExtraDestPaths(destPath, task.SourcePath, extras) {
+		e := extras[i]
+		fullDest := filepath.Join(root, dest)
+		if _, err := os.Lstat(fullDest); err == nil {
+			w.log.Warn().Str("task_id", task.ID.String()).Str("dest", dest).Msg("extra file already exists, skipping")
+			continue
+		}
+
+		method, err := importer.Transfer(e.SourcePath, fullDest, mode)
+		if err != nil {
+			w.log.Warn().Err(err).
+				Str("task_id", task.ID.String()).
+				Str("source", e.SourcePath).
+				Msg("failed to import extra file")
+			continue
+		}
+		if mode == importer.ModeMove && method == string(importer.ModeCopy) {
+			w.removeMovedSource(task, e.SourcePath)
+		}
+
+		_, err = w.repo.CreateMediaFileExtra(ctx, dbgen.CreateMediaFileExtraParams{
+			MediaFileID: mediaFileID,
+			Type:        string(e.Type),
+			Path:        dest,
+			Language:    strPtr(e.Language),
+			Forced:      e.Forced,
+			Sdh:         e.SDH,
+			SourcePath:  &e.SourcePath,
+			Method:      method,
+		})
+		if err != nil {
+			w.log.Warn().Err(err).Str("task_id", task.ID.String()).Str("dest", dest).Msg("failed to record extra file")
+		}
+		imported = append(imported, dest)
+	}
+
+	if len(imported) > 0 {
+		w.logEvent(ctx, task.ID, "extras_imported", "", map[string]any{
+			"paths": imported,
+		})
+	}
+}
+
 func (w *Worker) computeDestPath(task dbgen.ImportTask, details dbgen.GetImportTaskWithDetailsRow, mi *model.MediaInfoFields) (string, error) {
 	srcExt := filepath.Ext(task.SourcePath)
 
@@ -357,6 +642,13 @@ func (w *Worker) handleError(ctx context.Context, task dbgen.ImportTask, err err
 	// Permanent errors fail immediately
 	if category == apperrors.Permanent {
 		_, _ = w.repo.SetImportTaskFailed(ctx, task.ID, msg, category)
+		switch {
+		case errors.Is(err, importer.ErrVerification):
+			w.blocklistRelease(ctx, task, blocklist.ReasonVerificationFailed, msg)
+			w.searchAgain(ctx, task)
+		case importer.ReleaseFault(err):
+			w.blocklistRelease(ctx, task, blocklist.ReasonImportFailed, msg)
+		}
 		w.publishTaskUpdated(ctx, task)
 		return
 	}
@@ -389,6 +681,29 @@ func (w *Worker) handleError(ctx context.Context, task dbgen.ImportTask, err err
 	w.publishTaskUpdated(ctx, task)
 }
 
+// blocklistRelease blocklists the release behind a task that failed for good
+// because of the release itself. Errors are logged; they never affect the
+// task itself.
+func (w *Worker) blocklistRelease(ctx context.Context, task dbgen.ImportTask, reason, message string) {
+	if !task.DownloadJobID.Valid {
+		return
+	}
+	job, err := w.repo.GetDownloadJob(ctx, task.DownloadJobID)
+	if err != nil {
+		w.log.Warn().Err(err).Str("task_id", task.ID.String()).Msg("failed to load download job for blocklist")
+		return
+	}
+	entry, err := blocklist.AddForDownloadJob(ctx, w.repo, job, reason, message)
+	if err != nil {
+		w.log.Warn().Err(err).Str("task_id", task.ID.String()).Msg("failed to blocklist release")
+		return
+	}
+	w.logEvent(ctx, task.ID, "blocklisted", message, map[string]any{
+		"blocklist_id": entry.ID.String(),
+		"reason":       entry.Reason,
+	})
+}
+
 func (w *Worker) logEvent(ctx context.Context, taskID pgtype.UUID, eventType, message string, metadata map[string]any) {
 	var metaBytes []byte
 	if metadata != nil {
@@ -489,7 +804,7 @@ func (w *Worker) deriveSourcePath(ctx context.Context, task dbgen.ImportTask) (s
 	if task.MediaType == "movie" {
 		mainFile, ok := importer.PickMainMovieFile(files)
 		if !ok {
-			return "", apperrors.AsPermanent(fmt.Errorf("no video files found in download"))
+			return "", apperrors.AsPermanent(fmt.Errorf("%w in download", importer.ErrNoVideo))
 		}
 		rawPath = mainFile.Path
 	} else {
@@ -512,7 +827,7 @@ func (w *Worker) deriveSourcePath(ctx context.Context, task dbgen.ImportTask) (s
 
 		seasonNum := int(season.SeasonNumber)
 		epNum := int(episode.EpisodeNumber)
-		matched := importer.MatchFilesToEpisodes(files, &seasonNum, &epNum)
+		matched := importer.MatchFilesToEpisodes(files, &seasonNum, &epNum, w.absoluteEpisodeMap(ctx, task.MediaItemID))
 
 		if f, ok := matched[epNum]; ok {
 			rawPath = f.Path
@@ -529,10 +844,21 @@ func (w *Worker) deriveSourcePath(ctx context.Context, task dbgen.ImportTask) (s
 		}
 	}
 
-	// Apply path mapping (stub - returns unchanged for now)
+	// Apply the downloader's remote path mappings
 	return w.pathMapper.Apply(ctx, job.DownloaderID, rawPath), nil
 }
 
+// absoluteEpisodeMap loads the absolute episode numbering stored for a series,
+// for fansub releases.
+func (w *Worker) absoluteEpisodeMap(ctx context.Context, mediaItemID pgtype.UUID) importer.AbsoluteEpisodeMap {
+	rows, err := w.repo.ListAbsoluteEpisodeNumbers(ctx, mediaItemID)
+	if err != nil {
+		w.log.Warn().Err(err).Msg("failed to load absolute episode numbers")
+		return nil
+	}
+	return importer.AbsoluteEpisodeMapFromRows(rows)
+}
+
 func strPtr(s string) *string {
 	if s == "" {
 		return nil
@@ -0,0 +1,64 @@
+// Package metadata implements the worker that keeps the metadata of library
+// items (titles, release dates, seasons and episodes) in sync with TMDB.
+package metadata
+
+import (
+	"context"
+	"time"
+
+	"github.com/kyleaupton/arrflix/internal/logger"
+)
+
+// Refresher refreshes stale library items when a refresh is due.
+type Refresher interface {
+	RunIfDue(ctx context.Context)
+}
+
+// Worker asks the refresher to run on a fixed poll interval. The refresher
+// decides whether a refresh is due.
+type Worker struct {
+	refresher Refresher
+	log       *logger.Logger
+
+	pollInterval time.Duration
+}
+
+// Config holds worker configuration.
+type Config struct {
+	PollInterval time.Duration
+}
+
+// DefaultConfig returns default worker configuration.
+func DefaultConfig() Config {
+	return Config{
+		PollInterval: time.Minute,
+	}
+}
+
+// New creates a new metadata worker.
+func New(r Refresher, log *logger.Logger) *Worker {
+	cfg := DefaultConfig()
+	return &Worker{
+		refresher:    r,
+		log:          log,
+		pollInterval: cfg.PollInterval,
+	}
+}
+
+// Run starts the worker loop.
+func (w *Worker) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.pollInterval)
+	defer ticker.Stop()
+
+	w.log.Info().Msg("metadata worker started")
+
+	for {
+		select {
+		case <-ctx.Done():
+			w.log.Info().Msg("metadata worker stopped")
+			return
+		case <-ticker.C:
+			w.refresher.RunIfDue(ctx)
+		}
+	}
+}
@@ -0,0 +1,65 @@
+// Package requests implements the worker that follows approved media
+// requests through downloading and importing until they are available.
+package requests
+
+import (
+	"context"
+	"time"
+
+	"github.com/kyleaupton/arrflix/internal/logger"
+)
+
+// Tracker updates the status of approved requests.
+type Tracker interface {
+	UpdateStatuses(ctx context.Context) error
+}
+
+// Worker asks the tracker to update request statuses on a fixed poll interval.
+type Worker struct {
+	tracker Tracker
+	log     *logger.Logger
+
+	pollInterval time.Duration
+}
+
+// Config holds worker configuration.
+type Config struct {
+	PollInterval time.Duration
+}
+
+// DefaultConfig returns default worker configuration.
+func DefaultConfig() Config {
+	return Config{
+		PollInterval: time.Minute,
+	}
+}
+
+// New creates a new requests worker.
+func New(t Tracker, log *logger.Logger) *Worker {
+	cfg := DefaultConfig()
+	return &Worker{
+		tracker:      t,
+		log:          log,
+		pollInterval: cfg.PollInterval,
+	}
+}
+
+// Run starts the worker loop.
+func (w *Worker) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.pollInterval)
+	defer ticker.Stop()
+
+	w.log.Info().Msg("requests worker started")
+
+	for {
+		select {
+		case <-ctx.Done():
+			w.log.Info().Msg("requests worker stopped")
+			return
+		case <-ticker.C:
+			if err := w.tracker.UpdateStatuses(ctx); err != nil {
+				w.log.Error().Err(err).Msg("Failed to update request statuses")
+			}
+		}
+	}
+}
@@ -0,0 +1,65 @@
+// Package search implements the worker that periodically searches for
+// monitored movies and episodes that are missing from the library, or whose
+// file is below their quality profile's cutoff.
+package search
+
+import (
+	"context"
+	"time"
+
+	"github.com/kyleaupton/arrflix/internal/logger"
+)
+
+// Searcher runs an automatic search pass when one is due.
+type Searcher interface {
+	RunIfDue(ctx context.Context)
+}
+
+// Worker asks the searcher to run on a fixed poll interval. The searcher
+// decides, from its settings, whether a pass is due.
+type Worker struct {
+	searcher Searcher
+	log      *logger.Logger
+
+	pollInterval time.Duration
+}
+
+// Config holds worker configuration.
+type Config struct {
+	PollInterval time.Duration
+}
+
+// DefaultConfig returns default worker configuration.
+func DefaultConfig() Config {
+	return Config{
+		PollInterval: time.Minute,
+	}
+}
+
+// New creates a new search worker.
+func New(s Searcher, log *logger.Logger) *Worker {
+	cfg := DefaultConfig()
+	return &Worker{
+		searcher:     s,
+		log:          log,
+		pollInterval: cfg.PollInterval,
+	}
+}
+
+// Run starts the worker loop.
+func (w *Worker) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.pollInterval)
+	defer ticker.Stop()
+
+	w.log.Info().Msg("search worker started")
+
+	for {
+		select {
+		case <-ctx.Done():
+			w.log.Info().Msg("search worker stopped")
+			return
+		case <-ticker.C:
+			w.searcher.RunIfDue(ctx)
+		}
+	}
+}
@@ -48,6 +48,13 @@ func (a *Analyzer) WithTimeout(timeout time.Duration) *Analyzer {
 	return a
 }
 
+// Available reports whether the mediainfo binary can be found, telling a
+// file mediainfo can't read apart from a missing install.
+func (a *Analyzer) Available() bool {
+	_, err := exec.LookPath(a.mediaInfoPath)
+	return err == nil
+}
+
 // MediaInfoResponse represents the JSON structure returned by mediainfo --Output=JSON
 type MediaInfoResponse struct {
 	Media MediaInfoMedia `json:"media"`
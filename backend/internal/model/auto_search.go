@@ -0,0 +1,77 @@
+package model
+
+import "time"
+
+// Automatic search triggers and outcomes
+const (
+	AutoSearchTriggerScheduled = "scheduled"
+	AutoSearchTriggerManual    = "manual"
+
+	AutoSearchOutcomeGrabbed   = "grabbed"
+	AutoSearchOutcomeRejected  = "rejected"
+	AutoSearchOutcomeNoResults = "no_results"
+	AutoSearchOutcomeFailed    = "failed"
+)
+
+// AutoSearchDecision records what an automatic search found and what it
+// grabbed, if anything, and why.
+type AutoSearchDecision struct {
+	ID              string                `json:"id"`
+	MediaItemID     string                `json:"mediaItemId"`
+	MediaType       MediaType             `json:"mediaType"`
+	TmdbID          int64                 `json:"tmdbId"`
+	Season          *int                  `json:"season,omitempty"`
+	Episode         *int                  `json:"episode,omitempty"`
+	Trigger         string                `json:"trigger"` // scheduled or manual
+	Upgrade         bool                  `json:"upgrade"` // searched for a better release than the file in the library
+	Outcome         string                `json:"outcome"` // grabbed, rejected, no_results or failed
+	Reason          string                `json:"reason,omitempty"`
+	SearchSessionID string                `json:"searchSessionId,omitempty"`
+	CandidateCount  int                   `json:"candidateCount"`
+	ChosenTitle     string                `json:"chosenTitle,omitempty"`
+	ChosenIndexerID *int64                `json:"chosenIndexerId,omitempty"`
+	ChosenGUID      string                `json:"chosenGuid,omitempty"`
+	DownloadJobID   string                `json:"downloadJobId,omitempty"`
+	Candidates      []AutoSearchCandidate `json:"candidates"`
+	Trace           *EvaluationTrace      `json:"trace,omitempty"` // evaluation of the grabbed candidate
+	CreatedAt       time.Time             `json:"createdAt"`
+}
+
+// AutoSearchCandidate is the automatic search's verdict on one search result.
+// Accepted candidates are ranked by policy score, then quality profile rank,
+// then match confidence, then seeders.
+type AutoSearchCandidate struct {
+	Title           string   `json:"title"`
+	IndexerID       int64    `json:"indexerId"`
+	GUID            string   `json:"guid"`
+	Accepted        bool     `json:"accepted"`
+	Reason          string   `json:"reason,omitempty"` // why it was not accepted
+	Score           int      `json:"score"`
+	ProfileRank     int      `json:"profileRank"`
+	MatchConfidence int      `json:"matchConfidence"`
+	Seeders         int      `json:"seeders"`
+	Policies        []string `json:"policies,omitempty"` // matched policies
+}
+
+// AutoSearchRequest is the request body for searching a series automatically.
+// Season alone searches for a season pack.
+type AutoSearchRequest struct {
+	Season  *int `json:"season"`
+	Episode *int `json:"episode,omitempty"`
+}
+
+// AutoSearchRun summarizes one pass over the wanted movies and episodes.
+type AutoSearchRun struct {
+	Trigger    string               `json:"trigger"`
+	StartedAt  time.Time            `json:"startedAt"`
+	FinishedAt time.Time            `json:"finishedAt"`
+	Searched   int                  `json:"searched"`
+	Grabbed    int                  `json:"grabbed"`
+	Decisions  []AutoSearchDecision `json:"decisions"`
+}
+
+// PaginatedAutoSearchDecisionResponse is the envelope for paginated automatic search decisions
+type PaginatedAutoSearchDecisionResponse struct {
+	Data       []AutoSearchDecision `json:"data"`
+	Pagination Pagination           `json:"pagination"`
+}
@@ -0,0 +1,47 @@
+package model
+
+import "time"
+
+// BlocklistEntry is a release that will not be grabbed again
+type BlocklistEntry struct {
+	ID            string     `json:"id"`
+	InfoHash      string     `json:"infoHash,omitempty"`
+	IndexerID     *int64     `json:"indexerId,omitempty"`
+	GUID          string     `json:"guid,omitempty"`
+	Title         string     `json:"title"`
+	Protocol      string     `json:"protocol,omitempty"`
+	Reason        string     `json:"reason"` // download_failed, import_failed, verification_failed or manual
+	Message       string     `json:"message,omitempty"`
+	MediaItemID   string     `json:"mediaItemId,omitempty"`
+	DownloadJobID string     `json:"downloadJobId,omitempty"`
+	ExpiresAt     *time.Time `json:"expiresAt,omitempty"`
+	CreatedAt     time.Time  `json:"createdAt"`
+}
+
+// CreateBlocklistEntryRequest is the request body for manually blocklisting a release.
+// When DownloadJobID is set the release is taken from that job; otherwise Title
+// and at least one of InfoHash or IndexerID/GUID identify it.
+type CreateBlocklistEntryRequest struct {
+	DownloadJobID string     `json:"downloadJobId,omitempty"`
+	IndexerID     *int64     `json:"indexerId,omitempty"`
+	GUID          string     `json:"guid,omitempty"`
+	Title         string     `json:"title,omitempty"`
+	InfoHash      string     `json:"infoHash,omitempty"`
+	Protocol      string     `json:"protocol,omitempty"`
+	MediaItemID   string     `json:"mediaItemId,omitempty"`
+	Message       string     `json:"message,omitempty"`
+	ExpiresAt     *time.Time `json:"expiresAt,omitempty"`
+}
+
+// UpdateBlocklistEntryRequest is the request body for updating a blocklist entry.
+// A nil ExpiresAt makes the entry permanent.
+type UpdateBlocklistEntryRequest struct {
+	Message   string     `json:"message"`
+	ExpiresAt *time.Time `json:"expiresAt"`
+}
+
+// PaginatedBlocklistResponse is the envelope for paginated blocklist entries
+type PaginatedBlocklistResponse struct {
+	Data       []BlocklistEntry `json:"data"`
+	Pagination Pagination       `json:"pagination"`
+}
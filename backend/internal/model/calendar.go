@@ -0,0 +1,32 @@
+package model
+
+// Calendar release types
+const (
+	CalendarReleaseAir        = "air" // an episode airing
+	CalendarReleaseTheatrical = "theatrical"
+	CalendarReleaseDigital    = "digital"
+	CalendarReleasePhysical   = "physical"
+)
+
+// CalendarEntry is an episode airing or a movie release on a given day.
+type CalendarEntry struct {
+	MediaItemID  string    `json:"mediaItemId"`
+	MediaType    MediaType `json:"mediaType"`
+	TmdbID       int64     `json:"tmdbId"`
+	Title        string    `json:"title"`
+	Year         *int      `json:"year,omitempty"`
+	Season       *int      `json:"season,omitempty"`
+	Episode      *int      `json:"episode,omitempty"`
+	EpisodeTitle string    `json:"episodeTitle,omitempty"`
+	Date         string    `json:"date"`        // YYYY-MM-DD
+	ReleaseType  string    `json:"releaseType"` // air, theatrical, digital or physical
+	Monitored    bool      `json:"monitored"`
+	HasFile      bool      `json:"hasFile"` // already downloaded
+}
+
+// CalendarFeed is the iCal feed of the calendar for the current user. Path is
+// relative to the server and includes the secret token.
+type CalendarFeed struct {
+	Token string `json:"token"`
+	Path  string `json:"path"`
+}
@@ -22,14 +22,16 @@ const (
 // and name template system. It uses prefixed namespaces:
 //   - candidate.* - Torrent/release metadata (available at policy time)
 //   - quality.*   - Parsed quality info (available at policy time)
-//   - release.*   - Release metadata like group and edition (available at policy time)
+//   - release.*   - Release metadata like group, edition, languages and codec tags (available at policy time)
 //   - media.*     - TMDB/media metadata (available at policy time)
+//   - profile.*   - Quality profile verdict on the candidate (available at policy time)
 //   - mediainfo.* - Video file analysis (available only post-download)
 type EvaluationContext struct {
 	Candidate CandidateFields  `namespace:"candidate"`
 	Quality   QualityFields    `namespace:"quality"`
 	Release   ReleaseFields    `namespace:"release"`
 	Media     MediaFields      `namespace:"media"`
+	Profile   ProfileFields    `namespace:"profile"`
 	MediaInfo *MediaInfoFields `namespace:"mediainfo"` // nil until post-download
 }
 
@@ -49,6 +51,15 @@ type CandidateFields struct {
 	PublishDate time.Time `path:"candidate.publish_date" label:"Publish Date" type:"text" phase:"pre_download"`
 	Link        string    `path:"candidate.link" label:"Link" type:"text" phase:"pre_download"`
 	GUID        string    `path:"candidate.guid" label:"GUID" type:"text" phase:"pre_download"`
+
+	// Indexer priority and reliability, from Prowlarr and recent search history
+	IndexerPriority            int     `path:"candidate.indexer_priority" label:"Indexer Priority" type:"number" phase:"pre_download"`
+	IndexerSuccessRate         float64 `path:"candidate.indexer_success_rate" label:"Indexer Success Rate (%)" type:"number" phase:"pre_download"`
+	IndexerAvgLatencyMs        int64   `path:"candidate.indexer_avg_latency_ms" label:"Indexer Avg Latency (ms)" type:"number" phase:"pre_download"`
+	IndexerConsecutiveFailures int     `path:"candidate.indexer_consecutive_failures" label:"Indexer Consecutive Failures" type:"number" phase:"pre_download"`
+
+	Blocklisted     bool `path:"candidate.blocklisted" label:"Blocklisted" type:"boolean" phase:"pre_download"`
+	MatchConfidence int  `path:"candidate.match_confidence" label:"Match Confidence (%)" type:"number" phase:"pre_download"`
 }
 
 // QualityFields contains parsed quality information from the release title
@@ -65,6 +76,22 @@ type QualityFields struct {
 type ReleaseFields struct {
 	ReleaseGroup string `path:"release.release_group" label:"Release Group" type:"text" phase:"pre_download"`
 	Edition      string `path:"release.edition" label:"Edition" type:"text" phase:"pre_download"`
+
+	// Languages named in the title; ["Unknown"] when there are none
+	Languages         []string `path:"release.languages" label:"Languages" type:"enum" enumValues:"Unknown,Original,Arabic,Bulgarian,Catalan,Chinese,Croatian,Czech,Danish,Dutch,English,Estonian,Finnish,Flemish,French,German,Greek,Hebrew,Hindi,Hungarian,Icelandic,Indonesian,Italian,Japanese,Korean,Latvian,Lithuanian,Malayalam,Norwegian,Persian,Polish,Portuguese,Portuguese (Brazil),Romanian,Russian,Serbian,Slovak,Slovenian,Spanish,Spanish (Latino),Swedish,Tamil,Telugu,Thai,Turkish,Ukrainian,Vietnamese" phase:"pre_download"`
+	SubtitleLanguages []string `path:"release.subtitle_languages" label:"Subtitle Languages" type:"enum" enumValues:"Unknown,Original,Arabic,Bulgarian,Catalan,Chinese,Croatian,Czech,Danish,Dutch,English,Estonian,Finnish,Flemish,French,German,Greek,Hebrew,Hindi,Hungarian,Icelandic,Indonesian,Italian,Japanese,Korean,Latvian,Lithuanian,Malayalam,Norwegian,Persian,Polish,Portuguese,Portuguese (Brazil),Romanian,Russian,Serbian,Slovak,Slovenian,Spanish,Spanish (Latino),Swedish,Tamil,Telugu,Thai,Turkish,Ukrainian,Vietnamese" phase:"pre_download"`
+	IsMulti           bool     `path:"release.is_multi" label:"Is Multi-Audio" type:"boolean" phase:"pre_download"`
+	IsSubbed          bool     `path:"release.is_subbed" label:"Is Subbed" type:"boolean" phase:"pre_download"`
+
+	// Codec, HDR and audio tags from the title, named like their mediainfo.* counterparts
+	VideoCodec       string   `path:"release.video_codec" label:"Video Codec (Title)" type:"enum" enumValues:"Unknown,H.264,H.265,AV1,VP9,VC-1,MPEG-2,Xvid,DivX" phase:"pre_download"`
+	HDR              []string `path:"release.hdr" label:"HDR Formats (Title)" type:"enum" enumValues:"None,HDR10,HDR10+,Dolby Vision,HLG" phase:"pre_download"`
+	DolbyVisionOnly  bool     `path:"release.dolby_vision_only" label:"Dolby Vision Only" type:"boolean" phase:"pre_download"`
+	BitDepth         int      `path:"release.bit_depth" label:"Bit Depth (Title)" type:"number" phase:"pre_download"`
+	AudioCodec       string   `path:"release.audio_codec" label:"Audio Codec (Title)" type:"enum" enumValues:"Unknown,AAC,AC3,EAC3,DTS,DTS-HD HRA,DTS-HD MA,DTS:X,TrueHD,FLAC,Opus,MP3,PCM" phase:"pre_download"`
+	AudioChannels    string   `path:"release.audio_channels" label:"Audio Channels (Title)" type:"enum" enumValues:"Unknown,1.0,2.0,5.1,6.1,7.1" phase:"pre_download"`
+	Atmos            bool     `path:"release.atmos" label:"Atmos" type:"boolean" phase:"pre_download"`
+	StreamingService string   `path:"release.streaming_service" label:"Streaming Service" type:"enum" enumValues:"Amazon,Netflix,Disney+,Apple TV+,HBO Max,HBO,Hulu,Paramount+,Peacock,iTunes,Stan,Crave,Crunchyroll" phase:"pre_download"`
 }
 
 // MediaFields contains TMDB/media metadata
@@ -79,6 +106,22 @@ type MediaFields struct {
 	EpisodeTitle *string `path:"media.episode_title" label:"Episode Title" type:"text" phase:"pre_download"`
 }
 
+// ProfileFields contains the media's quality profile and its verdict on the
+// candidate relative to the file already in the library. Empty when no
+// profile applies.
+type ProfileFields struct {
+	Name            string `path:"profile.name" label:"Quality Profile" type:"text" phase:"pre_download"`
+	Status          string `path:"profile.status" label:"Profile Status" type:"enum" enumValues:"allowed,rejected,upgrade" phase:"pre_download"`
+	Reason          string `path:"profile.reason" label:"Profile Rejection Reason" type:"text" phase:"pre_download"`
+	Allowed         bool   `path:"profile.allowed" label:"Allowed by Profile" type:"boolean" phase:"pre_download"`
+	IsUpgrade       bool   `path:"profile.is_upgrade" label:"Is Upgrade" type:"boolean" phase:"pre_download"`
+	MeetsCutoff     bool   `path:"profile.meets_cutoff" label:"Meets Cutoff" type:"boolean" phase:"pre_download"`
+	Rank            int    `path:"profile.rank" label:"Quality Rank" type:"number" phase:"pre_download"`
+	Cutoff          string `path:"profile.cutoff" label:"Cutoff Quality" type:"text" phase:"pre_download"`
+	HasFile         bool   `path:"profile.has_file" label:"Has Existing File" type:"boolean" phase:"pre_download"`
+	ExistingQuality string `path:"profile.existing_quality" label:"Existing Quality" type:"text" phase:"pre_download"`
+}
+
 // MediaInfoFields contains video file analysis data (populated post-download via mediainfo)
 type MediaInfoFields struct {
 	// Video properties
@@ -126,6 +169,12 @@ func NewEvaluationContext(candidate DownloadCandidate, result release.ParseResul
 			PublishDate: candidate.PublishDate,
 			Link:        candidate.Link,
 			GUID:        candidate.GUID,
+
+			IndexerPriority:    candidate.IndexerPriority,
+			IndexerSuccessRate: 100,
+
+			Blocklisted:     candidate.Blocklisted,
+			MatchConfidence: candidate.MatchConfidence,
 		},
 		Quality: QualityFields{
 			Full:       result.Quality.Full(),
@@ -138,6 +187,20 @@ func NewEvaluationContext(candidate DownloadCandidate, result release.ParseResul
 		Release: ReleaseFields{
 			ReleaseGroup: result.Release.GetReleaseGroup(),
 			Edition:      result.Release.GetEdition(),
+
+			Languages:         release.LanguageStrings(result.Release.Languages),
+			SubtitleLanguages: release.LanguageStrings(result.Release.SubtitleLanguages),
+			IsMulti:           result.Release.MultiAudio,
+			IsSubbed:          result.Release.Subbed,
+
+			VideoCodec:       result.Release.VideoCodec,
+			HDR:              result.Release.HDR,
+			DolbyVisionOnly:  result.Release.DolbyVisionOnly,
+			BitDepth:         result.Release.BitDepth,
+			AudioCodec:       result.Release.AudioCodec,
+			AudioChannels:    result.Release.AudioChannels,
+			Atmos:            result.Release.Atmos,
+			StreamingService: result.Release.StreamingService,
 		},
 		Media:     MediaFields{},
 		MediaInfo: nil,
@@ -164,6 +227,20 @@ func (ctx EvaluationContext) WithSeriesInfo(season, episode *int, episodeTitle *
 	return ctx
 }
 
+// WithProfile sets the quality profile fields
+func (ctx EvaluationContext) WithProfile(p ProfileFields) EvaluationContext {
+	ctx.Profile = p
+	return ctx
+}
+
+// WithIndexerReliability sets the indexer reliability fields on the candidate
+func (ctx EvaluationContext) WithIndexerReliability(r IndexerReliability) EvaluationContext {
+	ctx.Candidate.IndexerSuccessRate = r.SuccessRate
+	ctx.Candidate.IndexerAvgLatencyMs = r.AvgLatencyMs
+	ctx.Candidate.IndexerConsecutiveFailures = r.ConsecutiveFailures
+	return ctx
+}
+
 // WithMediaInfo sets the mediainfo fields (post-download)
 func (ctx EvaluationContext) WithMediaInfo(mi *MediaInfoFields) EvaluationContext {
 	ctx.MediaInfo = mi
@@ -185,8 +262,12 @@ func (ctx *EvaluationContext) GetField(path string) (interface{}, error) {
 		return getFieldByPath(&ctx.Candidate, "candidate."+fieldPath)
 	case "quality":
 		return getFieldByPath(&ctx.Quality, "quality."+fieldPath)
+	case "release":
+		return getFieldByPath(&ctx.Release, "release."+fieldPath)
 	case "media":
 		return getFieldByPath(&ctx.Media, "media."+fieldPath)
+	case "profile":
+		return getFieldByPath(&ctx.Profile, "profile."+fieldPath)
 	case "mediainfo":
 		if ctx.MediaInfo == nil {
 			return nil, fmt.Errorf("mediainfo not available (pre-download phase)")
@@ -246,6 +327,7 @@ func ListContextFields() []ContextFieldInfo {
 	fields = append(fields, extractFieldsFromStruct(reflect.TypeOf(QualityFields{}))...)
 	fields = append(fields, extractFieldsFromStruct(reflect.TypeOf(ReleaseFields{}))...)
 	fields = append(fields, extractFieldsFromStruct(reflect.TypeOf(MediaFields{}))...)
+	fields = append(fields, extractFieldsFromStruct(reflect.TypeOf(ProfileFields{}))...)
 	fields = append(fields, extractFieldsFromStruct(reflect.TypeOf(MediaInfoFields{}))...)
 
 	return fields
@@ -317,6 +399,7 @@ func (ctx *EvaluationContext) ToTemplateData() map[string]any {
 		"Quality":   ctx.Quality,
 		"Release":   ctx.Release,
 		"Media":     ctx.Media,
+		"Profile":   ctx.Profile,
 	}
 
 	// Always include MediaInfo (empty struct if not available) to avoid <no value> in templates
@@ -23,6 +23,59 @@ type DownloadCandidate struct {
 	Categories  []string  `json:"categories"`
 	PublishDate time.Time `json:"publishDate"`
 	Title       string    `json:"title"`
+	InfoHash    string    `json:"infoHash,omitempty"`
+
+	// IndexerPriority is the Prowlarr priority of the indexer (lower is preferred).
+	IndexerPriority int `json:"indexerPriority"`
+
+	// MatchedAlias is the title alias (TMDB title, alternative/translated title,
+	// or custom alias) the release was found under.
+	MatchedAlias string `json:"matchedAlias,omitempty"`
+
+	// MatchConfidence (0-100) is how well the release title, year, season and
+	// episode match the requested media; MatchMismatches explains deductions.
+	MatchConfidence int      `json:"matchConfidence"`
+	MatchMismatches []string `json:"matchMismatches,omitempty"`
+
+	// Blocklisted is set when the release matches a blocklist entry;
+	// blocklisted candidates are shown but cannot be enqueued.
+	Blocklisted     bool   `json:"blocklisted"`
+	BlocklistReason string `json:"blocklistReason,omitempty"`
+
+	// ProfileStatus is the quality profile's verdict relative to the file
+	// already in the library: allowed, rejected or upgrade. Empty when no
+	// profile applies. ProfileReason explains a rejection.
+	ProfileStatus string `json:"profileStatus,omitempty"`
+	ProfileReason string `json:"profileReason,omitempty"`
+}
+
+// DownloadCandidatesResponse is the result of a candidate search across all indexer sources
+type DownloadCandidatesResponse struct {
+	Session    SearchSession        `json:"session"`
+	Candidates []DownloadCandidate  `json:"candidates"`
+	Sources    []SearchSourceReport `json:"sources"`
+}
+
+// SearchSession describes a persisted candidate search. Its results can be
+// reopened and enqueued until ExpiresAt.
+type SearchSession struct {
+	ID          string    `json:"id"`
+	MediaType   MediaType `json:"mediaType"`
+	TmdbID      int64     `json:"tmdbId"`
+	Season      *int      `json:"season,omitempty"`
+	Episode     *int      `json:"episode,omitempty"`
+	Query       string    `json:"query"`
+	ResultCount int64     `json:"resultCount"`
+	CreatedAt   time.Time `json:"createdAt"`
+	ExpiresAt   time.Time `json:"expiresAt"`
+}
+
+// SearchSourceReport describes how a single indexer source performed during a search
+type SearchSourceReport struct {
+	Name        string `json:"name"`
+	DurationMs  int64  `json:"durationMs"`
+	ResultCount int    `json:"resultCount"`
+	Error       string `json:"error,omitempty"`
 }
 
 func (c *DownloadCandidate) GetMediaType() (MediaType, error) {
@@ -41,6 +94,7 @@ func (c *DownloadCandidate) GetMediaType() (MediaType, error) {
 
 // EnqueueCandidateRequest is the request body for enqueueing a download candidate
 type EnqueueCandidateRequest struct {
+	SessionID string `json:"sessionId,omitempty"`
 	IndexerID int64  `json:"indexerId"`
 	GUID      string `json:"guid"`
 }
@@ -90,8 +90,9 @@ func (t Title) TitleKey() string {
 // HydratedTitle includes optional user overlay applied during hydration
 type HydratedTitle struct {
 	Title
-	IsInLibrary   bool `json:"isInLibrary,omitempty"`
-	IsDownloading bool `json:"isDownloading,omitempty"`
+	IsInLibrary   bool               `json:"isInLibrary,omitempty"`
+	IsDownloading bool               `json:"isDownloading,omitempty"`
+	RequestStatus MediaRequestStatus `json:"requestStatus,omitempty"` // the user's latest request for the title
 }
 
 // FeedRow is a populated row ready for the frontend
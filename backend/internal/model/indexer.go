@@ -206,3 +206,41 @@ type IndexerBatchTestResult struct {
 	Message     string `json:"message,omitempty" swagger:"description:Success message"`
 	Error       string `json:"error,omitempty" swagger:"description:Error message if test failed"`
 }
+
+// IndexerReliability summarizes how an indexer has performed in recent searches.
+// Indexers without any recorded searches report a 100% success rate.
+type IndexerReliability struct {
+	Searches            int64   `json:"searches"`
+	Failures            int64   `json:"failures"`
+	SuccessRate         float64 `json:"successRate"` // percent
+	AvgLatencyMs        int64   `json:"avgLatencyMs"`
+	ConsecutiveFailures int     `json:"consecutiveFailures"`
+}
+
+// IndexerStatus is the health of a single indexer as tracked from searches
+type IndexerStatus struct {
+	IndexerID     int64      `json:"indexerId"`
+	Name          string     `json:"name"`
+	Enabled       bool       `json:"enabled"`
+	Priority      int        `json:"priority"`
+	LastSuccessAt *time.Time `json:"lastSuccessAt,omitempty"`
+	LastFailureAt *time.Time `json:"lastFailureAt,omitempty"`
+	LastError     string     `json:"lastError,omitempty"`
+	DisabledUntil *time.Time `json:"disabledUntil,omitempty"`
+	IndexerReliability
+}
+
+// IndexerSearchEvent is a single recorded search against an indexer
+type IndexerSearchEvent struct {
+	Query       string    `json:"query"`
+	DurationMs  int64     `json:"durationMs"`
+	ResultCount int       `json:"resultCount"`
+	Error       string    `json:"error,omitempty"`
+	CreatedAt   time.Time `json:"createdAt"`
+}
+
+// IndexerStatusDetail is an indexer's status with its recent search history
+type IndexerStatusDetail struct {
+	IndexerStatus
+	History []IndexerSearchEvent `json:"history"`
+}
@@ -160,6 +160,13 @@ const (
 	MediaTypeSeries MediaType = "series"
 )
 
+// ExternalIDs holds the identifiers a title is known by outside of TMDB
+type ExternalIDs struct {
+	TmdbID int64  `json:"tmdbId"`
+	ImdbID string `json:"imdbId,omitempty"`
+	TvdbID int64  `json:"tvdbId,omitempty"`
+}
+
 type WatchProvider struct {
 	ProviderID      int    `json:"providerId"`
 	ProviderName    string `json:"providerName"`
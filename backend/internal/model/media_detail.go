@@ -38,6 +38,7 @@ type MovieDetail struct {
 	BackdropPath  string  `json:"backdropPath,omitempty"`
 
 	Files           []FileInfo      `json:"files"`
+	Monitoring      Monitoring      `json:"monitoring"`
 	Credits         *Credits        `json:"credits,omitempty"`
 	Videos          []Video         `json:"videos,omitempty"`
 	Recommendations []MovieRail     `json:"recommendations,omitempty"`
@@ -52,6 +53,8 @@ type EpisodeAvailability struct {
 	StillPath     string    `json:"stillPath,omitempty"`
 	AirDate       *string   `json:"airDate,omitempty"`
 	Available     bool      `json:"available"`
+	Monitored     bool      `json:"monitored"`
+	Missing       bool      `json:"missing"`
 	File          *FileInfo `json:"file,omitempty"`
 }
 
@@ -60,6 +63,7 @@ type SeasonDetail struct {
 	Overview     string                `json:"overview,omitempty"`
 	PosterPath   string                `json:"posterPath,omitempty"`
 	AirDate      string                `json:"airDate,omitempty"`
+	Monitored    bool                  `json:"monitored"`
 	Episodes     []EpisodeAvailability `json:"episodes"`
 }
 
@@ -81,6 +85,7 @@ type SeriesDetail struct {
 	BackdropPath   string  `json:"backdropPath,omitempty"`
 
 	Availability   Availability    `json:"availability"`
+	Monitoring     Monitoring      `json:"monitoring"`
 	Seasons        []SeasonDetail  `json:"seasons"`
 	Credits        *Credits        `json:"credits,omitempty"`
 	Videos         []Video         `json:"videos,omitempty"`
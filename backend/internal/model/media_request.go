@@ -0,0 +1,66 @@
+package model
+
+import "time"
+
+// MediaRequestStatus is where a media request stands, from the decision
+// through to the requested items being on disk.
+type MediaRequestStatus string
+
+const (
+	MediaRequestPending     MediaRequestStatus = "pending"
+	MediaRequestApproved    MediaRequestStatus = "approved"
+	MediaRequestDeclined    MediaRequestStatus = "declined"
+	MediaRequestDownloading MediaRequestStatus = "downloading"
+	MediaRequestImporting   MediaRequestStatus = "importing"
+	MediaRequestAvailable   MediaRequestStatus = "available"
+)
+
+// MediaRequest is a user's request for a movie, a series or some of its seasons.
+type MediaRequest struct {
+	ID          string             `json:"id"`
+	UserID      string             `json:"userId"`
+	RequestedBy string             `json:"requestedBy"` // username of the requester
+	MediaType   MediaType          `json:"mediaType"`
+	TmdbID      int64              `json:"tmdbId"`
+	Title       string             `json:"title"`
+	Year        *int               `json:"year,omitempty"`
+	Seasons     []int              `json:"seasons"`     // series only; empty is the whole series
+	SeasonCount int                `json:"seasonCount"` // seasons counted against the quota
+	Status      MediaRequestStatus `json:"status"`
+
+	QualityProfileID string `json:"qualityProfileId,omitempty"` // asked for by the requester
+
+	DecidedBy       string     `json:"decidedBy,omitempty"` // username of the approver
+	DecidedAt       *time.Time `json:"decidedAt,omitempty"`
+	DecisionComment string     `json:"decisionComment,omitempty"`
+
+	MediaItemID string     `json:"mediaItemId,omitempty"` // set on approval
+	AvailableAt *time.Time `json:"availableAt,omitempty"`
+	CreatedAt   time.Time  `json:"createdAt"`
+	UpdatedAt   time.Time  `json:"updatedAt"`
+}
+
+// CreateMediaRequest is the request body for requesting a movie or series.
+// QualityProfileID is used on approval of a title that isn't monitored yet
+// unless the approver picks another.
+type CreateMediaRequest struct {
+	MediaType        MediaType `json:"mediaType"`
+	TmdbID           int64     `json:"tmdbId"`
+	Seasons          []int     `json:"seasons,omitempty"` // series only; omitted requests the whole series
+	QualityProfileID *string   `json:"qualityProfileId,omitempty"`
+}
+
+// MediaRequestDecision is the request body for approving or declining a
+// request. LibraryID and QualityProfileID apply to approvals of titles that
+// aren't monitored yet; null uses the defaults.
+type MediaRequestDecision struct {
+	Comment          string  `json:"comment,omitempty"`
+	LibraryID        *string `json:"libraryId,omitempty"`
+	QualityProfileID *string `json:"qualityProfileId,omitempty"`
+}
+
+// PaginatedMediaRequestsResponse is the envelope for paginated media requests
+type PaginatedMediaRequestsResponse struct {
+	Data       []MediaRequest `json:"data"`
+	Pagination Pagination     `json:"pagination"`
+}
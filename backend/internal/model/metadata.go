@@ -0,0 +1,17 @@
+package model
+
+// MetadataRefresh is the outcome of refreshing a library item from TMDB.
+type MetadataRefresh struct {
+	MediaItemID string    `json:"mediaItemId"`
+	MediaType   MediaType `json:"mediaType"`
+	TmdbID      int64     `json:"tmdbId"`
+	Title       string    `json:"title"`
+	Year        *int      `json:"year,omitempty"`
+
+	// Series only
+	EpisodesAdded   int `json:"episodesAdded"`
+	EpisodesMoved   int `json:"episodesMoved"`   // renumbered or moved to another season
+	EpisodesMerged  int `json:"episodesMerged"`  // duplicate rows folded into the TMDB episode
+	EpisodesRemoved int `json:"episodesRemoved"` // gone from TMDB
+	EpisodesKept    int `json:"episodesKept"`    // gone from TMDB, kept for their files or downloads
+}
@@ -0,0 +1,48 @@
+package model
+
+// MonitorRequest is the request body for monitoring a movie or series.
+// Monitoring a title that isn't in the library yet adds it from TMDB.
+type MonitorRequest struct {
+	Monitored bool `json:"monitored"`
+
+	// Series only. MonitorNewSeasons monitors seasons TMDB adds later.
+	// Seasons picks the seasons to monitor; omitted monitors every season
+	// except specials. Ignored when Monitored is false.
+	MonitorNewSeasons bool  `json:"monitorNewSeasons"`
+	Seasons           []int `json:"seasons,omitempty"`
+
+	// LibraryID is where grabbed releases are imported; null uses the
+	// default library. QualityProfileID null uses the library's profile.
+	LibraryID        *string `json:"libraryId"`
+	QualityProfileID *string `json:"qualityProfileId"`
+}
+
+// SetMonitoredRequest toggles monitoring for a season or episode.
+type SetMonitoredRequest struct {
+	Monitored bool `json:"monitored"`
+}
+
+// Monitoring is the monitored and missing state of a movie or series.
+type Monitoring struct {
+	Monitored         bool    `json:"monitored"`
+	MonitorNewSeasons bool    `json:"monitorNewSeasons,omitempty"`
+	LibraryID         *string `json:"libraryId,omitempty"`
+	QualityProfileID  *string `json:"qualityProfileId,omitempty"`
+
+	HasFile bool `json:"hasFile"`
+	Missing bool `json:"missing"` // monitored with nothing (movie) or aired episodes (series) on disk
+
+	// Series only
+	MonitoredEpisodes int                `json:"monitoredEpisodes,omitempty"`
+	MissingEpisodes   int                `json:"missingEpisodes,omitempty"`
+	Seasons           []SeasonMonitoring `json:"seasons,omitempty"`
+}
+
+// SeasonMonitoring is the monitored and missing state of one season.
+type SeasonMonitoring struct {
+	SeasonNumber      int32 `json:"seasonNumber"`
+	Monitored         bool  `json:"monitored"`
+	Episodes          int   `json:"episodes"`
+	MonitoredEpisodes int   `json:"monitoredEpisodes"`
+	MissingEpisodes   int   `json:"missingEpisodes"`
+}
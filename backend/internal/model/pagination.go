@@ -24,5 +24,8 @@ type LibraryItem struct {
 	TmdbID     *int64 `json:"tmdbId,omitempty"`
 	PosterPath string `json:"posterPath,omitempty"`
 	CreatedAt  string `json:"createdAt"`
-}
 
+	Monitored       bool `json:"monitored"`
+	Missing         bool `json:"missing"`
+	MissingEpisodes int  `json:"missingEpisodes,omitempty"` // series only
+}
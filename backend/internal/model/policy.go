@@ -8,6 +8,12 @@ type Plan struct {
 	DownloaderID   string `json:"downloaderId"`   // how to download
 	LibraryID      string `json:"libraryId"`      // where to move/hardlink/copy the file to
 	NameTemplateID string `json:"nameTemplateId"` // how to name the file
+
+	// Score ranks candidates during automatic search; the highest wins.
+	// A rejected candidate is never grabbed automatically.
+	Score        int    `json:"score"`
+	Rejected     bool   `json:"rejected"`
+	RejectReason string `json:"rejectReason,omitempty"`
 }
 
 // Note: CandidateContext has been replaced by EvaluationContext in context.go
@@ -55,6 +61,8 @@ const (
 	ActionSetLibrary      ActionType = "set_library"
 	ActionSetNameTemplate ActionType = "set_name_template"
 	ActionStopProcessing  ActionType = "stop_processing"
+	ActionAddScore        ActionType = "add_score" // value is an integer, may be negative
+	ActionReject          ActionType = "reject"    // value is the reason
 )
 
 type Action struct {
@@ -77,6 +85,7 @@ type ContextSnapshot struct {
 	Quality   map[string]any `json:"quality"`
 	Release   map[string]any `json:"release"`
 	Media     map[string]any `json:"media"`
+	Profile   map[string]any `json:"profile,omitempty"`
 	MediaInfo map[string]any `json:"mediainfo,omitempty"`
 }
 
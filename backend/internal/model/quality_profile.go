@@ -0,0 +1,42 @@
+package model
+
+import (
+	"time"
+
+	"github.com/kyleaupton/arrflix/internal/qualityprofile"
+)
+
+// QualityProfile is an ordered list of allowed qualities, least preferred
+// first, with the quality at which upgrades stop.
+type QualityProfile struct {
+	ID              string                `json:"id"`
+	Name            string                `json:"name"`
+	Items           []qualityprofile.Item `json:"items"`
+	Cutoff          string                `json:"cutoff"` // name of an item in Items
+	UpgradesAllowed bool                  `json:"upgradesAllowed"`
+	Default         bool                  `json:"default"`
+	CreatedAt       time.Time             `json:"createdAt"`
+	UpdatedAt       time.Time             `json:"updatedAt"`
+}
+
+// QualityProfileRequest is the request body for creating or updating a quality profile.
+type QualityProfileRequest struct {
+	Name            string                `json:"name"`
+	Items           []qualityprofile.Item `json:"items"`
+	Cutoff          string                `json:"cutoff"`
+	UpgradesAllowed bool                  `json:"upgradesAllowed"`
+	Default         bool                  `json:"default"`
+}
+
+// SetQualityProfileRequest assigns a quality profile to a movie or series.
+// A nil QualityProfileID falls back to the library's profile.
+type SetQualityProfileRequest struct {
+	QualityProfileID *string `json:"qualityProfileId"`
+}
+
+// MediaQualityProfile is the profile that applies to a movie or series and
+// where it comes from.
+type MediaQualityProfile struct {
+	Profile QualityProfile `json:"profile"`
+	Source  string         `json:"source"` // media, library or default
+}
@@ -0,0 +1,36 @@
+package model
+
+import "time"
+
+// RemotePathMapping maps a path prefix as a downloader reports it to where
+// Arrflix sees the same storage.
+type RemotePathMapping struct {
+	ID             string    `json:"id"`
+	DownloaderID   string    `json:"downloaderId"`
+	DownloaderName string    `json:"downloaderName,omitempty"`
+	RemotePath     string    `json:"remotePath"` // e.g. /downloads
+	LocalPath      string    `json:"localPath"`  // e.g. /data/torrents
+	CreatedAt      time.Time `json:"createdAt"`
+	UpdatedAt      time.Time `json:"updatedAt"`
+}
+
+// RemotePathMappingRequest is the request body for creating or updating a
+// remote path mapping.
+type RemotePathMappingRequest struct {
+	DownloaderID string `json:"downloaderId"`
+	RemotePath   string `json:"remotePath"`
+	LocalPath    string `json:"localPath"`
+}
+
+// RemotePathCheck reports whether Arrflix can see the files of a downloader's
+// most recent item once its remote path mappings are applied.
+type RemotePathCheck struct {
+	DownloaderID string `json:"downloaderId"`
+	ItemName     string `json:"itemName"`
+	RemotePath   string `json:"remotePath"` // as the downloader reports it
+	LocalPath    string `json:"localPath"`  // after mapping
+	MappingID    string `json:"mappingId,omitempty"`
+	Mapped       bool   `json:"mapped"`
+	Exists       bool   `json:"exists"`
+	Message      string `json:"message"`
+}
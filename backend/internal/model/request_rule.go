@@ -0,0 +1,150 @@
+package model
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// RequestRuleAction is what a matching request rule does with a new request.
+type RequestRuleAction string
+
+const (
+	RequestRuleApprove         RequestRuleAction = "approve"
+	RequestRuleRequireApproval RequestRuleAction = "require_approval"
+)
+
+// RequestRule decides per role whether new requests are approved right away.
+// Rules of a user's roles are evaluated in priority order and the first match
+// decides; a rule without a condition always matches. When none matches the
+// request waits for approval.
+type RequestRule struct {
+	ID           string            `json:"id"`
+	RoleID       string            `json:"roleId"`
+	RoleName     string            `json:"roleName,omitempty"`
+	Name         string            `json:"name"`
+	Enabled      bool              `json:"enabled"`
+	Priority     int               `json:"priority"`
+	LeftOperand  string            `json:"leftOperand,omitempty"` // e.g. "request.max_resolution"
+	Operator     string            `json:"operator,omitempty"`
+	RightOperand string            `json:"rightOperand,omitempty"`
+	Action       RequestRuleAction `json:"action"`
+	CreatedAt    time.Time         `json:"createdAt"`
+	UpdatedAt    time.Time         `json:"updatedAt"`
+}
+
+// RequestRuleRequest is the request body for creating or updating a request
+// rule. Leave the operands and operator empty for a rule that always matches.
+type RequestRuleRequest struct {
+	RoleID       string            `json:"roleId"`
+	Name         string            `json:"name"`
+	Enabled      *bool             `json:"enabled,omitempty"` // default true
+	Priority     int               `json:"priority"`
+	LeftOperand  string            `json:"leftOperand,omitempty"`
+	Operator     string            `json:"operator,omitempty"`
+	RightOperand string            `json:"rightOperand,omitempty"`
+	Action       RequestRuleAction `json:"action"`
+}
+
+// RequestQuota limits how many movies and seasons a role or user can request
+// in a rolling window. A user's own quota replaces those of their roles;
+// across roles the most generous limit applies. Null limits are unlimited.
+type RequestQuota struct {
+	SubjectType string `json:"subjectType"` // role or user
+	SubjectID   string `json:"subjectId"`
+	MovieLimit  *int   `json:"movieLimit"`
+	SeasonLimit *int   `json:"seasonLimit"`
+	WindowDays  int    `json:"windowDays"`
+}
+
+// RequestQuotaRequest is the request body for setting a quota.
+type RequestQuotaRequest struct {
+	MovieLimit  *int `json:"movieLimit"`
+	SeasonLimit *int `json:"seasonLimit"`
+	WindowDays  int  `json:"windowDays,omitempty"` // default 7
+}
+
+// QuotaUsage is what a user has requested of one kind within its window.
+// Limit and Remaining are null when unlimited.
+type QuotaUsage struct {
+	Limit      *int `json:"limit"`
+	Used       int  `json:"used"`
+	Remaining  *int `json:"remaining"`
+	WindowDays int  `json:"windowDays"`
+}
+
+// RequestQuotaUsage is a user's quota usage for movies and seasons.
+type RequestQuotaUsage struct {
+	Movies  QuotaUsage `json:"movies"`
+	Seasons QuotaUsage `json:"seasons"`
+}
+
+// RequestEvaluation is the outcome of evaluating a user's request rules.
+type RequestEvaluation struct {
+	AutoApprove bool                    `json:"autoApprove"`
+	RuleID      string                  `json:"ruleId,omitempty"` // the rule that decided, if any
+	RuleName    string                  `json:"ruleName,omitempty"`
+	Rules       []RequestRuleEvaluation `json:"rules"`
+}
+
+// RequestRuleEvaluation is the result of evaluating a single request rule.
+type RequestRuleEvaluation struct {
+	RuleID        string            `json:"ruleId"`
+	RuleName      string            `json:"ruleName"`
+	Priority      int               `json:"priority"`
+	Matched       bool              `json:"matched"`
+	RuleEvaluated *RuleInfo         `json:"ruleEvaluated,omitempty"`
+	Action        RequestRuleAction `json:"action"`
+}
+
+// RequestContext is what request rules are evaluated against:
+//   - request.* - The requested title
+//   - user.*    - The requester
+type RequestContext struct {
+	Request RequestFields     `namespace:"request"`
+	User    RequestUserFields `namespace:"user"`
+}
+
+// RequestFields describes a new media request
+type RequestFields struct {
+	MediaType      string `path:"request.media_type" label:"Media Type" type:"enum" enumValues:"movie,series"`
+	Title          string `path:"request.title" label:"Title" type:"text"`
+	Year           int    `path:"request.year" label:"Year" type:"number"`
+	TmdbID         int64  `path:"request.tmdb_id" label:"TMDB ID" type:"number"`
+	SeasonCount    int    `path:"request.season_count" label:"Season Count" type:"number"`
+	WholeSeries    bool   `path:"request.whole_series" label:"Whole Series" type:"boolean"`
+	QualityProfile string `path:"request.quality_profile" label:"Quality Profile" type:"dynamic" dynamicSource:"/api/v1/quality-profiles"`
+	MaxResolution  string `path:"request.max_resolution" label:"Max Resolution" type:"enum" enumValues:"Unknown,SD,480p,576p,720p,1080p,1440p,2160p,4320p"`
+}
+
+// RequestUserFields describes the requester
+type RequestUserFields struct {
+	Username     string `path:"user.username" label:"Username" type:"text"`
+	OpenRequests int    `path:"user.open_requests" label:"Open Requests" type:"number"`
+}
+
+// GetField retrieves a field value by its path (e.g., "request.media_type")
+func (ctx *RequestContext) GetField(path string) (interface{}, error) {
+	namespace, _, ok := strings.Cut(path, ".")
+	if !ok {
+		return nil, fmt.Errorf("invalid field path: %s (expected namespace.field)", path)
+	}
+
+	switch namespace {
+	case "request":
+		return getFieldByPath(&ctx.Request, path)
+	case "user":
+		return getFieldByPath(&ctx.User, path)
+	default:
+		return nil, fmt.Errorf("unknown namespace: %s", namespace)
+	}
+}
+
+// ListRequestContextFields returns all fields available to request rules
+func ListRequestContextFields() []ContextFieldInfo {
+	var fields []ContextFieldInfo
+	fields = append(fields, extractFieldsFromStruct(reflect.TypeOf(RequestFields{}))...)
+	fields = append(fields, extractFieldsFromStruct(reflect.TypeOf(RequestUserFields{}))...)
+	return fields
+}
@@ -0,0 +1,29 @@
+package model
+
+// TitleAliasSource identifies where a search title came from
+type TitleAliasSource string
+
+const (
+	TitleAliasSourcePrimary     TitleAliasSource = "primary"
+	TitleAliasSourceOriginal    TitleAliasSource = "original"
+	TitleAliasSourceAlternative TitleAliasSource = "alternative"
+	TitleAliasSourceTranslation TitleAliasSource = "translation"
+	TitleAliasSourceCustom      TitleAliasSource = "custom"
+)
+
+// TitleAlias is a title a movie or series may be released under
+type TitleAlias struct {
+	ID       *string          `json:"id,omitempty"` // set for custom aliases
+	Title    string           `json:"title"`
+	Source   TitleAliasSource `json:"source"`
+	Country  string           `json:"country,omitempty"`  // ISO 3166-1, TMDB titles only
+	Language string           `json:"language,omitempty"` // ISO 639-1, translations only
+	Excluded bool             `json:"excluded"`           // hidden from candidate searches
+	Searched bool             `json:"searched"`           // queried during candidate searches
+}
+
+// CreateTitleAliasRequest is the request body for adding a custom title alias
+type CreateTitleAliasRequest struct {
+	Title    string `json:"title"`
+	Excluded bool   `json:"excluded"`
+}
@@ -0,0 +1,49 @@
+package model
+
+import "time"
+
+// WantedItem is a movie or episode that is missing its file, or whose file
+// is below its quality profile's cutoff.
+type WantedItem struct {
+	MediaItemID    string     `json:"mediaItemId"`
+	MediaType      MediaType  `json:"mediaType"`
+	TmdbID         int64      `json:"tmdbId"`
+	Title          string     `json:"title"`
+	Year           *int       `json:"year,omitempty"`
+	EpisodeID      string     `json:"episodeId,omitempty"` // episodes only
+	Season         *int       `json:"season,omitempty"`
+	Episode        *int       `json:"episode,omitempty"`
+	EpisodeTitle   string     `json:"episodeTitle,omitempty"`
+	AirDate        string     `json:"airDate,omitempty"` // YYYY-MM-DD; theatrical release for movies
+	Monitored      bool       `json:"monitored"`
+	LastSearchedAt *time.Time `json:"lastSearchedAt,omitempty"`
+
+	// Cutoff unmet only
+	Quality string `json:"quality,omitempty"` // quality of the file in the library
+	Cutoff  string `json:"cutoff,omitempty"`  // profile item at which upgrades stop
+}
+
+// PaginatedWantedResponse is the envelope for paginated wanted items
+type PaginatedWantedResponse struct {
+	Data       []WantedItem `json:"data"`
+	Pagination Pagination   `json:"pagination"`
+}
+
+// WantedSearchRequest is the request body for searching wanted items in bulk.
+type WantedSearchRequest struct {
+	Items []WantedSearchItem `json:"items"`
+}
+
+// WantedSearchItem is a movie, or an episode of a series, to search for.
+type WantedSearchItem struct {
+	MediaType MediaType `json:"mediaType"`
+	TmdbID    int64     `json:"tmdbId"`
+	Season    *int      `json:"season,omitempty"`  // series only
+	Episode   *int      `json:"episode,omitempty"` // series only; season alone searches for a season pack
+}
+
+// WantedSearchQueued is the response to a bulk search: the searches run in
+// the background and are recorded as automatic search decisions.
+type WantedSearchQueued struct {
+	Queued int `json:"queued"`
+}
@@ -3,21 +3,105 @@ package pathmapping
 
 import (
 	"context"
+	"path"
+	"path/filepath"
+	"strings"
 
 	"github.com/jackc/pgx/v5/pgtype"
+	dbgen "github.com/kyleaupton/arrflix/internal/db/sqlc"
 )
 
+// Mapping maps a path prefix as a downloader reports it to where Arrflix sees
+// the same location.
+type Mapping struct {
+	ID         pgtype.UUID
+	RemotePath string
+	LocalPath  string
+}
+
+// Store lists the remote path mappings of a downloader.
+type Store interface {
+	ListRemotePathMappingsForDownloader(ctx context.Context, downloaderID pgtype.UUID) ([]dbgen.RemotePathMapping, error)
+}
+
 // Mapper translates paths from the downloader's filesystem view to Arrflix's view.
-type Mapper struct{}
+type Mapper struct {
+	store Store
+}
 
 // New creates a new path mapper.
-func New() *Mapper {
-	return &Mapper{}
+func New(store Store) *Mapper {
+	return &Mapper{store: store}
 }
 
-// Apply translates a path from downloader's view to Arrflix's view.
-// Currently a no-op stub - returns path unchanged.
-// TODO: Implement remote_path_mapping table lookup using downloaderID.
+// Apply translates a path from downloader's view to Arrflix's view using the
+// downloader's remote path mappings. Paths no mapping matches are returned
+// unchanged, as are all paths when the mappings can't be loaded.
 func (m *Mapper) Apply(ctx context.Context, downloaderID pgtype.UUID, path string) string {
-	return path
+	mappings, err := m.Mappings(ctx, downloaderID)
+	if err != nil {
+		return path
+	}
+	mapped, _, _ := Map(mappings, path)
+	return mapped
+}
+
+// Mappings returns the remote path mappings of a downloader.
+func (m *Mapper) Mappings(ctx context.Context, downloaderID pgtype.UUID) ([]Mapping, error) {
+	rows, err := m.store.ListRemotePathMappingsForDownloader(ctx, downloaderID)
+	if err != nil {
+		return nil, err
+	}
+	mappings := make([]Mapping, 0, len(rows))
+	for _, row := range rows {
+		mappings = append(mappings, Mapping{ID: row.ID, RemotePath: row.RemotePath, LocalPath: row.LocalPath})
+	}
+	return mappings, nil
+}
+
+// Map translates p with the mapping whose remote path is its longest prefix,
+// compared by path components so /downloads doesn't match /downloads2.
+// Remote paths may use either separator, as downloaders can run on Windows.
+// It returns p unchanged and false when no mapping matches.
+func Map(mappings []Mapping, p string) (string, Mapping, bool) {
+	parts := components(p)
+	best, bestLen := -1, 0
+	for i, m := range mappings {
+		prefix := components(m.RemotePath)
+		if len(prefix) <= bestLen || !hasPrefix(parts, prefix) {
+			continue
+		}
+		best, bestLen = i, len(prefix)
+	}
+	if best < 0 {
+		return p, Mapping{}, false
+	}
+	rest := parts[bestLen:]
+	return filepath.Join(append([]string{mappings[best].LocalPath}, rest...)...), mappings[best], true
+}
+
+// components splits a cleaned path into its components. An absolute path
+// starts with an empty component, so it never matches a relative one.
+func components(p string) []string {
+	p = strings.TrimSpace(strings.ReplaceAll(p, `\`, "/"))
+	if p == "" {
+		return nil
+	}
+	p = path.Clean(p)
+	if p == "/" {
+		return []string{""}
+	}
+	return strings.Split(p, "/")
+}
+
+func hasPrefix(parts, prefix []string) bool {
+	if len(prefix) > len(parts) {
+		return false
+	}
+	for i := range prefix {
+		if parts[i] != prefix[i] {
+			return false
+		}
+	}
+	return true
 }
@@ -0,0 +1,38 @@
+package pathmapping
+
+import "testing"
+
+func TestMap(t *testing.T) {
+	mappings := []Mapping{
+		{RemotePath: "/downloads", LocalPath: "/data/torrents"},
+		{RemotePath: "/downloads/tv/", LocalPath: "/mnt/tv"},
+		{RemotePath: `D:\Downloads`, LocalPath: "/data/windows"},
+		{RemotePath: "/", LocalPath: "/remote-root"},
+	}
+
+	tests := []struct {
+		path    string
+		want    string
+		matched bool
+	}{
+		{"/downloads/Movie.2020/movie.mkv", "/data/torrents/Movie.2020/movie.mkv", true},
+		{"/downloads", "/data/torrents", true},
+		// The longest prefix wins
+		{"/downloads/tv/Show.S01/ep.mkv", "/mnt/tv/Show.S01/ep.mkv", true},
+		// Components, not string prefixes
+		{"/downloads2/movie.mkv", "/remote-root/downloads2/movie.mkv", true},
+		{`D:\Downloads\Movie\movie.mkv`, "/data/windows/Movie/movie.mkv", true},
+		{"relative/movie.mkv", "relative/movie.mkv", false},
+	}
+	for _, tt := range tests {
+		got, _, ok := Map(mappings, tt.path)
+		if got != tt.want || ok != tt.matched {
+			t.Errorf("Map(%q) = %q, %v; want %q, %v", tt.path, got, ok, tt.want, tt.matched)
+		}
+	}
+
+	// Without a catch-all, unmatched paths are unchanged
+	if got, _, ok := Map(mappings[:1], "/downloads2/movie.mkv"); ok || got != "/downloads2/movie.mkv" {
+		t.Errorf("Map() = %q, %v; want the path unchanged", got, ok)
+	}
+}
@@ -155,6 +155,14 @@ func (e *Engine) Evaluate(ctx context.Context, evalCtx model.EvaluationContext)
 		}
 	}
 
+	// Monitored titles go to the library chosen when they were monitored
+	if trace.FinalPlan.LibraryID == "" && evalCtx.Media.TmdbID != 0 {
+		item, err := e.repo.GetMediaItemByTmdbIDAndType(ctx, evalCtx.Media.TmdbID, string(mediaType))
+		if err == nil && item.LibraryID.Valid {
+			trace.FinalPlan.LibraryID = item.LibraryID.String()
+		}
+	}
+
 	if trace.FinalPlan.LibraryID == "" {
 		library, err := e.repo.GetDefaultLibrary(ctx, string(mediaType))
 		if err != nil {
@@ -303,7 +311,7 @@ func (e *Engine) getValue(operand string, evalCtx model.EvaluationContext) (inte
 
 		// Handle known namespaces using the unified GetField
 		switch namespace {
-		case "candidate", "quality", "media", "mediainfo":
+		case "candidate", "quality", "release", "media", "profile", "mediainfo":
 			val, err := evalCtx.GetField(operand)
 			if err != nil {
 				// For mediainfo fields that aren't available yet, return nil gracefully
@@ -321,20 +329,27 @@ func (e *Engine) getValue(operand string, evalCtx model.EvaluationContext) (inte
 		}
 	}
 
-	// Try to parse as number
+	return literal(operand), nil
+}
+
+// literal parses an operand that isn't a field reference as a number, or
+// keeps it as a string.
+func literal(operand string) interface{} {
 	if num, err := strconv.ParseInt(operand, 10, 64); err == nil {
-		return num, nil
+		return num
 	}
 	if num, err := strconv.ParseFloat(operand, 64); err == nil {
-		return num, nil
+		return num
 	}
-
-	// Return as string
-	return operand, nil
+	return operand
 }
 
 // compare compares two values based on operator
 func (e *Engine) compare(left interface{}, operator model.Operator, right interface{}) (bool, error) {
+	if list, ok := left.([]string); ok {
+		return e.compareList(list, operator, right)
+	}
+
 	switch operator {
 	case model.OpEq:
 		return e.equals(left, right), nil
@@ -370,6 +385,41 @@ func (e *Engine) compare(left interface{}, operator model.Operator, right interf
 	}
 }
 
+// compareList compares a list field (languages, HDR formats) with membership
+// semantics: release.languages == "French" holds when French is one of the
+// languages, release.languages not in "French,German" when neither is.
+func (e *Engine) compareList(list []string, operator model.Operator, right interface{}) (bool, error) {
+	anyOf := func(match func(v string) (bool, error)) (bool, error) {
+		for _, v := range list {
+			ok, err := match(v)
+			if err != nil || ok {
+				return ok, err
+			}
+		}
+		return false, nil
+	}
+	equal := func(v string) (bool, error) { return e.equals(v, right), nil }
+	in := func(v string) (bool, error) { return e.in(v, right) }
+	contains := func(v string) (bool, error) { return e.contains(v, right) }
+
+	switch operator {
+	case model.OpEq:
+		return anyOf(equal)
+	case model.OpNe:
+		found, err := anyOf(equal)
+		return !found, err
+	case model.OpContains:
+		return anyOf(contains)
+	case model.OpIn:
+		return anyOf(in)
+	case model.OpNotIn:
+		found, err := anyOf(in)
+		return !found, err
+	default:
+		return false, fmt.Errorf("operator %s is not supported for list fields", operator)
+	}
+}
+
 func (e *Engine) equals(left, right interface{}) bool {
 	return fmt.Sprintf("%v", left) == fmt.Sprintf("%v", right)
 }
@@ -460,6 +510,17 @@ func (e *Engine) applyAction(plan *model.Plan, action dbgen.Action) error {
 		plan.LibraryID = action.Value
 	case model.ActionSetNameTemplate:
 		plan.NameTemplateID = action.Value
+	case model.ActionAddScore:
+		score, err := strconv.Atoi(strings.TrimSpace(action.Value))
+		if err != nil {
+			return fmt.Errorf("invalid score %q: %w", action.Value, err)
+		}
+		plan.Score += score
+	case model.ActionReject:
+		plan.Rejected = true
+		if plan.RejectReason == "" {
+			plan.RejectReason = action.Value
+		}
 	case model.ActionStopProcessing:
 		// Handled in Evaluate
 	default:
@@ -497,8 +558,20 @@ func (e *Engine) buildContextSnapshot(evalCtx model.EvaluationContext) *model.Co
 			"version":    evalCtx.Quality.Version,
 		},
 		Release: map[string]any{
-			"release_group": evalCtx.Release.ReleaseGroup,
-			"edition":       evalCtx.Release.Edition,
+			"release_group":      evalCtx.Release.ReleaseGroup,
+			"edition":            evalCtx.Release.Edition,
+			"languages":          evalCtx.Release.Languages,
+			"subtitle_languages": evalCtx.Release.SubtitleLanguages,
+			"is_multi":           evalCtx.Release.IsMulti,
+			"is_subbed":          evalCtx.Release.IsSubbed,
+			"video_codec":        evalCtx.Release.VideoCodec,
+			"hdr":                evalCtx.Release.HDR,
+			"dolby_vision_only":  evalCtx.Release.DolbyVisionOnly,
+			"bit_depth":          evalCtx.Release.BitDepth,
+			"audio_codec":        evalCtx.Release.AudioCodec,
+			"audio_channels":     evalCtx.Release.AudioChannels,
+			"atmos":              evalCtx.Release.Atmos,
+			"streaming_service":  evalCtx.Release.StreamingService,
 		},
 		Media: map[string]any{
 			"type":    evalCtx.Media.Type,
@@ -508,6 +581,15 @@ func (e *Engine) buildContextSnapshot(evalCtx model.EvaluationContext) *model.Co
 		},
 	}
 
+	if evalCtx.Profile.Name != "" {
+		snapshot.Profile = map[string]any{
+			"name":             evalCtx.Profile.Name,
+			"status":           evalCtx.Profile.Status,
+			"meets_cutoff":     evalCtx.Profile.MeetsCutoff,
+			"existing_quality": evalCtx.Profile.ExistingQuality,
+		}
+	}
+
 	// Add optional media fields
 	if evalCtx.Media.Season != nil {
 		snapshot.Media["season"] = *evalCtx.Media.Season
@@ -3,9 +3,12 @@ package policy
 import (
 	"context"
 	"os"
+	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/kyleaupton/arrflix/internal"
+	dbgen "github.com/kyleaupton/arrflix/internal/db/sqlc"
 	"github.com/kyleaupton/arrflix/internal/logger"
 	"github.com/kyleaupton/arrflix/internal/model"
 	"github.com/kyleaupton/arrflix/internal/release"
@@ -54,3 +57,159 @@ func TestEngine_Evaluate(t *testing.T) {
 		t.Fatalf("expected name template ID to be Test Name Template, got %s", plan.NameTemplateID)
 	}
 }
+
+func TestEngine_ApplyScoreAndReject(t *testing.T) {
+	engine := &Engine{}
+	var plan model.Plan
+
+	for _, action := range []dbgen.Action{
+		{Type: string(model.ActionAddScore), Value: "10"},
+		{Type: string(model.ActionAddScore), Value: "-3"},
+		{Type: string(model.ActionReject), Value: "CAM release"},
+		{Type: string(model.ActionReject), Value: "second reason"},
+	} {
+		if err := engine.applyAction(&plan, action); err != nil {
+			t.Fatalf("apply %s: %v", action.Type, err)
+		}
+	}
+
+	if plan.Score != 7 {
+		t.Fatalf("expected score 7, got %d", plan.Score)
+	}
+	if !plan.Rejected || plan.RejectReason != "CAM release" {
+		t.Fatalf("expected rejection with first reason, got %v %q", plan.Rejected, plan.RejectReason)
+	}
+
+	if err := engine.applyAction(&plan, dbgen.Action{Type: string(model.ActionAddScore), Value: "ten"}); err == nil {
+		t.Fatal("expected error for non-integer score")
+	}
+}
+
+func TestEngine_EvaluateRequestRules(t *testing.T) {
+	engine := &Engine{}
+	str := func(s string) *string { return &s }
+
+	// Users need approval for 4K and auto-approve everything else
+	rules := []dbgen.ListRequestRulesForUserRow{
+		{Name: "4K needs approval", Priority: 10, LeftOperand: str("request.max_resolution"), Operator: str("=="), RightOperand: str("2160p"), Action: string(model.RequestRuleRequireApproval)},
+		{Name: "Movies", Priority: 5, LeftOperand: str("request.media_type"), Operator: str("=="), RightOperand: str("movie"), Action: string(model.RequestRuleApprove)},
+		{Name: "Small series", Priority: 0, LeftOperand: str("request.season_count"), Operator: str("<="), RightOperand: str("2"), Action: string(model.RequestRuleApprove)},
+	}
+
+	tests := []struct {
+		name        string
+		request     model.RequestFields
+		autoApprove bool
+		rule        string
+	}{
+		{"1080p movie", model.RequestFields{MediaType: "movie", MaxResolution: "1080p"}, true, "Movies"},
+		{"4K movie", model.RequestFields{MediaType: "movie", MaxResolution: "2160p"}, false, "4K needs approval"},
+		{"two seasons", model.RequestFields{MediaType: "series", SeasonCount: 2, MaxResolution: "1080p"}, true, "Small series"},
+		{"whole series", model.RequestFields{MediaType: "series", SeasonCount: 8, MaxResolution: "1080p"}, false, ""},
+	}
+	for _, tt := range tests {
+		eval, err := engine.evaluateRequestRules(rules, model.RequestContext{Request: tt.request})
+		if err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		if eval.AutoApprove != tt.autoApprove || eval.RuleName != tt.rule {
+			t.Errorf("%s: got autoApprove=%v by %q, want %v by %q", tt.name, eval.AutoApprove, eval.RuleName, tt.autoApprove, tt.rule)
+		}
+	}
+
+	// A rule without a condition always matches
+	eval, err := engine.evaluateRequestRules([]dbgen.ListRequestRulesForUserRow{
+		{Name: "Auto-approve", Action: string(model.RequestRuleApprove)},
+	}, model.RequestContext{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !eval.AutoApprove {
+		t.Error("expected unconditional rule to approve")
+	}
+}
+
+func TestEngine_CompareLanguages(t *testing.T) {
+	engine := &Engine{}
+	frenchEnglish := []string{"French", "English"}
+
+	tests := []struct {
+		name     string
+		left     []string
+		operator model.Operator
+		right    string
+		want     bool
+	}{
+		{"== member", frenchEnglish, model.OpEq, "French", true},
+		{"== not a member", frenchEnglish, model.OpEq, "German", false},
+		{"!= member", frenchEnglish, model.OpNe, "French", false},
+		{"!= not a member", frenchEnglish, model.OpNe, "German", true},
+		{"in", frenchEnglish, model.OpIn, "German,English", true},
+		{"in none", frenchEnglish, model.OpIn, "German,Italian", false},
+		{"not in", frenchEnglish, model.OpNotIn, "German,Italian", true},
+		{"not in member", []string{"French"}, model.OpNotIn, "French", false},
+		{"contains", []string{"Portuguese (Brazil)"}, model.OpContains, "Brazil", true},
+		{"unknown", []string{"Unknown"}, model.OpEq, "Unknown", true},
+		{"empty list", nil, model.OpEq, "French", false},
+	}
+	for _, tt := range tests {
+		got, err := engine.compare(tt.left, tt.operator, tt.right)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		if got != tt.want {
+			t.Errorf("%s: compare(%v %s %q) = %v, want %v", tt.name, tt.left, tt.operator, tt.right, got, tt.want)
+		}
+	}
+
+	if _, err := engine.compare(frenchEnglish, model.OpGt, "French"); err == nil {
+		t.Error("expected error for > on a list field")
+	}
+}
+
+func TestEngine_CompareHDR(t *testing.T) {
+	engine := &Engine{}
+
+	tests := []struct {
+		title    string
+		operator model.Operator
+		right    string
+		want     bool
+	}{
+		{"Movie.2023.2160p.WEB-DL.DV.HDR10.H.265-GRP", model.OpEq, "Dolby Vision", true},
+		{"Movie.2023.2160p.WEB-DL.DV.HDR10.H.265-GRP", model.OpNe, "Dolby Vision", false},
+		{"Movie.2023.2160p.WEB-DL.HDR10.H.265-GRP", model.OpEq, "Dolby Vision", false},
+		{"Movie.2023.2160p.WEB-DL.HDR10.H.265-GRP", model.OpIn, "HDR10,HDR10+", true},
+		{"Movie.2023.1080p.WEB-DL.H.264-GRP", model.OpEq, "None", true},
+		{"Movie.2023.1080p.WEB-DL.H.264-GRP", model.OpNotIn, "Dolby Vision,HDR10", true},
+	}
+	for _, tt := range tests {
+		candidate := model.DownloadCandidate{Title: tt.title}
+		evalCtx := model.NewEvaluationContext(candidate, release.Parse(tt.title))
+		left, err := engine.getValue("release.hdr", evalCtx)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.title, err)
+		}
+		got, err := engine.compare(left, tt.operator, tt.right)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.title, err)
+		}
+		if got != tt.want {
+			t.Errorf("%s: release.hdr %s %q = %v (hdr %v), want %v", tt.title, tt.operator, tt.right, got, left, tt.want)
+		}
+	}
+}
+
+func TestEngine_ContextSnapshotRelease(t *testing.T) {
+	engine := &Engine{}
+	snapshot := engine.buildContextSnapshot(model.EvaluationContext{})
+
+	fields := reflect.TypeOf(model.ReleaseFields{})
+	for i := 0; i < fields.NumField(); i++ {
+		path := fields.Field(i).Tag.Get("path")
+		key := strings.TrimPrefix(path, "release.")
+		if _, ok := snapshot.Release[key]; !ok {
+			t.Errorf("snapshot is missing %s", path)
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package policy
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	dbgen "github.com/kyleaupton/arrflix/internal/db/sqlc"
+	"github.com/kyleaupton/arrflix/internal/model"
+)
+
+// EvaluateRequest evaluates the enabled request rules of the user's roles in
+// priority order. The first matching rule decides whether the request is
+// approved right away; when none matches it waits for approval.
+func (e *Engine) EvaluateRequest(ctx context.Context, userID pgtype.UUID, reqCtx model.RequestContext) (model.RequestEvaluation, error) {
+	rules, err := e.repo.ListRequestRulesForUser(ctx, userID)
+	if err != nil {
+		return model.RequestEvaluation{}, fmt.Errorf("list request rules: %w", err)
+	}
+	return e.evaluateRequestRules(rules, reqCtx)
+}
+
+func (e *Engine) evaluateRequestRules(rules []dbgen.ListRequestRulesForUserRow, reqCtx model.RequestContext) (model.RequestEvaluation, error) {
+	eval := model.RequestEvaluation{Rules: []model.RequestRuleEvaluation{}}
+
+	for _, rule := range rules {
+		ruleEval := model.RequestRuleEvaluation{
+			RuleID:   rule.ID.String(),
+			RuleName: rule.Name,
+			Priority: int(rule.Priority),
+			Action:   model.RequestRuleAction(rule.Action),
+		}
+
+		// A rule without a condition always matches
+		matches := true
+		if rule.LeftOperand != nil && rule.Operator != nil && rule.RightOperand != nil {
+			leftVal, err := e.getRequestValue(*rule.LeftOperand, reqCtx)
+			if err != nil {
+				return eval, fmt.Errorf("evaluate request rule %s: %w", rule.ID.String(), err)
+			}
+			rightVal, err := e.getRequestValue(*rule.RightOperand, reqCtx)
+			if err != nil {
+				return eval, fmt.Errorf("evaluate request rule %s: %w", rule.ID.String(), err)
+			}
+			ruleEval.RuleEvaluated = &model.RuleInfo{
+				LeftOperand:        *rule.LeftOperand,
+				LeftResolvedValue:  leftVal,
+				Operator:           *rule.Operator,
+				RightOperand:       *rule.RightOperand,
+				RightResolvedValue: rightVal,
+			}
+
+			matches, err = e.compare(leftVal, model.Operator(*rule.Operator), rightVal)
+			if err != nil {
+				return eval, fmt.Errorf("evaluate request rule %s: %w", rule.ID.String(), err)
+			}
+		}
+
+		ruleEval.Matched = matches
+		eval.Rules = append(eval.Rules, ruleEval)
+		if matches {
+			eval.AutoApprove = ruleEval.Action == model.RequestRuleApprove
+			eval.RuleID = ruleEval.RuleID
+			eval.RuleName = ruleEval.RuleName
+			return eval, nil
+		}
+	}
+	return eval, nil
+}
+
+// getRequestValue resolves an operand against the request context: request.*
+// and user.* fields, otherwise a literal.
+func (e *Engine) getRequestValue(operand string, reqCtx model.RequestContext) (interface{}, error) {
+	if namespace, _, ok := strings.Cut(operand, "."); ok {
+		switch namespace {
+		case "request", "user":
+			return reqCtx.GetField(operand)
+		}
+	}
+	return literal(operand), nil
+}
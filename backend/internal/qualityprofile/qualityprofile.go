@@ -0,0 +1,287 @@
+// Package qualityprofile ranks release qualities against a quality profile
+// and decides whether a release is wanted, and whether it would upgrade the
+// file already in the library.
+package qualityprofile
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/kyleaupton/arrflix/internal/release"
+)
+
+// Status is the verdict on a release for a profile.
+type Status string
+
+const (
+	StatusAllowed  Status = "allowed"  // wanted, nothing in the library yet
+	StatusRejected Status = "rejected" // not wanted, see Decision.Reason
+	StatusUpgrade  Status = "upgrade"  // better than the file in the library
+)
+
+// bytesPerMB is the unit of the per-minute size limits.
+const bytesPerMB = 1 << 20
+
+// Item is one entry in a profile's ordered quality list: a single quality,
+// or a named group of qualities the profile treats as equal.
+type Item struct {
+	Name      string   `json:"name"`
+	Qualities []string `json:"qualities"` // release.Quality names, e.g. "WEBDL-1080p"
+	Allowed   bool     `json:"allowed"`
+
+	// Size limits in megabytes per minute of runtime; zero means no limit.
+	MinSize float64 `json:"minSize,omitempty"`
+	MaxSize float64 `json:"maxSize,omitempty"`
+}
+
+// Profile is an ordered list of qualities, least preferred first, with the
+// quality at which upgrades stop.
+type Profile struct {
+	Name            string
+	Items           []Item
+	Cutoff          string // name of the item at which upgrades stop
+	UpgradesAllowed bool
+}
+
+// Candidate is a release to decide on.
+type Candidate struct {
+	Quality        release.Quality
+	Size           int64 // bytes
+	RuntimeMinutes int   // zero skips the size limits
+}
+
+// Decision is the outcome of Evaluate.
+type Decision struct {
+	Status      Status `json:"status"`
+	Reason      string `json:"reason,omitempty"`
+	Item        string `json:"item,omitempty"` // profile item the quality belongs to
+	Rank        int    `json:"rank"`           // position in the profile; -1 when not listed
+	MeetsCutoff bool   `json:"meetsCutoff"`
+}
+
+// DefaultItems lists every quality on its own in release.Qualities order.
+// Everything except Unknown is allowed.
+func DefaultItems() []Item {
+	items := make([]Item, 0, len(release.Qualities))
+	for _, q := range release.Qualities {
+		items = append(items, Item{
+			Name:      q.String(),
+			Qualities: []string{q.String()},
+			Allowed:   q != release.Unknown,
+		})
+	}
+	return items
+}
+
+// Validate checks that every quality is known and listed once, that item
+// names are unique, and that the cutoff names an allowed item.
+func (p Profile) Validate() error {
+	if strings.TrimSpace(p.Name) == "" {
+		return errors.New("name required")
+	}
+	if len(p.Items) == 0 {
+		return errors.New("at least one quality required")
+	}
+
+	names := make(map[string]bool, len(p.Items))
+	seen := make(map[release.Quality]bool)
+	anyAllowed := false
+	for _, item := range p.Items {
+		if strings.TrimSpace(item.Name) == "" {
+			return errors.New("quality item name required")
+		}
+		if names[strings.ToLower(item.Name)] {
+			return fmt.Errorf("duplicate quality item %q", item.Name)
+		}
+		names[strings.ToLower(item.Name)] = true
+
+		if len(item.Qualities) == 0 {
+			return fmt.Errorf("quality item %q has no qualities", item.Name)
+		}
+		for _, name := range item.Qualities {
+			q, ok := release.QualityFromString(name)
+			if !ok {
+				return fmt.Errorf("unknown quality %q", name)
+			}
+			if seen[q] {
+				return fmt.Errorf("quality %q is listed more than once", name)
+			}
+			seen[q] = true
+		}
+
+		if item.MinSize < 0 || item.MaxSize < 0 {
+			return fmt.Errorf("quality item %q has a negative size limit", item.Name)
+		}
+		if item.MaxSize > 0 && item.MinSize > item.MaxSize {
+			return fmt.Errorf("quality item %q has a minimum size above its maximum", item.Name)
+		}
+		anyAllowed = anyAllowed || item.Allowed
+	}
+	if !anyAllowed {
+		return errors.New("at least one quality must be allowed")
+	}
+
+	cutoff := p.cutoffRank()
+	if cutoff < 0 {
+		return fmt.Errorf("cutoff %q is not a quality in the profile", p.Cutoff)
+	}
+	if !p.Items[cutoff].Allowed {
+		return fmt.Errorf("cutoff %q is not an allowed quality", p.Cutoff)
+	}
+	return nil
+}
+
+// Rank returns the position of the item containing q, or -1 when the profile
+// doesn't list it. Higher ranks are preferred.
+func (p Profile) Rank(q release.Quality) int {
+	name := q.String()
+	for i, item := range p.Items {
+		for _, n := range item.Qualities {
+			if strings.EqualFold(n, name) {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// MeetsCutoff reports whether q is at or above the profile's cutoff, so a
+// file of that quality is no longer upgraded.
+func (p Profile) MeetsCutoff(q release.Quality) bool {
+	cutoff := p.cutoffRank()
+	return cutoff >= 0 && p.Rank(q) >= cutoff
+}
+
+func (p Profile) cutoffRank() int {
+	for i, item := range p.Items {
+		if strings.EqualFold(item.Name, p.Cutoff) {
+			return i
+		}
+	}
+	return -1
+}
+
+// Evaluate decides on a candidate. existing is the quality of the file
+// already in the library, or nil when there is none. A candidate is an
+// upgrade when it ranks above the existing file and the existing file is
+// below the cutoff.
+func (p Profile) Evaluate(c Candidate, existing *release.Quality) Decision {
+	rank := p.Rank(c.Quality)
+	d := Decision{Rank: rank, MeetsCutoff: p.MeetsCutoff(c.Quality)}
+	if rank < 0 {
+		return d.reject(fmt.Sprintf("%s is not in profile %s", c.Quality, p.Name))
+	}
+
+	item := p.Items[rank]
+	d.Item = item.Name
+	if !item.Allowed {
+		return d.reject(fmt.Sprintf("%s is not allowed by profile %s", c.Quality, p.Name))
+	}
+
+	if c.RuntimeMinutes > 0 && c.Size > 0 {
+		perMinute := float64(c.Size) / bytesPerMB / float64(c.RuntimeMinutes)
+		if item.MinSize > 0 && perMinute < item.MinSize {
+			return d.reject(fmt.Sprintf("%.1f MB/min is below the %s minimum of %.1f MB/min", perMinute, item.Name, item.MinSize))
+		}
+		if item.MaxSize > 0 && perMinute > item.MaxSize {
+			return d.reject(fmt.Sprintf("%.1f MB/min is above the %s maximum of %.1f MB/min", perMinute, item.Name, item.MaxSize))
+		}
+	}
+
+	if existing == nil {
+		d.Status = StatusAllowed
+		return d
+	}
+	if !p.UpgradesAllowed {
+		return d.reject("upgrades are disabled in profile " + p.Name)
+	}
+	if p.MeetsCutoff(*existing) {
+		return d.reject(fmt.Sprintf("existing %s already meets the cutoff", existing))
+	}
+	if rank <= p.Rank(*existing) {
+		return d.reject(fmt.Sprintf("not an upgrade over existing %s", existing))
+	}
+	d.Status = StatusUpgrade
+	return d
+}
+
+// FileQuality returns the quality name to store on a media_file: the first of
+// names (the release title, then the file path) that carries a known quality.
+// It returns nil when none does.
+func FileQuality(names ...string) *string {
+	for _, name := range names {
+		if name == "" {
+			continue
+		}
+		if q := release.Parse(filepath.Base(name)).Quality.Quality; q != release.Unknown {
+			s := q.String()
+			return &s
+		}
+	}
+	return nil
+}
+
+// resolutions orders release resolutions from lowest to highest.
+var resolutions = []release.Resolution{
+	release.ResUnknown, release.ResSD, release.Res480p, release.Res576p, release.Res720p,
+	release.Res1080p, release.Res1440p, release.Res2160p, release.Res4320p,
+}
+
+// MaxResolution returns the highest resolution among the profile's allowed
+// qualities, e.g. "2160p", or Unknown when none has a known resolution.
+func (p Profile) MaxResolution() string {
+	best := 0
+	for _, item := range p.Items {
+		if !item.Allowed {
+			continue
+		}
+		for _, name := range item.Qualities {
+			q, ok := release.QualityFromString(name)
+			if !ok {
+				continue
+			}
+			if i := slices.Index(resolutions, release.Resolution(q.Resolution())); i > best {
+				best = i
+			}
+		}
+	}
+	return string(resolutions[best])
+}
+
+// ExistingQuality returns the quality of a library file: the stored quality
+// if there is one, otherwise the quality parsed from its path.
+func ExistingQuality(stored *string, path string) release.Quality {
+	if stored != nil {
+		if q, ok := release.QualityFromString(*stored); ok {
+			return q
+		}
+	}
+	return release.Parse(filepath.Base(path)).Quality.Quality
+}
+
+// ResolutionQuality returns the quality of a file known only by its video
+// resolution, as read by mediainfo: the lowest ranked HDTV quality of that
+// resolution, so a better sourced release is still an upgrade. Widths are
+// checked too since widescreen films are shorter than their nominal height.
+func ResolutionQuality(width, height int) release.Quality {
+	switch {
+	case width >= 3200 || height >= 2000:
+		return release.HDTV2160p
+	case width >= 1800 || height >= 1000:
+		return release.HDTV1080p
+	case width >= 1200 || height >= 700:
+		return release.HDTV720p
+	case width > 0 || height > 0:
+		return release.SDTV
+	}
+	return release.Unknown
+}
+
+func (d Decision) reject(reason string) Decision {
+	d.Status = StatusRejected
+	d.Reason = reason
+	return d
+}
@@ -0,0 +1,178 @@
+package qualityprofile
+
+import (
+	"testing"
+
+	"github.com/kyleaupton/arrflix/internal/release"
+)
+
+// HD-1080p profile: 720p allowed, a grouped 1080p WEB tier, Bluray-1080p as
+// the cutoff, and remux above the cutoff.
+var testProfile = Profile{
+	Name: "HD-1080p",
+	Items: []Item{
+		{Name: "SDTV", Qualities: []string{"SDTV"}},
+		{Name: "HDTV-720p", Qualities: []string{"HDTV-720p"}, Allowed: true},
+		{Name: "WEB 1080p", Qualities: []string{"WEBRip-1080p", "WEBDL-1080p"}, Allowed: true, MinSize: 5, MaxSize: 100},
+		{Name: "Bluray-1080p", Qualities: []string{"Bluray-1080p"}, Allowed: true},
+		{Name: "Bluray-1080p Remux", Qualities: []string{"Bluray-1080p Remux"}, Allowed: true},
+	},
+	Cutoff:          "Bluray-1080p",
+	UpgradesAllowed: true,
+}
+
+func qualityPtr(q release.Quality) *release.Quality { return &q }
+
+func TestEvaluate(t *testing.T) {
+	tests := []struct {
+		name      string
+		profile   Profile
+		candidate Candidate
+		existing  *release.Quality
+		want      Status
+		wantItem  string
+	}{
+		{"allowed without a file", testProfile, Candidate{Quality: release.HDTV720p}, nil, StatusAllowed, "HDTV-720p"},
+		{"disallowed quality", testProfile, Candidate{Quality: release.SDTV}, nil, StatusRejected, "SDTV"},
+		{"quality not in profile", testProfile, Candidate{Quality: release.WEBDL2160p}, nil, StatusRejected, ""},
+		{"grouped quality", testProfile, Candidate{Quality: release.WEBRip1080p}, nil, StatusAllowed, "WEB 1080p"},
+		{"upgrade below cutoff", testProfile, Candidate{Quality: release.WEBDL1080p}, qualityPtr(release.HDTV720p), StatusUpgrade, "WEB 1080p"},
+		{"same group is not an upgrade", testProfile, Candidate{Quality: release.WEBDL1080p}, qualityPtr(release.WEBRip1080p), StatusRejected, "WEB 1080p"},
+		{"downgrade", testProfile, Candidate{Quality: release.HDTV720p}, qualityPtr(release.WEBDL1080p), StatusRejected, "HDTV-720p"},
+		{"existing meets cutoff", testProfile, Candidate{Quality: release.Bluray1080pRemux}, qualityPtr(release.Bluray1080p), StatusRejected, "Bluray-1080p Remux"},
+		{"upgrade past cutoff", testProfile, Candidate{Quality: release.Bluray1080pRemux}, qualityPtr(release.WEBDL1080p), StatusUpgrade, "Bluray-1080p Remux"},
+		{"existing outside profile", testProfile, Candidate{Quality: release.HDTV720p}, qualityPtr(release.DVD), StatusUpgrade, "HDTV-720p"},
+		{"too small", testProfile, Candidate{Quality: release.WEBDL1080p, Size: 100 << 20, RuntimeMinutes: 60}, nil, StatusRejected, "WEB 1080p"},
+		{"too large", testProfile, Candidate{Quality: release.WEBDL1080p, Size: 8000 << 20, RuntimeMinutes: 60}, nil, StatusRejected, "WEB 1080p"},
+		{"within size limits", testProfile, Candidate{Quality: release.WEBDL1080p, Size: 3000 << 20, RuntimeMinutes: 60}, nil, StatusAllowed, "WEB 1080p"},
+		{"unknown runtime skips size limits", testProfile, Candidate{Quality: release.WEBDL1080p, Size: 100 << 20}, nil, StatusAllowed, "WEB 1080p"},
+		{"upgrades disabled", Profile{Name: "No upgrades", Items: testProfile.Items, Cutoff: "Bluray-1080p"}, Candidate{Quality: release.Bluray1080p}, qualityPtr(release.HDTV720p), StatusRejected, "Bluray-1080p"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.profile.Evaluate(tt.candidate, tt.existing)
+			if got.Status != tt.want {
+				t.Errorf("Evaluate() status = %s (%s), want %s", got.Status, got.Reason, tt.want)
+			}
+			if got.Item != tt.wantItem {
+				t.Errorf("Evaluate() item = %q, want %q", got.Item, tt.wantItem)
+			}
+			if got.Status == StatusRejected && got.Reason == "" {
+				t.Error("rejected without a reason")
+			}
+		})
+	}
+}
+
+func TestMeetsCutoff(t *testing.T) {
+	tests := []struct {
+		quality release.Quality
+		want    bool
+	}{
+		{release.HDTV720p, false},
+		{release.WEBDL1080p, false},
+		{release.Bluray1080p, true},
+		{release.Bluray1080pRemux, true},
+		{release.Bluray2160p, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.quality.String(), func(t *testing.T) {
+			if got := testProfile.MeetsCutoff(tt.quality); got != tt.want {
+				t.Errorf("MeetsCutoff(%s) = %v, want %v", tt.quality, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	if err := testProfile.Validate(); err != nil {
+		t.Fatalf("Validate() = %v", err)
+	}
+	if err := (Profile{Name: "Any", Items: DefaultItems(), Cutoff: "Bluray-2160p Remux"}).Validate(); err != nil {
+		t.Fatalf("Validate() default items = %v", err)
+	}
+
+	invalid := map[string]Profile{
+		"no name":            {Items: testProfile.Items, Cutoff: "Bluray-1080p"},
+		"no items":           {Name: "Empty", Cutoff: "Bluray-1080p"},
+		"missing cutoff":     {Name: "P", Items: testProfile.Items, Cutoff: "Bluray-2160p"},
+		"cutoff not allowed": {Name: "P", Items: testProfile.Items, Cutoff: "SDTV"},
+		"unknown quality": {Name: "P", Items: []Item{
+			{Name: "X", Qualities: []string{"VHS-240p"}, Allowed: true},
+		}, Cutoff: "X"},
+		"duplicate quality": {Name: "P", Items: []Item{
+			{Name: "A", Qualities: []string{"WEBDL-1080p"}, Allowed: true},
+			{Name: "B", Qualities: []string{"webdl-1080p"}, Allowed: true},
+		}, Cutoff: "B"},
+		"min above max": {Name: "P", Items: []Item{
+			{Name: "A", Qualities: []string{"WEBDL-1080p"}, Allowed: true, MinSize: 50, MaxSize: 10},
+		}, Cutoff: "A"},
+	}
+	for name, p := range invalid {
+		t.Run(name, func(t *testing.T) {
+			if err := p.Validate(); err == nil {
+				t.Error("Validate() = nil, want an error")
+			}
+		})
+	}
+}
+
+func TestFileQuality(t *testing.T) {
+	got := FileQuality("", "Movie.2020.1080p.BluRay.x264-GRP", "Movie (2020)/Movie (2020) WEBDL-720p.mkv")
+	if got == nil || *got != "Bluray-1080p" {
+		t.Errorf("FileQuality() = %v, want Bluray-1080p", got)
+	}
+	if got := FileQuality("Movie (2020)/Movie (2020)"); got != nil {
+		t.Errorf("FileQuality() = %q, want nil", *got)
+	}
+
+	stored := "WEBDL-2160p"
+	if got := ExistingQuality(&stored, "Movie (2020)/Movie (2020) Bluray-1080p.mkv"); got != release.WEBDL2160p {
+		t.Errorf("ExistingQuality() = %s, want the stored WEBDL-2160p", got)
+	}
+	if got := ExistingQuality(nil, "Show/Season 01/Show - S01E01 - HDTV-720p.mkv"); got != release.HDTV720p {
+		t.Errorf("ExistingQuality() = %s, want HDTV-720p from the path", got)
+	}
+}
+
+func TestMaxResolution(t *testing.T) {
+	if got := testProfile.MaxResolution(); got != "1080p" {
+		t.Errorf("MaxResolution() = %s, want 1080p", got)
+	}
+
+	uhd := Profile{Name: "Any", Items: DefaultItems()}
+	if got := uhd.MaxResolution(); got != "2160p" {
+		t.Errorf("MaxResolution() = %s, want 2160p", got)
+	}
+
+	// Disallowed qualities don't count
+	sd := Profile{Name: "SD", Items: []Item{
+		{Name: "SDTV", Qualities: []string{"SDTV"}, Allowed: true},
+		{Name: "HDTV-720p", Qualities: []string{"HDTV-720p"}},
+	}}
+	if got := sd.MaxResolution(); got != "SD" {
+		t.Errorf("MaxResolution() = %s, want SD", got)
+	}
+}
+
+func TestResolutionQuality(t *testing.T) {
+	tests := []struct {
+		width, height int
+		want          release.Quality
+	}{
+		{3840, 2160, release.HDTV2160p},
+		{3840, 1600, release.HDTV2160p},
+		{1920, 1080, release.HDTV1080p},
+		{1920, 800, release.HDTV1080p},
+		{1280, 720, release.HDTV720p},
+		{720, 480, release.SDTV},
+		{0, 0, release.Unknown},
+	}
+	for _, tt := range tests {
+		if got := ResolutionQuality(tt.width, tt.height); got != tt.want {
+			t.Errorf("ResolutionQuality(%d, %d) = %s, want %s", tt.width, tt.height, got, tt.want)
+		}
+	}
+}
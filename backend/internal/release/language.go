@@ -0,0 +1,309 @@
+package release
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// Language is a spoken or subtitle language detected in a release title.
+// Values follow Sonarr's language names.
+type Language string
+
+const (
+	LangUnknown  Language = "Unknown"
+	LangOriginal Language = "Original" // original audio (VO, MULTi, DUAL)
+
+	LangArabic        Language = "Arabic"
+	LangBulgarian     Language = "Bulgarian"
+	LangCatalan       Language = "Catalan"
+	LangChinese       Language = "Chinese"
+	LangCroatian      Language = "Croatian"
+	LangCzech         Language = "Czech"
+	LangDanish        Language = "Danish"
+	LangDutch         Language = "Dutch"
+	LangEnglish       Language = "English"
+	LangEstonian      Language = "Estonian"
+	LangFinnish       Language = "Finnish"
+	LangFlemish       Language = "Flemish"
+	LangFrench        Language = "French"
+	LangGerman        Language = "German"
+	LangGreek         Language = "Greek"
+	LangHebrew        Language = "Hebrew"
+	LangHindi         Language = "Hindi"
+	LangHungarian     Language = "Hungarian"
+	LangIcelandic     Language = "Icelandic"
+	LangIndonesian    Language = "Indonesian"
+	LangItalian       Language = "Italian"
+	LangJapanese      Language = "Japanese"
+	LangKorean        Language = "Korean"
+	LangLatvian       Language = "Latvian"
+	LangLithuanian    Language = "Lithuanian"
+	LangMalayalam     Language = "Malayalam"
+	LangNorwegian     Language = "Norwegian"
+	LangPersian       Language = "Persian"
+	LangPolish        Language = "Polish"
+	LangPortuguese    Language = "Portuguese"
+	LangPortugueseBR  Language = "Portuguese (Brazil)"
+	LangRomanian      Language = "Romanian"
+	LangRussian       Language = "Russian"
+	LangSerbian       Language = "Serbian"
+	LangSlovak        Language = "Slovak"
+	LangSlovenian     Language = "Slovenian"
+	LangSpanish       Language = "Spanish"
+	LangSpanishLatino Language = "Spanish (Latino)"
+	LangSwedish       Language = "Swedish"
+	LangTamil         Language = "Tamil"
+	LangTelugu        Language = "Telugu"
+	LangThai          Language = "Thai"
+	LangTurkish       Language = "Turkish"
+	LangUkrainian     Language = "Ukrainian"
+	LangVietnamese    Language = "Vietnamese"
+)
+
+// Languages lists every language value, for field registries.
+var Languages = []Language{
+	LangUnknown, LangOriginal,
+	LangArabic, LangBulgarian, LangCatalan, LangChinese, LangCroatian, LangCzech,
+	LangDanish, LangDutch, LangEnglish, LangEstonian, LangFinnish, LangFlemish,
+	LangFrench, LangGerman, LangGreek, LangHebrew, LangHindi, LangHungarian,
+	LangIcelandic, LangIndonesian, LangItalian, LangJapanese, LangKorean,
+	LangLatvian, LangLithuanian, LangMalayalam, LangNorwegian, LangPersian,
+	LangPolish, LangPortuguese, LangPortugueseBR, LangRomanian, LangRussian,
+	LangSerbian, LangSlovak, LangSlovenian, LangSpanish, LangSpanishLatino,
+	LangSwedish, LangTamil, LangTelugu, LangThai, LangTurkish, LangUkrainian,
+	LangVietnamese,
+}
+
+// languageNames maps lowercase language names and spellings, matched case-insensitively.
+var languageNames = map[string]Language{
+	"arabic": LangArabic, "bulgarian": LangBulgarian, "catalan": LangCatalan, "català": LangCatalan,
+	"chinese": LangChinese, "mandarin": LangChinese, "cantonese": LangChinese,
+	"croatian": LangCroatian, "hrvatski": LangCroatian, "czech": LangCzech, "cesky": LangCzech,
+	"danish": LangDanish, "dansk": LangDanish, "dutch": LangDutch, "nederlands": LangDutch,
+	"english": LangEnglish, "estonian": LangEstonian, "finnish": LangFinnish, "suomi": LangFinnish,
+	"flemish": LangFlemish, "vlaams": LangFlemish,
+	"french": LangFrench, "français": LangFrench, "francais": LangFrench, "truefrench": LangFrench,
+	"german": LangGerman, "deutsch": LangGerman, "greek": LangGreek, "hebrew": LangHebrew,
+	"hindi": LangHindi, "hungarian": LangHungarian, "magyar": LangHungarian,
+	"icelandic": LangIcelandic, "indonesian": LangIndonesian,
+	"italian": LangItalian, "italiano": LangItalian, "japanese": LangJapanese,
+	"korean": LangKorean, "latvian": LangLatvian, "lithuanian": LangLithuanian,
+	"malayalam": LangMalayalam, "norwegian": LangNorwegian, "norsk": LangNorwegian,
+	"persian": LangPersian, "farsi": LangPersian, "polish": LangPolish, "polski": LangPolish,
+	"portuguese": LangPortuguese, "português": LangPortuguese, "brazilian": LangPortugueseBR,
+	"romanian": LangRomanian, "russian": LangRussian, "serbian": LangSerbian,
+	"slovak": LangSlovak, "slovenian": LangSlovenian,
+	"spanish": LangSpanish, "español": LangSpanish, "espanol": LangSpanish, "castellano": LangSpanish,
+	"latino": LangSpanishLatino, "swedish": LangSwedish, "svenska": LangSwedish,
+	"tamil": LangTamil, "telugu": LangTelugu, "thai": LangThai, "turkish": LangTurkish,
+	"ukrainian": LangUkrainian, "vietnamese": LangVietnamese,
+	// French dub markers (VF = version française)
+	"vff": LangFrench, "vfq": LangFrench, "vfi": LangFrench, "vf2": LangFrench,
+}
+
+// languageCodes maps ISO 639-1/639-2 codes and scene abbreviations. They are
+// short enough to collide with ordinary words, so only all-uppercase tokens match.
+var languageCodes = map[string]Language{
+	"ARA": LangArabic, "BG": LangBulgarian, "BUL": LangBulgarian, "CAT": LangCatalan,
+	"CHI": LangChinese, "CHS": LangChinese, "CHT": LangChinese, "ZH": LangChinese, "CN": LangChinese,
+	"HRV": LangCroatian, "CZ": LangCzech, "CZE": LangCzech, "CES": LangCzech,
+	"DK": LangDanish, "DAN": LangDanish, "NL": LangDutch, "NLD": LangDutch,
+	"ENG": LangEnglish, "EST": LangEstonian, "FIN": LangFinnish,
+	"FR": LangFrench, "FRA": LangFrench, "FRE": LangFrench, "VF": LangFrench,
+	"GER": LangGerman, "DEU": LangGerman, "GR": LangGreek, "GRE": LangGreek, "ELL": LangGreek,
+	"HEB": LangHebrew, "HIN": LangHindi, "HUN": LangHungarian, "ICE": LangIcelandic, "ISL": LangIcelandic,
+	"IND": LangIndonesian, "ITA": LangItalian, "JAP": LangJapanese, "JPN": LangJapanese,
+	"KOR": LangKorean, "LAV": LangLatvian, "LIT": LangLithuanian, "NOR": LangNorwegian,
+	"PER": LangPersian, "FAS": LangPersian, "PL": LangPolish, "POL": LangPolish,
+	"POR": LangPortuguese, "PT": LangPortuguese, "PTBR": LangPortugueseBR,
+	"RO": LangRomanian, "RUM": LangRomanian, "RON": LangRomanian, "RU": LangRussian, "RUS": LangRussian,
+	"SRP": LangSerbian, "SLK": LangSlovak, "SLO": LangSlovak, "SLV": LangSlovenian,
+	"ESP": LangSpanish, "SPA": LangSpanish, "SWE": LangSwedish, "TAM": LangTamil, "TEL": LangTelugu,
+	"THA": LangThai, "TUR": LangTurkish, "UKR": LangUkrainian, "UA": LangUkrainian, "VIE": LangVietnamese,
+}
+
+// LanguageInfo is the audio and subtitle language information in a release title.
+type LanguageInfo struct {
+	Languages         []Language // audio languages; [Unknown] when none are named
+	SubtitleLanguages []Language
+	Multi             bool // MULTi, DUAL or a dual-language marker
+	Subbed            bool // SUBBED, VOST* or any subtitle language
+}
+
+var languageTokenRegex = regexp.MustCompile(`[\p{L}\p{N}]+`)
+
+// languageStopRegex matches uppercase language markers that end a title
+// ("Movie.FRENCH.1080p"). Mixed-case names are left alone since they are
+// often part of the title ("The French Dispatch").
+var languageStopRegex = regexp.MustCompile(`\b(?:MULTi|MULTI|DUAL|VOSTFR|VOSTF|VOST|SUBBED|TRUEFRENCH|FRENCH|GERMAN|ITALIAN|SPANISH|DUTCH|RUSSIAN|POLISH|JAPANESE|KOREAN|CHINESE|HUNGARIAN|SWEDISH|DANISH|NORWEGIAN|FINNISH|PORTUGUESE|CZECH|TURKISH|GREEK|HEBREW|ARABIC|HINDI|ENGLISH|VFF|VFQ)\b`)
+
+// ParseLanguages detects audio and subtitle languages in the part of a
+// release name that follows the title.
+func ParseLanguages(tail string) LanguageInfo {
+	var info LanguageInfo
+	tokens := languageTokenRegex.FindAllString(tail, -1)
+
+	addAudio := func(l Language) {
+		if !containsLanguage(info.Languages, l) {
+			info.Languages = append(info.Languages, l)
+		}
+	}
+	addSub := func(l Language) {
+		info.Subbed = true
+		if !containsLanguage(info.SubtitleLanguages, l) {
+			info.SubtitleLanguages = append(info.SubtitleLanguages, l)
+		}
+	}
+
+	for i := 0; i < len(tokens); i++ {
+		tok := tokens[i]
+		lower := strings.ToLower(tok)
+		next := ""
+		if i+1 < len(tokens) {
+			next = strings.ToLower(tokens[i+1])
+		}
+
+		switch {
+		case lower == "multi":
+			info.Multi = true
+			addAudio(LangOriginal)
+			continue
+		case lower == "dual" || (lower == "dl" && i > 0 && isLanguageToken(tokens[i-1])):
+			// "German DL" is German plus the original audio; "WEB-DL" is not
+			info.Multi = true
+			addAudio(LangOriginal)
+			if next == "audio" {
+				i++
+			}
+			continue
+		case lower == "subbed" || lower == "hardsub" || lower == "hardsubs" || lower == "softsub" || lower == "softsubs":
+			info.Subbed = true
+			continue
+		case strings.HasPrefix(lower, "vost") || strings.HasPrefix(lower, "vosub"):
+			// VOST, VOSTFR, VOSTENG: original audio with subtitles
+			addAudio(LangOriginal)
+			info.Subbed = true
+			code := strings.TrimPrefix(strings.TrimPrefix(lower, "vosub"), "vost")
+			if l, ok := subtitleSuffixLanguage(code); ok {
+				addSub(l)
+			}
+			continue
+		case lower == "vo":
+			addAudio(LangOriginal)
+			continue
+		case strings.HasPrefix(lower, "sub") && len(lower) > 4 && lower != "subs":
+			// SUBFRENCH, SUBITA
+			if l, ok := subtitleSuffixLanguage(lower[3:]); ok {
+				addSub(l)
+				continue
+			}
+		case strings.HasPrefix(lower, "st") && len(lower) == 4 && isUpper(tok):
+			// STFR
+			if l, ok := subtitleSuffixLanguage(lower[2:]); ok {
+				addSub(l)
+				continue
+			}
+		case (strings.HasSuffix(lower, "subs") || strings.HasSuffix(lower, "sub")) && lower != "sub" && lower != "subs":
+			// NLSubs, ENGSUB
+			code := strings.TrimSuffix(strings.TrimSuffix(lower, "s"), "sub")
+			if l, ok := subtitleSuffixLanguage(code); ok {
+				addSub(l)
+				continue
+			}
+		case lower == "pt" && next == "br":
+			addAudio(LangPortugueseBR)
+			i++
+			continue
+		}
+
+		l, ok := tokenLanguage(tok)
+		if !ok {
+			continue
+		}
+		// "Spanish Latino" is a single language
+		if l == LangSpanish && next == "latino" {
+			l = LangSpanishLatino
+			i++
+		}
+		if next == "sub" || next == "subs" || next == "subtitles" || next == "subbed" {
+			addSub(l)
+			i++
+			continue
+		}
+		if i > 0 && (strings.ToLower(tokens[i-1]) == "sub" || strings.ToLower(tokens[i-1]) == "subs") {
+			addSub(l)
+			continue
+		}
+		addAudio(l)
+	}
+
+	if len(info.Languages) == 0 {
+		info.Languages = []Language{LangUnknown}
+	}
+	return info
+}
+
+// tokenLanguage maps a single title token to a language.
+func tokenLanguage(tok string) (Language, bool) {
+	if l, ok := languageNames[strings.ToLower(tok)]; ok {
+		return l, true
+	}
+	if isUpper(tok) {
+		if l, ok := languageCodes[tok]; ok {
+			return l, true
+		}
+	}
+	return "", false
+}
+
+func isLanguageToken(tok string) bool {
+	_, ok := tokenLanguage(tok)
+	return ok
+}
+
+// subtitleSuffixLanguage maps the language part of fused markers like
+// VOSTFR, SUBFRENCH or NLSubs, where case can't be relied on.
+func subtitleSuffixLanguage(s string) (Language, bool) {
+	if s == "" {
+		return "", false
+	}
+	if l, ok := languageNames[s]; ok {
+		return l, true
+	}
+	if l, ok := languageCodes[strings.ToUpper(s)]; ok {
+		return l, true
+	}
+	return "", false
+}
+
+func isUpper(s string) bool {
+	hasLetter := false
+	for _, r := range s {
+		if unicode.IsLower(r) {
+			return false
+		}
+		if unicode.IsLetter(r) {
+			hasLetter = true
+		}
+	}
+	return hasLetter
+}
+
+func containsLanguage(langs []Language, l Language) bool {
+	for _, x := range langs {
+		if x == l {
+			return true
+		}
+	}
+	return false
+}
+
+// LanguageStrings converts languages to their string values.
+func LanguageStrings(langs []Language) []string {
+	out := make([]string, 0, len(langs))
+	for _, l := range langs {
+		out = append(out, string(l))
+	}
+	return out
+}
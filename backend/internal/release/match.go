@@ -0,0 +1,195 @@
+package release
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// StrictMatchConfidence is the minimum confidence a release needs to be kept
+// when results are filtered strictly.
+const StrictMatchConfidence = 80
+
+// Confidence penalties applied by ValidateMatch
+const (
+	penaltyTitleMismatch   = 70
+	penaltyTitleExtraWords = 50
+	penaltyYearMismatch    = 40
+	penaltyYearOffByOne    = 10
+	penaltyYearMissing     = 5
+	penaltySeasonMismatch  = 70
+	penaltyEpisodeMismatch = 60
+	penaltyNoEpisodeInfo   = 30
+	penaltySinglePartial   = 20
+)
+
+// MatchTarget describes the media a release is expected to contain.
+type MatchTarget struct {
+	Titles  []string // primary title and aliases
+	Year    int      // movies only, zero when unknown
+	Series  bool
+	Season  *int
+	Episode *int
+
+	// Absolute numbers of the target episode (or every episode of the target
+	// season), for checking anime releases. Empty when the series has none.
+	AbsoluteEpisodes []int
+}
+
+// MatchResult reports how well a release matches a target. Confidence is
+// 0-100; Mismatches explains every deduction.
+type MatchResult struct {
+	Confidence int
+	Mismatches []string
+}
+
+// ValidateMatch compares a parsed release title against the media it should contain.
+func ValidateMatch(info TitleInfo, target MatchTarget) MatchResult {
+	result := MatchResult{Confidence: 100}
+	deduct := func(penalty int, format string, args ...any) {
+		result.Confidence -= penalty
+		result.Mismatches = append(result.Mismatches, fmt.Sprintf(format, args...))
+	}
+
+	switch matchTitle(info.Name, target.Titles) {
+	case titleMatchExtraWords:
+		deduct(penaltyTitleExtraWords, "title %q has extra words", info.Name)
+	case titleMatchNone:
+		deduct(penaltyTitleMismatch, "title %q does not match", info.Name)
+	}
+
+	if !target.Series && target.Year != 0 {
+		switch diff := info.Year - target.Year; {
+		case info.Year == 0:
+			deduct(penaltyYearMissing, "no year in release")
+		case diff == 0:
+		case diff == 1 || diff == -1:
+			deduct(penaltyYearOffByOne, "year %d is one off from %d", info.Year, target.Year)
+		default:
+			deduct(penaltyYearMismatch, "year %d does not match %d", info.Year, target.Year)
+		}
+	}
+
+	_, hasSeason := info.Season()
+	if target.Series {
+		switch {
+		case !hasSeason:
+			// Daily numbering can't be checked against a season here, and
+			// absolute numbering only when the series' mapping is known.
+			if target.Season != nil && !info.IsSeries() {
+				deduct(penaltyNoEpisodeInfo, "no season or episode in release")
+			}
+			if len(info.AbsoluteEpisodes) > 0 && len(target.AbsoluteEpisodes) > 0 && !overlaps(info.AbsoluteEpisodes, target.AbsoluteEpisodes) {
+				deduct(penaltyEpisodeMismatch, "absolute episode %s does not match %s", formatNumbers(info.AbsoluteEpisodes), formatNumbers(target.AbsoluteEpisodes))
+			}
+		case target.Season != nil && !containsInt(info.Seasons, *target.Season):
+			deduct(penaltySeasonMismatch, "season %s does not match %d", formatNumbers(info.Seasons), *target.Season)
+		case target.Episode != nil && len(info.Episodes) > 0 && !containsInt(info.Episodes, *target.Episode):
+			deduct(penaltyEpisodeMismatch, "episode %s does not match %d", formatNumbers(info.Episodes), *target.Episode)
+		case target.Season != nil && target.Episode == nil && len(info.Episodes) > 0:
+			deduct(penaltySinglePartial, "episode %s is not a full season", formatNumbers(info.Episodes))
+		}
+	} else if hasSeason {
+		deduct(penaltyEpisodeMismatch, "release is a series episode or season")
+	}
+
+	if result.Confidence < 0 {
+		result.Confidence = 0
+	}
+	return result
+}
+
+type titleMatch int
+
+const (
+	titleMatchExact titleMatch = iota
+	titleMatchExtraWords
+	titleMatchNone
+)
+
+// matchTitle compares a parsed release title against every alias. Extra
+// trailing words usually mean a sequel or spin-off ("Title 2", "Title Origins"),
+// unless they are a country code or year.
+func matchTitle(title string, aliases []string) titleMatch {
+	if len(aliases) == 0 {
+		return titleMatchExact
+	}
+	t := normalizeMatchTitle(title)
+	best := titleMatchNone
+	for _, alias := range aliases {
+		a := normalizeMatchTitle(alias)
+		if a == "" {
+			continue
+		}
+		if t == a {
+			return titleMatchExact
+		}
+		if rest, ok := strings.CutPrefix(t, a+" "); ok {
+			if onlyQualifiers(rest) {
+				return titleMatchExact
+			}
+			best = titleMatchExtraWords
+		}
+	}
+	return best
+}
+
+// titleQualifiers are country codes that set apart same-named shows ("The
+// Office US", "Shameless UK").
+var titleQualifiers = map[string]bool{"us": true, "uk": true, "au": true, "nz": true, "ca": true}
+
+// onlyQualifiers reports whether the words a release title has beyond an
+// alias only tell its versions apart: a country code or a year, as in
+// "Doctor Who 2005".
+func onlyQualifiers(words string) bool {
+	for _, w := range strings.Fields(words) {
+		if titleQualifiers[w] {
+			continue
+		}
+		if n, err := strconv.Atoi(w); err == nil && len(w) == 4 && n >= 1900 && n <= 2099 {
+			continue
+		}
+		return false
+	}
+	return true
+}
+
+// normalizeMatchTitle lowercases a title, drops punctuation and a leading
+// article, and spells out "&" so alias spellings compare equal.
+func normalizeMatchTitle(title string) string {
+	title = strings.ReplaceAll(strings.ToLower(title), "&", " and ")
+	var b strings.Builder
+	space := false
+	for _, r := range title {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			if space && b.Len() > 0 {
+				b.WriteByte(' ')
+			}
+			b.WriteRune(r)
+			space = false
+		case r == '\'':
+			// "Grey's" == "Greys"
+		default:
+			space = true
+		}
+	}
+	return strings.TrimPrefix(b.String(), "the ")
+}
+
+func overlaps(a, b []int) bool {
+	for _, n := range a {
+		if containsInt(b, n) {
+			return true
+		}
+	}
+	return false
+}
+
+func formatNumbers(numbers []int) string {
+	if len(numbers) == 1 {
+		return strconv.Itoa(numbers[0])
+	}
+	return fmt.Sprintf("%d-%d", numbers[0], numbers[len(numbers)-1])
+}
@@ -0,0 +1,55 @@
+package release
+
+import "testing"
+
+func TestValidateMatch(t *testing.T) {
+	movie := MatchTarget{Titles: []string{"The Matrix", "Matrix"}, Year: 1999}
+	series := MatchTarget{Titles: []string{"Grey's Anatomy"}, Series: true, Season: intPtr(2), Episode: intPtr(5)}
+	seasonPack := MatchTarget{Titles: []string{"Grey's Anatomy"}, Series: true, Season: intPtr(2)}
+	office := MatchTarget{Titles: []string{"The Office"}, Series: true, Season: intPtr(2), Episode: intPtr(5)}
+	anime := MatchTarget{Titles: []string{"Show Name"}, Series: true, Season: intPtr(2), Episode: intPtr(3), AbsoluteEpisodes: []int{15}}
+	animeSeason := MatchTarget{Titles: []string{"Show Name"}, Series: true, Season: intPtr(2), AbsoluteEpisodes: []int{13, 14, 15, 16, 17, 18, 19, 20, 21, 22, 23, 24}}
+
+	tests := []struct {
+		name       string
+		release    string
+		target     MatchTarget
+		confidence int
+		mismatches int
+	}{
+		{"exact movie", "The.Matrix.1999.1080p.BluRay.x264-GRP", movie, 100, 0},
+		{"alias without article", "Matrix 1999 720p WEB-DL", movie, 100, 0},
+		{"sequel", "The.Matrix.Reloaded.2003.1080p.BluRay", movie, 10, 2},
+		{"year off by one", "The.Matrix.2000.1080p", movie, 90, 1},
+		{"missing year", "The.Matrix.1080p.BluRay", movie, 95, 1},
+		{"different movie", "Inception.2010.1080p", movie, 0, 2},
+		{"episode", "Greys.Anatomy.S02E05.720p.HDTV", series, 100, 0},
+		{"country suffix", "The.Office.US.S02E05.720p.HDTV", office, 100, 0},
+		{"year suffix", "The Office (2005) S02E05 1080p WEB-DL", office, 100, 0},
+		{"spin-off", "The.Office.Christmas.Special.S02E05.720p", office, 50, 1},
+		{"episode in multi", "Greys Anatomy S02E04E05 720p", series, 100, 0},
+		{"season pack for episode", "Greys.Anatomy.S02.1080p.WEB-DL", series, 100, 0},
+		{"wrong season", "Greys.Anatomy.S03E05.720p", series, 30, 1},
+		{"wrong episode", "Greys.Anatomy.S02E06.720p", series, 40, 1},
+		{"episode for season pack", "Greys.Anatomy.S02E06.720p", seasonPack, 80, 1},
+		{"no episode info", "Greys.Anatomy.720p.HDTV", series, 70, 1},
+		{"episode for movie", "The.Matrix.S01E01.1080p", movie, 35, 2},
+		{"absolute episode", "[SubsPlease] Show Name - 15 (1080p) [ABCD1234].mkv", anime, 100, 0},
+		{"wrong absolute episode", "[SubsPlease] Show Name - 16 (1080p)", anime, 40, 1},
+		{"batch covering episode", "[Group] Show Name (13-24) [BD 1080p]", anime, 100, 0},
+		{"batch for season", "[Group] Show Name (13-24) [BD 1080p]", animeSeason, 100, 0},
+		{"batch for other season", "[Group] Show Name (01-12) [BD 1080p]", animeSeason, 40, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ValidateMatch(ParseTitleInfo(tt.release), tt.target)
+			if got.Confidence != tt.confidence {
+				t.Errorf("Confidence = %d, want %d (mismatches: %v)", got.Confidence, tt.confidence, got.Mismatches)
+			}
+			if len(got.Mismatches) != tt.mismatches {
+				t.Errorf("Mismatches = %v, want %d", got.Mismatches, tt.mismatches)
+			}
+		})
+	}
+}
@@ -0,0 +1,161 @@
+package release
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Value names match the mediainfo package, so a pre-download policy on
+// release.video_codec reads the same as one on mediainfo.video_codec.
+const (
+	HDRNone        = "None"
+	HDR10          = "HDR10"
+	HDR10Plus      = "HDR10+"
+	HDRDolbyVision = "Dolby Vision"
+	HDRHLG         = "HLG"
+)
+
+// MediaTags are the video, audio and source tags a release name advertises.
+type MediaTags struct {
+	VideoCodec       string   // H.264, H.265, AV1, ...; "Unknown" when not tagged
+	HDR              []string // every HDR format tagged; ["None"] for SDR
+	DolbyVisionOnly  bool     // Dolby Vision without an HDR10/HDR10+/HLG fallback layer
+	BitDepth         int      // 8, 10 or 12; zero when unknown
+	AudioCodec       string   // EAC3, TrueHD, DTS-HD MA, ...; "Unknown" when not tagged
+	AudioChannels    string   // 2.0, 5.1, 7.1; "Unknown" when not tagged
+	Atmos            bool     // Dolby Atmos object audio
+	StreamingService string   // Netflix, Amazon, Disney+, ...; empty when not a streaming release
+}
+
+var (
+	videoCodecPatterns = []struct {
+		regex *regexp.Regexp
+		codec string
+	}{
+		{regexp.MustCompile(`(?i)\b(?:[xh][-_. ]?265|HEVC)\b`), "H.265"},
+		{regexp.MustCompile(`(?i)\b(?:[xh][-_. ]?264|AVC)\b`), "H.264"},
+		{regexp.MustCompile(`(?i)\bAV1\b`), "AV1"},
+		{regexp.MustCompile(`(?i)\bVP9\b`), "VP9"},
+		{regexp.MustCompile(`(?i)\bVC[-_. ]?1\b`), "VC-1"},
+		{MPEG2Regex, "MPEG-2"},
+		{regexp.MustCompile(`(?i)\bXvid(?:HD)?\b`), "Xvid"},
+		{regexp.MustCompile(`(?i)\bDivX\b`), "DivX"},
+	}
+
+	hdrDolbyVisionRegex = regexp.MustCompile(`(?i)\b(?:DV|DoVi|Dolby[-_. ]?Vision)\b`)
+	hdr10PlusRegex      = regexp.MustCompile(`(?i)\bHDR10(?:\+|[-_. ]?Plus|P\b)`)
+	hdr10Regex          = regexp.MustCompile(`(?i)\b(?:HDR10|HDR|PQ10)\b`)
+	hdrHLGRegex         = regexp.MustCompile(`(?i)\bHLG(?:10)?\b`)
+
+	bitDepthRegex = regexp.MustCompile(`(?i)\b(8|10|12)[-_. ]?bits?\b|\b(Hi10P?)\b`)
+
+	// Checked in order: the more specific DTS and Dolby formats come first.
+	audioCodecPatterns = []struct {
+		regex *regexp.Regexp
+		codec string
+	}{
+		{regexp.MustCompile(`(?i)\bTrue[-_. ]?HD\b`), "TrueHD"},
+		{regexp.MustCompile(`(?i)\bDTS[-_. ]?(?:HD[-_. ]?)?MA\b`), "DTS-HD MA"},
+		{regexp.MustCompile(`(?i)\bDTS[-_. ]?X\b|\bDTS:X\b`), "DTS:X"},
+		{regexp.MustCompile(`(?i)\bDTS[-_. ]?HD(?:[-_. ]?HRA)?\b`), "DTS-HD HRA"},
+		{regexp.MustCompile(`(?i)\bDTS\b`), "DTS"},
+		{regexp.MustCompile(`(?i)\b(?:DDP|DD\+|E[-_. ]?AC[-_. ]?3)|\bDD[-_. ]?Plus\b`), "EAC3"},
+		{regexp.MustCompile(`(?i)\b(?:DD|AC[-_. ]?3|Dolby[-_. ]?Digital)(?:\b|\d)`), "AC3"},
+		{regexp.MustCompile(`(?i)\bAAC`), "AAC"},
+		{regexp.MustCompile(`(?i)\bFLAC\b`), "FLAC"},
+		{regexp.MustCompile(`(?i)\bOpus\b`), "Opus"},
+		{regexp.MustCompile(`(?i)\bMP3\b`), "MP3"},
+		{regexp.MustCompile(`(?i)\bL?PCM\b`), "PCM"},
+	}
+
+	// 5.1, DDP5.1, AAC2.0, TrueHD 7.1; the leading non-digit keeps "H.265.1080p" out
+	audioChannelsRegex = regexp.MustCompile(`(?:^|[^\d])([12567])[. ]([01])(?:[^\d]|$)`)
+	atmosRegex         = regexp.MustCompile(`(?i)\bAtmos\b`)
+
+	// Streaming service tags are short and collide with words, so they must
+	// appear in their canonical case as a whole token.
+	streamingServices = map[string]string{
+		"AMZN": "Amazon",
+		"NF":   "Netflix",
+		"DSNP": "Disney+",
+		"DSNY": "Disney+",
+		"DNSP": "Disney+",
+		"ATVP": "Apple TV+",
+		"HMAX": "HBO Max",
+		"HBO":  "HBO",
+		"HULU": "Hulu",
+		"PMTP": "Paramount+",
+		"PCOK": "Peacock",
+		"iT":   "iTunes",
+		"STAN": "Stan",
+		"CRAV": "Crave",
+		"CR":   "Crunchyroll",
+	}
+	streamingTokenRegex = regexp.MustCompile(`[A-Za-z0-9+]+`)
+)
+
+// ParseMediaTags detects codec, HDR, audio and streaming service tags in the
+// part of a release name that follows the title.
+func ParseMediaTags(tail string) MediaTags {
+	tags := MediaTags{VideoCodec: "Unknown", AudioCodec: "Unknown", AudioChannels: "Unknown"}
+
+	for _, p := range videoCodecPatterns {
+		if p.regex.MatchString(tail) {
+			tags.VideoCodec = p.codec
+			break
+		}
+	}
+
+	hasDV := hdrDolbyVisionRegex.MatchString(tail)
+	if hasDV {
+		tags.HDR = append(tags.HDR, HDRDolbyVision)
+	}
+	if hdr10PlusRegex.MatchString(tail) {
+		tags.HDR = append(tags.HDR, HDR10Plus)
+	} else if hdr10Regex.MatchString(tail) {
+		tags.HDR = append(tags.HDR, HDR10)
+	}
+	if hdrHLGRegex.MatchString(tail) {
+		tags.HDR = append(tags.HDR, HDRHLG)
+	}
+	tags.DolbyVisionOnly = hasDV && len(tags.HDR) == 1
+	if len(tags.HDR) == 0 {
+		tags.HDR = []string{HDRNone}
+	}
+
+	if m := bitDepthRegex.FindStringSubmatch(tail); m != nil {
+		if m[1] != "" {
+			tags.BitDepth = atoi(m[1])
+		} else {
+			tags.BitDepth = 10
+		}
+	} else if tags.IsHDR() {
+		// Every HDR format is at least 10-bit
+		tags.BitDepth = 10
+	}
+
+	for _, p := range audioCodecPatterns {
+		if p.regex.MatchString(tail) {
+			tags.AudioCodec = p.codec
+			break
+		}
+	}
+	if m := audioChannelsRegex.FindStringSubmatch(tail); m != nil {
+		tags.AudioChannels = m[1] + "." + m[2]
+	}
+	tags.Atmos = atmosRegex.MatchString(tail)
+
+	for _, tok := range streamingTokenRegex.FindAllString(tail, -1) {
+		if service, ok := streamingServices[tok]; ok {
+			tags.StreamingService = service
+			break
+		}
+	}
+
+	return tags
+}
+
+// IsHDR reports whether any HDR format was tagged.
+func (t MediaTags) IsHDR() bool {
+	return len(t.HDR) > 0 && !strings.EqualFold(t.HDR[0], HDRNone)
+}
@@ -163,6 +163,44 @@ func (q Quality) IsRemux() bool {
 	return q == Bluray1080pRemux || q == Bluray2160pRemux
 }
 
+// Qualities lists every quality from least to most preferred, in Sonarr's
+// default order. New quality profiles start from this order.
+var Qualities = []Quality{
+	Unknown,
+	SDTV,
+	WEBRip480p,
+	WEBDL480p,
+	DVD,
+	Bluray480p,
+	Bluray576p,
+	HDTV720p,
+	HDTV1080p,
+	RAWHD,
+	WEBRip720p,
+	WEBDL720p,
+	Bluray720p,
+	WEBRip1080p,
+	WEBDL1080p,
+	Bluray1080p,
+	Bluray1080pRemux,
+	HDTV2160p,
+	WEBRip2160p,
+	WEBDL2160p,
+	Bluray2160p,
+	Bluray2160pRemux,
+}
+
+// QualityFromString returns the quality named by String() (e.g. "WEBDL-1080p"),
+// case-insensitively.
+func QualityFromString(name string) (Quality, bool) {
+	for _, q := range Qualities {
+		if strings.EqualFold(q.String(), name) {
+			return q, true
+		}
+	}
+	return Unknown, false
+}
+
 type Revision struct {
 	Version  int
 	Real     int
@@ -171,10 +209,12 @@ type Revision struct {
 
 // ParseResult contains all information parsed from a release title.
 // It separates quality metrics (resolution, source, codecs) from release metadata
-// (release group, edition) for semantic clarity.
+// (release group, edition) and from what the release contains (title, year,
+// seasons and episodes) for semantic clarity.
 type ParseResult struct {
 	Quality QualityInfo
 	Release ReleaseInfo
+	Title   TitleInfo
 }
 
 // QualityInfo represents encoding quality characteristics.
@@ -226,6 +266,13 @@ func (qi QualityInfo) String() string {
 type ReleaseInfo struct {
 	ReleaseGroup *string // Release group name (e.g., "DIMENSION", "NTb", "Tigole")
 	Edition      *string // Movie edition (e.g., "Director's Cut", "Extended", "IMAX")
+
+	Languages         []Language // Audio languages; [Unknown] when the title names none
+	SubtitleLanguages []Language // Subtitle languages (e.g., French for VOSTFR)
+	MultiAudio        bool       // MULTi, DUAL or "German DL"
+	Subbed            bool       // SUBBED, VOST* or named subtitles
+
+	MediaTags // Codec, HDR, audio and streaming service tags
 }
 
 // GetReleaseGroup returns the release group name, or empty string if not found
@@ -798,10 +845,24 @@ func ParseResolution(name string) int {
 	return 0
 }
 
-// Parse extracts quality and release information from a release title.
-// It returns a ParseResult containing separated QualityInfo (resolution, source, etc.)
-// and ReleaseInfo (release group, edition).
+// Parse extracts quality, release and title information from a release title.
+// It returns a ParseResult containing separated QualityInfo (resolution, source, etc.),
+// ReleaseInfo (release group, edition) and TitleInfo (title, year, episodes).
 func Parse(name string) ParseResult {
+	result := parseQualityAndRelease(name)
+	var tail string
+	result.Title, tail = parseTitleInfo(name)
+
+	langs := ParseLanguages(tail)
+	result.Release.Languages = langs.Languages
+	result.Release.SubtitleLanguages = langs.SubtitleLanguages
+	result.Release.MultiAudio = langs.Multi
+	result.Release.Subbed = langs.Subbed
+	result.Release.MediaTags = ParseMediaTags(tail)
+	return result
+}
+
+func parseQualityAndRelease(name string) ParseResult {
 	normalizedName := strings.ReplaceAll(name, "_", " ")
 	normalizedName = strings.TrimSpace(normalizedName)
 
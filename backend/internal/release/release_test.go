@@ -1,7 +1,9 @@
 package release
 
 import (
+	"reflect"
 	"testing"
+	"time"
 )
 
 func TestQualityMethods(t *testing.T) {
@@ -723,3 +725,273 @@ func TestParseEdition(t *testing.T) {
 		})
 	}
 }
+
+func intPtr(v int) *int { return &v }
+
+func TestParseTitle(t *testing.T) {
+	date := func(s string) *time.Time {
+		d, _ := time.Parse("2006-01-02", s)
+		return &d
+	}
+
+	tests := []struct {
+		title    string
+		expected TitleInfo
+	}{
+		// ===== Movies =====
+		{"The.Matrix.1999.1080p.BluRay.x264-GROUP", TitleInfo{Name: "The Matrix", Year: 1999}},
+		{"Blade Runner 2049 2017 2160p UHD BluRay", TitleInfo{Name: "Blade Runner 2049", Year: 2017}},
+		{"2012.2009.1080p.BluRay", TitleInfo{Name: "2012", Year: 2009}},
+		{"Movie Name (2020) [1080p]", TitleInfo{Name: "Movie Name", Year: 2020}},
+		{"www.Example.org - Movie.Name.2018.720p.WEB-DL", TitleInfo{Name: "Movie Name", Year: 2018}},
+		{"Movie Name 2019.mkv", TitleInfo{Name: "Movie Name", Year: 2019}},
+
+		// ===== Single episodes =====
+		{"Show.Name.S01E05.720p.HDTV.x264-GRP", TitleInfo{Name: "Show Name", Seasons: []int{1}, Episodes: []int{5}}},
+		{"Show Name S1E5 HDTV", TitleInfo{Name: "Show Name", Seasons: []int{1}, Episodes: []int{5}}},
+		{"Show.Name.S01.E05.720p", TitleInfo{Name: "Show Name", Seasons: []int{1}, Episodes: []int{5}}},
+		{"Show.Name.2x07.HDTV", TitleInfo{Name: "Show Name", Seasons: []int{2}, Episodes: []int{7}}},
+		{"Doctor.Who.2005.S10E01.1080p", TitleInfo{Name: "Doctor Who", Year: 2005, Seasons: []int{10}, Episodes: []int{1}}},
+		{"Show.Name.S03E105.1080p", TitleInfo{Name: "Show Name", Seasons: []int{3}, Episodes: []int{105}}},
+		{"[SubGroup] Show Name S01E01 [1080p].mkv", TitleInfo{Name: "Show Name", Seasons: []int{1}, Episodes: []int{1}}},
+		{"Show.Name.S2024E05.1080p.WEB-DL", TitleInfo{Name: "Show Name", Seasons: []int{2024}, Episodes: []int{5}}},
+
+		// ===== Multi-episode =====
+		{"Show.Name.S02E01E02.1080p.WEB-DL", TitleInfo{Name: "Show Name", Seasons: []int{2}, Episodes: []int{1, 2}}},
+		{"Show Name S03E01-E03 1080p", TitleInfo{Name: "Show Name", Seasons: []int{3}, Episodes: []int{1, 2, 3}}},
+		{"Show.Name.S01E01-03.720p", TitleInfo{Name: "Show Name", Seasons: []int{1}, Episodes: []int{1, 2, 3}}},
+		{"Show.Name.S01E01.E02.E03.720p", TitleInfo{Name: "Show Name", Seasons: []int{1}, Episodes: []int{1, 2, 3}}},
+		{"Show.Name.S01E01E03.720p", TitleInfo{Name: "Show Name", Seasons: []int{1}, Episodes: []int{1, 3}}},
+		{"Show.Name.1x01-1x03.HDTV", TitleInfo{Name: "Show Name", Seasons: []int{1}, Episodes: []int{1, 2, 3}}},
+		{"Show.Name.S01E01-720p", TitleInfo{Name: "Show Name", Seasons: []int{1}, Episodes: []int{1}}},
+
+		// ===== Season packs =====
+		{"Show.Name.S04.1080p.BluRay", TitleInfo{Name: "Show Name", Seasons: []int{4}, FullSeason: true}},
+		{"Show Name Season 2 Complete 720p", TitleInfo{Name: "Show Name", Seasons: []int{2}, FullSeason: true}},
+		{"Show.Name.Season.03.1080p", TitleInfo{Name: "Show Name", Seasons: []int{3}, FullSeason: true}},
+		{"Show.Name.S2024.1080p.WEB-DL", TitleInfo{Name: "Show Name", Seasons: []int{2024}, FullSeason: true}},
+
+		// ===== Complete series =====
+		{"Show.Name.Complete.Series.1080p.BluRay", TitleInfo{Name: "Show Name", CompleteSeries: true}},
+		{"Show Name The Complete Series 720p WEB-DL", TitleInfo{Name: "Show Name", CompleteSeries: true}},
+		{"Show.Name.2005.Complete.Series.DVDRip", TitleInfo{Name: "Show Name", Year: 2005, CompleteSeries: true}},
+
+		// ===== Multi-season packs =====
+		{"Show.Name.S01-S03.1080p.BluRay", TitleInfo{Name: "Show Name", Seasons: []int{1, 2, 3}, MultiSeason: true}},
+		{"Show Name S01-04 720p", TitleInfo{Name: "Show Name", Seasons: []int{1, 2, 3, 4}, MultiSeason: true}},
+		{"Show Name Seasons 1 to 3 1080p", TitleInfo{Name: "Show Name", Seasons: []int{1, 2, 3}, MultiSeason: true}},
+		{"Show Name Season 1-2 WEB-DL", TitleInfo{Name: "Show Name", Seasons: []int{1, 2}, MultiSeason: true}},
+
+		// ===== Daily =====
+		{"The.Daily.Show.2023.05.14.720p.WEB", TitleInfo{Name: "The Daily Show", AirDate: date("2023-05-14")}},
+		{"Late Night 2021-11-02 1080p HDTV", TitleInfo{Name: "Late Night", AirDate: date("2021-11-02")}},
+
+		// ===== Absolute =====
+		{"[SubGroup] Anime Title - 1089 [1080p].mkv", TitleInfo{Name: "Anime Title", AbsoluteEpisodes: []int{1089}}},
+		{"Anime Title - 12v2 [720p]", TitleInfo{Name: "Anime Title", AbsoluteEpisodes: []int{12}}},
+		{"Anime Title - 01-12 [BD 1080p]", TitleInfo{Name: "Anime Title", AbsoluteEpisodes: []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}, Batch: true}},
+		{"Anime.Title.E143.1080p.WEB", TitleInfo{Name: "Anime Title", AbsoluteEpisodes: []int{143}}},
+		{"Anime Title Episode 25 1080p", TitleInfo{Name: "Anime Title", AbsoluteEpisodes: []int{25}}},
+
+		// ===== Fansub =====
+		{"[SubsPlease] Show Name - 1071 (1080p) [ABCD1234].mkv", TitleInfo{Name: "Show Name", AbsoluteEpisodes: []int{1071}, CRC32: "ABCD1234"}},
+		{"[Erai-raws] Show Name - 05v2 [1080p][Multiple Subtitle][0a1b2c3d].mkv", TitleInfo{Name: "Show Name", AbsoluteEpisodes: []int{5}, CRC32: "0A1B2C3D"}},
+		{"[Group] Show Name 07 [720p][ABCDEF12].mkv", TitleInfo{Name: "Show Name", AbsoluteEpisodes: []int{7}, CRC32: "ABCDEF12"}},
+		{"[Group] Show Name (01-26) [BD 1080p]", TitleInfo{Name: "Show Name", AbsoluteEpisodes: []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20, 21, 22, 23, 24, 25, 26}, Batch: true}},
+		{"[Group] Show Name 01~03 [1080p]", TitleInfo{Name: "Show Name", AbsoluteEpisodes: []int{1, 2, 3}, Batch: true}},
+		{"[Group] Show Name - 13-15 [Batch] [1080p]", TitleInfo{Name: "Show Name", AbsoluteEpisodes: []int{13, 14, 15}, Batch: true}},
+		{"[Group] Show Name [Batch] [1080p]", TitleInfo{Name: "Show Name", Batch: true}},
+		{"[Group] Show Name 2 - 03 [1080p]", TitleInfo{Name: "Show Name 2", AbsoluteEpisodes: []int{3}}},
+		{"[Group] Show Name S02E03 [1080p]", TitleInfo{Name: "Show Name", Seasons: []int{2}, Episodes: []int{3}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			result := Parse(tt.title)
+			if !reflect.DeepEqual(result.Title, tt.expected) {
+				t.Errorf("Parse(%q).Title = %+v, want %+v", tt.title, result.Title, tt.expected)
+			}
+		})
+	}
+}
+
+func TestTitleInfoHasEpisode(t *testing.T) {
+	tests := []struct {
+		title   string
+		season  int
+		episode int
+		want    bool
+	}{
+		{"Show.S01E05.720p", 1, 5, true},
+		{"Show.S01E05.720p", 1, 6, false},
+		{"Show.S01E04E05.720p", 1, 5, true},
+		{"Show.S02.1080p", 2, 9, true},
+		{"Show.S02.1080p", 3, 1, false},
+		{"Show.S01-S03.1080p", 3, 1, true},
+		{"Show.Complete.Series.1080p", 5, 3, true},
+		{"Show.S2024E05.1080p", 2024, 5, true},
+		{"Movie.2020.1080p", 1, 1, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			if got := ParseTitleInfo(tt.title).HasEpisode(tt.season, tt.episode); got != tt.want {
+				t.Errorf("HasEpisode(%d, %d) = %v, want %v", tt.season, tt.episode, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseLanguages(t *testing.T) {
+	tests := []struct {
+		title    string
+		expected []Language
+	}{
+		// ===== Sonarr language parser parity =====
+		{"Title.the.Series.2009.S01E14.English.HDTV.XviD-LOL", []Language{LangEnglish}},
+		{"Title.the.Series.2009.S01E14.French.HDTV.XviD-LOL", []Language{LangFrench}},
+		{"Title.the.Series.2009.S01E14.Spanish.HDTV.XviD-LOL", []Language{LangSpanish}},
+		{"Title.the.Series.2009.S01E14.German.HDTV.XviD-LOL", []Language{LangGerman}},
+		{"Title.the.Series.2009.S01E14.Germany.HDTV.XviD-LOL", []Language{LangUnknown}},
+		{"Title.the.Series.2009.S01E14.Italian.HDTV.XviD-LOL", []Language{LangItalian}},
+		{"Title.the.Series.2009.S01E14.Danish.HDTV.XviD-LOL", []Language{LangDanish}},
+		{"Title.the.Series.2009.S01E14.Dutch.HDTV.XviD-LOL", []Language{LangDutch}},
+		{"Title.the.Series.2009.S01E14.Japanese.HDTV.XviD-LOL", []Language{LangJapanese}},
+		{"Title.the.Series.2009.S01E14.Icelandic.HDTV.XviD-LOL", []Language{LangIcelandic}},
+		{"Title.the.Series.2009.S01E14.Cantonese.HDTV.XviD-LOL", []Language{LangChinese}},
+		{"Title.the.Series.2009.S01E14.Mandarin.HDTV.XviD-LOL", []Language{LangChinese}},
+		{"Title.the.Series.2009.S01E14.Korean.HDTV.XviD-LOL", []Language{LangKorean}},
+		{"Title.the.Series.2009.S01E14.Russian.HDTV.XviD-LOL", []Language{LangRussian}},
+		{"Title.the.Series.2009.S01E14.Polish.HDTV.XviD-LOL", []Language{LangPolish}},
+		{"Title.the.Series.2009.S01E14.Vietnamese.HDTV.XviD-LOL", []Language{LangVietnamese}},
+		{"Title.the.Series.2009.S01E14.Swedish.HDTV.XviD-LOL", []Language{LangSwedish}},
+		{"Title.the.Series.2009.S01E14.Norwegian.HDTV.XviD-LOL", []Language{LangNorwegian}},
+		{"Title.the.Series.2009.S01E14.Finnish.HDTV.XviD-LOL", []Language{LangFinnish}},
+		{"Title.the.Series.2009.S01E14.Turkish.HDTV.XviD-LOL", []Language{LangTurkish}},
+		{"Title.the.Series.2009.S01E14.Portuguese.HDTV.XviD-LOL", []Language{LangPortuguese}},
+		{"Title.the.Series.2009.S01E14.Flemish.HDTV.XviD-LOL", []Language{LangFlemish}},
+		{"Title.the.Series.2009.S01E14.Greek.HDTV.XviD-LOL", []Language{LangGreek}},
+		{"Title.the.Series.2009.S01E14.HDTV.XviD.HUN-LOL", []Language{LangHungarian}},
+		{"Title.the.Series.2009.S01E14.Hebrew.HDTV.XviD-LOL", []Language{LangHebrew}},
+		{"Title.the.Series.2009.S01E14.Lithuanian.HDTV.XviD-LOL", []Language{LangLithuanian}},
+		{"Title.the.Series.2009.S01E14.Czech.HDTV.XviD-LOL", []Language{LangCzech}},
+		{"Title.the.Series.2009.S01E14.Arabic.HDTV.XviD-LOL", []Language{LangArabic}},
+		{"Title.the.Series.2009.S01E14.Hindi.HDTV.XviD-LOL", []Language{LangHindi}},
+		{"Title.the.Series.2009.S01E14.Bulgarian.HDTV.XviD-LOL", []Language{LangBulgarian}},
+		{"Title.the.Series.2009.S01E14.Ukrainian.HDTV.XviD-LOL", []Language{LangUkrainian}},
+		{"Title.the.Series.2009.S01E14.HDTV.XviD-LOL", []Language{LangUnknown}},
+		{"Title.the.Series.S01E01.FRENCH.720p.HDTV.x264-GRP", []Language{LangFrench}},
+		{"Title.the.Movie.2019.TRUEFRENCH.1080p.BluRay.x264-GRP", []Language{LangFrench}},
+		{"Title.the.Series.S01E01.VFF.1080p.WEB-DL-GRP", []Language{LangFrench}},
+		{"Title.the.Series.S01E01.ITA.ENG.1080p.WEB-DL-GRP", []Language{LangItalian, LangEnglish}},
+		{"Title.the.Series.S01E01.Spanish.Latino.1080p.WEB-DL-GRP", []Language{LangSpanishLatino}},
+		{"Title.the.Movie.2020.PT-BR.1080p.WEB-DL-GRP", []Language{LangPortugueseBR}},
+		{"Title.the.Movie.2020.German.DL.1080p.BluRay.x264-GRP", []Language{LangGerman, LangOriginal}},
+		{"Title.the.Movie.2020.MULTi.1080p.BluRay.x264-GRP", []Language{LangOriginal}},
+		{"Title.the.Series.S01E01.VOSTFR.720p.WEB-DL-GRP", []Language{LangOriginal}},
+
+		// ===== Titles and codes that are not language markers =====
+		{"The.French.Dispatch.2021.1080p.WEB-DL-GRP", []Language{LangUnknown}},
+		{"Title.the.Movie.2020.1080p.WEB-DL.DDP5.1-GRP", []Language{LangUnknown}},
+		{"Title.the.Movie.2020.1080p.BluRay.x264-Pt", []Language{LangUnknown}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			got := Parse(tt.title).Release.Languages
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("Languages = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestParseSubtitles(t *testing.T) {
+	tests := []struct {
+		title     string
+		subtitles []Language
+		subbed    bool
+		multi     bool
+	}{
+		{"Title.the.Series.S01E01.VOSTFR.720p.WEB-DL-GRP", []Language{LangFrench}, true, false},
+		{"Title.the.Series.S01E01.SUBFRENCH.720p.WEB-DL-GRP", []Language{LangFrench}, true, false},
+		{"Title.the.Series.S01E01.NLSubs.720p.WEB-DL-GRP", []Language{LangDutch}, true, false},
+		{"Title.the.Movie.2020.English.Subs.1080p.BluRay-GRP", []Language{LangEnglish}, true, false},
+		{"Title.the.Series.S01E01.SUBBED.720p.WEB-DL-GRP", nil, true, false},
+		{"Title.the.Movie.2020.DUAL.1080p.BluRay.x264-GRP", nil, false, true},
+		{"Title.the.Movie.2020.Dual.Audio.1080p.BluRay.x264-GRP", nil, false, true},
+		{"Title.the.Movie.2020.1080p.WEB-DL.x264-GRP", nil, false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			got := Parse(tt.title).Release
+			if !reflect.DeepEqual(got.SubtitleLanguages, tt.subtitles) {
+				t.Errorf("SubtitleLanguages = %v, want %v", got.SubtitleLanguages, tt.subtitles)
+			}
+			if got.Subbed != tt.subbed {
+				t.Errorf("Subbed = %v, want %v", got.Subbed, tt.subbed)
+			}
+			if got.MultiAudio != tt.multi {
+				t.Errorf("MultiAudio = %v, want %v", got.MultiAudio, tt.multi)
+			}
+		})
+	}
+}
+
+func TestParseMediaTags(t *testing.T) {
+	tests := []struct {
+		title    string
+		expected MediaTags
+	}{
+		{
+			"The.Show.S09E03.2160p.DSNP.WEB-DL.DDP5.1.H.265-GROUP",
+			MediaTags{VideoCodec: "H.265", HDR: []string{HDRNone}, AudioCodec: "EAC3", AudioChannels: "5.1", StreamingService: "Disney+"},
+		},
+		{
+			"Movie.Name.2021.2160p.AMZN.WEB-DL.DDP5.1.Atmos.DV.HDR10.H.265-GROUP",
+			MediaTags{VideoCodec: "H.265", HDR: []string{HDRDolbyVision, HDR10}, BitDepth: 10, AudioCodec: "EAC3", AudioChannels: "5.1", Atmos: true, StreamingService: "Amazon"},
+		},
+		{
+			"Movie.Name.2021.2160p.NF.WEB-DL.DDP5.1.Atmos.DV.H.265-GROUP",
+			MediaTags{VideoCodec: "H.265", HDR: []string{HDRDolbyVision}, DolbyVisionOnly: true, BitDepth: 10, AudioCodec: "EAC3", AudioChannels: "5.1", Atmos: true, StreamingService: "Netflix"},
+		},
+		{
+			"Movie.Name.2019.2160p.UHD.BluRay.REMUX.HDR10+.HEVC.TrueHD.7.1.Atmos-GROUP",
+			MediaTags{VideoCodec: "H.265", HDR: []string{HDR10Plus}, BitDepth: 10, AudioCodec: "TrueHD", AudioChannels: "7.1", Atmos: true},
+		},
+		{
+			"Movie.Name.2010.1080p.BluRay.DTS-HD.MA.5.1.x264-GROUP",
+			MediaTags{VideoCodec: "H.264", HDR: []string{HDRNone}, AudioCodec: "DTS-HD MA", AudioChannels: "5.1"},
+		},
+		{
+			"Show.Name.S01E01.1080p.ATVP.WEB-DL.DD5.1.H.264-GROUP",
+			MediaTags{VideoCodec: "H.264", HDR: []string{HDRNone}, AudioCodec: "AC3", AudioChannels: "5.1", StreamingService: "Apple TV+"},
+		},
+		{
+			"Show.Name.S02E04.2160p.iT.WEB-DL.HLG.AV1.10bit.AAC2.0-GROUP",
+			MediaTags{VideoCodec: "AV1", HDR: []string{HDRHLG}, BitDepth: 10, AudioCodec: "AAC", AudioChannels: "2.0", StreamingService: "iTunes"},
+		},
+		{
+			"[SubsPlease] Show Name - 05 (1080p) [Hi10P FLAC]",
+			MediaTags{VideoCodec: "Unknown", HDR: []string{HDRNone}, BitDepth: 10, AudioCodec: "FLAC", AudioChannels: "Unknown"},
+		},
+		{
+			"Show.Name.S04E05.HDTV.XviD-LOL",
+			MediaTags{VideoCodec: "Xvid", HDR: []string{HDRNone}, AudioCodec: "Unknown", AudioChannels: "Unknown"},
+		},
+		{
+			"Movie.Name.2020.1080p.WEB-DL-GRP",
+			MediaTags{VideoCodec: "Unknown", HDR: []string{HDRNone}, AudioCodec: "Unknown", AudioChannels: "Unknown"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			got := Parse(tt.title).Release.MediaTags
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("MediaTags = %+v, want %+v", got, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,329 @@
+package release
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// TitleInfo is what a release name says about the media it contains: the
+// title and year, and for series the seasons, episodes, air date or absolute
+// episode numbers.
+type TitleInfo struct {
+	Name string
+	Year int // zero when not found
+
+	// Seasons holds one season for episodes and season packs, or every season
+	// of a multi-season pack.
+	Seasons  []int
+	Episodes []int // episode numbers within Seasons[0]; empty for packs
+
+	FullSeason     bool // a complete season without episode numbers
+	MultiSeason    bool // a pack spanning several seasons
+	CompleteSeries bool // every season, without season numbers
+
+	AirDate          *time.Time // daily shows
+	AbsoluteEpisodes []int      // anime-style absolute numbering
+
+	// Fansub releases: a batch covers a range of absolute episodes, and the
+	// CRC32 tag is the checksum of the file (e.g. [ABCD1234]).
+	Batch bool
+	CRC32 string
+}
+
+// Season returns the (first) season number, if any.
+func (t TitleInfo) Season() (int, bool) {
+	if len(t.Seasons) == 0 {
+		return 0, false
+	}
+	return t.Seasons[0], true
+}
+
+// IsSeries reports whether the name carries any series numbering.
+func (t TitleInfo) IsSeries() bool {
+	return len(t.Seasons) > 0 || t.AirDate != nil || len(t.AbsoluteEpisodes) > 0 || t.CompleteSeries
+}
+
+// HasEpisode reports whether the release contains the given episode, either
+// explicitly or as part of a season pack.
+func (t TitleInfo) HasEpisode(season, episode int) bool {
+	if t.CompleteSeries {
+		return true
+	}
+	if t.FullSeason || t.MultiSeason {
+		return containsInt(t.Seasons, season)
+	}
+	s, ok := t.Season()
+	return ok && s == season && containsInt(t.Episodes, episode)
+}
+
+var (
+	// S01E01, S1E1, S01.E01, S01E01E02, S01E01-E03, S01E01-03, S2024E05
+	titleEpisodeRegex = regexp.MustCompile(`(?i)\bS(\d{1,2}|(?:19|20)\d{2})[-_. ]?E(\d{1,3})((?:[-_. ]?E\d{1,3}|-\d{1,3})*)\b`)
+	titleEpisodeTail  = regexp.MustCompile(`(?i)(-?)[_. ]?E?(\d{1,3})`)
+	// 1x01, 1x01-1x03, 1x01-03
+	titleCrossEpisodeRegex = regexp.MustCompile(`(?i)\b(\d{1,2})x(\d{2,3})(?:-(?:\d{1,2}x)?(\d{2,3}))?\b`)
+	// S01-S03, S01-03, Season 1-3, Seasons 1 to 3, S01 & S02
+	titleMultiSeasonRegex = regexp.MustCompile(`(?i)\b(?:S(\d{1,2})[-_. ]?(?:-|to|&)[-_. ]?S?(\d{1,2})|Seasons?[-_. ]?(\d{1,2})[-_. ]?(?:-|to|&)[-_. ]?(\d{1,2}))\b`)
+	// S01, Season 1, Season.01, S2024
+	titleSeasonRegex = regexp.MustCompile(`(?i)\b(?:S(\d{1,2}|(?:19|20)\d{2})|Season[-_. ]?(\d{1,2}))\b`)
+	// Complete.Series, The Complete Series
+	titleCompleteSeriesRegex = regexp.MustCompile(`(?i)\b(?:The[-_. ])?Complete[-_. ]Series\b`)
+	// 2023.05.14, 2023-05-14
+	titleDailyRegex = regexp.MustCompile(`\b((?:19|20)\d{2})[-_. ](0[1-9]|1[0-2])[-_. ](0[1-9]|[12]\d|3[01])\b`)
+	// "Title - 123", "Title - 01-12", "Title E123", "Title Episode 123"
+	titleAbsoluteRegex = regexp.MustCompile(`(?i)(?:\s-\s|\b(?:E|EP|Episode)[-_. ]?)(\d{2,4})(?:v\d)?(?:\s?[-~]\s?(\d{2,4})(?:v\d)?)?\b`)
+	// Fansub forms after a [Group] prefix: "Title 07 [720p]", "Title (01-26)", "Title 01~12"
+	titleAnimeAbsoluteRegex = regexp.MustCompile(`\s\(?(\d{2,4})(?:v\d)?(?:\s?[-~]\s?(\d{2,4})(?:v\d)?)?\)?(?:\s*[\[(]|\s*$|\s+END\b)`)
+	titleBatchRegex         = regexp.MustCompile(`(?i)[\[(]?\bBatch\b[\])]?`)
+	titleCRC32Regex         = regexp.MustCompile(`\[([0-9A-Fa-f]{8})\]`)
+	titleYearRegex          = regexp.MustCompile(`\b((?:19|20)\d{2})\b`)
+	// First token that is clearly not part of the title
+	titleStopRegex = regexp.MustCompile(`(?i)\b(?:2160p|1080[pi]|720p|576p|480p|4k|uhd|blu-?ray|web-?dl|webrip|web|hdtv|dvdrip|bdrip|brrip|remux|x26[45]|h26[45]|hevc|proper|repack|multi|complete)\b`)
+)
+
+// ParseTitleInfo extracts the title, year and series numbering from a release name.
+func ParseTitleInfo(name string) TitleInfo {
+	info, _ := parseTitleInfo(name)
+	return info
+}
+
+// parseTitleInfo also returns the rest of the name after the title, where
+// quality, language and group markers live.
+func parseTitleInfo(name string) (TitleInfo, string) {
+	cleaned := strings.TrimSpace(removeFileExtension(strings.ReplaceAll(name, "_", " ")))
+	cleaned = WebsitePrefixRegex.ReplaceAllString(cleaned, "")
+	anime := false
+	if m := AnimeReleaseGroupRegex.FindStringIndex(cleaned); m != nil {
+		cleaned = cleaned[m[1]:]
+		anime = true
+	}
+
+	var info TitleInfo
+	end := len(cleaned)
+	cut := func(pos int) {
+		if pos > 0 && pos < end {
+			end = pos
+		}
+	}
+
+	if all := titleCRC32Regex.FindAllStringSubmatchIndex(cleaned, -1); len(all) > 0 {
+		m := all[len(all)-1]
+		info.CRC32 = strings.ToUpper(cleaned[m[2]:m[3]])
+		cut(m[0])
+	}
+	if m := titleBatchRegex.FindStringIndex(cleaned); m != nil {
+		info.Batch = true
+		cut(m[0])
+	}
+
+	switch {
+	case parseEpisodeMarker(cleaned, &info, cut):
+	case parseCrossEpisodeMarker(cleaned, &info, cut):
+	case parseDailyMarker(cleaned, &info, cut):
+	case parseMultiSeasonMarker(cleaned, &info, cut):
+	case parseSeasonMarker(cleaned, &info, cut):
+	case parseCompleteSeriesMarker(cleaned, &info, cut):
+	case parseAbsoluteMarker(cleaned, &info, cut):
+	case anime:
+		parseAnimeAbsoluteMarker(cleaned[:end], &info, cut)
+	}
+	if len(info.AbsoluteEpisodes) > 1 {
+		info.Batch = true
+	}
+
+	if m := titleStopRegex.FindStringIndex(cleaned); m != nil {
+		cut(m[0])
+	}
+	if m := languageStopRegex.FindStringIndex(cleaned); m != nil {
+		cut(m[0])
+	}
+
+	// The year is the last one before the end of the title; a year at the very
+	// start is part of the title (e.g. "2012 2009 1080p").
+	head := cleaned[:end]
+	for _, m := range titleYearRegex.FindAllStringSubmatchIndex(head, -1) {
+		if m[0] == 0 {
+			continue
+		}
+		info.Year, _ = strconv.Atoi(head[m[2]:m[3]])
+		end = m[0]
+	}
+
+	info.Name = strings.TrimSpace(strings.Trim(strings.ReplaceAll(cleaned[:end], ".", " "), " -([{"))
+	return info, cleaned[end:]
+}
+
+func parseEpisodeMarker(s string, info *TitleInfo, cut func(int)) bool {
+	m := titleEpisodeRegex.FindStringSubmatchIndex(s)
+	if m == nil {
+		return false
+	}
+	season := atoi(s[m[2]:m[3]])
+	first := atoi(s[m[4]:m[5]])
+	info.Seasons = []int{season}
+	info.Episodes = []int{first}
+
+	last := first
+	for _, t := range titleEpisodeTail.FindAllStringSubmatch(s[m[6]:m[7]], -1) {
+		n := atoi(t[2])
+		if t[1] == "-" {
+			info.Episodes = appendRange(info.Episodes, last, n)
+		} else if !containsInt(info.Episodes, n) {
+			info.Episodes = append(info.Episodes, n)
+		}
+		last = n
+	}
+	cut(m[0])
+	return true
+}
+
+func parseCrossEpisodeMarker(s string, info *TitleInfo, cut func(int)) bool {
+	m := titleCrossEpisodeRegex.FindStringSubmatchIndex(s)
+	if m == nil {
+		return false
+	}
+	first := atoi(s[m[4]:m[5]])
+	info.Seasons = []int{atoi(s[m[2]:m[3]])}
+	info.Episodes = []int{first}
+	if m[6] >= 0 {
+		info.Episodes = appendRange(info.Episodes, first, atoi(s[m[6]:m[7]]))
+	}
+	cut(m[0])
+	return true
+}
+
+func parseDailyMarker(s string, info *TitleInfo, cut func(int)) bool {
+	m := titleDailyRegex.FindStringSubmatchIndex(s)
+	if m == nil || m[0] == 0 {
+		return false
+	}
+	date, err := time.Parse("2006-01-02", s[m[2]:m[3]]+"-"+s[m[4]:m[5]]+"-"+s[m[6]:m[7]])
+	if err != nil {
+		return false
+	}
+	info.AirDate = &date
+	cut(m[0])
+	return true
+}
+
+func parseMultiSeasonMarker(s string, info *TitleInfo, cut func(int)) bool {
+	m := titleMultiSeasonRegex.FindStringSubmatchIndex(s)
+	if m == nil {
+		return false
+	}
+	var first, last int
+	if m[2] >= 0 {
+		first, last = atoi(s[m[2]:m[3]]), atoi(s[m[4]:m[5]])
+	} else {
+		first, last = atoi(s[m[6]:m[7]]), atoi(s[m[8]:m[9]])
+	}
+	if last <= first {
+		return false
+	}
+	info.Seasons = appendRange([]int{first}, first, last)
+	info.MultiSeason = true
+	cut(m[0])
+	return true
+}
+
+func parseSeasonMarker(s string, info *TitleInfo, cut func(int)) bool {
+	m := titleSeasonRegex.FindStringSubmatchIndex(s)
+	if m == nil {
+		return false
+	}
+	if m[2] >= 0 {
+		info.Seasons = []int{atoi(s[m[2]:m[3]])}
+	} else {
+		info.Seasons = []int{atoi(s[m[4]:m[5]])}
+	}
+	info.FullSeason = true
+	cut(m[0])
+	return true
+}
+
+func parseCompleteSeriesMarker(s string, info *TitleInfo, cut func(int)) bool {
+	m := titleCompleteSeriesRegex.FindStringIndex(s)
+	if m == nil {
+		return false
+	}
+	info.CompleteSeries = true
+	cut(m[0])
+	return true
+}
+
+func parseAbsoluteMarker(s string, info *TitleInfo, cut func(int)) bool {
+	for _, m := range titleAbsoluteRegex.FindAllStringSubmatchIndex(s, -1) {
+		first := atoi(s[m[2]:m[3]])
+		if isYear(first) {
+			continue
+		}
+		info.AbsoluteEpisodes = []int{first}
+		if m[4] >= 0 {
+			info.AbsoluteEpisodes = appendRange(info.AbsoluteEpisodes, first, atoi(s[m[4]:m[5]]))
+		}
+		cut(m[0])
+		return true
+	}
+	return false
+}
+
+// parseAnimeAbsoluteMarker handles the bare episode numbers fansub groups use,
+// which are only trusted after a [Group] prefix.
+func parseAnimeAbsoluteMarker(s string, info *TitleInfo, cut func(int)) bool {
+	for _, m := range titleAnimeAbsoluteRegex.FindAllStringSubmatchIndex(s, -1) {
+		first := atoi(s[m[2]:m[3]])
+		if isYear(first) || isResolution(first) {
+			continue
+		}
+		info.AbsoluteEpisodes = []int{first}
+		if m[4] >= 0 {
+			info.AbsoluteEpisodes = appendRange(info.AbsoluteEpisodes, first, atoi(s[m[4]:m[5]]))
+		}
+		cut(m[0])
+		return true
+	}
+	return false
+}
+
+// appendRange appends the episodes after from up to and including to. Ranges
+// that run backwards or are implausibly long only add the end episode.
+func appendRange(episodes []int, from, to int) []int {
+	if to <= from || to-from > 200 {
+		if !containsInt(episodes, to) {
+			episodes = append(episodes, to)
+		}
+		return episodes
+	}
+	for n := from + 1; n <= to; n++ {
+		episodes = append(episodes, n)
+	}
+	return episodes
+}
+
+func isYear(n int) bool {
+	return n >= 1900 && n <= 2099
+}
+
+func isResolution(n int) bool {
+	switch n {
+	case 480, 576, 720, 1080, 2160:
+		return true
+	}
+	return false
+}
+
+func atoi(s string) int {
+	n, _ := strconv.Atoi(s)
+	return n
+}
+
+func containsInt(values []int, v int) bool {
+	for _, x := range values {
+		if x == v {
+			return true
+		}
+	}
+	return false
+}
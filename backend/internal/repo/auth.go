@@ -26,6 +26,7 @@ type AuthRepo interface {
 	AssignRole(ctx context.Context, userID, roleID pgtype.UUID) error
 	UnassignAllRoles(ctx context.Context, userID pgtype.UUID) error
 	CountUsersByRole(ctx context.Context, roleID pgtype.UUID) (int64, error)
+	UserHasPermission(ctx context.Context, userID pgtype.UUID, permissionKey string) (bool, error)
 	// Identity
 	GetIdentityByProviderSubject(ctx context.Context, provider dbgen.AuthProvider, subject string) (dbgen.UserIdentity, error)
 	UpsertIdentity(ctx context.Context, params dbgen.UpsertIdentityParams) (dbgen.UserIdentity, error)
@@ -104,6 +105,13 @@ func (r *Repository) CountUsersByRole(ctx context.Context, roleID pgtype.UUID) (
 	return r.Q.CountUsersByRole(ctx, roleID)
 }
 
+func (r *Repository) UserHasPermission(ctx context.Context, userID pgtype.UUID, permissionKey string) (bool, error) {
+	return r.Q.UserHasPermission(ctx, dbgen.UserHasPermissionParams{
+		PermissionKey: permissionKey,
+		UserID:        userID,
+	})
+}
+
 func (r *Repository) GetUserByID(ctx context.Context, id pgtype.UUID) (dbgen.AppUser, error) {
 	return r.Q.GetUserByID(ctx, id)
 }
@@ -0,0 +1,87 @@
+package repo
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	dbgen "github.com/kyleaupton/arrflix/internal/db/sqlc"
+)
+
+type AutoSearchRepo interface {
+	// Wanted items
+	ListWantedMovies(ctx context.Context, searchedBefore time.Time, limit int32) ([]dbgen.MediaItem, error)
+	ListWantedEpisodes(ctx context.Context, searchedBefore time.Time, limit int32) ([]dbgen.ListWantedEpisodesRow, error)
+	SetMediaItemSearchedAt(ctx context.Context, id pgtype.UUID) error
+	SetEpisodesSearchedAt(ctx context.Context, ids []pgtype.UUID) error
+
+	// Upgrade candidates
+	ListUpgradeMovies(ctx context.Context, searchedBefore time.Time, limit int32) ([]dbgen.MediaItem, error)
+	ListUpgradeEpisodes(ctx context.Context, searchedBefore time.Time, limit int32) ([]dbgen.ListUpgradeEpisodesRow, error)
+
+	// Decisions
+	CreateAutoSearchDecision(ctx context.Context, params dbgen.CreateAutoSearchDecisionParams) (dbgen.AutoSearchDecision, error)
+	GetAutoSearchDecision(ctx context.Context, id pgtype.UUID) (dbgen.AutoSearchDecision, error)
+	ListAutoSearchDecisionsPaginated(ctx context.Context, mediaItemID pgtype.UUID, outcome *string, pageSize, offset int32) ([]dbgen.AutoSearchDecision, error)
+	CountAutoSearchDecisions(ctx context.Context, mediaItemID pgtype.UUID, outcome *string) (int64, error)
+}
+
+func (r *Repository) ListWantedMovies(ctx context.Context, searchedBefore time.Time, limit int32) ([]dbgen.MediaItem, error) {
+	return r.Q.ListWantedMovies(ctx, dbgen.ListWantedMoviesParams{
+		SearchedBefore: pgtype.Timestamptz{Time: searchedBefore, Valid: true},
+		RowLimit:       limit,
+	})
+}
+
+func (r *Repository) ListWantedEpisodes(ctx context.Context, searchedBefore time.Time, limit int32) ([]dbgen.ListWantedEpisodesRow, error) {
+	return r.Q.ListWantedEpisodes(ctx, dbgen.ListWantedEpisodesParams{
+		SearchedBefore: pgtype.Timestamptz{Time: searchedBefore, Valid: true},
+		RowLimit:       limit,
+	})
+}
+
+func (r *Repository) SetMediaItemSearchedAt(ctx context.Context, id pgtype.UUID) error {
+	return r.Q.SetMediaItemSearchedAt(ctx, id)
+}
+
+func (r *Repository) SetEpisodesSearchedAt(ctx context.Context, ids []pgtype.UUID) error {
+	return r.Q.SetEpisodesSearchedAt(ctx, ids)
+}
+
+func (r *Repository) ListUpgradeMovies(ctx context.Context, searchedBefore time.Time, limit int32) ([]dbgen.MediaItem, error) {
+	return r.Q.ListUpgradeMovies(ctx, dbgen.ListUpgradeMoviesParams{
+		SearchedBefore: pgtype.Timestamptz{Time: searchedBefore, Valid: true},
+		RowLimit:       limit,
+	})
+}
+
+func (r *Repository) ListUpgradeEpisodes(ctx context.Context, searchedBefore time.Time, limit int32) ([]dbgen.ListUpgradeEpisodesRow, error) {
+	return r.Q.ListUpgradeEpisodes(ctx, dbgen.ListUpgradeEpisodesParams{
+		SearchedBefore: pgtype.Timestamptz{Time: searchedBefore, Valid: true},
+		RowLimit:       limit,
+	})
+}
+
+func (r *Repository) CreateAutoSearchDecision(ctx context.Context, params dbgen.CreateAutoSearchDecisionParams) (dbgen.AutoSearchDecision, error) {
+	return r.Q.CreateAutoSearchDecision(ctx, params)
+}
+
+func (r *Repository) GetAutoSearchDecision(ctx context.Context, id pgtype.UUID) (dbgen.AutoSearchDecision, error) {
+	return r.Q.GetAutoSearchDecision(ctx, id)
+}
+
+func (r *Repository) ListAutoSearchDecisionsPaginated(ctx context.Context, mediaItemID pgtype.UUID, outcome *string, pageSize, offset int32) ([]dbgen.AutoSearchDecision, error) {
+	return r.Q.ListAutoSearchDecisionsPaginated(ctx, dbgen.ListAutoSearchDecisionsPaginatedParams{
+		MediaItemID: mediaItemID,
+		Outcome:     outcome,
+		PageSize:    pageSize,
+		OffsetVal:   offset,
+	})
+}
+
+func (r *Repository) CountAutoSearchDecisions(ctx context.Context, mediaItemID pgtype.UUID, outcome *string) (int64, error) {
+	return r.Q.CountAutoSearchDecisions(ctx, dbgen.CountAutoSearchDecisionsParams{
+		MediaItemID: mediaItemID,
+		Outcome:     outcome,
+	})
+}
@@ -0,0 +1,63 @@
+package repo
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	dbgen "github.com/kyleaupton/arrflix/internal/db/sqlc"
+)
+
+type BlocklistRepo interface {
+	CreateBlocklistEntry(ctx context.Context, params dbgen.CreateBlocklistEntryParams) (dbgen.Blocklist, error)
+	GetBlocklistEntry(ctx context.Context, id pgtype.UUID) (dbgen.Blocklist, error)
+	GetBlocklistEntryByDownloadJob(ctx context.Context, downloadJobID pgtype.UUID) (dbgen.Blocklist, error)
+	ListBlocklistEntriesPaginated(ctx context.Context, mediaItemID pgtype.UUID, pageSize, offset int32) ([]dbgen.Blocklist, error)
+	CountBlocklistEntries(ctx context.Context, mediaItemID pgtype.UUID) (int64, error)
+	UpdateBlocklistEntry(ctx context.Context, id pgtype.UUID, message *string, expiresAt pgtype.Timestamptz) (dbgen.Blocklist, error)
+	DeleteBlocklistEntry(ctx context.Context, id pgtype.UUID) error
+	FindBlocklistMatches(ctx context.Context, infoHashes, indexerGUIDs, normalizedTitles []string) ([]dbgen.Blocklist, error)
+}
+
+func (r *Repository) CreateBlocklistEntry(ctx context.Context, params dbgen.CreateBlocklistEntryParams) (dbgen.Blocklist, error) {
+	return r.Q.CreateBlocklistEntry(ctx, params)
+}
+
+func (r *Repository) GetBlocklistEntry(ctx context.Context, id pgtype.UUID) (dbgen.Blocklist, error) {
+	return r.Q.GetBlocklistEntry(ctx, id)
+}
+
+func (r *Repository) GetBlocklistEntryByDownloadJob(ctx context.Context, downloadJobID pgtype.UUID) (dbgen.Blocklist, error) {
+	return r.Q.GetBlocklistEntryByDownloadJob(ctx, downloadJobID)
+}
+
+func (r *Repository) ListBlocklistEntriesPaginated(ctx context.Context, mediaItemID pgtype.UUID, pageSize, offset int32) ([]dbgen.Blocklist, error) {
+	return r.Q.ListBlocklistEntriesPaginated(ctx, dbgen.ListBlocklistEntriesPaginatedParams{
+		MediaItemID: mediaItemID,
+		PageSize:    pageSize,
+		OffsetVal:   offset,
+	})
+}
+
+func (r *Repository) CountBlocklistEntries(ctx context.Context, mediaItemID pgtype.UUID) (int64, error) {
+	return r.Q.CountBlocklistEntries(ctx, mediaItemID)
+}
+
+func (r *Repository) UpdateBlocklistEntry(ctx context.Context, id pgtype.UUID, message *string, expiresAt pgtype.Timestamptz) (dbgen.Blocklist, error) {
+	return r.Q.UpdateBlocklistEntry(ctx, dbgen.UpdateBlocklistEntryParams{
+		Message:   message,
+		ExpiresAt: expiresAt,
+		ID:        id,
+	})
+}
+
+func (r *Repository) DeleteBlocklistEntry(ctx context.Context, id pgtype.UUID) error {
+	return r.Q.DeleteBlocklistEntry(ctx, id)
+}
+
+func (r *Repository) FindBlocklistMatches(ctx context.Context, infoHashes, indexerGUIDs, normalizedTitles []string) ([]dbgen.Blocklist, error) {
+	return r.Q.FindBlocklistMatches(ctx, dbgen.FindBlocklistMatchesParams{
+		InfoHashes:       infoHashes,
+		IndexerGuids:     indexerGUIDs,
+		NormalizedTitles: normalizedTitles,
+	})
+}
@@ -0,0 +1,61 @@
+package repo
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	dbgen "github.com/kyleaupton/arrflix/internal/db/sqlc"
+)
+
+type CalendarRepo interface {
+	// Release dates
+	SetMediaItemReleaseDates(ctx context.Context, id pgtype.UUID, release, digital, physical pgtype.Date) error
+
+	// Calendar
+	ListCalendarEpisodes(ctx context.Context, start, end time.Time) ([]dbgen.ListCalendarEpisodesRow, error)
+	ListCalendarMovies(ctx context.Context, start, end time.Time) ([]dbgen.ListCalendarMoviesRow, error)
+
+	// Feed tokens
+	GetCalendarToken(ctx context.Context, userID pgtype.UUID) (dbgen.CalendarToken, error)
+	GetCalendarTokenByToken(ctx context.Context, token string) (dbgen.CalendarToken, error)
+	UpsertCalendarToken(ctx context.Context, userID pgtype.UUID, token string) (dbgen.CalendarToken, error)
+}
+
+func (r *Repository) SetMediaItemReleaseDates(ctx context.Context, id pgtype.UUID, release, digital, physical pgtype.Date) error {
+	return r.Q.SetMediaItemReleaseDates(ctx, dbgen.SetMediaItemReleaseDatesParams{
+		ID:                  id,
+		ReleaseDate:         release,
+		DigitalReleaseDate:  digital,
+		PhysicalReleaseDate: physical,
+	})
+}
+
+func (r *Repository) ListCalendarEpisodes(ctx context.Context, start, end time.Time) ([]dbgen.ListCalendarEpisodesRow, error) {
+	return r.Q.ListCalendarEpisodes(ctx, dbgen.ListCalendarEpisodesParams{
+		StartDate: pgtype.Date{Time: start, Valid: true},
+		EndDate:   pgtype.Date{Time: end, Valid: true},
+	})
+}
+
+func (r *Repository) ListCalendarMovies(ctx context.Context, start, end time.Time) ([]dbgen.ListCalendarMoviesRow, error) {
+	return r.Q.ListCalendarMovies(ctx, dbgen.ListCalendarMoviesParams{
+		StartDate: pgtype.Date{Time: start, Valid: true},
+		EndDate:   pgtype.Date{Time: end, Valid: true},
+	})
+}
+
+func (r *Repository) GetCalendarToken(ctx context.Context, userID pgtype.UUID) (dbgen.CalendarToken, error) {
+	return r.Q.GetCalendarToken(ctx, userID)
+}
+
+func (r *Repository) GetCalendarTokenByToken(ctx context.Context, token string) (dbgen.CalendarToken, error) {
+	return r.Q.GetCalendarTokenByToken(ctx, token)
+}
+
+func (r *Repository) UpsertCalendarToken(ctx context.Context, userID pgtype.UUID, token string) (dbgen.CalendarToken, error) {
+	return r.Q.UpsertCalendarToken(ctx, dbgen.UpsertCalendarTokenParams{
+		UserID: userID,
+		Token:  token,
+	})
+}
@@ -0,0 +1,80 @@
+package repo
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	dbgen "github.com/kyleaupton/arrflix/internal/db/sqlc"
+)
+
+type IndexerHealthRepo interface {
+	ListIndexerHealth(ctx context.Context) ([]dbgen.IndexerHealth, error)
+	GetIndexerHealth(ctx context.Context, indexerID int64) (dbgen.IndexerHealth, error)
+	ListDisabledIndexerIDs(ctx context.Context) ([]int64, error)
+	RecordIndexerSuccess(ctx context.Context, indexerID int64, indexerName string) (dbgen.IndexerHealth, error)
+	RecordIndexerFailure(ctx context.Context, indexerID int64, indexerName string, lastError *string) (dbgen.IndexerHealth, error)
+	SetIndexerDisabledUntil(ctx context.Context, indexerID int64, until pgtype.Timestamptz) error
+	ResetIndexerHealth(ctx context.Context, indexerID int64) (dbgen.IndexerHealth, error)
+	CreateIndexerSearchEvent(ctx context.Context, params dbgen.CreateIndexerSearchEventParams) error
+	ListIndexerSearchEvents(ctx context.Context, indexerID int64, limit int32) ([]dbgen.IndexerSearchEvent, error)
+	ListIndexerSearchStats(ctx context.Context, since time.Time) ([]dbgen.ListIndexerSearchStatsRow, error)
+	DeleteIndexerSearchEventsBefore(ctx context.Context, before time.Time) error
+}
+
+func (r *Repository) ListIndexerHealth(ctx context.Context) ([]dbgen.IndexerHealth, error) {
+	return r.Q.ListIndexerHealth(ctx)
+}
+
+func (r *Repository) GetIndexerHealth(ctx context.Context, indexerID int64) (dbgen.IndexerHealth, error) {
+	return r.Q.GetIndexerHealth(ctx, indexerID)
+}
+
+func (r *Repository) ListDisabledIndexerIDs(ctx context.Context) ([]int64, error) {
+	return r.Q.ListDisabledIndexerIDs(ctx)
+}
+
+func (r *Repository) RecordIndexerSuccess(ctx context.Context, indexerID int64, indexerName string) (dbgen.IndexerHealth, error) {
+	return r.Q.RecordIndexerSuccess(ctx, dbgen.RecordIndexerSuccessParams{
+		IndexerID:   indexerID,
+		IndexerName: indexerName,
+	})
+}
+
+func (r *Repository) RecordIndexerFailure(ctx context.Context, indexerID int64, indexerName string, lastError *string) (dbgen.IndexerHealth, error) {
+	return r.Q.RecordIndexerFailure(ctx, dbgen.RecordIndexerFailureParams{
+		IndexerID:   indexerID,
+		IndexerName: indexerName,
+		LastError:   lastError,
+	})
+}
+
+func (r *Repository) SetIndexerDisabledUntil(ctx context.Context, indexerID int64, until pgtype.Timestamptz) error {
+	return r.Q.SetIndexerDisabledUntil(ctx, dbgen.SetIndexerDisabledUntilParams{
+		DisabledUntil: until,
+		IndexerID:     indexerID,
+	})
+}
+
+func (r *Repository) ResetIndexerHealth(ctx context.Context, indexerID int64) (dbgen.IndexerHealth, error) {
+	return r.Q.ResetIndexerHealth(ctx, indexerID)
+}
+
+func (r *Repository) CreateIndexerSearchEvent(ctx context.Context, params dbgen.CreateIndexerSearchEventParams) error {
+	return r.Q.CreateIndexerSearchEvent(ctx, params)
+}
+
+func (r *Repository) ListIndexerSearchEvents(ctx context.Context, indexerID int64, limit int32) ([]dbgen.IndexerSearchEvent, error) {
+	return r.Q.ListIndexerSearchEvents(ctx, dbgen.ListIndexerSearchEventsParams{
+		IndexerID: indexerID,
+		LimitVal:  limit,
+	})
+}
+
+func (r *Repository) ListIndexerSearchStats(ctx context.Context, since time.Time) ([]dbgen.ListIndexerSearchStatsRow, error) {
+	return r.Q.ListIndexerSearchStats(ctx, since)
+}
+
+func (r *Repository) DeleteIndexerSearchEventsBefore(ctx context.Context, before time.Time) error {
+	return r.Q.DeleteIndexerSearchEventsBefore(ctx, before)
+}
@@ -11,8 +11,8 @@ type LibraryRepo interface {
 	ListLibraries(ctx context.Context) ([]dbgen.Library, error)
 	GetLibrary(ctx context.Context, id pgtype.UUID) (dbgen.Library, error)
 	GetDefaultLibrary(ctx context.Context, typ string) (dbgen.Library, error)
-	CreateLibrary(ctx context.Context, name, typ, rootPath string, enabled bool, isDefault bool) (dbgen.Library, error)
-	UpdateLibrary(ctx context.Context, id pgtype.UUID, name, typ, rootPath string, enabled bool, isDefault bool) (dbgen.Library, error)
+	CreateLibrary(ctx context.Context, params dbgen.CreateLibraryParams) (dbgen.Library, error)
+	UpdateLibrary(ctx context.Context, params dbgen.UpdateLibraryParams) (dbgen.Library, error)
 	DeleteLibrary(ctx context.Context, id pgtype.UUID) error
 }
 
@@ -28,25 +28,12 @@ func (r *Repository) GetDefaultLibrary(ctx context.Context, typ string) (dbgen.L
 	return r.Q.GetDefaultLibrary(ctx, typ)
 }
 
-func (r *Repository) CreateLibrary(ctx context.Context, name, typ, rootPath string, enabled bool, isDefault bool) (dbgen.Library, error) {
-	return r.Q.CreateLibrary(ctx, dbgen.CreateLibraryParams{
-		Name:      name,
-		Type:      typ,
-		RootPath:  rootPath,
-		Enabled:   enabled,
-		IsDefault: isDefault,
-	})
+func (r *Repository) CreateLibrary(ctx context.Context, params dbgen.CreateLibraryParams) (dbgen.Library, error) {
+	return r.Q.CreateLibrary(ctx, params)
 }
 
-func (r *Repository) UpdateLibrary(ctx context.Context, id pgtype.UUID, name, typ, rootPath string, enabled bool, isDefault bool) (dbgen.Library, error) {
-	return r.Q.UpdateLibrary(ctx, dbgen.UpdateLibraryParams{
-		ID:        id,
-		Name:      name,
-		Type:      typ,
-		RootPath:  rootPath,
-		Enabled:   enabled,
-		IsDefault: isDefault,
-	})
+func (r *Repository) UpdateLibrary(ctx context.Context, params dbgen.UpdateLibraryParams) (dbgen.Library, error) {
+	return r.Q.UpdateLibrary(ctx, params)
 }
 
 func (r *Repository) DeleteLibrary(ctx context.Context, id pgtype.UUID) error {
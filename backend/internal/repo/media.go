@@ -36,6 +36,8 @@ type MediaRepo interface {
 	CreateMediaItem(ctx context.Context, typ, title string, year *int32, tmdbID *int64) (dbgen.MediaItem, error)
 	UpsertMediaItem(ctx context.Context, typ, title string, year *int32, tmdbID *int64) (dbgen.MediaItem, error)
 	UpdateMediaItem(ctx context.Context, id pgtype.UUID, title string, year *int32, tmdbID *int64) (dbgen.MediaItem, error)
+	UpdateMediaItemExternalIDs(ctx context.Context, id pgtype.UUID, imdbID *string, tvdbID *int64) (dbgen.MediaItem, error)
+	SetMediaItemQualityProfile(ctx context.Context, id pgtype.UUID, qualityProfileID pgtype.UUID) (dbgen.MediaItem, error)
 	DeleteMediaItem(ctx context.Context, id pgtype.UUID) error
 
 	// Seasons
@@ -49,11 +51,21 @@ type MediaRepo interface {
 	GetEpisode(ctx context.Context, id pgtype.UUID) (dbgen.MediaEpisode, error)
 	GetEpisodeByNumber(ctx context.Context, seasonID pgtype.UUID, episodeNumber int32) (dbgen.MediaEpisode, error)
 	UpsertEpisode(ctx context.Context, seasonID pgtype.UUID, episodeNumber int32, title *string, airDate pgtype.Date, tmdbID *int64, tvdbID *int64) (dbgen.MediaEpisode, error)
+	SetEpisodeAbsoluteNumber(ctx context.Context, seasonID pgtype.UUID, episodeNumber int32, absoluteNumber int32) (dbgen.MediaEpisode, error)
+	ListAbsoluteEpisodeNumbers(ctx context.Context, mediaItemID pgtype.UUID) ([]dbgen.ListAbsoluteEpisodeNumbersRow, error)
+
+	// Monitoring
+	SetMediaItemMonitoring(ctx context.Context, id pgtype.UUID, monitored, monitorNewSeasons bool, libraryID pgtype.UUID) (dbgen.MediaItem, error)
+	SetSeasonMonitored(ctx context.Context, id pgtype.UUID, monitored bool) (dbgen.MediaSeason, error)
+	SetSeasonEpisodesMonitored(ctx context.Context, seasonID pgtype.UUID, monitored bool) error
+	SetEpisodeMonitored(ctx context.Context, id pgtype.UUID, monitored bool) (dbgen.MediaEpisode, error)
+	ListMonitoringCounts(ctx context.Context, mediaItemIDs []pgtype.UUID) ([]dbgen.ListMonitoringCountsRow, error)
 
 	// Files (removed season_id and status)
 	GetMediaFile(ctx context.Context, id pgtype.UUID) (dbgen.MediaFile, error)
 	GetMediaFileByLibraryAndPath(ctx context.Context, libraryID pgtype.UUID, path string) (dbgen.MediaFile, error)
-	CreateMediaFile(ctx context.Context, libraryID, mediaItemID pgtype.UUID, episodeID *pgtype.UUID, path string) (dbgen.MediaFile, error)
+	CreateMediaFile(ctx context.Context, libraryID, mediaItemID pgtype.UUID, episodeID *pgtype.UUID, path string, quality *string) (dbgen.MediaFile, error)
+	ReplaceMediaFile(ctx context.Context, id pgtype.UUID, path string, quality *string) (dbgen.MediaFile, error)
 	ListMediaFilesForItem(ctx context.Context, mediaItemID pgtype.UUID) ([]dbgen.ListMediaFilesForItemRow, error)
 	ListEpisodeAvailabilityForSeries(ctx context.Context, mediaItemID pgtype.UUID) ([]dbgen.ListEpisodeAvailabilityForSeriesRow, error)
 	DeleteMediaFile(ctx context.Context, id pgtype.UUID) error
@@ -154,6 +166,21 @@ func (r *Repository) UpdateMediaItem(ctx context.Context, id pgtype.UUID, title
 	})
 }
 
+func (r *Repository) UpdateMediaItemExternalIDs(ctx context.Context, id pgtype.UUID, imdbID *string, tvdbID *int64) (dbgen.MediaItem, error) {
+	return r.Q.UpdateMediaItemExternalIDs(ctx, dbgen.UpdateMediaItemExternalIDsParams{
+		ID:     id,
+		ImdbID: imdbID,
+		TvdbID: tvdbID,
+	})
+}
+
+func (r *Repository) SetMediaItemQualityProfile(ctx context.Context, id pgtype.UUID, qualityProfileID pgtype.UUID) (dbgen.MediaItem, error) {
+	return r.Q.SetMediaItemQualityProfile(ctx, dbgen.SetMediaItemQualityProfileParams{
+		ID:               id,
+		QualityProfileID: qualityProfileID,
+	})
+}
+
 func (r *Repository) DeleteMediaItem(ctx context.Context, id pgtype.UUID) error {
 	return r.Q.DeleteMediaItem(ctx, id)
 }
@@ -207,6 +234,43 @@ func (r *Repository) UpsertEpisode(ctx context.Context, seasonID pgtype.UUID, ep
 	})
 }
 
+func (r *Repository) SetEpisodeAbsoluteNumber(ctx context.Context, seasonID pgtype.UUID, episodeNumber int32, absoluteNumber int32) (dbgen.MediaEpisode, error) {
+	return r.Q.SetEpisodeAbsoluteNumber(ctx, dbgen.SetEpisodeAbsoluteNumberParams{
+		SeasonID:       seasonID,
+		EpisodeNumber:  episodeNumber,
+		AbsoluteNumber: &absoluteNumber,
+	})
+}
+
+func (r *Repository) ListAbsoluteEpisodeNumbers(ctx context.Context, mediaItemID pgtype.UUID) ([]dbgen.ListAbsoluteEpisodeNumbersRow, error) {
+	return r.Q.ListAbsoluteEpisodeNumbers(ctx, mediaItemID)
+}
+
+func (r *Repository) SetMediaItemMonitoring(ctx context.Context, id pgtype.UUID, monitored, monitorNewSeasons bool, libraryID pgtype.UUID) (dbgen.MediaItem, error) {
+	return r.Q.SetMediaItemMonitoring(ctx, dbgen.SetMediaItemMonitoringParams{
+		ID:                id,
+		Monitored:         monitored,
+		MonitorNewSeasons: monitorNewSeasons,
+		LibraryID:         libraryID,
+	})
+}
+
+func (r *Repository) SetSeasonMonitored(ctx context.Context, id pgtype.UUID, monitored bool) (dbgen.MediaSeason, error) {
+	return r.Q.SetSeasonMonitored(ctx, dbgen.SetSeasonMonitoredParams{ID: id, Monitored: monitored})
+}
+
+func (r *Repository) SetSeasonEpisodesMonitored(ctx context.Context, seasonID pgtype.UUID, monitored bool) error {
+	return r.Q.SetSeasonEpisodesMonitored(ctx, dbgen.SetSeasonEpisodesMonitoredParams{SeasonID: seasonID, Monitored: monitored})
+}
+
+func (r *Repository) SetEpisodeMonitored(ctx context.Context, id pgtype.UUID, monitored bool) (dbgen.MediaEpisode, error) {
+	return r.Q.SetEpisodeMonitored(ctx, dbgen.SetEpisodeMonitoredParams{ID: id, Monitored: monitored})
+}
+
+func (r *Repository) ListMonitoringCounts(ctx context.Context, mediaItemIDs []pgtype.UUID) ([]dbgen.ListMonitoringCountsRow, error) {
+	return r.Q.ListMonitoringCounts(ctx, mediaItemIDs)
+}
+
 func (r *Repository) GetMediaFile(ctx context.Context, id pgtype.UUID) (dbgen.MediaFile, error) {
 	return r.Q.GetMediaFile(ctx, id)
 }
@@ -218,7 +282,7 @@ func (r *Repository) GetMediaFileByLibraryAndPath(ctx context.Context, libraryID
 	})
 }
 
-func (r *Repository) CreateMediaFile(ctx context.Context, libraryID, mediaItemID pgtype.UUID, episodeID *pgtype.UUID, path string) (dbgen.MediaFile, error) {
+func (r *Repository) CreateMediaFile(ctx context.Context, libraryID, mediaItemID pgtype.UUID, episodeID *pgtype.UUID, path string, quality *string) (dbgen.MediaFile, error) {
 	var episode pgtype.UUID
 	if episodeID != nil {
 		episode = *episodeID
@@ -228,6 +292,15 @@ func (r *Repository) CreateMediaFile(ctx context.Context, libraryID, mediaItemID
 		MediaItemID: mediaItemID,
 		EpisodeID:   episode,
 		Path:        path,
+		Quality:     quality,
+	})
+}
+
+func (r *Repository) ReplaceMediaFile(ctx context.Context, id pgtype.UUID, path string, quality *string) (dbgen.MediaFile, error) {
+	return r.Q.ReplaceMediaFile(ctx, dbgen.ReplaceMediaFileParams{
+		ID:      id,
+		Path:    path,
+		Quality: quality,
 	})
 }
 
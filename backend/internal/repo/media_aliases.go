@@ -0,0 +1,33 @@
+package repo
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	dbgen "github.com/kyleaupton/arrflix/internal/db/sqlc"
+)
+
+type MediaAliasRepo interface {
+	ListMediaItemAliases(ctx context.Context, mediaItemID pgtype.UUID) ([]dbgen.MediaItemAlias, error)
+	UpsertMediaItemAlias(ctx context.Context, mediaItemID pgtype.UUID, title string, excluded bool) (dbgen.MediaItemAlias, error)
+	DeleteMediaItemAlias(ctx context.Context, id, mediaItemID pgtype.UUID) error
+}
+
+func (r *Repository) ListMediaItemAliases(ctx context.Context, mediaItemID pgtype.UUID) ([]dbgen.MediaItemAlias, error) {
+	return r.Q.ListMediaItemAliases(ctx, mediaItemID)
+}
+
+func (r *Repository) UpsertMediaItemAlias(ctx context.Context, mediaItemID pgtype.UUID, title string, excluded bool) (dbgen.MediaItemAlias, error) {
+	return r.Q.UpsertMediaItemAlias(ctx, dbgen.UpsertMediaItemAliasParams{
+		MediaItemID: mediaItemID,
+		Title:       title,
+		Excluded:    excluded,
+	})
+}
+
+func (r *Repository) DeleteMediaItemAlias(ctx context.Context, id, mediaItemID pgtype.UUID) error {
+	return r.Q.DeleteMediaItemAlias(ctx, dbgen.DeleteMediaItemAliasParams{
+		ID:          id,
+		MediaItemID: mediaItemID,
+	})
+}
@@ -0,0 +1,26 @@
+package repo
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	dbgen "github.com/kyleaupton/arrflix/internal/db/sqlc"
+)
+
+type MediaFileExtraRepo interface {
+	ListMediaFileExtras(ctx context.Context, mediaFileID pgtype.UUID) ([]dbgen.MediaFileExtra, error)
+	CreateMediaFileExtra(ctx context.Context, params dbgen.CreateMediaFileExtraParams) (dbgen.MediaFileExtra, error)
+	DeleteMediaFileExtra(ctx context.Context, id pgtype.UUID) error
+}
+
+func (r *Repository) ListMediaFileExtras(ctx context.Context, mediaFileID pgtype.UUID) ([]dbgen.MediaFileExtra, error) {
+	return r.Q.ListMediaFileExtras(ctx, mediaFileID)
+}
+
+func (r *Repository) CreateMediaFileExtra(ctx context.Context, params dbgen.CreateMediaFileExtraParams) (dbgen.MediaFileExtra, error) {
+	return r.Q.CreateMediaFileExtra(ctx, params)
+}
+
+func (r *Repository) DeleteMediaFileExtra(ctx context.Context, id pgtype.UUID) error {
+	return r.Q.DeleteMediaFileExtra(ctx, id)
+}
@@ -0,0 +1,77 @@
+package repo
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	dbgen "github.com/kyleaupton/arrflix/internal/db/sqlc"
+)
+
+type MediaRequestsRepo interface {
+	CreateMediaRequest(ctx context.Context, params dbgen.CreateMediaRequestParams) (dbgen.MediaRequest, error)
+	GetMediaRequest(ctx context.Context, id pgtype.UUID) (dbgen.GetMediaRequestRow, error)
+	ListMediaRequests(ctx context.Context, params dbgen.ListMediaRequestsParams) ([]dbgen.ListMediaRequestsRow, error)
+	CountMediaRequests(ctx context.Context, params dbgen.CountMediaRequestsParams) (int64, error)
+	CountOpenMediaRequestsForUser(ctx context.Context, userID pgtype.UUID) (int64, error)
+	ListOpenMediaRequestsForTitle(ctx context.Context, userID pgtype.UUID, mediaType string, tmdbID int64) ([]dbgen.ListOpenMediaRequestsForTitleRow, error)
+	ListUserMediaRequestStatuses(ctx context.Context, userID pgtype.UUID) ([]dbgen.ListUserMediaRequestStatusesRow, error)
+	DecideMediaRequest(ctx context.Context, params dbgen.DecideMediaRequestParams) (dbgen.MediaRequest, error)
+	SetMediaRequestStatus(ctx context.Context, id pgtype.UUID, status string) error
+	GetTitleAvailability(ctx context.Context, mediaType string, tmdbID int64, seasons []int32) (dbgen.GetTitleAvailabilityRow, error)
+	ListActiveMediaRequests(ctx context.Context) ([]dbgen.ListActiveMediaRequestsRow, error)
+}
+
+func (r *Repository) CreateMediaRequest(ctx context.Context, params dbgen.CreateMediaRequestParams) (dbgen.MediaRequest, error) {
+	return r.Q.CreateMediaRequest(ctx, params)
+}
+
+func (r *Repository) GetMediaRequest(ctx context.Context, id pgtype.UUID) (dbgen.GetMediaRequestRow, error) {
+	return r.Q.GetMediaRequest(ctx, id)
+}
+
+func (r *Repository) ListMediaRequests(ctx context.Context, params dbgen.ListMediaRequestsParams) ([]dbgen.ListMediaRequestsRow, error) {
+	return r.Q.ListMediaRequests(ctx, params)
+}
+
+func (r *Repository) CountMediaRequests(ctx context.Context, params dbgen.CountMediaRequestsParams) (int64, error) {
+	return r.Q.CountMediaRequests(ctx, params)
+}
+
+func (r *Repository) CountOpenMediaRequestsForUser(ctx context.Context, userID pgtype.UUID) (int64, error) {
+	return r.Q.CountOpenMediaRequestsForUser(ctx, userID)
+}
+
+func (r *Repository) ListOpenMediaRequestsForTitle(ctx context.Context, userID pgtype.UUID, mediaType string, tmdbID int64) ([]dbgen.ListOpenMediaRequestsForTitleRow, error) {
+	return r.Q.ListOpenMediaRequestsForTitle(ctx, dbgen.ListOpenMediaRequestsForTitleParams{
+		UserID:    userID,
+		MediaType: mediaType,
+		TmdbID:    tmdbID,
+	})
+}
+
+func (r *Repository) ListUserMediaRequestStatuses(ctx context.Context, userID pgtype.UUID) ([]dbgen.ListUserMediaRequestStatusesRow, error) {
+	return r.Q.ListUserMediaRequestStatuses(ctx, userID)
+}
+
+func (r *Repository) DecideMediaRequest(ctx context.Context, params dbgen.DecideMediaRequestParams) (dbgen.MediaRequest, error) {
+	return r.Q.DecideMediaRequest(ctx, params)
+}
+
+func (r *Repository) SetMediaRequestStatus(ctx context.Context, id pgtype.UUID, status string) error {
+	return r.Q.SetMediaRequestStatus(ctx, dbgen.SetMediaRequestStatusParams{
+		Status: status,
+		ID:     id,
+	})
+}
+
+func (r *Repository) GetTitleAvailability(ctx context.Context, mediaType string, tmdbID int64, seasons []int32) (dbgen.GetTitleAvailabilityRow, error) {
+	return r.Q.GetTitleAvailability(ctx, dbgen.GetTitleAvailabilityParams{
+		Seasons:   seasons,
+		MediaType: mediaType,
+		TmdbID:    tmdbID,
+	})
+}
+
+func (r *Repository) ListActiveMediaRequests(ctx context.Context) ([]dbgen.ListActiveMediaRequestsRow, error) {
+	return r.Q.ListActiveMediaRequests(ctx)
+}
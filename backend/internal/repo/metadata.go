@@ -0,0 +1,32 @@
+package repo
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	dbgen "github.com/kyleaupton/arrflix/internal/db/sqlc"
+)
+
+// MetadataRepo covers the refresh schedule. Episode reconciliation runs in a
+// transaction, through the queries directly.
+type MetadataRepo interface {
+	ListMetadataRefreshItems(ctx context.Context, refreshedBefore time.Time, limit int32) ([]dbgen.MediaItem, error)
+	SetMediaItemMetadataRefreshed(ctx context.Context, id pgtype.UUID) error
+	ListEpisodesForMedia(ctx context.Context, mediaItemID pgtype.UUID) ([]dbgen.ListEpisodesForMediaRow, error)
+}
+
+func (r *Repository) ListMetadataRefreshItems(ctx context.Context, refreshedBefore time.Time, limit int32) ([]dbgen.MediaItem, error) {
+	return r.Q.ListMetadataRefreshItems(ctx, dbgen.ListMetadataRefreshItemsParams{
+		RefreshedBefore: pgtype.Timestamptz{Time: refreshedBefore, Valid: true},
+		RowLimit:        limit,
+	})
+}
+
+func (r *Repository) SetMediaItemMetadataRefreshed(ctx context.Context, id pgtype.UUID) error {
+	return r.Q.SetMediaItemMetadataRefreshed(ctx, id)
+}
+
+func (r *Repository) ListEpisodesForMedia(ctx context.Context, mediaItemID pgtype.UUID) ([]dbgen.ListEpisodesForMediaRow, error) {
+	return r.Q.ListEpisodesForMedia(ctx, mediaItemID)
+}
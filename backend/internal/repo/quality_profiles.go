@@ -0,0 +1,59 @@
+package repo
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	dbgen "github.com/kyleaupton/arrflix/internal/db/sqlc"
+)
+
+type QualityProfileRepo interface {
+	ListQualityProfiles(ctx context.Context) ([]dbgen.QualityProfile, error)
+	GetQualityProfile(ctx context.Context, id pgtype.UUID) (dbgen.QualityProfile, error)
+	GetDefaultQualityProfile(ctx context.Context) (dbgen.QualityProfile, error)
+	CreateQualityProfile(ctx context.Context, name string, items []byte, cutoff string, upgradesAllowed, isDefault bool) (dbgen.QualityProfile, error)
+	UpdateQualityProfile(ctx context.Context, id pgtype.UUID, name string, items []byte, cutoff string, upgradesAllowed, isDefault bool) (dbgen.QualityProfile, error)
+	UnsetDefaultQualityProfiles(ctx context.Context, exceptID pgtype.UUID) error
+	DeleteQualityProfile(ctx context.Context, id pgtype.UUID) error
+}
+
+func (r *Repository) ListQualityProfiles(ctx context.Context) ([]dbgen.QualityProfile, error) {
+	return r.Q.ListQualityProfiles(ctx)
+}
+
+func (r *Repository) GetQualityProfile(ctx context.Context, id pgtype.UUID) (dbgen.QualityProfile, error) {
+	return r.Q.GetQualityProfile(ctx, id)
+}
+
+func (r *Repository) GetDefaultQualityProfile(ctx context.Context) (dbgen.QualityProfile, error) {
+	return r.Q.GetDefaultQualityProfile(ctx)
+}
+
+func (r *Repository) CreateQualityProfile(ctx context.Context, name string, items []byte, cutoff string, upgradesAllowed, isDefault bool) (dbgen.QualityProfile, error) {
+	return r.Q.CreateQualityProfile(ctx, dbgen.CreateQualityProfileParams{
+		Name:            name,
+		Items:           items,
+		Cutoff:          cutoff,
+		UpgradesAllowed: upgradesAllowed,
+		IsDefault:       isDefault,
+	})
+}
+
+func (r *Repository) UpdateQualityProfile(ctx context.Context, id pgtype.UUID, name string, items []byte, cutoff string, upgradesAllowed, isDefault bool) (dbgen.QualityProfile, error) {
+	return r.Q.UpdateQualityProfile(ctx, dbgen.UpdateQualityProfileParams{
+		ID:              id,
+		Name:            name,
+		Items:           items,
+		Cutoff:          cutoff,
+		UpgradesAllowed: upgradesAllowed,
+		IsDefault:       isDefault,
+	})
+}
+
+func (r *Repository) UnsetDefaultQualityProfiles(ctx context.Context, exceptID pgtype.UUID) error {
+	return r.Q.UnsetDefaultQualityProfiles(ctx, exceptID)
+}
+
+func (r *Repository) DeleteQualityProfile(ctx context.Context, id pgtype.UUID) error {
+	return r.Q.DeleteQualityProfile(ctx, id)
+}
@@ -0,0 +1,50 @@
+package repo
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	dbgen "github.com/kyleaupton/arrflix/internal/db/sqlc"
+)
+
+type RemotePathMappingRepo interface {
+	ListRemotePathMappings(ctx context.Context) ([]dbgen.ListRemotePathMappingsRow, error)
+	ListRemotePathMappingsForDownloader(ctx context.Context, downloaderID pgtype.UUID) ([]dbgen.RemotePathMapping, error)
+	GetRemotePathMapping(ctx context.Context, id pgtype.UUID) (dbgen.RemotePathMapping, error)
+	CreateRemotePathMapping(ctx context.Context, downloaderID pgtype.UUID, remotePath, localPath string) (dbgen.RemotePathMapping, error)
+	UpdateRemotePathMapping(ctx context.Context, id, downloaderID pgtype.UUID, remotePath, localPath string) (dbgen.RemotePathMapping, error)
+	DeleteRemotePathMapping(ctx context.Context, id pgtype.UUID) error
+}
+
+func (r *Repository) ListRemotePathMappings(ctx context.Context) ([]dbgen.ListRemotePathMappingsRow, error) {
+	return r.Q.ListRemotePathMappings(ctx)
+}
+
+func (r *Repository) ListRemotePathMappingsForDownloader(ctx context.Context, downloaderID pgtype.UUID) ([]dbgen.RemotePathMapping, error) {
+	return r.Q.ListRemotePathMappingsForDownloader(ctx, downloaderID)
+}
+
+func (r *Repository) GetRemotePathMapping(ctx context.Context, id pgtype.UUID) (dbgen.RemotePathMapping, error) {
+	return r.Q.GetRemotePathMapping(ctx, id)
+}
+
+func (r *Repository) CreateRemotePathMapping(ctx context.Context, downloaderID pgtype.UUID, remotePath, localPath string) (dbgen.RemotePathMapping, error) {
+	return r.Q.CreateRemotePathMapping(ctx, dbgen.CreateRemotePathMappingParams{
+		DownloaderID: downloaderID,
+		RemotePath:   remotePath,
+		LocalPath:    localPath,
+	})
+}
+
+func (r *Repository) UpdateRemotePathMapping(ctx context.Context, id, downloaderID pgtype.UUID, remotePath, localPath string) (dbgen.RemotePathMapping, error) {
+	return r.Q.UpdateRemotePathMapping(ctx, dbgen.UpdateRemotePathMappingParams{
+		DownloaderID: downloaderID,
+		RemotePath:   remotePath,
+		LocalPath:    localPath,
+		ID:           id,
+	})
+}
+
+func (r *Repository) DeleteRemotePathMapping(ctx context.Context, id pgtype.UUID) error {
+	return r.Q.DeleteRemotePathMapping(ctx, id)
+}
@@ -0,0 +1,75 @@
+package repo
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	dbgen "github.com/kyleaupton/arrflix/internal/db/sqlc"
+)
+
+// RequestRulesRepo covers request quotas and auto-approval rules.
+type RequestRulesRepo interface {
+	ListRequestQuotas(ctx context.Context) ([]dbgen.RequestQuota, error)
+	ListRequestQuotasForUser(ctx context.Context, userID pgtype.UUID) ([]dbgen.RequestQuota, error)
+	UpsertRequestQuota(ctx context.Context, params dbgen.UpsertRequestQuotaParams) (dbgen.RequestQuota, error)
+	DeleteRequestQuota(ctx context.Context, subjectType dbgen.GrantSubject, subjectID pgtype.UUID) error
+	GetUserRequestUsage(ctx context.Context, userID pgtype.UUID, moviesSince, seasonsSince time.Time) (dbgen.GetUserRequestUsageRow, error)
+	ListRequestRules(ctx context.Context) ([]dbgen.ListRequestRulesRow, error)
+	ListRequestRulesForUser(ctx context.Context, userID pgtype.UUID) ([]dbgen.ListRequestRulesForUserRow, error)
+	GetRequestRule(ctx context.Context, id pgtype.UUID) (dbgen.RequestRule, error)
+	CreateRequestRule(ctx context.Context, params dbgen.CreateRequestRuleParams) (dbgen.RequestRule, error)
+	UpdateRequestRule(ctx context.Context, params dbgen.UpdateRequestRuleParams) (dbgen.RequestRule, error)
+	DeleteRequestRule(ctx context.Context, id pgtype.UUID) error
+}
+
+func (r *Repository) ListRequestQuotas(ctx context.Context) ([]dbgen.RequestQuota, error) {
+	return r.Q.ListRequestQuotas(ctx)
+}
+
+func (r *Repository) ListRequestQuotasForUser(ctx context.Context, userID pgtype.UUID) ([]dbgen.RequestQuota, error) {
+	return r.Q.ListRequestQuotasForUser(ctx, userID)
+}
+
+func (r *Repository) UpsertRequestQuota(ctx context.Context, params dbgen.UpsertRequestQuotaParams) (dbgen.RequestQuota, error) {
+	return r.Q.UpsertRequestQuota(ctx, params)
+}
+
+func (r *Repository) DeleteRequestQuota(ctx context.Context, subjectType dbgen.GrantSubject, subjectID pgtype.UUID) error {
+	return r.Q.DeleteRequestQuota(ctx, dbgen.DeleteRequestQuotaParams{
+		SubjectType: subjectType,
+		SubjectID:   subjectID,
+	})
+}
+
+func (r *Repository) GetUserRequestUsage(ctx context.Context, userID pgtype.UUID, moviesSince, seasonsSince time.Time) (dbgen.GetUserRequestUsageRow, error) {
+	return r.Q.GetUserRequestUsage(ctx, dbgen.GetUserRequestUsageParams{
+		MoviesSince:  moviesSince,
+		SeasonsSince: seasonsSince,
+		UserID:       userID,
+	})
+}
+
+func (r *Repository) ListRequestRules(ctx context.Context) ([]dbgen.ListRequestRulesRow, error) {
+	return r.Q.ListRequestRules(ctx)
+}
+
+func (r *Repository) ListRequestRulesForUser(ctx context.Context, userID pgtype.UUID) ([]dbgen.ListRequestRulesForUserRow, error) {
+	return r.Q.ListRequestRulesForUser(ctx, userID)
+}
+
+func (r *Repository) GetRequestRule(ctx context.Context, id pgtype.UUID) (dbgen.RequestRule, error) {
+	return r.Q.GetRequestRule(ctx, id)
+}
+
+func (r *Repository) CreateRequestRule(ctx context.Context, params dbgen.CreateRequestRuleParams) (dbgen.RequestRule, error) {
+	return r.Q.CreateRequestRule(ctx, params)
+}
+
+func (r *Repository) UpdateRequestRule(ctx context.Context, params dbgen.UpdateRequestRuleParams) (dbgen.RequestRule, error) {
+	return r.Q.UpdateRequestRule(ctx, params)
+}
+
+func (r *Repository) DeleteRequestRule(ctx context.Context, id pgtype.UUID) error {
+	return r.Q.DeleteRequestRule(ctx, id)
+}
@@ -0,0 +1,62 @@
+package repo
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	dbgen "github.com/kyleaupton/arrflix/internal/db/sqlc"
+)
+
+type SearchResultRepo interface {
+	CreateSearchSession(ctx context.Context, params dbgen.CreateSearchSessionParams) (dbgen.SearchSession, error)
+	GetSearchSession(ctx context.Context, id pgtype.UUID) (dbgen.SearchSession, error)
+	ListSearchSessions(ctx context.Context, mediaType string, tmdbID int64, limit int32) ([]dbgen.ListSearchSessionsRow, error)
+	DeleteExpiredSearchSessions(ctx context.Context) error
+	InsertSearchResults(ctx context.Context, params dbgen.InsertSearchResultsParams) error
+	ListSearchResults(ctx context.Context, sessionID pgtype.UUID) ([]dbgen.SearchResult, error)
+	GetSearchResultInSession(ctx context.Context, sessionID pgtype.UUID, indexerID int64, guid string) (dbgen.GetSearchResultInSessionRow, error)
+	GetLatestSearchResult(ctx context.Context, indexerID int64, guid string) (dbgen.GetLatestSearchResultRow, error)
+}
+
+func (r *Repository) CreateSearchSession(ctx context.Context, params dbgen.CreateSearchSessionParams) (dbgen.SearchSession, error) {
+	return r.Q.CreateSearchSession(ctx, params)
+}
+
+func (r *Repository) GetSearchSession(ctx context.Context, id pgtype.UUID) (dbgen.SearchSession, error) {
+	return r.Q.GetSearchSession(ctx, id)
+}
+
+func (r *Repository) ListSearchSessions(ctx context.Context, mediaType string, tmdbID int64, limit int32) ([]dbgen.ListSearchSessionsRow, error) {
+	return r.Q.ListSearchSessions(ctx, dbgen.ListSearchSessionsParams{
+		MediaType: mediaType,
+		TmdbID:    tmdbID,
+		LimitVal:  limit,
+	})
+}
+
+func (r *Repository) DeleteExpiredSearchSessions(ctx context.Context) error {
+	return r.Q.DeleteExpiredSearchSessions(ctx)
+}
+
+func (r *Repository) InsertSearchResults(ctx context.Context, params dbgen.InsertSearchResultsParams) error {
+	return r.Q.InsertSearchResults(ctx, params)
+}
+
+func (r *Repository) ListSearchResults(ctx context.Context, sessionID pgtype.UUID) ([]dbgen.SearchResult, error) {
+	return r.Q.ListSearchResults(ctx, sessionID)
+}
+
+func (r *Repository) GetSearchResultInSession(ctx context.Context, sessionID pgtype.UUID, indexerID int64, guid string) (dbgen.GetSearchResultInSessionRow, error) {
+	return r.Q.GetSearchResultInSession(ctx, dbgen.GetSearchResultInSessionParams{
+		SessionID: sessionID,
+		IndexerID: indexerID,
+		Guid:      guid,
+	})
+}
+
+func (r *Repository) GetLatestSearchResult(ctx context.Context, indexerID int64, guid string) (dbgen.GetLatestSearchResultRow, error) {
+	return r.Q.GetLatestSearchResult(ctx, dbgen.GetLatestSearchResultParams{
+		IndexerID: indexerID,
+		Guid:      guid,
+	})
+}
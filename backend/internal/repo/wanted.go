@@ -0,0 +1,25 @@
+package repo
+
+import (
+	"context"
+
+	dbgen "github.com/kyleaupton/arrflix/internal/db/sqlc"
+)
+
+type WantedRepo interface {
+	ListWantedMissing(ctx context.Context, params dbgen.ListWantedMissingParams) ([]dbgen.ListWantedMissingRow, error)
+	CountWantedMissing(ctx context.Context, params dbgen.CountWantedMissingParams) (int64, error)
+	ListWantedCutoffFiles(ctx context.Context, params dbgen.ListWantedCutoffFilesParams) ([]dbgen.ListWantedCutoffFilesRow, error)
+}
+
+func (r *Repository) ListWantedMissing(ctx context.Context, params dbgen.ListWantedMissingParams) ([]dbgen.ListWantedMissingRow, error) {
+	return r.Q.ListWantedMissing(ctx, params)
+}
+
+func (r *Repository) CountWantedMissing(ctx context.Context, params dbgen.CountWantedMissingParams) (int64, error) {
+	return r.Q.CountWantedMissing(ctx, params)
+}
+
+func (r *Repository) ListWantedCutoffFiles(ctx context.Context, params dbgen.ListWantedCutoffFilesParams) ([]dbgen.ListWantedCutoffFilesRow, error) {
+	return r.Q.ListWantedCutoffFiles(ctx, params)
+}
@@ -0,0 +1,125 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/kyleaupton/arrflix/internal/importer"
+)
+
+const (
+	// TMDB episode group type for absolute (anime) ordering
+	tmdbEpisodeGroupAbsolute = 2
+	tmdbGenreAnimation       = 16
+)
+
+// IsAnime reports whether a series is Japanese animation, whose releases are
+// usually numbered absolutely by fansub groups.
+func (s *MediaService) IsAnime(ctx context.Context, tmdbID int64) bool {
+	details, err := s.tmdb.GetSeriesDetails(ctx, tmdbID)
+	if err != nil || details.OriginalLanguage != "ja" {
+		return false
+	}
+	for _, g := range details.Genres {
+		if g.ID == tmdbGenreAnimation {
+			return true
+		}
+	}
+	return false
+}
+
+// AbsoluteEpisodeMap returns the absolute episode numbering of a series. A
+// TMDB "Absolute" episode group wins when one exists; otherwise the regular
+// seasons are numbered in order with specials left out.
+func (s *MediaService) AbsoluteEpisodeMap(ctx context.Context, tmdbID int64) (importer.AbsoluteEpisodeMap, error) {
+	if order, ok := s.absoluteEpisodeGroupOrder(ctx, tmdbID); ok {
+		return importer.NewAbsoluteEpisodeMap(order), nil
+	}
+
+	details, err := s.tmdb.GetSeriesDetails(ctx, tmdbID)
+	if err != nil {
+		return nil, fmt.Errorf("get series details: %w", err)
+	}
+
+	seasons := make([]int, 0, len(details.Seasons))
+	for _, season := range details.Seasons {
+		if season.SeasonNumber > 0 {
+			seasons = append(seasons, season.SeasonNumber)
+		}
+	}
+	sort.Ints(seasons)
+
+	var order []importer.EpisodeNumber
+	for _, number := range seasons {
+		season, err := s.tmdb.GetTVSeasonDetails(ctx, tmdbID, number)
+		if err != nil {
+			return nil, fmt.Errorf("get season %d: %w", number, err)
+		}
+		for _, ep := range season.Episodes {
+			order = append(order, importer.EpisodeNumber{Season: ep.SeasonNumber, Episode: ep.EpisodeNumber})
+		}
+	}
+	return importer.NewAbsoluteEpisodeMap(order), nil
+}
+
+// absoluteEpisodeGroupOrder returns the episodes of the series' first
+// "Absolute" episode group, in order. Lookup failures fall back to seasons.
+func (s *MediaService) absoluteEpisodeGroupOrder(ctx context.Context, tmdbID int64) ([]importer.EpisodeNumber, bool) {
+	groups, err := s.tmdb.GetSeriesEpisodeGroups(ctx, tmdbID)
+	if err != nil || groups.TVEpisodeGroupsResults == nil {
+		return nil, false
+	}
+
+	for _, g := range groups.Results {
+		if g.Type != tmdbEpisodeGroupAbsolute {
+			continue
+		}
+		details, err := s.tmdb.GetEpisodeGroupDetails(ctx, g.ID)
+		if err != nil {
+			s.logger.Debug().Err(err).Str("group_id", g.ID).Msg("Failed to fetch absolute episode group")
+			return nil, false
+		}
+
+		parts := details.Groups
+		sort.SliceStable(parts, func(i, j int) bool { return parts[i].Order < parts[j].Order })
+		var order []importer.EpisodeNumber
+		for _, part := range parts {
+			eps := part.Episodes
+			sort.SliceStable(eps, func(i, j int) bool { return eps[i].Order < eps[j].Order })
+			for _, ep := range eps {
+				order = append(order, importer.EpisodeNumber{Season: ep.SeasonNumber, Episode: ep.EpisodeNumber})
+			}
+		}
+		return order, len(order) > 0
+	}
+	return nil, false
+}
+
+// SyncAbsoluteEpisodeNumbers stores a series' absolute numbering on its
+// episodes, creating missing episode rows, so the download and import workers
+// can map fansub releases without TMDB access.
+func (s *MediaService) SyncAbsoluteEpisodeNumbers(ctx context.Context, mediaItemID pgtype.UUID, tmdbID int64) (importer.AbsoluteEpisodeMap, error) {
+	abs, err := s.AbsoluteEpisodeMap(ctx, tmdbID)
+	if err != nil {
+		return nil, err
+	}
+
+	seasonIDs := make(map[int]pgtype.UUID)
+	for number, ep := range abs {
+		seasonID, ok := seasonIDs[ep.Season]
+		if !ok {
+			season, err := s.repo.UpsertSeason(ctx, mediaItemID, int32(ep.Season), pgtype.Date{Valid: false})
+			if err != nil {
+				return nil, fmt.Errorf("upsert season %d: %w", ep.Season, err)
+			}
+			seasonID = season.ID
+			seasonIDs[ep.Season] = seasonID
+		}
+		if _, err := s.repo.SetEpisodeAbsoluteNumber(ctx, seasonID, int32(ep.Episode), int32(number)); err != nil {
+			return nil, fmt.Errorf("set absolute number %d: %w", number, err)
+		}
+	}
+	return abs, nil
+}
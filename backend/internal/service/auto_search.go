@@ -0,0 +1,714 @@
+package service
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	dbgen "github.com/kyleaupton/arrflix/internal/db/sqlc"
+	"github.com/kyleaupton/arrflix/internal/logger"
+	"github.com/kyleaupton/arrflix/internal/model"
+	"github.com/kyleaupton/arrflix/internal/qualityprofile"
+	"github.com/kyleaupton/arrflix/internal/repo"
+)
+
+var (
+	ErrAutoSearchRunning          = errors.New("an automatic search is already running")
+	ErrAutoSearchInvalid          = errors.New("a season is required to search a series")
+	ErrAutoSearchDecisionNotFound = errors.New("automatic search decision not found")
+)
+
+// maxDecisionCandidates caps the per-candidate verdicts stored with a decision.
+const maxDecisionCandidates = 50
+
+// AutoSearchService searches for monitored movies and aired episodes that
+// have no file, or a file below their quality profile's cutoff, and grabs the
+// best acceptable release.
+type AutoSearchService struct {
+	repo       *repo.Repository
+	logger     *logger.Logger
+	settings   *SettingsService
+	candidates *DownloadCandidatesService
+
+	running  sync.Mutex // one pass, or one queued search, at a time
+	mu       sync.Mutex
+	lastRun  time.Time
+	queue    []model.WantedSearchItem
+	draining bool
+}
+
+// NewAutoSearchService creates a new automatic search service
+func NewAutoSearchService(r *repo.Repository, l *logger.Logger, settings *SettingsService, candidates *DownloadCandidatesService) *AutoSearchService {
+	return &AutoSearchService{repo: r, logger: l, settings: settings, candidates: candidates}
+}
+
+// searchTarget is one search: a movie, a season pack or an episode.
+// episodeIDs are the wanted episodes the search covers. upgrade is set when
+// the target has a file and the search is for a better one.
+type searchTarget struct {
+	mediaItemID pgtype.UUID
+	mediaType   model.MediaType
+	tmdbID      int64
+	title       string
+	season      *int
+	episode     *int
+	episodeIDs  []pgtype.UUID
+	upgrade     bool
+}
+
+// RunIfDue runs a scheduled pass when automatic search is enabled and
+// autosearch.interval_minutes have passed since the last one.
+func (s *AutoSearchService) RunIfDue(ctx context.Context) {
+	if !s.settings.GetBool(ctx, "autosearch.enabled") {
+		return
+	}
+	interval := time.Duration(s.settings.GetInt(ctx, "autosearch.interval_minutes")) * time.Minute
+	s.mu.Lock()
+	due := time.Since(s.lastRun) >= interval
+	s.mu.Unlock()
+	if !due {
+		return
+	}
+
+	if _, err := s.Run(ctx, model.AutoSearchTriggerScheduled); err != nil && !errors.Is(err, ErrAutoSearchRunning) {
+		s.logger.Error().Err(err).Msg("Automatic search failed")
+	}
+}
+
+// Run searches for up to autosearch.max_searches_per_run wanted movies and
+// episodes, least recently searched first, waiting
+// autosearch.search_delay_seconds between searches. Searches left over go to
+// upgrades when autosearch.upgrades is on.
+func (s *AutoSearchService) Run(ctx context.Context, trigger string) (model.AutoSearchRun, error) {
+	if !s.running.TryLock() {
+		return model.AutoSearchRun{}, ErrAutoSearchRunning
+	}
+	defer s.running.Unlock()
+	return s.run(ctx, trigger)
+}
+
+// Start runs a pass in the background, returning ErrAutoSearchRunning when
+// one is already in progress.
+func (s *AutoSearchService) Start(trigger string) error {
+	if !s.running.TryLock() {
+		return ErrAutoSearchRunning
+	}
+	go func() {
+		defer s.running.Unlock()
+		if _, err := s.run(context.Background(), trigger); err != nil {
+			s.logger.Error().Err(err).Msg("Automatic search failed")
+		}
+	}()
+	return nil
+}
+
+func (s *AutoSearchService) run(ctx context.Context, trigger string) (model.AutoSearchRun, error) {
+	run := model.AutoSearchRun{Trigger: trigger, StartedAt: time.Now(), Decisions: []model.AutoSearchDecision{}}
+	s.mu.Lock()
+	s.lastRun = run.StartedAt
+	s.mu.Unlock()
+
+	targets, err := s.wanted(ctx)
+	if err != nil {
+		return run, err
+	}
+	delay := time.Duration(s.settings.GetInt(ctx, "autosearch.search_delay_seconds")) * time.Second
+
+	for i, target := range targets {
+		if i > 0 && delay > 0 {
+			select {
+			case <-ctx.Done():
+				return run, ctx.Err()
+			case <-time.After(delay):
+			}
+		}
+
+		decision, err := s.search(ctx, target, trigger)
+		if err != nil {
+			s.logger.Error().Err(err).Int64("tmdb_id", target.tmdbID).Msg("Failed to record automatic search decision")
+		}
+		s.markSearched(ctx, target)
+
+		run.Searched++
+		if decision.Outcome == model.AutoSearchOutcomeGrabbed {
+			run.Grabbed++
+		}
+		run.Decisions = append(run.Decisions, decision)
+	}
+
+	run.FinishedAt = time.Now()
+	if run.Searched > 0 {
+		s.logger.Info().Int("searched", run.Searched).Int("grabbed", run.Grabbed).Str("trigger", trigger).Msg("Automatic search finished")
+	}
+	return run, nil
+}
+
+// wanted returns the searches for the next pass. Movies and episodes are
+// interleaved so neither starves the other, and wanted episodes that make up
+// a whole aired season are searched as one season pack.
+func (s *AutoSearchService) wanted(ctx context.Context) ([]searchTarget, error) {
+	limit := int(s.settings.GetInt(ctx, "autosearch.max_searches_per_run"))
+	if limit <= 0 {
+		return nil, nil
+	}
+	before := time.Now().Add(-time.Duration(s.settings.GetInt(ctx, "autosearch.retry_hours")) * time.Hour)
+
+	movies, err := s.repo.ListWantedMovies(ctx, before, int32(limit))
+	if err != nil {
+		return nil, fmt.Errorf("list wanted movies: %w", err)
+	}
+	// Episodes are grouped into season packs, so fetch more rows than searches
+	episodes, err := s.repo.ListWantedEpisodes(ctx, before, int32(limit*50))
+	if err != nil {
+		return nil, fmt.Errorf("list wanted episodes: %w", err)
+	}
+
+	var movieTargets []searchTarget
+	for _, m := range movies {
+		if m.TmdbID == nil {
+			continue
+		}
+		movieTargets = append(movieTargets, searchTarget{
+			mediaItemID: m.ID,
+			mediaType:   model.MediaTypeMovie,
+			tmdbID:      *m.TmdbID,
+			title:       m.Title,
+		})
+	}
+
+	var episodeTargets []searchTarget
+	packs := make(map[pgtype.UUID]int) // season ID -> index in episodeTargets
+	for _, ep := range episodes {
+		if ep.TmdbID == nil {
+			continue
+		}
+		if ep.SeasonPack {
+			if i, ok := packs[ep.SeasonID]; ok {
+				episodeTargets[i].episodeIDs = append(episodeTargets[i].episodeIDs, ep.EpisodeID)
+				continue
+			}
+			packs[ep.SeasonID] = len(episodeTargets)
+		}
+		season := int(ep.SeasonNumber)
+		target := searchTarget{
+			mediaItemID: ep.MediaItemID,
+			mediaType:   model.MediaTypeSeries,
+			tmdbID:      *ep.TmdbID,
+			title:       ep.Title,
+			season:      &season,
+			episodeIDs:  []pgtype.UUID{ep.EpisodeID},
+		}
+		if !ep.SeasonPack {
+			episode := int(ep.EpisodeNumber)
+			target.episode = &episode
+		}
+		episodeTargets = append(episodeTargets, target)
+	}
+
+	targets := interleave(movieTargets, episodeTargets, limit)
+	if len(targets) < limit && s.settings.GetBool(ctx, "autosearch.upgrades") {
+		upgrades, err := s.upgrades(ctx, before, limit-len(targets))
+		if err != nil {
+			return nil, err
+		}
+		targets = append(targets, upgrades...)
+	}
+	return targets, nil
+}
+
+// upgrades returns up to limit movies and episodes whose file is below their
+// profile's cutoff. Those that already meet it are marked searched, so they
+// don't crowd out the rest until autosearch.retry_hours pass.
+func (s *AutoSearchService) upgrades(ctx context.Context, before time.Time, limit int) ([]searchTarget, error) {
+	// Most items with a file are usually at their cutoff, so look further ahead
+	movies, err := s.repo.ListUpgradeMovies(ctx, before, int32(limit*10))
+	if err != nil {
+		return nil, fmt.Errorf("list upgrade movies: %w", err)
+	}
+	episodes, err := s.repo.ListUpgradeEpisodes(ctx, before, int32(limit*10))
+	if err != nil {
+		return nil, fmt.Errorf("list upgrade episodes: %w", err)
+	}
+
+	var movieTargets []searchTarget
+	for _, m := range movies {
+		if m.TmdbID == nil || len(movieTargets) >= limit {
+			continue
+		}
+		target := searchTarget{
+			mediaItemID: m.ID,
+			mediaType:   model.MediaTypeMovie,
+			tmdbID:      *m.TmdbID,
+			title:       m.Title,
+			upgrade:     true,
+		}
+		if !s.candidates.profiles.belowCutoff(ctx, target.mediaType, target.tmdbID, nil, nil) {
+			s.markSearched(ctx, target)
+			continue
+		}
+		movieTargets = append(movieTargets, target)
+	}
+
+	var episodeTargets []searchTarget
+	for _, ep := range episodes {
+		if ep.TmdbID == nil || len(episodeTargets) >= limit {
+			continue
+		}
+		season, episode := int(ep.SeasonNumber), int(ep.EpisodeNumber)
+		target := searchTarget{
+			mediaItemID: ep.MediaItemID,
+			mediaType:   model.MediaTypeSeries,
+			tmdbID:      *ep.TmdbID,
+			title:       ep.Title,
+			season:      &season,
+			episode:     &episode,
+			episodeIDs:  []pgtype.UUID{ep.EpisodeID},
+			upgrade:     true,
+		}
+		if !s.candidates.profiles.belowCutoff(ctx, target.mediaType, target.tmdbID, target.season, target.episode) {
+			s.markSearched(ctx, target)
+			continue
+		}
+		episodeTargets = append(episodeTargets, target)
+	}
+
+	return interleave(movieTargets, episodeTargets, limit), nil
+}
+
+// interleave alternates movie and episode searches, up to limit, so neither
+// starves the other.
+func interleave(movies, episodes []searchTarget, limit int) []searchTarget {
+	targets := make([]searchTarget, 0, limit)
+	for i := 0; len(targets) < limit && (i < len(movies) || i < len(episodes)); i++ {
+		if i < len(movies) {
+			targets = append(targets, movies[i])
+		}
+		if i < len(episodes) && len(targets) < limit {
+			targets = append(targets, episodes[i])
+		}
+	}
+	return targets
+}
+
+// markSearched delays the next search of a target by autosearch.retry_hours.
+func (s *AutoSearchService) markSearched(ctx context.Context, target searchTarget) {
+	var err error
+	if target.mediaType == model.MediaTypeMovie {
+		err = s.repo.SetMediaItemSearchedAt(ctx, target.mediaItemID)
+	} else if len(target.episodeIDs) > 0 {
+		err = s.repo.SetEpisodesSearchedAt(ctx, target.episodeIDs)
+	}
+	if err != nil {
+		s.logger.Warn().Err(err).Int64("tmdb_id", target.tmdbID).Msg("Failed to record search time")
+	}
+}
+
+// SearchMovie searches for a movie in the library and grabs the best
+// acceptable release.
+func (s *AutoSearchService) SearchMovie(ctx context.Context, tmdbID int64, trigger string) (model.AutoSearchDecision, error) {
+	item, err := s.libraryItem(ctx, model.MediaTypeMovie, tmdbID)
+	if err != nil {
+		return model.AutoSearchDecision{}, err
+	}
+	target := searchTarget{mediaItemID: item.ID, mediaType: model.MediaTypeMovie, tmdbID: tmdbID, title: item.Title}
+	target.upgrade = s.candidates.profiles.belowCutoff(ctx, target.mediaType, tmdbID, nil, nil)
+	decision, err := s.search(ctx, target, trigger)
+	s.markSearched(ctx, target)
+	return decision, err
+}
+
+// SearchSeries searches for a season pack (episode nil) or an episode of a
+// series in the library and grabs the best acceptable release.
+func (s *AutoSearchService) SearchSeries(ctx context.Context, tmdbID int64, season, episode *int, trigger string) (model.AutoSearchDecision, error) {
+	if season == nil {
+		return model.AutoSearchDecision{}, ErrAutoSearchInvalid
+	}
+	item, err := s.libraryItem(ctx, model.MediaTypeSeries, tmdbID)
+	if err != nil {
+		return model.AutoSearchDecision{}, err
+	}
+	target := searchTarget{
+		mediaItemID: item.ID,
+		mediaType:   model.MediaTypeSeries,
+		tmdbID:      tmdbID,
+		title:       item.Title,
+		season:      season,
+		episode:     episode,
+	}
+	target.upgrade = s.candidates.profiles.belowCutoff(ctx, target.mediaType, tmdbID, season, episode)
+	return s.search(ctx, target, trigger)
+}
+
+// Queue adds searches to run in the background, autosearch.search_delay_seconds
+// apart, and returns how many were added. Searches already queued are
+// skipped. A scheduled pass in progress finishes before the next queued
+// search starts.
+func (s *AutoSearchService) Queue(items []model.WantedSearchItem) int {
+	s.mu.Lock()
+	queued := 0
+	for _, item := range items {
+		if slices.ContainsFunc(s.queue, func(q model.WantedSearchItem) bool { return sameSearch(q, item) }) {
+			continue
+		}
+		s.queue = append(s.queue, item)
+		queued++
+	}
+	start := !s.draining && len(s.queue) > 0
+	if start {
+		s.draining = true
+	}
+	s.mu.Unlock()
+
+	if start {
+		go s.drain(context.Background())
+	}
+	return queued
+}
+
+// drain runs queued searches until the queue is empty.
+func (s *AutoSearchService) drain(ctx context.Context) {
+	for i := 0; ; i++ {
+		s.mu.Lock()
+		if len(s.queue) == 0 {
+			s.draining = false
+			s.mu.Unlock()
+			return
+		}
+		item := s.queue[0]
+		s.queue = s.queue[1:]
+		s.mu.Unlock()
+
+		if delay := time.Duration(s.settings.GetInt(ctx, "autosearch.search_delay_seconds")) * time.Second; i > 0 && delay > 0 {
+			time.Sleep(delay)
+		}
+
+		s.running.Lock()
+		var err error
+		if item.MediaType == model.MediaTypeMovie {
+			_, err = s.SearchMovie(ctx, item.TmdbID, model.AutoSearchTriggerManual)
+		} else {
+			_, err = s.SearchSeries(ctx, item.TmdbID, item.Season, item.Episode, model.AutoSearchTriggerManual)
+		}
+		s.running.Unlock()
+		if err != nil {
+			s.logger.Warn().Err(err).Int64("tmdb_id", item.TmdbID).Msg("Queued search failed")
+		}
+	}
+}
+
+func sameSearch(a, b model.WantedSearchItem) bool {
+	return a.MediaType == b.MediaType && a.TmdbID == b.TmdbID &&
+		equalIntPtr(a.Season, b.Season) && equalIntPtr(a.Episode, b.Episode)
+}
+
+func equalIntPtr(a, b *int) bool {
+	return (a == nil && b == nil) || (a != nil && b != nil && *a == *b)
+}
+
+func (s *AutoSearchService) libraryItem(ctx context.Context, mediaType model.MediaType, tmdbID int64) (dbgen.MediaItem, error) {
+	item, err := s.repo.GetMediaItemByTmdbIDAndType(ctx, tmdbID, string(mediaType))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return dbgen.MediaItem{}, ErrMediaNotInLibrary
+		}
+		return dbgen.MediaItem{}, err
+	}
+	return item, nil
+}
+
+// rankedCandidate is a search result that passed every check, with the
+// values it is ranked by.
+type rankedCandidate struct {
+	candidate model.DownloadCandidate
+	verdict   int // index in the decision's candidates
+	score     int
+	rank      int
+}
+
+// search runs one search, picks the best acceptable candidate, enqueues it
+// and records the decision. The returned decision is valid even when err is
+// set, since failures are decisions too.
+func (s *AutoSearchService) search(ctx context.Context, target searchTarget, trigger string) (model.AutoSearchDecision, error) {
+	params := dbgen.CreateAutoSearchDecisionParams{
+		MediaItemID:   target.mediaItemID,
+		MediaType:     string(target.mediaType),
+		TmdbID:        target.tmdbID,
+		SeasonNumber:  intToInt32Ptr(target.season),
+		EpisodeNumber: intToInt32Ptr(target.episode),
+		Trigger:       trigger,
+		Upgrade:       target.upgrade,
+	}
+
+	var (
+		resp model.DownloadCandidatesResponse
+		err  error
+	)
+	if target.mediaType == model.MediaTypeMovie {
+		resp, err = s.candidates.SearchDownloadCandidates(ctx, target.tmdbID, true)
+	} else {
+		resp, err = s.candidates.SearchSeriesDownloadCandidates(ctx, target.tmdbID, target.season, target.episode, true)
+	}
+	if err != nil {
+		params.Outcome = model.AutoSearchOutcomeFailed
+		params.Reason = strPtr(err.Error())
+		return s.record(ctx, target, params, nil, nil)
+	}
+	_ = params.SearchSessionID.Scan(resp.Session.ID)
+	params.CandidateCount = int32(len(resp.Candidates))
+
+	if len(resp.Candidates) == 0 {
+		params.Outcome = model.AutoSearchOutcomeNoResults
+		return s.record(ctx, target, params, nil, nil)
+	}
+
+	verdicts, accepted := s.evaluate(ctx, target, resp.Candidates)
+	slices.SortStableFunc(accepted, func(a, b rankedCandidate) int {
+		return cmp.Or(
+			cmp.Compare(b.score, a.score),
+			cmp.Compare(b.rank, a.rank),
+			cmp.Compare(b.candidate.MatchConfidence, a.candidate.MatchConfidence),
+			cmp.Compare(b.candidate.Seeders, a.candidate.Seeders),
+		)
+	})
+
+	for _, pick := range accepted {
+		// A release can only be grabbed once; earlier grabs failed or were replaced
+		if _, err := s.repo.GetDownloadJobByCandidate(ctx, pick.candidate.IndexerID, pick.candidate.GUID); err == nil {
+			verdicts[pick.verdict].Accepted = false
+			verdicts[pick.verdict].Reason = "already grabbed"
+			continue
+		}
+
+		var (
+			trace model.EvaluationTrace
+			job   dbgen.DownloadJob
+		)
+		if target.mediaType == model.MediaTypeMovie {
+			trace, job, err = s.candidates.EnqueueCandidate(ctx, target.tmdbID, resp.Session.ID, pick.candidate.IndexerID, pick.candidate.GUID)
+		} else {
+			trace, job, err = s.candidates.EnqueueSeriesCandidate(ctx, target.tmdbID, resp.Session.ID, pick.candidate.IndexerID, pick.candidate.GUID, target.season, target.episode)
+		}
+		params.ChosenTitle = &pick.candidate.Title
+		params.ChosenIndexerID = &pick.candidate.IndexerID
+		params.ChosenGuid = &pick.candidate.GUID
+		if err != nil {
+			params.Outcome = model.AutoSearchOutcomeFailed
+			params.Reason = strPtr(fmt.Sprintf("enqueue failed: %v", err))
+			return s.record(ctx, target, params, verdicts, &trace)
+		}
+		params.Outcome = model.AutoSearchOutcomeGrabbed
+		params.DownloadJobID = job.ID
+		params.Reason = strPtr(fmt.Sprintf("best of %d acceptable candidates (score %d)", len(accepted), pick.score))
+		return s.record(ctx, target, params, verdicts, &trace)
+	}
+
+	params.Outcome = model.AutoSearchOutcomeRejected
+	params.Reason = strPtr(fmt.Sprintf("none of %d candidates were acceptable", len(resp.Candidates)))
+	return s.record(ctx, target, params, verdicts, nil)
+}
+
+// evaluate gives a verdict on every candidate. Blocklisted releases and
+// releases the quality profile rejects are skipped; the rest are run through
+// the policy engine, which can score or reject them.
+func (s *AutoSearchService) evaluate(ctx context.Context, target searchTarget, candidates []model.DownloadCandidate) ([]model.AutoSearchCandidate, []rankedCandidate) {
+	verdicts := make([]model.AutoSearchCandidate, 0, len(candidates))
+	var accepted []rankedCandidate
+	reliability := s.candidates.health.ReliabilityLookup(ctx)
+
+	for _, c := range candidates {
+		v := model.AutoSearchCandidate{
+			Title:           c.Title,
+			IndexerID:       c.IndexerID,
+			GUID:            c.GUID,
+			MatchConfidence: c.MatchConfidence,
+			Seeders:         c.Seeders,
+		}
+
+		switch {
+		case c.Blocklisted:
+			v.Reason = "blocklisted"
+		case c.ProfileStatus == string(qualityprofile.StatusRejected):
+			v.Reason = "quality profile: " + c.ProfileReason
+		}
+		if v.Reason != "" {
+			verdicts = append(verdicts, v)
+			continue
+		}
+
+		var evalCtx model.EvaluationContext
+		if target.mediaType == model.MediaTypeMovie {
+			evalCtx = s.candidates.buildMovieEvaluationContext(ctx, c, target.tmdbID, reliability)
+		} else {
+			evalCtx = s.candidates.buildSeriesEvaluationContext(ctx, c, target.tmdbID, target.season, target.episode, reliability)
+		}
+		v.ProfileRank = evalCtx.Profile.Rank
+
+		trace, err := s.candidates.policyEngine.Evaluate(ctx, evalCtx)
+		if err != nil {
+			v.Reason = fmt.Sprintf("policy evaluation failed: %v", err)
+			verdicts = append(verdicts, v)
+			continue
+		}
+		for _, p := range trace.Policies {
+			if p.Matched {
+				v.Policies = append(v.Policies, p.PolicyName)
+			}
+		}
+		v.Score = trace.FinalPlan.Score
+		if trace.FinalPlan.Rejected {
+			v.Reason = "rejected by policy"
+			if trace.FinalPlan.RejectReason != "" {
+				v.Reason += ": " + trace.FinalPlan.RejectReason
+			}
+			verdicts = append(verdicts, v)
+			continue
+		}
+
+		v.Accepted = true
+		accepted = append(accepted, rankedCandidate{candidate: c, verdict: len(verdicts), score: v.Score, rank: v.ProfileRank})
+		verdicts = append(verdicts, v)
+	}
+	return verdicts, accepted
+}
+
+// record persists and logs a decision.
+func (s *AutoSearchService) record(ctx context.Context, target searchTarget, params dbgen.CreateAutoSearchDecisionParams, verdicts []model.AutoSearchCandidate, trace *model.EvaluationTrace) (model.AutoSearchDecision, error) {
+	// Accepted candidates first, best first, so the cap keeps the useful ones
+	slices.SortStableFunc(verdicts, func(a, b model.AutoSearchCandidate) int {
+		if a.Accepted != b.Accepted {
+			if a.Accepted {
+				return -1
+			}
+			return 1
+		}
+		return cmp.Compare(b.Score, a.Score)
+	})
+	if len(verdicts) > maxDecisionCandidates {
+		verdicts = verdicts[:maxDecisionCandidates]
+	}
+
+	var err error
+	if params.Candidates, err = json.Marshal(verdicts); err != nil {
+		return model.AutoSearchDecision{}, err
+	}
+	if trace != nil {
+		if params.Trace, err = json.Marshal(trace); err != nil {
+			return model.AutoSearchDecision{}, err
+		}
+	}
+
+	event := s.logger.Info()
+	if params.Outcome == model.AutoSearchOutcomeFailed {
+		event = s.logger.Warn()
+	}
+	event = event.Str("title", target.title).Int64("tmdb_id", target.tmdbID).
+		Str("trigger", params.Trigger).Bool("upgrade", params.Upgrade).Str("outcome", params.Outcome).Int32("candidates", params.CandidateCount)
+	if target.season != nil {
+		event = event.Int("season", *target.season)
+	}
+	if target.episode != nil {
+		event = event.Int("episode", *target.episode)
+	}
+	if params.ChosenTitle != nil {
+		event = event.Str("release", *params.ChosenTitle)
+	}
+	if params.Reason != nil {
+		event = event.Str("reason", *params.Reason)
+	}
+	if trace != nil {
+		event = event.RawJSON("trace", params.Trace)
+	}
+	event.Msg("Automatic search decision")
+
+	row, err := s.repo.CreateAutoSearchDecision(ctx, params)
+	if err != nil {
+		return model.AutoSearchDecision{}, fmt.Errorf("create auto search decision: %w", err)
+	}
+	return autoSearchDecisionToModel(row), nil
+}
+
+// ListDecisions returns automatic search decisions newest first, optionally
+// for a single media item or outcome.
+func (s *AutoSearchService) ListDecisions(ctx context.Context, mediaItemID pgtype.UUID, outcome *string, page, pageSize int) (model.PaginatedAutoSearchDecisionResponse, error) {
+	total, err := s.repo.CountAutoSearchDecisions(ctx, mediaItemID, outcome)
+	if err != nil {
+		return model.PaginatedAutoSearchDecisionResponse{}, err
+	}
+
+	rows, err := s.repo.ListAutoSearchDecisionsPaginated(ctx, mediaItemID, outcome, int32(pageSize), int32((page-1)*pageSize))
+	if err != nil {
+		return model.PaginatedAutoSearchDecisionResponse{}, err
+	}
+
+	decisions := make([]model.AutoSearchDecision, 0, len(rows))
+	for _, row := range rows {
+		decisions = append(decisions, autoSearchDecisionToModel(row))
+	}
+
+	return model.PaginatedAutoSearchDecisionResponse{
+		Data: decisions,
+		Pagination: model.Pagination{
+			Total:      total,
+			Page:       page,
+			PageSize:   pageSize,
+			TotalPages: int(math.Ceil(float64(total) / float64(pageSize))),
+		},
+	}, nil
+}
+
+// GetDecision returns a single automatic search decision.
+func (s *AutoSearchService) GetDecision(ctx context.Context, id pgtype.UUID) (model.AutoSearchDecision, error) {
+	row, err := s.repo.GetAutoSearchDecision(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return model.AutoSearchDecision{}, ErrAutoSearchDecisionNotFound
+		}
+		return model.AutoSearchDecision{}, err
+	}
+	return autoSearchDecisionToModel(row), nil
+}
+
+func autoSearchDecisionToModel(row dbgen.AutoSearchDecision) model.AutoSearchDecision {
+	d := model.AutoSearchDecision{
+		ID:              row.ID.String(),
+		MediaItemID:     row.MediaItemID.String(),
+		MediaType:       model.MediaType(row.MediaType),
+		TmdbID:          row.TmdbID,
+		Season:          int32ToIntPtr(row.SeasonNumber),
+		Episode:         int32ToIntPtr(row.EpisodeNumber),
+		Trigger:         row.Trigger,
+		Upgrade:         row.Upgrade,
+		Outcome:         row.Outcome,
+		Reason:          coalesce(row.Reason, ""),
+		CandidateCount:  int(row.CandidateCount),
+		ChosenTitle:     coalesce(row.ChosenTitle, ""),
+		ChosenIndexerID: row.ChosenIndexerID,
+		ChosenGUID:      coalesce(row.ChosenGuid, ""),
+		Candidates:      []model.AutoSearchCandidate{},
+		CreatedAt:       row.CreatedAt,
+	}
+	if row.SearchSessionID.Valid {
+		d.SearchSessionID = row.SearchSessionID.String()
+	}
+	if row.DownloadJobID.Valid {
+		d.DownloadJobID = row.DownloadJobID.String()
+	}
+	if len(row.Candidates) > 0 {
+		_ = json.Unmarshal(row.Candidates, &d.Candidates)
+	}
+	if len(row.Trace) > 0 {
+		var trace model.EvaluationTrace
+		if err := json.Unmarshal(row.Trace, &trace); err == nil {
+			d.Trace = &trace
+		}
+	}
+	return d
+}
@@ -0,0 +1,236 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/kyleaupton/arrflix/internal/blocklist"
+	dbgen "github.com/kyleaupton/arrflix/internal/db/sqlc"
+	"github.com/kyleaupton/arrflix/internal/indexer"
+	"github.com/kyleaupton/arrflix/internal/logger"
+	"github.com/kyleaupton/arrflix/internal/model"
+	"github.com/kyleaupton/arrflix/internal/repo"
+)
+
+var (
+	ErrBlocklistEntryNotFound = errors.New("blocklist entry not found")
+	ErrBlocklistEntryInvalid  = errors.New("a title and an info hash or indexer and guid are required")
+	ErrBlocklistJobNotFound   = errors.New("download job not found")
+)
+
+// BlocklistService manages releases that must not be grabbed again
+type BlocklistService struct {
+	repo   *repo.Repository
+	logger *logger.Logger
+}
+
+// NewBlocklistService creates a new blocklist service
+func NewBlocklistService(r *repo.Repository, l *logger.Logger) *BlocklistService {
+	return &BlocklistService{repo: r, logger: l}
+}
+
+// List returns blocklist entries newest first, optionally for a single media item.
+func (s *BlocklistService) List(ctx context.Context, mediaItemID pgtype.UUID, page, pageSize int) (model.PaginatedBlocklistResponse, error) {
+	total, err := s.repo.CountBlocklistEntries(ctx, mediaItemID)
+	if err != nil {
+		return model.PaginatedBlocklistResponse{}, err
+	}
+
+	rows, err := s.repo.ListBlocklistEntriesPaginated(ctx, mediaItemID, int32(pageSize), int32((page-1)*pageSize))
+	if err != nil {
+		return model.PaginatedBlocklistResponse{}, err
+	}
+
+	entries := make([]model.BlocklistEntry, 0, len(rows))
+	for _, row := range rows {
+		entries = append(entries, blocklistEntryToModel(row))
+	}
+
+	return model.PaginatedBlocklistResponse{
+		Data: entries,
+		Pagination: model.Pagination{
+			Total:      total,
+			Page:       page,
+			PageSize:   pageSize,
+			TotalPages: int(math.Ceil(float64(total) / float64(pageSize))),
+		},
+	}, nil
+}
+
+// Get returns a single blocklist entry.
+func (s *BlocklistService) Get(ctx context.Context, id pgtype.UUID) (model.BlocklistEntry, error) {
+	row, err := s.repo.GetBlocklistEntry(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return model.BlocklistEntry{}, ErrBlocklistEntryNotFound
+		}
+		return model.BlocklistEntry{}, err
+	}
+	return blocklistEntryToModel(row), nil
+}
+
+// Create manually blocklists a release, either the one grabbed by a download
+// job or one described directly in the request.
+func (s *BlocklistService) Create(ctx context.Context, req model.CreateBlocklistEntryRequest) (model.BlocklistEntry, error) {
+	expiresAt := timePtrToTimestamptz(req.ExpiresAt)
+
+	if req.DownloadJobID != "" {
+		var jobID pgtype.UUID
+		if err := jobID.Scan(req.DownloadJobID); err != nil {
+			return model.BlocklistEntry{}, ErrBlocklistJobNotFound
+		}
+		job, err := s.repo.GetDownloadJob(ctx, jobID)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return model.BlocklistEntry{}, ErrBlocklistJobNotFound
+			}
+			return model.BlocklistEntry{}, err
+		}
+		row, err := blocklist.AddForDownloadJob(ctx, s.repo, job, blocklist.ReasonManual, req.Message)
+		if err != nil {
+			return model.BlocklistEntry{}, fmt.Errorf("blocklist download job: %w", err)
+		}
+		if expiresAt.Valid {
+			if row, err = s.repo.UpdateBlocklistEntry(ctx, row.ID, row.Message, expiresAt); err != nil {
+				return model.BlocklistEntry{}, err
+			}
+		}
+		return blocklistEntryToModel(row), nil
+	}
+
+	title := strings.TrimSpace(req.Title)
+	infoHash := blocklist.NormalizeInfoHash(req.InfoHash)
+	hasGUID := req.IndexerID != nil && req.GUID != ""
+	if title == "" || (infoHash == "" && !hasGUID) {
+		return model.BlocklistEntry{}, ErrBlocklistEntryInvalid
+	}
+
+	params := dbgen.CreateBlocklistEntryParams{
+		Title:           title,
+		NormalizedTitle: indexer.NormalizeTitle(title),
+		Reason:          blocklist.ReasonManual,
+		ExpiresAt:       expiresAt,
+	}
+	if infoHash != "" {
+		params.InfoHash = &infoHash
+	}
+	if hasGUID {
+		params.IndexerID = req.IndexerID
+		params.Guid = &req.GUID
+	}
+	if req.Protocol != "" {
+		params.Protocol = &req.Protocol
+	}
+	if req.Message != "" {
+		params.Message = &req.Message
+	}
+	if req.MediaItemID != "" {
+		if err := params.MediaItemID.Scan(req.MediaItemID); err != nil {
+			return model.BlocklistEntry{}, fmt.Errorf("invalid media item id: %w", err)
+		}
+	}
+
+	row, err := s.repo.CreateBlocklistEntry(ctx, params)
+	if err != nil {
+		return model.BlocklistEntry{}, err
+	}
+	return blocklistEntryToModel(row), nil
+}
+
+// Update changes an entry's message and expiry.
+func (s *BlocklistService) Update(ctx context.Context, id pgtype.UUID, req model.UpdateBlocklistEntryRequest) (model.BlocklistEntry, error) {
+	var message *string
+	if req.Message != "" {
+		message = &req.Message
+	}
+	row, err := s.repo.UpdateBlocklistEntry(ctx, id, message, timePtrToTimestamptz(req.ExpiresAt))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return model.BlocklistEntry{}, ErrBlocklistEntryNotFound
+		}
+		return model.BlocklistEntry{}, err
+	}
+	return blocklistEntryToModel(row), nil
+}
+
+// Delete removes an entry so the release may be grabbed again.
+func (s *BlocklistService) Delete(ctx context.Context, id pgtype.UUID) error {
+	if _, err := s.repo.GetBlocklistEntry(ctx, id); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrBlocklistEntryNotFound
+		}
+		return err
+	}
+	return s.repo.DeleteBlocklistEntry(ctx, id)
+}
+
+// Flag marks the candidates that match an unexpired blocklist entry.
+func (s *BlocklistService) Flag(ctx context.Context, candidates []model.DownloadCandidate) error {
+	if len(candidates) == 0 {
+		return nil
+	}
+
+	infoHashes := []string{}
+	indexerGUIDs := make([]string, 0, len(candidates))
+	titles := make([]string, 0, len(candidates))
+	for _, c := range candidates {
+		if h := blocklist.NormalizeInfoHash(c.InfoHash); h != "" {
+			infoHashes = append(infoHashes, h)
+		}
+		indexerGUIDs = append(indexerGUIDs, blocklist.IndexerGUIDKey(c.IndexerID, c.GUID))
+		titles = append(titles, indexer.NormalizeTitle(c.Title))
+	}
+
+	rows, err := s.repo.FindBlocklistMatches(ctx, infoHashes, indexerGUIDs, titles)
+	if err != nil {
+		return fmt.Errorf("find blocklist matches: %w", err)
+	}
+
+	for i := range candidates {
+		c := &candidates[i]
+		c.Blocklisted, c.BlocklistReason = false, ""
+		rel := blocklist.Release{IndexerID: c.IndexerID, GUID: c.GUID, Title: c.Title, InfoHash: c.InfoHash}
+		for _, row := range rows {
+			if blocklist.EntryFromRow(row).Matches(rel) {
+				c.Blocklisted, c.BlocklistReason = true, row.Reason
+				break
+			}
+		}
+	}
+	return nil
+}
+
+func blocklistEntryToModel(row dbgen.Blocklist) model.BlocklistEntry {
+	e := model.BlocklistEntry{
+		ID:        row.ID.String(),
+		InfoHash:  coalesce(row.InfoHash, ""),
+		IndexerID: row.IndexerID,
+		GUID:      coalesce(row.Guid, ""),
+		Title:     row.Title,
+		Protocol:  coalesce(row.Protocol, ""),
+		Reason:    row.Reason,
+		Message:   coalesce(row.Message, ""),
+		ExpiresAt: timestamptzPtr(row.ExpiresAt),
+		CreatedAt: row.CreatedAt,
+	}
+	if row.MediaItemID.Valid {
+		e.MediaItemID = row.MediaItemID.String()
+	}
+	if row.DownloadJobID.Valid {
+		e.DownloadJobID = row.DownloadJobID.String()
+	}
+	return e
+}
+
+func timePtrToTimestamptz(t *time.Time) pgtype.Timestamptz {
+	if t == nil {
+		return pgtype.Timestamptz{}
+	}
+	return pgtype.Timestamptz{Time: *t, Valid: true}
+}
@@ -0,0 +1,209 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/kyleaupton/arrflix/internal/ical"
+	"github.com/kyleaupton/arrflix/internal/logger"
+	"github.com/kyleaupton/arrflix/internal/model"
+	"github.com/kyleaupton/arrflix/internal/repo"
+)
+
+var (
+	ErrCalendarRange        = errors.New("end must not be before start, and the range can span at most a year")
+	ErrCalendarTokenInvalid = errors.New("invalid calendar token")
+)
+
+const (
+	// calendarMaxRange caps the span of a calendar request.
+	calendarMaxRange = 366 * 24 * time.Hour
+	// The iCal feed covers the recent past and the coming year.
+	calendarFeedPast   = 30 * 24 * time.Hour
+	calendarFeedFuture = 365 * 24 * time.Hour
+)
+
+// CalendarService serves the release and air dates of library items, kept
+// up to date by the metadata refresh, as a calendar and an iCal feed.
+type CalendarService struct {
+	repo     *repo.Repository
+	logger   *logger.Logger
+	settings *SettingsService
+}
+
+// NewCalendarService creates a new calendar service
+func NewCalendarService(r *repo.Repository, l *logger.Logger, settings *SettingsService) *CalendarService {
+	return &CalendarService{repo: r, logger: l, settings: settings}
+}
+
+// List returns the episodes airing and movies released between start and
+// end, inclusive, by date.
+func (s *CalendarService) List(ctx context.Context, start, end time.Time) ([]model.CalendarEntry, error) {
+	if end.Before(start) || end.Sub(start) > calendarMaxRange {
+		return nil, ErrCalendarRange
+	}
+
+	episodes, err := s.repo.ListCalendarEpisodes(ctx, start, end)
+	if err != nil {
+		return nil, fmt.Errorf("list calendar episodes: %w", err)
+	}
+	movies, err := s.repo.ListCalendarMovies(ctx, start, end)
+	if err != nil {
+		return nil, fmt.Errorf("list calendar movies: %w", err)
+	}
+
+	entries := make([]model.CalendarEntry, 0, len(episodes)+len(movies))
+	for _, ep := range episodes {
+		season, episode := int(ep.SeasonNumber), int(ep.EpisodeNumber)
+		entries = append(entries, model.CalendarEntry{
+			MediaItemID:  ep.MediaItemID.String(),
+			MediaType:    model.MediaTypeSeries,
+			TmdbID:       derefInt64(ep.TmdbID),
+			Title:        ep.Title,
+			Season:       &season,
+			Episode:      &episode,
+			EpisodeTitle: coalesce(ep.EpisodeTitle, ""),
+			Date:         ep.AirDate.Time.Format(time.DateOnly),
+			ReleaseType:  model.CalendarReleaseAir,
+			Monitored:    ep.Monitored,
+			HasFile:      ep.HasFile,
+		})
+	}
+	for _, m := range movies {
+		for _, release := range []struct {
+			kind string
+			date pgtype.Date
+		}{
+			{model.CalendarReleaseTheatrical, m.ReleaseDate},
+			{model.CalendarReleaseDigital, m.DigitalReleaseDate},
+			{model.CalendarReleasePhysical, m.PhysicalReleaseDate},
+		} {
+			if !release.date.Valid || release.date.Time.Before(start) || release.date.Time.After(end) {
+				continue
+			}
+			entries = append(entries, model.CalendarEntry{
+				MediaItemID: m.MediaItemID.String(),
+				MediaType:   model.MediaTypeMovie,
+				TmdbID:      derefInt64(m.TmdbID),
+				Title:       m.Title,
+				Year:        int32ToIntPtr(m.Year),
+				Date:        release.date.Time.Format(time.DateOnly),
+				ReleaseType: release.kind,
+				Monitored:   m.Monitored,
+				HasFile:     m.HasFile,
+			})
+		}
+	}
+
+	// Dates are YYYY-MM-DD, so they sort as strings
+	slices.SortStableFunc(entries, func(a, b model.CalendarEntry) int {
+		return strings.Compare(a.Date, b.Date)
+	})
+	return entries, nil
+}
+
+// Feed renders the iCal feed for the holder of a calendar token.
+func (s *CalendarService) Feed(ctx context.Context, token string) ([]byte, error) {
+	if token == "" {
+		return nil, ErrCalendarTokenInvalid
+	}
+	if _, err := s.repo.GetCalendarTokenByToken(ctx, token); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrCalendarTokenInvalid
+		}
+		return nil, err
+	}
+
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	entries, err := s.List(ctx, today.Add(-calendarFeedPast), today.Add(calendarFeedFuture))
+	if err != nil {
+		return nil, err
+	}
+
+	cal := ical.Calendar{Name: s.settings.GetText(ctx, "site.title"), Events: make([]ical.Event, 0, len(entries))}
+	for _, e := range entries {
+		date, err := time.Parse(time.DateOnly, e.Date)
+		if err != nil {
+			continue
+		}
+		cal.Events = append(cal.Events, calendarEvent(e, date))
+	}
+	return cal.Encode(now), nil
+}
+
+func calendarEvent(e model.CalendarEntry, date time.Time) ical.Event {
+	event := ical.Event{Date: date}
+	if e.MediaType == model.MediaTypeSeries {
+		event.UID = fmt.Sprintf("%s-s%de%d@arrflix", e.MediaItemID, *e.Season, *e.Episode)
+		event.Summary = fmt.Sprintf("%s - S%02dE%02d", e.Title, *e.Season, *e.Episode)
+		if e.EpisodeTitle != "" {
+			event.Summary += " - " + e.EpisodeTitle
+		}
+	} else {
+		event.UID = fmt.Sprintf("%s-%s@arrflix", e.MediaItemID, e.ReleaseType)
+		event.Summary = e.Title
+		if e.Year != nil {
+			event.Summary += fmt.Sprintf(" (%d)", *e.Year)
+		}
+		event.Summary += fmt.Sprintf(" - %s release", e.ReleaseType)
+	}
+
+	if e.HasFile {
+		event.Description = "Downloaded"
+	} else if e.Monitored {
+		event.Description = "Not downloaded (monitored)"
+	} else {
+		event.Description = "Not downloaded"
+	}
+	return event
+}
+
+// FeedToken returns the user's iCal feed, creating its token on first use.
+func (s *CalendarService) FeedToken(ctx context.Context, userID pgtype.UUID) (model.CalendarFeed, error) {
+	row, err := s.repo.GetCalendarToken(ctx, userID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return s.RegenerateFeedToken(ctx, userID)
+	}
+	if err != nil {
+		return model.CalendarFeed{}, err
+	}
+	return calendarFeed(row.Token), nil
+}
+
+// RegenerateFeedToken replaces the user's feed token, cutting off anything
+// subscribed with the old one.
+func (s *CalendarService) RegenerateFeedToken(ctx context.Context, userID pgtype.UUID) (model.CalendarFeed, error) {
+	b := make([]byte, 24)
+	if _, err := rand.Read(b); err != nil {
+		return model.CalendarFeed{}, fmt.Errorf("generate token: %w", err)
+	}
+	row, err := s.repo.UpsertCalendarToken(ctx, userID, hex.EncodeToString(b))
+	if err != nil {
+		return model.CalendarFeed{}, err
+	}
+	return calendarFeed(row.Token), nil
+}
+
+func calendarFeed(token string) model.CalendarFeed {
+	return model.CalendarFeed{
+		Token: token,
+		Path:  "/api/v1/calendar/feed.ics?token=" + url.QueryEscape(token),
+	}
+}
+
+func derefInt64(v *int64) int64 {
+	if v == nil {
+		return 0
+	}
+	return *v
+}
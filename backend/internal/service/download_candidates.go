@@ -5,10 +5,10 @@ import (
 	"errors"
 	"fmt"
 	"strconv"
+	"strings"
 	"sync"
 	"time"
 
-	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
 	dbgen "github.com/kyleaupton/arrflix/internal/db/sqlc"
 	"github.com/kyleaupton/arrflix/internal/indexer"
@@ -20,47 +20,48 @@ import (
 )
 
 var (
-	ErrCandidateNotFound = errors.New("candidate not found in cache (may have expired)")
-	ErrCandidateExpired  = errors.New("candidate cache expired")
+	ErrCandidateNotFound    = errors.New("candidate not found in search results")
+	ErrCandidateExpired     = errors.New("search results expired, search again")
+	ErrCandidateBlocklisted = errors.New("release is blocklisted")
 )
 
-const cacheTTL = 5 * time.Minute
-
-// cachedSearchResult stores a search result with its expiration time
-type cachedSearchResult struct {
-	result    indexer.SearchResult
-	expiresAt time.Time
-}
-
 // DownloadCandidatesService handles download candidate search and enqueueing
 type DownloadCandidatesService struct {
 	repo         *repo.Repository
 	logger       *logger.Logger
 	source       indexer.IndexerSource
 	media        *MediaService
+	aliases      *TitleAliasesService
+	settings     *SettingsService
+	health       *IndexerHealthService
+	blocklist    *BlocklistService
+	profiles     *QualityProfilesService
 	policyEngine *policy.Engine
-	cache        map[string]*cachedSearchResult
-	cacheMu      sync.RWMutex
 }
 
 // NewDownloadCandidatesService creates a new download candidates service
-func NewDownloadCandidatesService(r *repo.Repository, l *logger.Logger, source indexer.IndexerSource, media *MediaService, engine *policy.Engine) *DownloadCandidatesService {
+func NewDownloadCandidatesService(r *repo.Repository, l *logger.Logger, source indexer.IndexerSource, media *MediaService, aliases *TitleAliasesService, settings *SettingsService, health *IndexerHealthService, blocklist *BlocklistService, profiles *QualityProfilesService, engine *policy.Engine) *DownloadCandidatesService {
 	return &DownloadCandidatesService{
 		repo:         r,
 		logger:       l,
 		source:       source,
 		media:        media,
+		aliases:      aliases,
+		settings:     settings,
+		health:       health,
+		blocklist:    blocklist,
+		profiles:     profiles,
 		policyEngine: engine,
-		cache:        make(map[string]*cachedSearchResult),
 	}
 }
 
-// SearchDownloadCandidates searches for download candidates for a movie
-func (s *DownloadCandidatesService) SearchDownloadCandidates(ctx context.Context, movieID int64) ([]model.DownloadCandidate, error) {
+// SearchDownloadCandidates searches for download candidates for a movie.
+// With strict set, releases below release.StrictMatchConfidence are dropped.
+func (s *DownloadCandidatesService) SearchDownloadCandidates(ctx context.Context, movieID int64, strict bool) (model.DownloadCandidatesResponse, error) {
 	// Get movie details to construct search query
 	movie, err := s.media.GetMovie(ctx, movieID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get movie: %w", err)
+		return model.DownloadCandidatesResponse{}, fmt.Errorf("failed to get movie: %w", err)
 	}
 
 	// Construct search query: "Title Year"
@@ -71,102 +72,276 @@ func (s *DownloadCandidatesService) SearchDownloadCandidates(ctx context.Context
 			year = movie.ReleaseDate[:4]
 		}
 	}
-	query := movie.Title
-	if year != "" {
-		query = fmt.Sprintf("%s %s", movie.Title, year)
+	queryFor := func(title string) string {
+		if year != "" {
+			return fmt.Sprintf("%s %s", title, year)
+		}
+		return title
 	}
 
 	searchQuery := indexer.SearchQuery{
-		Query:     query,
+		Query:     queryFor(movie.Title),
 		MediaType: indexer.MediaTypeMovie,
 		Limit:     100,
 	}
+	s.applyExternalIDs(ctx, &searchQuery, model.MediaTypeMovie, movieID)
 
-	return s.searchAndCache(ctx, searchQuery)
+	aliases, titles := s.searchTitles(ctx, model.MediaTypeMovie, movieID, movie.Title)
+	target := release.MatchTarget{Titles: titles}
+	if year != "" {
+		target.Year, _ = strconv.Atoi(year)
+	}
+	check := s.profiles.checkFor(ctx, model.MediaTypeMovie, movieID, nil, nil, movie.Runtime)
+	return s.searchAndPersist(ctx, searchQuery, aliases, queryFor, target, check, strict)
 }
 
-// SearchSeriesDownloadCandidates searches for download candidates for a series, season, or episode
-func (s *DownloadCandidatesService) SearchSeriesDownloadCandidates(ctx context.Context, seriesID int64, season *int, episode *int) ([]model.DownloadCandidate, error) {
+// SearchSeriesDownloadCandidates searches for download candidates for a series, season, or episode.
+// With strict set, releases below release.StrictMatchConfidence are dropped.
+func (s *DownloadCandidatesService) SearchSeriesDownloadCandidates(ctx context.Context, seriesID int64, season *int, episode *int, strict bool) (model.DownloadCandidatesResponse, error) {
 	series, err := s.media.GetSeries(ctx, seriesID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get series: %w", err)
+		return model.DownloadCandidatesResponse{}, fmt.Errorf("failed to get series: %w", err)
 	}
 
-	query := series.Title
-	if season != nil {
-		if episode != nil {
-			query = fmt.Sprintf("%s S%02dE%02d", series.Title, *season, *episode)
-		} else {
-			query = fmt.Sprintf("%s S%02d", series.Title, *season)
+	queryFor := func(title string) string {
+		if season != nil {
+			if episode != nil {
+				return fmt.Sprintf("%s S%02dE%02d", title, *season, *episode)
+			}
+			return fmt.Sprintf("%s S%02d", title, *season)
 		}
+		return title
 	}
 
 	searchQuery := indexer.SearchQuery{
-		Query:     query,
+		Query:     queryFor(series.Title),
 		MediaType: indexer.MediaTypeSeries,
 		Season:    season,
 		Episode:   episode,
 		Limit:     100,
 	}
+	s.applyExternalIDs(ctx, &searchQuery, model.MediaTypeSeries, seriesID)
+
+	aliases, titles := s.searchTitles(ctx, model.MediaTypeSeries, seriesID, series.Title)
+	target := release.MatchTarget{
+		Titles:  titles,
+		Series:  true,
+		Season:  season,
+		Episode: episode,
+	}
+
+	// Fansub releases are named "Title - 1071" rather than SxxEyy
+	if season != nil && s.media.IsAnime(ctx, seriesID) {
+		abs, err := s.media.AbsoluteEpisodeMap(ctx, seriesID)
+		if err != nil {
+			s.logger.Warn().Err(err).Int64("tmdb_id", seriesID).Msg("Failed to map absolute episode numbers")
+		} else if episode != nil {
+			if n, ok := abs.Absolute(*season, *episode); ok {
+				target.AbsoluteEpisodes = []int{n}
+				queryFor = func(title string) string {
+					return fmt.Sprintf("%s %02d", title, n)
+				}
+				searchQuery.Query = queryFor(series.Title)
+			}
+		} else {
+			target.AbsoluteEpisodes = abs.SeasonAbsolutes(*season)
+		}
+	}
 
-	return s.searchAndCache(ctx, searchQuery)
+	check := s.profiles.checkFor(ctx, model.MediaTypeSeries, seriesID, season, episode, s.seriesRuntime(ctx, seriesID, season, episode))
+	return s.searchAndPersist(ctx, searchQuery, aliases, queryFor, target, check, strict)
 }
 
-func (s *DownloadCandidatesService) searchAndCache(ctx context.Context, query indexer.SearchQuery) ([]model.DownloadCandidate, error) {
-	results, err := s.source.Search(ctx, query)
+// applyExternalIDs adds TMDB/IMDb/TVDB IDs to a search query so sources can
+// run an ID-based search. Lookup failures leave the text query in place.
+func (s *DownloadCandidatesService) applyExternalIDs(ctx context.Context, query *indexer.SearchQuery, mediaType model.MediaType, tmdbID int64) {
+	query.TmdbID = tmdbID
+
+	ids, err := s.media.GetExternalIDs(ctx, mediaType, tmdbID)
 	if err != nil {
-		s.logger.Error().Err(err).Str("query", query.Query).Msg("Failed to search indexer")
-		return nil, fmt.Errorf("failed to search indexer: %w", err)
+		s.logger.Warn().Err(err).Int64("tmdb_id", tmdbID).Msg("Failed to get external IDs, using text search")
+		return
 	}
+	query.ImdbID = ids.ImdbID
+	query.TvdbID = ids.TvdbID
+}
 
-	// Clear expired entries from cache
-	s.cleanExpiredCache()
+// searchTitles returns the title aliases to search for and the titles a
+// release may legitimately be named after, falling back to the primary title
+// alone if aliases can't be loaded.
+func (s *DownloadCandidatesService) searchTitles(ctx context.Context, mediaType model.MediaType, tmdbID int64, title string) ([]model.TitleAlias, []string) {
+	aliases, titles, err := s.aliases.SearchTitles(ctx, mediaType, tmdbID)
+	if err != nil || len(aliases) == 0 {
+		if err != nil {
+			s.logger.Warn().Err(err).Int64("tmdb_id", tmdbID).Msg("Failed to load title aliases, searching primary title only")
+		}
+		return []model.TitleAlias{{Title: title, Source: model.TitleAliasSourcePrimary, Searched: true}}, []string{title}
+	}
+	return aliases, append([]string{title}, titles...)
+}
 
-	// Store results in cache and transform to DownloadCandidate
-	candidates := make([]model.DownloadCandidate, 0, len(results))
-	for _, result := range results {
-		// Cache the result
-		cacheKey := s.cacheKey(result.IndexerID, result.GUID)
-		s.cacheMu.Lock()
-		s.cache[cacheKey] = &cachedSearchResult{
-			result:    result,
-			expiresAt: time.Now().Add(cacheTTL),
+// searchAndPersist issues one query per title alias, merges and dedupes the
+// results, validates each against the target, marks each with the quality
+// profile's verdict when check is set, and persists them as a search session
+// for later evaluation. Only the first (primary) query carries external IDs;
+// the alias queries are text-only.
+func (s *DownloadCandidatesService) searchAndPersist(ctx context.Context, base indexer.SearchQuery, aliases []model.TitleAlias, queryFor func(title string) string, target release.MatchTarget, check *profileCheck, strict bool) (model.DownloadCandidatesResponse, error) {
+	base.ExcludeIndexerIDs = s.health.DisabledIndexerIDs(ctx)
+
+	reports := make([]indexer.SearchReport, len(aliases))
+	errs := make([]error, len(aliases))
+
+	var wg sync.WaitGroup
+	for i, alias := range aliases {
+		wg.Add(1)
+		go func(i int, alias model.TitleAlias) {
+			defer wg.Done()
+			query := base
+			query.Query = queryFor(alias.Title)
+			if i > 0 {
+				query.TmdbID, query.ImdbID, query.TvdbID = 0, "", 0
+			}
+			reports[i], errs[i] = s.search(ctx, query)
+		}(i, alias)
+	}
+	wg.Wait()
+
+	// One outcome per indexer for the whole search, not one per title queried
+	indexerReports := make([][]indexer.IndexerReport, len(reports))
+	for i, report := range reports {
+		indexerReports[i] = report.Indexers
+	}
+	s.health.Record(ctx, queryFor(aliases[0].Title), indexer.MergeReports(indexerReports...))
+
+	var all []indexer.SearchResult
+	queryAlias := make(map[string]string)
+	var failed []error
+	for i, report := range reports {
+		if errs[i] != nil {
+			s.logger.Error().Err(errs[i]).Str("query", queryFor(aliases[i].Title)).Msg("Failed to search indexer")
+			failed = append(failed, errs[i])
+			continue
+		}
+		for _, r := range report.Results {
+			key := resultKey(r.IndexerID, r.GUID)
+			if _, ok := queryAlias[key]; !ok {
+				queryAlias[key] = aliases[i].Title
+			}
 		}
-		s.cacheMu.Unlock()
+		all = append(all, report.Results...)
+	}
+	if len(failed) == len(aliases) {
+		return model.DownloadCandidatesResponse{}, fmt.Errorf("failed to search indexer: %w", errors.Join(failed...))
+	}
+
+	results := indexer.Dedupe(all)
 
-		// Transform to DownloadCandidate for API response
+	candidates := make([]model.DownloadCandidate, 0, len(results))
+	for _, result := range results {
 		candidate := searchResultToCandidate(result)
+		candidate.MatchedAlias = matchAlias(result.Title, aliases, queryAlias[resultKey(result.IndexerID, result.GUID)])
+		match := release.ValidateMatch(release.ParseTitleInfo(result.Title), target)
+		if strict && match.Confidence < release.StrictMatchConfidence {
+			continue
+		}
+		candidate.MatchConfidence = match.Confidence
+		candidate.MatchMismatches = match.Mismatches
+		if check != nil {
+			check.mark(&candidate)
+		}
 		candidates = append(candidates, candidate)
 	}
+	if err := s.blocklist.Flag(ctx, candidates); err != nil {
+		s.logger.Warn().Err(err).Msg("Failed to check candidates against blocklist")
+	}
+	sources := mergeSourceReports(reports)
 
-	return candidates, nil
+	session, err := s.saveSearchSession(ctx, base, candidates, sources)
+	if err != nil {
+		return model.DownloadCandidatesResponse{}, fmt.Errorf("failed to save search results: %w", err)
+	}
+
+	return model.DownloadCandidatesResponse{
+		Session:    session,
+		Candidates: candidates,
+		Sources:    sources,
+	}, nil
 }
 
-// EvaluateCandidate returns the evaluation trace for a candidate
-func (s *DownloadCandidatesService) EvaluateCandidate(ctx context.Context, movieID int64, indexerID int64, guid string) (model.EvaluationTrace, error) {
-	// Lookup torrent from cache
-	cacheKey := s.cacheKey(indexerID, guid)
-	s.cacheMu.RLock()
-	cached, exists := s.cache[cacheKey]
-	s.cacheMu.RUnlock()
+// matchAlias returns the alias whose normalized title appears in the release
+// title, preferring the longest match. Falls back to the alias that was queried.
+func matchAlias(releaseTitle string, aliases []model.TitleAlias, queried string) string {
+	normalized := " " + indexer.NormalizeTitle(releaseTitle) + " "
+	best := ""
+	bestLen := 0
+	for _, a := range aliases {
+		n := indexer.NormalizeTitle(a.Title)
+		if n != "" && len(n) > bestLen && strings.Contains(normalized, " "+n+" ") {
+			best, bestLen = a.Title, len(n)
+		}
+	}
+	if best == "" {
+		return queried
+	}
+	return best
+}
 
-	if !exists {
-		return model.EvaluationTrace{}, ErrCandidateNotFound
+// mergeSourceReports combines per-alias search reports into one entry per
+// source. Queries run concurrently, so the slowest duration is reported.
+func mergeSourceReports(reports []indexer.SearchReport) []model.SearchSourceReport {
+	var out []model.SearchSourceReport
+	index := make(map[string]int)
+	for _, report := range reports {
+		for _, src := range report.Sources {
+			i, ok := index[src.Name]
+			if !ok {
+				i = len(out)
+				index[src.Name] = i
+				out = append(out, model.SearchSourceReport{Name: src.Name})
+			}
+			if ms := src.Duration.Milliseconds(); ms > out[i].DurationMs {
+				out[i].DurationMs = ms
+			}
+			out[i].ResultCount += src.ResultCount
+			if src.Err != nil && out[i].Error == "" {
+				out[i].Error = src.Err.Error()
+			}
+		}
 	}
+	return out
+}
 
-	// Check if expired
-	if time.Now().After(cached.expiresAt) {
-		s.cacheMu.Lock()
-		delete(s.cache, cacheKey)
-		s.cacheMu.Unlock()
-		return model.EvaluationTrace{}, ErrCandidateExpired
+// search runs the query against the configured source, collecting per-source
+// details when the source supports them.
+func (s *DownloadCandidatesService) search(ctx context.Context, query indexer.SearchQuery) (indexer.SearchReport, error) {
+	if rs, ok := s.source.(indexer.ReportingSource); ok {
+		return rs.SearchWithReport(ctx, query)
 	}
 
-	// Transform to DownloadCandidate
-	candidate := searchResultToCandidate(cached.result)
+	start := time.Now()
+	results, err := s.source.Search(ctx, query)
+	if err != nil {
+		return indexer.SearchReport{}, err
+	}
+	return indexer.SearchReport{
+		Results: results,
+		Sources: []indexer.SourceReport{{
+			Name:        "default",
+			Duration:    time.Since(start),
+			ResultCount: len(results),
+		}},
+	}, nil
+}
+
+// EvaluateCandidate returns the evaluation trace for a candidate
+func (s *DownloadCandidatesService) EvaluateCandidate(ctx context.Context, movieID int64, sessionID string, indexerID int64, guid string) (model.EvaluationTrace, error) {
+	candidate, err := s.lookupCandidate(ctx, sessionID, indexerID, guid)
+	if err != nil {
+		return model.EvaluationTrace{}, err
+	}
 
 	// Build evaluation context with media info
-	evalCtx := s.buildMovieEvaluationContext(ctx, candidate, movieID)
+	evalCtx := s.buildMovieEvaluationContext(ctx, candidate, movieID, s.health.ReliabilityLookup(ctx))
 
 	trace, err := s.policyEngine.Evaluate(ctx, evalCtx)
 	if err != nil {
@@ -178,34 +353,22 @@ func (s *DownloadCandidatesService) EvaluateCandidate(ctx context.Context, movie
 }
 
 // PreviewCandidate previews what will happen when a candidate is enqueued.
-func (s *DownloadCandidatesService) PreviewCandidate(ctx context.Context, movieID int64, indexerID int64, guid string) (model.EvaluationTrace, error) {
-	return s.EvaluateCandidate(ctx, movieID, indexerID, guid)
+func (s *DownloadCandidatesService) PreviewCandidate(ctx context.Context, movieID int64, sessionID string, indexerID int64, guid string) (model.EvaluationTrace, error) {
+	return s.EvaluateCandidate(ctx, movieID, sessionID, indexerID, guid)
 }
 
 // EnqueueCandidate creates a durable download job for a candidate (movies-only).
-func (s *DownloadCandidatesService) EnqueueCandidate(ctx context.Context, movieID int64, indexerID int64, guid string) (model.EvaluationTrace, dbgen.DownloadJob, error) {
-	// Lookup candidate from cache
-	cacheKey := s.cacheKey(indexerID, guid)
-	s.cacheMu.RLock()
-	cached, exists := s.cache[cacheKey]
-	s.cacheMu.RUnlock()
-
-	if !exists {
-		return model.EvaluationTrace{}, dbgen.DownloadJob{}, ErrCandidateNotFound
+func (s *DownloadCandidatesService) EnqueueCandidate(ctx context.Context, movieID int64, sessionID string, indexerID int64, guid string) (model.EvaluationTrace, dbgen.DownloadJob, error) {
+	candidate, err := s.lookupCandidate(ctx, sessionID, indexerID, guid)
+	if err != nil {
+		return model.EvaluationTrace{}, dbgen.DownloadJob{}, err
 	}
-
-	// Check if expired
-	if time.Now().After(cached.expiresAt) {
-		s.cacheMu.Lock()
-		delete(s.cache, cacheKey)
-		s.cacheMu.Unlock()
-		return model.EvaluationTrace{}, dbgen.DownloadJob{}, ErrCandidateExpired
+	if candidate.Blocklisted {
+		return model.EvaluationTrace{}, dbgen.DownloadJob{}, ErrCandidateBlocklisted
 	}
 
-	candidate := searchResultToCandidate(cached.result)
-
 	// Build evaluation context with media info
-	evalCtx := s.buildMovieEvaluationContext(ctx, candidate, movieID)
+	evalCtx := s.buildMovieEvaluationContext(ctx, candidate, movieID, s.health.ReliabilityLookup(ctx))
 
 	trace, err := s.policyEngine.Evaluate(ctx, evalCtx)
 	if err != nil {
@@ -225,28 +388,9 @@ func (s *DownloadCandidatesService) EnqueueCandidate(ctx context.Context, movieI
 	}
 
 	// Ensure media_item exists for this movie/library and link the job to it.
-	mi, err := s.repo.GetMediaItemByTmdbID(ctx, movieID)
+	mi, err := s.media.EnsureMediaItem(ctx, model.MediaTypeMovie, movieID)
 	if err != nil {
-		if errors.Is(err, pgx.ErrNoRows) {
-			movie, err := s.media.GetMovie(ctx, movieID)
-			if err != nil {
-				return trace, dbgen.DownloadJob{}, fmt.Errorf("get movie: %w", err)
-			}
-			var yearInt *int32
-			if len(movie.ReleaseDate) >= 4 {
-				if y, err := strconv.Atoi(movie.ReleaseDate[:4]); err == nil {
-					yy := int32(y)
-					yearInt = &yy
-				}
-			}
-			tmdb := movieID
-			mi, err = s.repo.CreateMediaItem(ctx, "movie", movie.Title, yearInt, &tmdb)
-			if err != nil {
-				return trace, dbgen.DownloadJob{}, fmt.Errorf("create media item: %w", err)
-			}
-		} else {
-			return trace, dbgen.DownloadJob{}, fmt.Errorf("get media item: %w", err)
-		}
+		return trace, dbgen.DownloadJob{}, err
 	}
 
 	job, err := s.repo.CreateDownloadJob(ctx, dbgen.CreateDownloadJobParams{
@@ -270,29 +414,17 @@ func (s *DownloadCandidatesService) EnqueueCandidate(ctx context.Context, movieI
 }
 
 // EnqueueSeriesCandidate creates a durable download job for a series candidate.
-func (s *DownloadCandidatesService) EnqueueSeriesCandidate(ctx context.Context, seriesID int64, indexerID int64, guid string, seasonNumber *int, episodeNumber *int) (model.EvaluationTrace, dbgen.DownloadJob, error) {
-	// Lookup candidate from cache
-	cacheKey := s.cacheKey(indexerID, guid)
-	s.cacheMu.RLock()
-	cached, exists := s.cache[cacheKey]
-	s.cacheMu.RUnlock()
-
-	if !exists {
-		return model.EvaluationTrace{}, dbgen.DownloadJob{}, ErrCandidateNotFound
+func (s *DownloadCandidatesService) EnqueueSeriesCandidate(ctx context.Context, seriesID int64, sessionID string, indexerID int64, guid string, seasonNumber *int, episodeNumber *int) (model.EvaluationTrace, dbgen.DownloadJob, error) {
+	candidate, err := s.lookupCandidate(ctx, sessionID, indexerID, guid)
+	if err != nil {
+		return model.EvaluationTrace{}, dbgen.DownloadJob{}, err
 	}
-
-	// Check if expired
-	if time.Now().After(cached.expiresAt) {
-		s.cacheMu.Lock()
-		delete(s.cache, cacheKey)
-		s.cacheMu.Unlock()
-		return model.EvaluationTrace{}, dbgen.DownloadJob{}, ErrCandidateExpired
+	if candidate.Blocklisted {
+		return model.EvaluationTrace{}, dbgen.DownloadJob{}, ErrCandidateBlocklisted
 	}
 
-	candidate := searchResultToCandidate(cached.result)
-
 	// Build evaluation context with media info
-	evalCtx := s.buildSeriesEvaluationContext(ctx, candidate, seriesID, seasonNumber, episodeNumber)
+	evalCtx := s.buildSeriesEvaluationContext(ctx, candidate, seriesID, seasonNumber, episodeNumber, s.health.ReliabilityLookup(ctx))
 
 	trace, err := s.policyEngine.Evaluate(ctx, evalCtx)
 	if err != nil {
@@ -312,27 +444,15 @@ func (s *DownloadCandidatesService) EnqueueSeriesCandidate(ctx context.Context,
 	}
 
 	// Ensure media_item exists for this series and link the job to it.
-	mi, err := s.repo.GetMediaItemByTmdbIDAndType(ctx, seriesID, string(model.MediaTypeSeries))
+	mi, err := s.media.EnsureMediaItem(ctx, model.MediaTypeSeries, seriesID)
 	if err != nil {
-		if errors.Is(err, pgx.ErrNoRows) {
-			series, err := s.media.GetSeries(ctx, seriesID)
-			if err != nil {
-				return trace, dbgen.DownloadJob{}, fmt.Errorf("get series: %w", err)
-			}
-			var yearInt *int32
-			if len(series.FirstAirDate) >= 4 {
-				if y, err := strconv.Atoi(series.FirstAirDate[:4]); err == nil {
-					yy := int32(y)
-					yearInt = &yy
-				}
-			}
-			tmdb := seriesID
-			mi, err = s.repo.CreateMediaItem(ctx, "series", series.Title, yearInt, &tmdb)
-			if err != nil {
-				return trace, dbgen.DownloadJob{}, fmt.Errorf("create media item: %w", err)
-			}
-		} else {
-			return trace, dbgen.DownloadJob{}, fmt.Errorf("get media item: %w", err)
+		return trace, dbgen.DownloadJob{}, err
+	}
+
+	// Store absolute numbering so the workers can place fansub files
+	if s.media.IsAnime(ctx, seriesID) {
+		if _, err := s.media.SyncAbsoluteEpisodeNumbers(ctx, mi.ID, seriesID); err != nil {
+			s.logger.Warn().Err(err).Int64("tmdb_id", seriesID).Msg("Failed to store absolute episode numbers")
 		}
 	}
 
@@ -408,31 +528,24 @@ func searchResultToCandidate(result indexer.SearchResult) model.DownloadCandidat
 		Categories:  result.Categories,
 		PublishDate: result.PublishDate,
 		Title:       result.Title,
+		InfoHash:    result.InfoHash,
+
+		IndexerPriority: result.IndexerPriority,
 	}
 }
 
-// cacheKey generates a cache key from indexer ID and GUID
-func (s *DownloadCandidatesService) cacheKey(indexerID int64, guid string) string {
+// resultKey identifies a release by indexer ID and GUID
+func resultKey(indexerID int64, guid string) string {
 	return fmt.Sprintf("%d:%s", indexerID, guid)
 }
 
-// cleanExpiredCache removes expired entries from the cache
-func (s *DownloadCandidatesService) cleanExpiredCache() {
-	now := time.Now()
-	s.cacheMu.Lock()
-	defer s.cacheMu.Unlock()
-
-	for key, cached := range s.cache {
-		if now.After(cached.expiresAt) {
-			delete(s.cache, key)
-		}
-	}
-}
-
-// buildMovieEvaluationContext creates an EvaluationContext for a movie candidate
-func (s *DownloadCandidatesService) buildMovieEvaluationContext(ctx context.Context, candidate model.DownloadCandidate, movieID int64) model.EvaluationContext {
+// buildMovieEvaluationContext creates an EvaluationContext for a movie candidate.
+// reliability comes from IndexerHealthService.ReliabilityLookup, loaded once
+// for all candidates of a search.
+func (s *DownloadCandidatesService) buildMovieEvaluationContext(ctx context.Context, candidate model.DownloadCandidate, movieID int64, reliability func(indexerID int64) model.IndexerReliability) model.EvaluationContext {
 	q := release.Parse(candidate.Title)
 	evalCtx := model.NewEvaluationContext(candidate, q)
+	evalCtx = evalCtx.WithIndexerReliability(reliability(candidate.IndexerID))
 
 	// Try to get movie details from TMDB to populate media fields
 	movie, err := s.media.GetMovie(ctx, movieID)
@@ -446,13 +559,18 @@ func (s *DownloadCandidatesService) buildMovieEvaluationContext(ctx context.Cont
 		evalCtx = evalCtx.WithMedia(model.MediaTypeMovie, movie.Title, year, movieID)
 	}
 
+	if check := s.profiles.checkFor(ctx, model.MediaTypeMovie, movieID, nil, nil, movie.Runtime); check != nil {
+		evalCtx = evalCtx.WithProfile(check.fields(candidate))
+	}
+
 	return evalCtx
 }
 
 // buildSeriesEvaluationContext creates an EvaluationContext for a series candidate
-func (s *DownloadCandidatesService) buildSeriesEvaluationContext(ctx context.Context, candidate model.DownloadCandidate, seriesID int64, seasonNumber *int, episodeNumber *int) model.EvaluationContext {
+func (s *DownloadCandidatesService) buildSeriesEvaluationContext(ctx context.Context, candidate model.DownloadCandidate, seriesID int64, seasonNumber *int, episodeNumber *int, reliability func(indexerID int64) model.IndexerReliability) model.EvaluationContext {
 	q := release.Parse(candidate.Title)
 	evalCtx := model.NewEvaluationContext(candidate, q)
+	evalCtx = evalCtx.WithIndexerReliability(reliability(candidate.IndexerID))
 
 	// Try to get series details from TMDB to populate media fields
 	series, err := s.media.GetSeries(ctx, seriesID)
@@ -477,5 +595,35 @@ func (s *DownloadCandidatesService) buildSeriesEvaluationContext(ctx context.Con
 	}
 	evalCtx = evalCtx.WithSeriesInfo(seasonNumber, episodeNumber, episodeTitle)
 
+	runtime := s.seriesRuntime(ctx, seriesID, seasonNumber, episodeNumber)
+	if check := s.profiles.checkFor(ctx, model.MediaTypeSeries, seriesID, seasonNumber, episodeNumber, runtime); check != nil {
+		evalCtx = evalCtx.WithProfile(check.fields(candidate))
+	}
+
 	return evalCtx
 }
+
+// seriesRuntime returns the expected runtime in minutes of an episode, or of
+// a whole season for a season pack. It returns zero for a series pack or
+// when TMDB has no runtime, which skips the profile's size limits.
+func (s *DownloadCandidatesService) seriesRuntime(ctx context.Context, seriesID int64, season, episode *int) int {
+	if season == nil {
+		return 0
+	}
+	details, err := s.media.tmdb.GetSeriesDetails(ctx, seriesID)
+	if err != nil {
+		return 0
+	}
+	runtime := extractEpisodeRuntime(details.EpisodeRunTime)
+	if runtime == nil {
+		return 0
+	}
+	if episode != nil {
+		return *runtime
+	}
+	seasonDetails, err := s.media.tmdb.GetTVSeasonDetails(ctx, seriesID, *season)
+	if err != nil {
+		return 0
+	}
+	return *runtime * len(seasonDetails.Episodes)
+}
@@ -3,6 +3,7 @@ package service
 import (
 	"context"
 
+	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/kyleaupton/arrflix/internal/feed"
 	"github.com/kyleaupton/arrflix/internal/logger"
 	"github.com/kyleaupton/arrflix/internal/model"
@@ -31,6 +32,7 @@ func NewFeedService(r *repo.Repository, l *logger.Logger, tmdb *TmdbService) *Fe
 	}
 }
 
-func (s *FeedService) GetFeed(ctx context.Context) (*model.Feed, error) {
-	return s.composer.BuildFeed(ctx)
+// GetFeed builds the home feed, with the user's request statuses on its titles
+func (s *FeedService) GetFeed(ctx context.Context, userID pgtype.UUID) (*model.Feed, error) {
+	return s.composer.BuildFeed(ctx, userID)
 }
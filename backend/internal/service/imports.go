@@ -15,6 +15,7 @@ import (
 	"github.com/kyleaupton/arrflix/internal/logger"
 	"github.com/kyleaupton/arrflix/internal/mediainfo"
 	"github.com/kyleaupton/arrflix/internal/model"
+	"github.com/kyleaupton/arrflix/internal/qualityprofile"
 	"github.com/kyleaupton/arrflix/internal/release"
 	"github.com/kyleaupton/arrflix/internal/repo"
 	"github.com/kyleaupton/arrflix/internal/template"
@@ -131,7 +132,7 @@ func (s *ImportService) ImportMovieFile(ctx context.Context, job dbgen.DownloadJ
 	mf, err := s.repo.GetMediaFileByLibraryAndPath(ctx, lib.ID, destRel)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
-			mf, err = s.repo.CreateMediaFile(ctx, lib.ID, mediaItem.ID, nil, destRel)
+			mf, err = s.repo.CreateMediaFile(ctx, lib.ID, mediaItem.ID, nil, destRel, qualityprofile.FileQuality(job.CandidateTitle, sourcePath))
 			if err != nil {
 				return ImportResult{}, fmt.Errorf("create media file: %w", err)
 			}
@@ -210,7 +211,8 @@ func (s *ImportService) ImportSeriesJob(ctx context.Context, job dbgen.DownloadJ
 		}
 	}
 
-	matchedFiles := importer.MatchFilesToEpisodes(files, targetSeason, targetEpisode)
+	abs := s.absoluteEpisodeMap(ctx, mediaItem.ID)
+	matchedFiles := importer.MatchFilesToEpisodes(files, targetSeason, targetEpisode, abs)
 	s.log.Debug().
 		Int("matched_count", len(matchedFiles)).
 		Interface("matched_files", matchedFiles).
@@ -240,14 +242,14 @@ func (s *ImportService) ImportSeriesJob(ctx context.Context, job dbgen.DownloadJ
 			}
 		} else {
 			// If we don't know the season, we might need to parse it from the file
-			info, ok := importer.ParseSeriesInfo(filepath.Base(f.Path))
+			parsedSeason, ok := seasonForEpisode(importer.ResolveSeriesEpisodes(filepath.Base(f.Path), abs), epNum)
 			if !ok {
 				s.log.Warn().Str("file", f.Path).Msg("Failed to parse series info from filename")
 				continue
 			}
-			season, err = s.repo.UpsertSeason(ctx, mediaItem.ID, int32(info.Season), pgtype.Date{Valid: false})
+			season, err = s.repo.UpsertSeason(ctx, mediaItem.ID, int32(parsedSeason), pgtype.Date{Valid: false})
 			if err != nil {
-				s.log.Error().Err(err).Int("season", info.Season).Msg("Failed to upsert parsed season")
+				s.log.Error().Err(err).Int("season", parsedSeason).Msg("Failed to upsert parsed season")
 				continue
 			}
 		}
@@ -319,7 +321,7 @@ func (s *ImportService) ImportSeriesJob(ctx context.Context, job dbgen.DownloadJ
 			continue
 		}
 
-		mf, err := s.repo.CreateMediaFile(ctx, lib.ID, mediaItem.ID, &episode.ID, destRel)
+		mf, err := s.repo.CreateMediaFile(ctx, lib.ID, mediaItem.ID, &episode.ID, destRel, qualityprofile.FileQuality(job.CandidateTitle, sourcePath))
 		if err != nil {
 			s.log.Error().Err(err).Str("path", destRel).Msg("Failed to create media file record")
 			continue
@@ -406,3 +408,23 @@ func (s *ImportService) buildSeriesEvalContext(mediaItem dbgen.MediaItem, candid
 
 	return evalCtx
 }
+
+// absoluteEpisodeMap loads the absolute episode numbering stored for a series.
+func (s *ImportService) absoluteEpisodeMap(ctx context.Context, mediaItemID pgtype.UUID) importer.AbsoluteEpisodeMap {
+	rows, err := s.repo.ListAbsoluteEpisodeNumbers(ctx, mediaItemID)
+	if err != nil {
+		s.log.Warn().Err(err).Msg("Failed to load absolute episode numbers")
+		return nil
+	}
+	return importer.AbsoluteEpisodeMapFromRows(rows)
+}
+
+// seasonForEpisode returns the season a parsed file places an episode in.
+func seasonForEpisode(episodes []importer.EpisodeNumber, episode int) (int, bool) {
+	for _, ep := range episodes {
+		if ep.Episode == episode {
+			return ep.Season, true
+		}
+	}
+	return 0, false
+}
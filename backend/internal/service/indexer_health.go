@@ -0,0 +1,285 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	dbgen "github.com/kyleaupton/arrflix/internal/db/sqlc"
+	"github.com/kyleaupton/arrflix/internal/indexer"
+	"github.com/kyleaupton/arrflix/internal/logger"
+	"github.com/kyleaupton/arrflix/internal/model"
+	"github.com/kyleaupton/arrflix/internal/repo"
+)
+
+var ErrIndexerHealthNotFound = errors.New("no search history for indexer")
+
+const (
+	// indexerStatsWindow is the period reliability stats are computed over.
+	indexerStatsWindow = 7 * 24 * time.Hour
+	// indexerEventRetention is how long per-search events are kept.
+	indexerEventRetention = 30 * 24 * time.Hour
+	// indexerHistoryLimit caps the history returned for a single indexer.
+	indexerHistoryLimit = 50
+)
+
+// IndexerHealthService records per-indexer search outcomes and temporarily
+// disables indexers that keep failing.
+type IndexerHealthService struct {
+	repo   *repo.Repository
+	logger *logger.Logger
+	source indexer.IndexerSource
+}
+
+// NewIndexerHealthService creates a new indexer health service
+func NewIndexerHealthService(r *repo.Repository, l *logger.Logger, source indexer.IndexerSource) *IndexerHealthService {
+	return &IndexerHealthService{repo: r, logger: l, source: source}
+}
+
+// Record stores the outcome of a search for each indexer involved and applies
+// exponential backoff to indexers that have failed repeatedly.
+func (s *IndexerHealthService) Record(ctx context.Context, query string, reports []indexer.IndexerReport) {
+	for _, r := range reports {
+		var errMsg *string
+		if r.Err != nil {
+			msg := r.Err.Error()
+			errMsg = &msg
+		}
+
+		if err := s.repo.CreateIndexerSearchEvent(ctx, dbgen.CreateIndexerSearchEventParams{
+			IndexerID:   r.IndexerID,
+			Query:       query,
+			DurationMs:  int32(r.Duration.Milliseconds()),
+			ResultCount: int32(r.ResultCount),
+			Error:       errMsg,
+		}); err != nil {
+			s.logger.Warn().Err(err).Int64("indexer_id", r.IndexerID).Msg("Failed to record indexer search event")
+		}
+
+		if r.Err == nil {
+			if _, err := s.repo.RecordIndexerSuccess(ctx, r.IndexerID, r.IndexerName); err != nil {
+				s.logger.Warn().Err(err).Int64("indexer_id", r.IndexerID).Msg("Failed to record indexer success")
+			}
+			continue
+		}
+
+		health, err := s.repo.RecordIndexerFailure(ctx, r.IndexerID, r.IndexerName, errMsg)
+		if err != nil {
+			s.logger.Warn().Err(err).Int64("indexer_id", r.IndexerID).Msg("Failed to record indexer failure")
+			continue
+		}
+
+		if backoff := indexer.Backoff(int(health.ConsecutiveFailures)); backoff > 0 {
+			until := time.Now().Add(backoff)
+			if err := s.repo.SetIndexerDisabledUntil(ctx, r.IndexerID, pgtype.Timestamptz{Time: until, Valid: true}); err != nil {
+				s.logger.Warn().Err(err).Int64("indexer_id", r.IndexerID).Msg("Failed to disable indexer")
+				continue
+			}
+			s.logger.Warn().
+				Int64("indexer_id", r.IndexerID).
+				Str("indexer", r.IndexerName).
+				Int32("consecutive_failures", health.ConsecutiveFailures).
+				Time("disabled_until", until).
+				Msg("Indexer temporarily disabled after repeated failures")
+		}
+	}
+
+	if err := s.repo.DeleteIndexerSearchEventsBefore(ctx, time.Now().Add(-indexerEventRetention)); err != nil {
+		s.logger.Warn().Err(err).Msg("Failed to prune indexer search events")
+	}
+}
+
+// DisabledIndexerIDs returns the indexers currently backed off.
+func (s *IndexerHealthService) DisabledIndexerIDs(ctx context.Context) []int64 {
+	ids, err := s.repo.ListDisabledIndexerIDs(ctx)
+	if err != nil {
+		s.logger.Warn().Err(err).Msg("Failed to list disabled indexers")
+		return nil
+	}
+	return ids
+}
+
+// Reliability returns recent reliability stats keyed by indexer ID.
+func (s *IndexerHealthService) Reliability(ctx context.Context) (map[int64]model.IndexerReliability, error) {
+	stats, err := s.repo.ListIndexerSearchStats(ctx, time.Now().Add(-indexerStatsWindow))
+	if err != nil {
+		return nil, err
+	}
+	health, err := s.repo.ListIndexerHealth(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make(map[int64]model.IndexerReliability, len(stats))
+	for _, st := range stats {
+		out[st.IndexerID] = reliabilityFromStats(st.Searches, st.Failures, st.AvgDurationMs)
+	}
+	for _, h := range health {
+		r, ok := out[h.IndexerID]
+		if !ok {
+			r = reliabilityFromStats(0, 0, 0)
+		}
+		r.ConsecutiveFailures = int(h.ConsecutiveFailures)
+		out[h.IndexerID] = r
+	}
+	return out, nil
+}
+
+// ReliabilityLookup loads the reliability stats once and returns a lookup by
+// indexer, so the candidates of a search don't each aggregate them again.
+// Indexers without history, or every indexer if the stats can't be loaded,
+// get neutral stats.
+func (s *IndexerHealthService) ReliabilityLookup(ctx context.Context) func(indexerID int64) model.IndexerReliability {
+	all, err := s.Reliability(ctx)
+	if err != nil {
+		s.logger.Warn().Err(err).Msg("Failed to load indexer reliability")
+	}
+	return func(indexerID int64) model.IndexerReliability {
+		if r, ok := all[indexerID]; ok {
+			return r
+		}
+		return reliabilityFromStats(0, 0, 0)
+	}
+}
+
+// ListStatus returns the health of every configured indexer, plus any
+// indexer with recorded history that is no longer configured.
+func (s *IndexerHealthService) ListStatus(ctx context.Context) ([]model.IndexerStatus, error) {
+	health, err := s.repo.ListIndexerHealth(ctx)
+	if err != nil {
+		return nil, err
+	}
+	reliability, err := s.Reliability(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	byID := make(map[int64]dbgen.IndexerHealth, len(health))
+	for _, h := range health {
+		byID[h.IndexerID] = h
+	}
+
+	var out []model.IndexerStatus
+	seen := make(map[int64]bool)
+
+	infos, err := s.source.ListIndexers(ctx)
+	if err != nil {
+		s.logger.Warn().Err(err).Msg("Failed to list indexers, reporting recorded history only")
+	}
+	for _, info := range infos {
+		status := model.IndexerStatus{
+			IndexerID: info.ID,
+			Name:      info.Name,
+			Enabled:   info.Enabled,
+			Priority:  info.Priority,
+		}
+		if h, ok := byID[info.ID]; ok {
+			applyIndexerHealth(&status, h)
+		}
+		status.IndexerReliability = reliabilityOrDefault(reliability, info.ID)
+		out = append(out, status)
+		seen[info.ID] = true
+	}
+
+	for _, h := range health {
+		if seen[h.IndexerID] {
+			continue
+		}
+		status := model.IndexerStatus{IndexerID: h.IndexerID, Name: h.IndexerName}
+		applyIndexerHealth(&status, h)
+		status.IndexerReliability = reliabilityOrDefault(reliability, h.IndexerID)
+		out = append(out, status)
+	}
+
+	sort.SliceStable(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out, nil
+}
+
+// GetStatus returns an indexer's health along with its recent search history.
+func (s *IndexerHealthService) GetStatus(ctx context.Context, indexerID int64) (model.IndexerStatusDetail, error) {
+	statuses, err := s.ListStatus(ctx)
+	if err != nil {
+		return model.IndexerStatusDetail{}, err
+	}
+
+	var detail model.IndexerStatusDetail
+	found := false
+	for _, st := range statuses {
+		if st.IndexerID == indexerID {
+			detail.IndexerStatus = st
+			found = true
+			break
+		}
+	}
+	if !found {
+		return model.IndexerStatusDetail{}, ErrIndexerHealthNotFound
+	}
+
+	events, err := s.repo.ListIndexerSearchEvents(ctx, indexerID, indexerHistoryLimit)
+	if err != nil {
+		return model.IndexerStatusDetail{}, err
+	}
+	detail.History = make([]model.IndexerSearchEvent, 0, len(events))
+	for _, e := range events {
+		detail.History = append(detail.History, model.IndexerSearchEvent{
+			Query:       e.Query,
+			DurationMs:  int64(e.DurationMs),
+			ResultCount: int(e.ResultCount),
+			Error:       coalesce(e.Error, ""),
+			CreatedAt:   e.CreatedAt,
+		})
+	}
+
+	return detail, nil
+}
+
+// Reset clears an indexer's failure count and re-enables it immediately.
+func (s *IndexerHealthService) Reset(ctx context.Context, indexerID int64) error {
+	_, err := s.repo.ResetIndexerHealth(ctx, indexerID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ErrIndexerHealthNotFound
+	}
+	return err
+}
+
+func applyIndexerHealth(status *model.IndexerStatus, h dbgen.IndexerHealth) {
+	if status.Name == "" {
+		status.Name = h.IndexerName
+	}
+	status.LastSuccessAt = timestamptzPtr(h.LastSuccessAt)
+	status.LastFailureAt = timestamptzPtr(h.LastFailureAt)
+	status.LastError = coalesce(h.LastError, "")
+	if h.DisabledUntil.Valid && h.DisabledUntil.Time.After(time.Now()) {
+		status.DisabledUntil = &h.DisabledUntil.Time
+	}
+}
+
+func reliabilityOrDefault(all map[int64]model.IndexerReliability, indexerID int64) model.IndexerReliability {
+	if r, ok := all[indexerID]; ok {
+		return r
+	}
+	return reliabilityFromStats(0, 0, 0)
+}
+
+func reliabilityFromStats(searches, failures, avgDurationMs int64) model.IndexerReliability {
+	rate := 100.0
+	if searches > 0 {
+		rate = float64(searches-failures) / float64(searches) * 100
+	}
+	return model.IndexerReliability{
+		Searches:     searches,
+		Failures:     failures,
+		SuccessRate:  rate,
+		AvgLatencyMs: avgDurationMs,
+	}
+}
+
+func timestamptzPtr(t pgtype.Timestamptz) *time.Time {
+	if !t.Valid {
+		return nil
+	}
+	return &t.Time
+}
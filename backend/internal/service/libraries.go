@@ -3,10 +3,12 @@ package service
 import (
 	"context"
 	"errors"
+	"fmt"
 	"os"
 
 	"github.com/jackc/pgx/v5/pgtype"
 	dbgen "github.com/kyleaupton/arrflix/internal/db/sqlc"
+	"github.com/kyleaupton/arrflix/internal/importer"
 	"github.com/kyleaupton/arrflix/internal/repo"
 )
 
@@ -30,36 +32,86 @@ func (s *LibrariesService) GetDefault(ctx context.Context, typ string) (dbgen.Li
 	return s.repo.GetDefaultLibrary(ctx, typ)
 }
 
-func (s *LibrariesService) Create(ctx context.Context, name, typ, rootPath string, enabled bool, isDefault bool) (dbgen.Library, error) {
-	if name == "" {
-		return dbgen.Library{}, errors.New("name required")
-	}
-	if typ != "movie" && typ != "series" {
-		return dbgen.Library{}, errors.New("type must be 'movie' or 'series'")
-	}
-	if rootPath == "" {
-		return dbgen.Library{}, errors.New("root_path required")
+// LibraryParams are the settings of a library.
+type LibraryParams struct {
+	Name             string
+	Type             string
+	RootPath         string
+	Enabled          bool
+	Default          bool
+	QualityProfileID pgtype.UUID
+	ImportMode       string
+
+	// ImportExtraFiles imports the subtitles, NFOs and artwork that come
+	// with a video; ExtraFileExtensions whitelists them (nil for the default).
+	ImportExtraFiles    bool
+	ExtraFileExtensions []string
+}
+
+func (s *LibrariesService) Create(ctx context.Context, p LibraryParams) (dbgen.Library, error) {
+	if err := p.validate(); err != nil {
+		return dbgen.Library{}, err
 	}
-	if _, err := os.Stat(rootPath); err != nil {
-		return dbgen.Library{}, errors.New("root_path not found on server")
+	return s.repo.CreateLibrary(ctx, dbgen.CreateLibraryParams{
+		Name:                p.Name,
+		Type:                p.Type,
+		RootPath:            p.RootPath,
+		Enabled:             p.Enabled,
+		IsDefault:           p.Default,
+		QualityProfileID:    p.QualityProfileID,
+		ImportMode:          p.ImportMode,
+		ImportExtraFiles:    p.ImportExtraFiles,
+		ExtraFileExtensions: p.ExtraFileExtensions,
+	})
+}
+
+func (s *LibrariesService) Update(ctx context.Context, id pgtype.UUID, p LibraryParams) (dbgen.Library, error) {
+	if err := p.validate(); err != nil {
+		return dbgen.Library{}, err
 	}
-	return s.repo.CreateLibrary(ctx, name, typ, rootPath, enabled, isDefault)
+	return s.repo.UpdateLibrary(ctx, dbgen.UpdateLibraryParams{
+		ID:                  id,
+		Name:                p.Name,
+		Type:                p.Type,
+		RootPath:            p.RootPath,
+		Enabled:             p.Enabled,
+		IsDefault:           p.Default,
+		QualityProfileID:    p.QualityProfileID,
+		ImportMode:          p.ImportMode,
+		ImportExtraFiles:    p.ImportExtraFiles,
+		ExtraFileExtensions: p.ExtraFileExtensions,
+	})
 }
 
-func (s *LibrariesService) Update(ctx context.Context, id pgtype.UUID, name, typ, rootPath string, enabled bool, isDefault bool) (dbgen.Library, error) {
-	if name == "" {
-		return dbgen.Library{}, errors.New("name required")
+// validate checks p and normalizes the import mode and extra extensions.
+func (p *LibraryParams) validate() error {
+	if p.Name == "" {
+		return errors.New("name required")
+	}
+	if p.Type != "movie" && p.Type != "series" {
+		return errors.New("type must be 'movie' or 'series'")
+	}
+	if p.RootPath == "" {
+		return errors.New("root_path required")
 	}
-	if typ != "movie" && typ != "series" {
-		return dbgen.Library{}, errors.New("type must be 'movie' or 'series'")
+	if _, err := os.Stat(p.RootPath); err != nil {
+		return errors.New("root_path not found on server")
 	}
-	if rootPath == "" {
-		return dbgen.Library{}, errors.New("root_path required")
+	mode, err := importer.ParseMode(p.ImportMode)
+	if err != nil {
+		return err
+	}
+	p.ImportMode = string(mode)
+
+	if p.ExtraFileExtensions == nil {
+		p.ExtraFileExtensions = importer.DefaultExtraExtensions
 	}
-	if _, err := os.Stat(rootPath); err != nil {
-		return dbgen.Library{}, errors.New("root_path not found on server")
+	exts, err := importer.NormalizeExtraExtensions(p.ExtraFileExtensions)
+	if err != nil {
+		return fmt.Errorf("extra_file_extensions: %w", err)
 	}
-	return s.repo.UpdateLibrary(ctx, id, name, typ, rootPath, enabled, isDefault)
+	p.ExtraFileExtensions = exts
+	return nil
 }
 
 func (s *LibrariesService) Delete(ctx context.Context, id pgtype.UUID) error {
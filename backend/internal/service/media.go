@@ -2,6 +2,8 @@ package service
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"math"
 	"sort"
 	"strconv"
@@ -9,6 +11,8 @@ import (
 	"time"
 
 	tmdb "github.com/cyruzin/golang-tmdb"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
 	dbgen "github.com/kyleaupton/arrflix/internal/db/sqlc"
 	"github.com/kyleaupton/arrflix/internal/logger"
 	"github.com/kyleaupton/arrflix/internal/model"
@@ -91,6 +95,7 @@ func (s *MediaService) ListLibraryItemsPaginated(ctx context.Context, params Lib
 
 	// Enrich with TMDB data concurrently
 	items := s.enrichLibraryItemsConcurrently(ctx, dbItems)
+	s.addMonitoringState(ctx, dbItems, items)
 
 	// Calculate total pages
 	totalPages := int(math.Ceil(float64(total) / float64(params.PageSize)))
@@ -147,6 +152,25 @@ func (s *MediaService) enrichLibraryItemsConcurrently(ctx context.Context, dbIte
 	return items
 }
 
+// addMonitoringState sets the monitored and missing state on library items
+func (s *MediaService) addMonitoringState(ctx context.Context, dbItems []dbgen.MediaItem, items []model.LibraryItem) {
+	ids := make([]pgtype.UUID, len(dbItems))
+	for i, item := range dbItems {
+		ids[i] = item.ID
+	}
+	counts, err := s.repo.ListMonitoringCounts(ctx, ids)
+	if err != nil {
+		s.logger.Warn().Err(err).Msg("Failed to count missing episodes for library items")
+	}
+
+	for i, item := range dbItems {
+		m := buildMonitoring(item, countsFor(counts, item.ID), nil)
+		items[i].Monitored = m.Monitored
+		items[i].Missing = m.Missing
+		items[i].MissingEpisodes = m.MissingEpisodes
+	}
+}
+
 // extractMovieCertification extracts US certification (fallback to GB, CA, AU)
 func extractMovieCertification(releaseDates *tmdb.MovieReleaseDates) string {
 	if releaseDates == nil || releaseDates.Results == nil {
@@ -407,6 +431,128 @@ func (s *MediaService) GetMovie(ctx context.Context, id int64) (model.Movie, err
 	}, nil
 }
 
+// EnsureMediaItem returns the media_item for a TMDB title, creating it from
+// TMDB details when it does not exist yet.
+func (s *MediaService) EnsureMediaItem(ctx context.Context, mediaType model.MediaType, tmdbID int64) (dbgen.MediaItem, error) {
+	mi, err := s.repo.GetMediaItemByTmdbIDAndType(ctx, tmdbID, string(mediaType))
+	if err == nil {
+		return mi, nil
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return dbgen.MediaItem{}, fmt.Errorf("get media item: %w", err)
+	}
+
+	var title string
+	var year *int32
+	switch mediaType {
+	case model.MediaTypeMovie:
+		movie, err := s.GetMovie(ctx, tmdbID)
+		if err != nil {
+			return dbgen.MediaItem{}, fmt.Errorf("get movie: %w", err)
+		}
+		title, year = movie.Title, parseYear(movie.ReleaseDate)
+	case model.MediaTypeSeries:
+		series, err := s.GetSeries(ctx, tmdbID)
+		if err != nil {
+			return dbgen.MediaItem{}, fmt.Errorf("get series: %w", err)
+		}
+		title, year = series.Title, parseYear(series.FirstAirDate)
+	default:
+		return dbgen.MediaItem{}, fmt.Errorf("unsupported media type %q", mediaType)
+	}
+
+	mi, err = s.repo.CreateMediaItem(ctx, string(mediaType), title, year, &tmdbID)
+	if err != nil {
+		return dbgen.MediaItem{}, fmt.Errorf("create media item: %w", err)
+	}
+
+	// Cache external IDs on the new media item for ID-based searches
+	if _, err := s.GetExternalIDs(ctx, mediaType, tmdbID); err != nil {
+		s.logger.Warn().Err(err).Int64("tmdb_id", tmdbID).Msg("Failed to cache external IDs on media item")
+	}
+
+	return mi, nil
+}
+
+// GetExternalIDs returns the IMDb/TVDB IDs for a title. IDs cached on the
+// media_item are used when present; otherwise they are fetched from TMDB and
+// stored on the media_item if one exists.
+func (s *MediaService) GetExternalIDs(ctx context.Context, mediaType model.MediaType, tmdbID int64) (model.ExternalIDs, error) {
+	ids := model.ExternalIDs{TmdbID: tmdbID}
+
+	mi, err := s.repo.GetMediaItemByTmdbIDAndType(ctx, tmdbID, string(mediaType))
+	hasItem := err == nil
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return ids, err
+	}
+	if hasItem && (mi.ImdbID != nil || mi.TvdbID != nil) {
+		if mi.ImdbID != nil {
+			ids.ImdbID = *mi.ImdbID
+		}
+		if mi.TvdbID != nil {
+			ids.TvdbID = *mi.TvdbID
+		}
+		return ids, nil
+	}
+
+	switch mediaType {
+	case model.MediaTypeMovie:
+		ext, err := s.tmdb.GetMovieExternalIDs(ctx, tmdbID)
+		if err != nil {
+			return ids, err
+		}
+		ids.ImdbID = ext.IMDbID
+	case model.MediaTypeSeries:
+		ext, err := s.tmdb.GetSeriesExternalIDs(ctx, tmdbID)
+		if err != nil {
+			return ids, err
+		}
+		ids.ImdbID = ext.IMDbID
+		ids.TvdbID = ext.TVDBID
+	}
+
+	if hasItem && (ids.ImdbID != "" || ids.TvdbID != 0) {
+		var imdbID *string
+		var tvdbID *int64
+		if ids.ImdbID != "" {
+			imdbID = &ids.ImdbID
+		}
+		if ids.TvdbID != 0 {
+			tvdbID = &ids.TvdbID
+		}
+		if _, err := s.repo.UpdateMediaItemExternalIDs(ctx, mi.ID, imdbID, tvdbID); err != nil {
+			s.logger.Warn().Err(err).Int64("tmdb_id", tmdbID).Msg("Failed to cache external IDs on media item")
+		}
+	}
+
+	return ids, nil
+}
+
+// Runtime returns the TMDB runtime in minutes of a movie, or of a single
+// episode of a series, falling back to the series' usual episode runtime.
+// It returns zero when TMDB has none.
+func (s *MediaService) Runtime(ctx context.Context, mediaType model.MediaType, tmdbID int64, season, episode int) int {
+	if mediaType == model.MediaTypeMovie {
+		details, err := s.tmdb.GetMovieDetails(ctx, tmdbID)
+		if err != nil {
+			return 0
+		}
+		return details.Runtime
+	}
+
+	if ep, err := s.tmdb.GetEpisodeDetails(ctx, tmdbID, int64(season), int64(episode)); err == nil && ep.Runtime > 0 {
+		return ep.Runtime
+	}
+	details, err := s.tmdb.GetSeriesDetails(ctx, tmdbID)
+	if err != nil {
+		return 0
+	}
+	if runtime := extractEpisodeRuntime(details.EpisodeRunTime); runtime != nil {
+		return *runtime
+	}
+	return 0
+}
+
 func (s *MediaService) GetMovieDetail(ctx context.Context, tmdbID int64) (model.MovieDetail, error) {
 	// Use extended fetch to get release dates and watch providers in one call
 	tmdbDetails, err := s.tmdb.GetMovieDetailsWithExtras(ctx, tmdbID)
@@ -427,6 +573,12 @@ func (s *MediaService) GetMovieDetail(ctx context.Context, tmdbID int64) (model.
 	}
 
 	fileInfos := buildFileInfos(files)
+	var monitoring model.Monitoring
+	if local {
+		if monitoring, err = loadMonitoring(ctx, s.repo, mediaItem); err != nil {
+			s.logger.Debug().Err(err).Int64("tmdb_id", tmdbID).Msg("Failed to load movie monitoring")
+		}
+	}
 	genres := make([]model.Genre, 0, len(tmdbDetails.Genres))
 	for _, g := range tmdbDetails.Genres {
 		genres = append(genres, model.Genre{TmdbID: g.ID, Name: g.Name})
@@ -493,6 +645,7 @@ func (s *MediaService) GetMovieDetail(ctx context.Context, tmdbID int64) (model.
 		PosterPath:      tmdbDetails.PosterPath,
 		BackdropPath:    tmdbDetails.BackdropPath,
 		Files:           fileInfos,
+		Monitoring:      monitoring,
 		Credits:         credits,
 		Videos:          videos,
 		Recommendations: recommendations,
@@ -582,6 +735,20 @@ func (s *MediaService) GetSeriesDetail(ctx context.Context, tmdbID int64) (model
 	}
 
 	fileInfos, availability := buildFileInfoAndAvailability(files)
+	monitoring := model.Monitoring{Seasons: []model.SeasonMonitoring{}}
+	var episodeMonitoring map[episodeKey]episodeState
+	seasonMonitored := make(map[int32]bool)
+	if local {
+		if monitoring, err = loadMonitoring(ctx, s.repo, mediaItem); err != nil {
+			s.logger.Debug().Err(err).Int64("tmdb_id", tmdbID).Msg("Failed to load series monitoring")
+		}
+		if rows, err := s.repo.ListEpisodeAvailabilityForSeries(ctx, mediaItem.ID); err == nil {
+			episodeMonitoring = episodeStates(mediaItem, rows)
+		}
+		for _, season := range monitoring.Seasons {
+			seasonMonitored[season.SeasonNumber] = season.Monitored
+		}
+	}
 	genres := make([]model.Genre, 0, len(tmdbDetails.Genres))
 	for _, g := range tmdbDetails.Genres {
 		genres = append(genres, model.Genre{TmdbID: g.ID, Name: g.Name})
@@ -625,6 +792,7 @@ func (s *MediaService) GetSeriesDetail(ctx context.Context, tmdbID int64) (model
 				Overview:     sInfo.Overview,
 				PosterPath:   sInfo.PosterPath,
 				AirDate:      sInfo.AirDate,
+				Monitored:    seasonMonitored[int32(sInfo.SeasonNumber)],
 			})
 			continue
 		}
@@ -644,6 +812,10 @@ func (s *MediaService) GetSeriesDetail(ctx context.Context, tmdbID int64) (model
 				ep.Available = true
 				ep.File = &f
 			}
+			if st, ok := episodeMonitoring[episodeKey{ep.SeasonNumber, ep.EpisodeNumber}]; ok {
+				ep.Monitored = st.monitored
+				ep.Missing = st.missing
+			}
 
 			eps = append(eps, ep)
 		}
@@ -653,6 +825,7 @@ func (s *MediaService) GetSeriesDetail(ctx context.Context, tmdbID int64) (model
 			Overview:     fullSeason.Overview,
 			PosterPath:   fullSeason.PosterPath,
 			AirDate:      fullSeason.AirDate,
+			Monitored:    seasonMonitored[int32(sInfo.SeasonNumber)],
 			Episodes:     eps,
 		})
 	}
@@ -708,6 +881,7 @@ func (s *MediaService) GetSeriesDetail(ctx context.Context, tmdbID int64) (model
 		PosterPath:     tmdbDetails.PosterPath,
 		BackdropPath:   tmdbDetails.BackdropPath,
 		Availability:   availability,
+		Monitoring:     monitoring,
 		Seasons:        seasons,
 		Credits:        credits,
 		Videos:         videos,
@@ -0,0 +1,669 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	dbgen "github.com/kyleaupton/arrflix/internal/db/sqlc"
+	"github.com/kyleaupton/arrflix/internal/logger"
+	"github.com/kyleaupton/arrflix/internal/model"
+	"github.com/kyleaupton/arrflix/internal/repo"
+)
+
+var (
+	ErrMediaRequestInvalid   = errors.New("invalid media request")
+	ErrMediaRequestForbidden = errors.New("not allowed")
+	ErrMediaRequestNotFound  = errors.New("media request not found")
+	ErrMediaRequestDuplicate = errors.New("an open request of yours already covers this title")
+	ErrMediaRequestAvailable = errors.New("everything requested is already available")
+	ErrMediaRequestLimit     = errors.New("too many open requests")
+	ErrMediaRequestQuota     = errors.New("request quota exceeded")
+	ErrMediaRequestDecided   = errors.New("media request has already been decided")
+)
+
+const (
+	permRequestsCreate  = "requests.create"
+	permRequestsApprove = "requests.approve"
+)
+
+// MediaRequestsService lets users request movies, series and seasons, and
+// users with requests.approve approve or decline them. Approval monitors the
+// title and queues a search; the request then follows the title's download
+// jobs and import tasks until everything requested is on disk. Request
+// quotas and the auto-approval rules of the requester's roles apply to new
+// requests.
+type MediaRequestsService struct {
+	repo       *repo.Repository
+	logger     *logger.Logger
+	settings   *SettingsService
+	tmdb       *TmdbService
+	monitoring *MonitoringService
+	autoSearch *AutoSearchService
+	profiles   *QualityProfilesService
+	rules      *RequestRulesService
+}
+
+// NewMediaRequestsService creates a new media requests service
+func NewMediaRequestsService(r *repo.Repository, l *logger.Logger, settings *SettingsService, tmdb *TmdbService, monitoring *MonitoringService, autoSearch *AutoSearchService, profiles *QualityProfilesService, rules *RequestRulesService) *MediaRequestsService {
+	return &MediaRequestsService{repo: r, logger: l, settings: settings, tmdb: tmdb, monitoring: monitoring, autoSearch: autoSearch, profiles: profiles, rules: rules}
+}
+
+// Create requests a movie, a whole series or some of its seasons. A user
+// can't request what is already available or covered by one of their open
+// requests, has at most requests.max_per_user open requests, and can't go
+// over their quota. The request is approved right away when one of the
+// user's request rules says so.
+func (s *MediaRequestsService) Create(ctx context.Context, userID pgtype.UUID, req model.CreateMediaRequest) (model.MediaRequest, error) {
+	if err := s.require(ctx, userID, permRequestsCreate); err != nil {
+		return model.MediaRequest{}, err
+	}
+	seasons, err := requestSeasons(req)
+	if err != nil {
+		return model.MediaRequest{}, err
+	}
+	profileID, err := s.requestProfile(ctx, req.QualityProfileID)
+	if err != nil {
+		return model.MediaRequest{}, err
+	}
+	title, err := s.lookup(ctx, req.MediaType, req.TmdbID, seasons)
+	if err != nil {
+		return model.MediaRequest{}, err
+	}
+
+	available, err := s.available(ctx, string(req.MediaType), req.TmdbID, seasons)
+	if err != nil {
+		return model.MediaRequest{}, err
+	}
+	if available {
+		return model.MediaRequest{}, ErrMediaRequestAvailable
+	}
+
+	open, err := s.repo.ListOpenMediaRequestsForTitle(ctx, userID, string(req.MediaType), req.TmdbID)
+	if err != nil {
+		return model.MediaRequest{}, fmt.Errorf("list open requests: %w", err)
+	}
+	for _, o := range open {
+		if seasonsOverlap(o.Seasons, seasons) {
+			return model.MediaRequest{}, ErrMediaRequestDuplicate
+		}
+	}
+
+	openCount, err := s.repo.CountOpenMediaRequestsForUser(ctx, userID)
+	if err != nil {
+		return model.MediaRequest{}, fmt.Errorf("count open requests: %w", err)
+	}
+	if limit := s.settings.GetInt(ctx, "requests.max_per_user"); limit > 0 && openCount >= limit {
+		return model.MediaRequest{}, fmt.Errorf("%w: at most %d can be open at once", ErrMediaRequestLimit, limit)
+	}
+
+	// A whole series counts every season but specials
+	var seasonCount int32
+	if req.MediaType == model.MediaTypeSeries {
+		seasonCount = int32(len(seasons))
+		if seasonCount == 0 {
+			seasonCount = int32(title.seasons)
+		}
+	}
+	if err := s.checkQuota(ctx, userID, req.MediaType, int(seasonCount)); err != nil {
+		return model.MediaRequest{}, err
+	}
+
+	row, err := s.repo.CreateMediaRequest(ctx, dbgen.CreateMediaRequestParams{
+		UserID:           userID,
+		MediaType:        string(req.MediaType),
+		TmdbID:           req.TmdbID,
+		Title:            title.name,
+		Year:             title.year,
+		Seasons:          seasons,
+		SeasonCount:      seasonCount,
+		QualityProfileID: profileID,
+	})
+	if err != nil {
+		return model.MediaRequest{}, fmt.Errorf("create request: %w", err)
+	}
+	s.logger.Info().Str("type", string(req.MediaType)).Int64("tmdb_id", req.TmdbID).Ints32("seasons", seasons).Msg("Media requested")
+
+	s.autoApprove(ctx, userID, row, int(openCount))
+	return s.load(ctx, row.ID)
+}
+
+// Get returns a request. Users without requests.approve only see their own.
+func (s *MediaRequestsService) Get(ctx context.Context, userID, id pgtype.UUID) (model.MediaRequest, error) {
+	row, err := s.repo.GetMediaRequest(ctx, id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return model.MediaRequest{}, ErrMediaRequestNotFound
+	}
+	if err != nil {
+		return model.MediaRequest{}, err
+	}
+	if row.UserID != userID {
+		approver, err := s.can(ctx, userID, permRequestsApprove)
+		if err != nil {
+			return model.MediaRequest{}, err
+		}
+		if !approver {
+			return model.MediaRequest{}, ErrMediaRequestNotFound
+		}
+	}
+	return mediaRequest(row), nil
+}
+
+// List lists requests newest first, optionally by status. Users with
+// requests.approve see everyone's requests, other users their own.
+func (s *MediaRequestsService) List(ctx context.Context, userID pgtype.UUID, status *string, page, pageSize int) (model.PaginatedMediaRequestsResponse, error) {
+	approver, err := s.can(ctx, userID, permRequestsApprove)
+	if err != nil {
+		return model.PaginatedMediaRequestsResponse{}, err
+	}
+	var owner pgtype.UUID
+	if !approver {
+		owner = userID
+	}
+
+	total, err := s.repo.CountMediaRequests(ctx, dbgen.CountMediaRequestsParams{UserID: owner, Status: status})
+	if err != nil {
+		return model.PaginatedMediaRequestsResponse{}, fmt.Errorf("count requests: %w", err)
+	}
+	rows, err := s.repo.ListMediaRequests(ctx, dbgen.ListMediaRequestsParams{
+		UserID:    owner,
+		Status:    status,
+		PageSize:  int32(pageSize),
+		OffsetVal: int32((page - 1) * pageSize),
+	})
+	if err != nil {
+		return model.PaginatedMediaRequestsResponse{}, fmt.Errorf("list requests: %w", err)
+	}
+
+	items := make([]model.MediaRequest, 0, len(rows))
+	for _, row := range rows {
+		items = append(items, mediaRequest(dbgen.GetMediaRequestRow(row)))
+	}
+	return model.PaginatedMediaRequestsResponse{
+		Data: items,
+		Pagination: model.Pagination{
+			Total:      total,
+			Page:       page,
+			PageSize:   pageSize,
+			TotalPages: int(math.Ceil(float64(total) / float64(pageSize))),
+		},
+	}, nil
+}
+
+// Approve approves a pending request: the title is monitored, along with the
+// requested seasons of a series, and a search is queued for it. A title that
+// is already monitored keeps its library, quality profile and monitored
+// seasons; otherwise libraryID may be invalid (NULL) to use the default, and
+// qualityProfileID to use the profile the requester asked for, if any.
+func (s *MediaRequestsService) Approve(ctx context.Context, userID, id pgtype.UUID, comment string, libraryID, qualityProfileID pgtype.UUID) (model.MediaRequest, error) {
+	row, err := s.pending(ctx, userID, id)
+	if err != nil {
+		return model.MediaRequest{}, err
+	}
+	if !qualityProfileID.Valid {
+		qualityProfileID = row.QualityProfileID
+	}
+	if err := s.approve(ctx, row, userID, comment, libraryID, qualityProfileID); err != nil {
+		return model.MediaRequest{}, err
+	}
+	return s.load(ctx, id)
+}
+
+// Decline declines a pending request.
+func (s *MediaRequestsService) Decline(ctx context.Context, userID, id pgtype.UUID, comment string) (model.MediaRequest, error) {
+	row, err := s.pending(ctx, userID, id)
+	if err != nil {
+		return model.MediaRequest{}, err
+	}
+	if _, err := s.decide(ctx, userID, id, model.MediaRequestDeclined, comment, pgtype.UUID{}); err != nil {
+		return model.MediaRequest{}, err
+	}
+	s.logger.Info().Str("request_id", id.String()).Str("title", row.Title).Msg("Media request declined")
+	return s.load(ctx, id)
+}
+
+// UpdateStatuses moves approved requests along as their title is downloaded
+// and imported, and to available once everything requested is on disk. A
+// request whose downloads failed falls back to approved, where the monitored
+// title is searched again like any other missing item.
+func (s *MediaRequestsService) UpdateStatuses(ctx context.Context) error {
+	rows, err := s.repo.ListActiveMediaRequests(ctx)
+	if err != nil {
+		return fmt.Errorf("list active requests: %w", err)
+	}
+	for _, row := range rows {
+		next := model.MediaRequestApproved
+		switch {
+		case row.Importing:
+			next = model.MediaRequestImporting
+		case row.Downloading:
+			next = model.MediaRequestDownloading
+		}
+		available, err := s.available(ctx, row.MediaType, row.TmdbID, row.Seasons)
+		if err != nil {
+			s.logger.Warn().Err(err).Str("request_id", row.ID.String()).Msg("Failed to check request availability")
+			continue
+		}
+		if available {
+			next = model.MediaRequestAvailable
+		}
+		if string(next) == row.Status {
+			continue
+		}
+
+		if err := s.repo.SetMediaRequestStatus(ctx, row.ID, string(next)); err != nil {
+			s.logger.Warn().Err(err).Str("request_id", row.ID.String()).Msg("Failed to update request status")
+			continue
+		}
+		s.logger.Info().Str("request_id", row.ID.String()).Str("from", row.Status).Str("to", string(next)).Msg("Media request status changed")
+	}
+	return nil
+}
+
+// approve monitors the title of a pending request, marks the request
+// approved by decidedBy (invalid when approved by a rule) and queues a search.
+func (s *MediaRequestsService) approve(ctx context.Context, row dbgen.GetMediaRequestRow, decidedBy pgtype.UUID, comment string, libraryID, qualityProfileID pgtype.UUID) error {
+	item, err := s.monitor(ctx, row, libraryID, qualityProfileID)
+	if err != nil {
+		return err
+	}
+	if _, err := s.decide(ctx, decidedBy, row.ID, model.MediaRequestApproved, comment, item.ID); err != nil {
+		return err
+	}
+
+	queued, err := s.search(ctx, row, item)
+	if err != nil {
+		s.logger.Warn().Err(err).Str("request_id", row.ID.String()).Msg("Failed to queue search for approved request")
+	}
+	s.logger.Info().Str("request_id", row.ID.String()).Str("title", row.Title).Int("searches", queued).Msg("Media request approved")
+	return nil
+}
+
+// autoApprove approves a new request when the first matching request rule of
+// the requester's roles says so. A request that can't be approved stays
+// pending for a manual decision.
+func (s *MediaRequestsService) autoApprove(ctx context.Context, userID pgtype.UUID, created dbgen.MediaRequest, openCount int) {
+	reqCtx, err := s.requestContext(ctx, userID, created, openCount)
+	if err != nil {
+		s.logger.Warn().Err(err).Str("request_id", created.ID.String()).Msg("Failed to build request rule context")
+		return
+	}
+	eval, err := s.rules.Evaluate(ctx, userID, reqCtx)
+	if err != nil {
+		s.logger.Warn().Err(err).Str("request_id", created.ID.String()).Msg("Failed to evaluate request rules")
+		return
+	}
+	if !eval.AutoApprove {
+		return
+	}
+
+	row, err := s.repo.GetMediaRequest(ctx, created.ID)
+	if err != nil {
+		s.logger.Warn().Err(err).Str("request_id", created.ID.String()).Msg("Failed to load request for auto-approval")
+		return
+	}
+	comment := fmt.Sprintf("Auto-approved by rule %q", eval.RuleName)
+	if err := s.approve(ctx, row, pgtype.UUID{}, comment, pgtype.UUID{}, row.QualityProfileID); err != nil {
+		s.logger.Warn().Err(err).Str("request_id", created.ID.String()).Str("rule", eval.RuleName).Msg("Failed to auto-approve request")
+	}
+}
+
+// requestContext describes a new request for the request rules. The quality
+// profile is the one asked for, or the one the title would get otherwise.
+func (s *MediaRequestsService) requestContext(ctx context.Context, userID pgtype.UUID, row dbgen.MediaRequest, openCount int) (model.RequestContext, error) {
+	user, err := s.repo.GetUserByID(ctx, userID)
+	if err != nil {
+		return model.RequestContext{}, fmt.Errorf("get user: %w", err)
+	}
+	var year int
+	if row.Year != nil {
+		year = int(*row.Year)
+	}
+	reqCtx := model.RequestContext{
+		Request: model.RequestFields{
+			MediaType:   row.MediaType,
+			Title:       row.Title,
+			Year:        year,
+			TmdbID:      row.TmdbID,
+			SeasonCount: int(row.SeasonCount),
+			WholeSeries: row.MediaType == string(model.MediaTypeSeries) && len(row.Seasons) == 0,
+		},
+		User: model.RequestUserFields{
+			Username:     user.Username,
+			OpenRequests: openCount,
+		},
+	}
+
+	var profileRow dbgen.QualityProfile
+	if row.QualityProfileID.Valid {
+		profileRow, err = s.repo.GetQualityProfile(ctx, row.QualityProfileID)
+	} else {
+		profileRow, _, err = s.profiles.resolve(ctx, model.MediaType(row.MediaType), row.TmdbID)
+	}
+	if err != nil {
+		return model.RequestContext{}, fmt.Errorf("get quality profile: %w", err)
+	}
+	profile, err := profileFromRow(profileRow)
+	if err != nil {
+		return model.RequestContext{}, err
+	}
+	reqCtx.Request.QualityProfile = profile.Name
+	reqCtx.Request.MaxResolution = profile.MaxResolution()
+	return reqCtx, nil
+}
+
+// checkQuota fails with ErrMediaRequestQuota when a request for a movie, or
+// for seasonCount seasons of a series, would take the user over their quota.
+func (s *MediaRequestsService) checkQuota(ctx context.Context, userID pgtype.UUID, mediaType model.MediaType, seasonCount int) error {
+	usage, err := s.rules.Usage(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if mediaType == model.MediaTypeMovie {
+		if u := usage.Movies; u.Limit != nil && u.Used+1 > *u.Limit {
+			return fmt.Errorf("%w: %d of %d movies requested in the last %d days",
+				ErrMediaRequestQuota, u.Used, *u.Limit, u.WindowDays)
+		}
+		return nil
+	}
+	if u := usage.Seasons; u.Limit != nil && u.Used+seasonCount > *u.Limit {
+		return fmt.Errorf("%w: this request covers %d seasons and %d of %d were requested in the last %d days",
+			ErrMediaRequestQuota, seasonCount, u.Used, *u.Limit, u.WindowDays)
+	}
+	return nil
+}
+
+// requestProfile validates the quality profile a requester asked for.
+func (s *MediaRequestsService) requestProfile(ctx context.Context, id *string) (pgtype.UUID, error) {
+	var profileID pgtype.UUID
+	if id == nil || *id == "" {
+		return profileID, nil
+	}
+	if err := profileID.Scan(*id); err != nil {
+		return pgtype.UUID{}, fmt.Errorf("%w: invalid qualityProfileId", ErrMediaRequestInvalid)
+	}
+	if _, err := s.repo.GetQualityProfile(ctx, profileID); errors.Is(err, pgx.ErrNoRows) {
+		return pgtype.UUID{}, fmt.Errorf("%w: quality profile not found", ErrMediaRequestInvalid)
+	} else if err != nil {
+		return pgtype.UUID{}, fmt.Errorf("get quality profile: %w", err)
+	}
+	return profileID, nil
+}
+
+// require fails with ErrMediaRequestForbidden unless the user has the permission.
+func (s *MediaRequestsService) require(ctx context.Context, userID pgtype.UUID, permission string) error {
+	ok, err := s.can(ctx, userID, permission)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return fmt.Errorf("%w: requires %s", ErrMediaRequestForbidden, permission)
+	}
+	return nil
+}
+
+func (s *MediaRequestsService) can(ctx context.Context, userID pgtype.UUID, permission string) (bool, error) {
+	ok, err := s.repo.UserHasPermission(ctx, userID, permission)
+	if err != nil {
+		return false, fmt.Errorf("check permission: %w", err)
+	}
+	return ok, nil
+}
+
+// pending returns a request awaiting a decision by a user with requests.approve.
+func (s *MediaRequestsService) pending(ctx context.Context, userID, id pgtype.UUID) (dbgen.GetMediaRequestRow, error) {
+	if err := s.require(ctx, userID, permRequestsApprove); err != nil {
+		return dbgen.GetMediaRequestRow{}, err
+	}
+	row, err := s.repo.GetMediaRequest(ctx, id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return dbgen.GetMediaRequestRow{}, ErrMediaRequestNotFound
+	}
+	if err != nil {
+		return dbgen.GetMediaRequestRow{}, err
+	}
+	if row.Status != string(model.MediaRequestPending) {
+		return dbgen.GetMediaRequestRow{}, ErrMediaRequestDecided
+	}
+	return row, nil
+}
+
+func (s *MediaRequestsService) decide(ctx context.Context, userID, id pgtype.UUID, status model.MediaRequestStatus, comment string, mediaItemID pgtype.UUID) (dbgen.MediaRequest, error) {
+	var decisionComment *string
+	if comment != "" {
+		decisionComment = &comment
+	}
+	row, err := s.repo.DecideMediaRequest(ctx, dbgen.DecideMediaRequestParams{
+		Status:          string(status),
+		DecidedBy:       userID,
+		DecisionComment: decisionComment,
+		MediaItemID:     mediaItemID,
+		ID:              id,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		// Decided by someone else in the meantime
+		return dbgen.MediaRequest{}, ErrMediaRequestDecided
+	}
+	if err != nil {
+		return dbgen.MediaRequest{}, fmt.Errorf("decide request: %w", err)
+	}
+	return row, nil
+}
+
+// monitor monitors the requested title and returns its media item.
+func (s *MediaRequestsService) monitor(ctx context.Context, row dbgen.GetMediaRequestRow, libraryID, qualityProfileID pgtype.UUID) (dbgen.MediaItem, error) {
+	mediaType := model.MediaType(row.MediaType)
+	req := model.MonitorRequest{Monitored: true}
+
+	existing, err := s.repo.GetMediaItemByTmdbIDAndType(ctx, row.TmdbID, row.MediaType)
+	switch {
+	case err == nil && existing.Monitored:
+		libraryID, qualityProfileID = existing.LibraryID, existing.QualityProfileID
+		if mediaType == model.MediaTypeSeries {
+			req.MonitorNewSeasons = existing.MonitorNewSeasons
+			if req.Seasons, err = s.monitoredSeasons(ctx, existing.ID, row.Seasons); err != nil {
+				return dbgen.MediaItem{}, err
+			}
+		}
+	case err != nil && !errors.Is(err, pgx.ErrNoRows):
+		return dbgen.MediaItem{}, fmt.Errorf("get media item: %w", err)
+	case mediaType == model.MediaTypeSeries:
+		// A whole series stays whole as TMDB adds seasons
+		req.MonitorNewSeasons = len(row.Seasons) == 0
+		for _, n := range row.Seasons {
+			req.Seasons = append(req.Seasons, int(n))
+		}
+	}
+
+	if _, err := s.monitoring.Monitor(ctx, mediaType, row.TmdbID, req, libraryID, qualityProfileID); err != nil {
+		return dbgen.MediaItem{}, fmt.Errorf("monitor: %w", err)
+	}
+	item, err := s.repo.GetMediaItemByTmdbIDAndType(ctx, row.TmdbID, row.MediaType)
+	if err != nil {
+		return dbgen.MediaItem{}, fmt.Errorf("get media item: %w", err)
+	}
+	return item, nil
+}
+
+// monitoredSeasons adds the requested seasons (every season but specials
+// when none were named) to those a monitored series already monitors.
+func (s *MediaRequestsService) monitoredSeasons(ctx context.Context, mediaItemID pgtype.UUID, requested []int32) ([]int, error) {
+	rows, err := s.repo.ListSeasonsForMedia(ctx, mediaItemID)
+	if err != nil {
+		return nil, fmt.Errorf("list seasons: %w", err)
+	}
+	seasons := []int{}
+	for _, season := range rows {
+		want := season.Monitored || slices.Contains(requested, season.SeasonNumber) ||
+			(len(requested) == 0 && season.SeasonNumber > 0)
+		if want {
+			seasons = append(seasons, int(season.SeasonNumber))
+		}
+	}
+	for _, n := range requested {
+		if !slices.Contains(seasons, int(n)) {
+			seasons = append(seasons, int(n))
+		}
+	}
+	return seasons, nil
+}
+
+// search queues a search for the movie, or a season pack search for each
+// requested season, and returns how many were queued.
+func (s *MediaRequestsService) search(ctx context.Context, row dbgen.GetMediaRequestRow, item dbgen.MediaItem) (int, error) {
+	mediaType := model.MediaType(row.MediaType)
+	if mediaType == model.MediaTypeMovie {
+		return s.autoSearch.Queue([]model.WantedSearchItem{{MediaType: mediaType, TmdbID: row.TmdbID}}), nil
+	}
+
+	seasons := row.Seasons
+	if len(seasons) == 0 {
+		rows, err := s.repo.ListSeasonsForMedia(ctx, item.ID)
+		if err != nil {
+			return 0, fmt.Errorf("list seasons: %w", err)
+		}
+		for _, season := range rows {
+			if season.SeasonNumber > 0 {
+				seasons = append(seasons, season.SeasonNumber)
+			}
+		}
+	}
+	items := make([]model.WantedSearchItem, 0, len(seasons))
+	for _, n := range seasons {
+		season := int(n)
+		items = append(items, model.WantedSearchItem{MediaType: mediaType, TmdbID: row.TmdbID, Season: &season})
+	}
+	return s.autoSearch.Queue(items), nil
+}
+
+// requestTitle is a requested title as TMDB knows it.
+type requestTitle struct {
+	name    string
+	year    *int32
+	seasons int // series only; seasons other than specials
+}
+
+// lookup returns the TMDB title, year and season count of a requested title,
+// checking that requested seasons exist.
+func (s *MediaRequestsService) lookup(ctx context.Context, mediaType model.MediaType, tmdbID int64, seasons []int32) (requestTitle, error) {
+	if mediaType == model.MediaTypeMovie {
+		movie, err := s.tmdb.GetMovieDetails(ctx, tmdbID)
+		if err != nil {
+			return requestTitle{}, fmt.Errorf("get movie: %w", err)
+		}
+		return requestTitle{name: movie.Title, year: parseYear(movie.ReleaseDate)}, nil
+	}
+
+	series, err := s.tmdb.GetSeriesDetails(ctx, tmdbID)
+	if err != nil {
+		return requestTitle{}, fmt.Errorf("get series: %w", err)
+	}
+	title := requestTitle{name: series.Name, year: parseYear(series.FirstAirDate)}
+	known := make(map[int32]bool, len(series.Seasons))
+	for _, season := range series.Seasons {
+		known[int32(season.SeasonNumber)] = true
+		if season.SeasonNumber > 0 {
+			title.seasons++
+		}
+	}
+	for _, n := range seasons {
+		if !known[n] {
+			return requestTitle{}, fmt.Errorf("%w: %d", ErrSeasonNotFound, n)
+		}
+	}
+	return title, nil
+}
+
+// available reports whether a movie, or every aired episode of the given
+// seasons of a series, is on disk. A series with nothing aired isn't available.
+func (s *MediaRequestsService) available(ctx context.Context, mediaType string, tmdbID int64, seasons []int32) (bool, error) {
+	row, err := s.repo.GetTitleAvailability(ctx, mediaType, tmdbID, seasons)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("get availability: %w", err)
+	}
+	return row.Aired > 0 && row.OnDisk >= row.Aired, nil
+}
+
+func (s *MediaRequestsService) load(ctx context.Context, id pgtype.UUID) (model.MediaRequest, error) {
+	row, err := s.repo.GetMediaRequest(ctx, id)
+	if err != nil {
+		return model.MediaRequest{}, fmt.Errorf("get request: %w", err)
+	}
+	return mediaRequest(row), nil
+}
+
+// requestSeasons validates a request and returns its seasons sorted and
+// deduplicated; never nil, as the column is NOT NULL.
+func requestSeasons(req model.CreateMediaRequest) ([]int32, error) {
+	if req.TmdbID <= 0 {
+		return nil, fmt.Errorf("%w: tmdbId is required", ErrMediaRequestInvalid)
+	}
+	switch req.MediaType {
+	case model.MediaTypeMovie:
+		if len(req.Seasons) > 0 {
+			return nil, fmt.Errorf("%w: seasons only apply to series", ErrMediaRequestInvalid)
+		}
+	case model.MediaTypeSeries:
+	default:
+		return nil, fmt.Errorf("%w: mediaType must be movie or series", ErrMediaRequestInvalid)
+	}
+
+	seasons := []int32{}
+	for _, n := range req.Seasons {
+		if n < 0 {
+			return nil, fmt.Errorf("%w: invalid season %d", ErrMediaRequestInvalid, n)
+		}
+		if !slices.Contains(seasons, int32(n)) {
+			seasons = append(seasons, int32(n))
+		}
+	}
+	slices.Sort(seasons)
+	return seasons, nil
+}
+
+// seasonsOverlap reports whether two requests for a series ask for any of the
+// same seasons. No seasons is the whole series.
+func seasonsOverlap(a, b []int32) bool {
+	if len(a) == 0 || len(b) == 0 {
+		return true
+	}
+	return slices.ContainsFunc(a, func(n int32) bool { return slices.Contains(b, n) })
+}
+
+func mediaRequest(row dbgen.GetMediaRequestRow) model.MediaRequest {
+	out := model.MediaRequest{
+		ID:              row.ID.String(),
+		UserID:          row.UserID.String(),
+		RequestedBy:     row.RequestedBy,
+		MediaType:       model.MediaType(row.MediaType),
+		TmdbID:          row.TmdbID,
+		Title:           row.Title,
+		Year:            int32ToIntPtr(row.Year),
+		Seasons:         make([]int, 0, len(row.Seasons)),
+		SeasonCount:     int(row.SeasonCount),
+		Status:          model.MediaRequestStatus(row.Status),
+		DecidedBy:       coalesce(row.DecidedByUsername, ""),
+		DecidedAt:       timestamptzPtr(row.DecidedAt),
+		DecisionComment: coalesce(row.DecisionComment, ""),
+		AvailableAt:     timestamptzPtr(row.AvailableAt),
+		CreatedAt:       row.CreatedAt,
+		UpdatedAt:       row.UpdatedAt,
+	}
+	for _, n := range row.Seasons {
+		out.Seasons = append(out.Seasons, int(n))
+	}
+	if row.MediaItemID.Valid {
+		out.MediaItemID = row.MediaItemID.String()
+	}
+	if row.QualityProfileID.Valid {
+		out.QualityProfileID = row.QualityProfileID.String()
+	}
+	return out
+}
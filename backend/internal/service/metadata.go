@@ -0,0 +1,537 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	tmdb "github.com/cyruzin/golang-tmdb"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	dbgen "github.com/kyleaupton/arrflix/internal/db/sqlc"
+	"github.com/kyleaupton/arrflix/internal/logger"
+	"github.com/kyleaupton/arrflix/internal/model"
+	"github.com/kyleaupton/arrflix/internal/repo"
+)
+
+var (
+	ErrMetadataRefreshRunning = errors.New("a metadata refresh is already running")
+	ErrMetadataNotInLibrary   = errors.New("media item is not in the library")
+)
+
+const (
+	// metadataRefreshInterval is how often stale items are looked for.
+	metadataRefreshInterval = 15 * time.Minute
+	// metadataRefreshBatch caps the items refreshed per pass.
+	metadataRefreshBatch = 50
+)
+
+// TMDB release types, see https://developer.themoviedb.org/reference/movie-release-dates
+const (
+	tmdbReleaseLimited    = 2
+	tmdbReleaseTheatrical = 3
+	tmdbReleaseDigital    = 4
+	tmdbReleasePhysical   = 5
+)
+
+// MetadataService refreshes library items from TMDB. Seasons and episodes are
+// created lazily (by the scanner, the download worker and monitoring) and
+// this is where they are reconciled with TMDB afterwards.
+type MetadataService struct {
+	repo     *repo.Repository
+	logger   *logger.Logger
+	settings *SettingsService
+	tmdb     *TmdbService
+
+	running sync.Mutex // one scheduled pass at a time
+	items   sync.Mutex // one item at a time, so passes and on-demand refreshes don't interleave
+	mu      sync.Mutex
+	lastRun time.Time
+}
+
+// NewMetadataService creates a new metadata service
+func NewMetadataService(r *repo.Repository, l *logger.Logger, settings *SettingsService, tmdb *TmdbService) *MetadataService {
+	return &MetadataService{repo: r, logger: l, settings: settings, tmdb: tmdb}
+}
+
+// RunIfDue refreshes stale items every metadataRefreshInterval.
+func (s *MetadataService) RunIfDue(ctx context.Context) {
+	s.mu.Lock()
+	due := time.Since(s.lastRun) >= metadataRefreshInterval
+	s.mu.Unlock()
+	if !due {
+		return
+	}
+
+	if _, err := s.RefreshStale(ctx); err != nil && !errors.Is(err, ErrMetadataRefreshRunning) {
+		s.logger.Error().Err(err).Msg("Metadata refresh failed")
+	}
+}
+
+// RefreshStale refreshes up to metadataRefreshBatch library items last
+// refreshed more than metadata.refresh_hours ago, returning how many were
+// refreshed. An item that fails is not retried until it is stale again, so a
+// broken item can't hold up the rest.
+func (s *MetadataService) RefreshStale(ctx context.Context) (int, error) {
+	if !s.running.TryLock() {
+		return 0, ErrMetadataRefreshRunning
+	}
+	defer s.running.Unlock()
+
+	s.mu.Lock()
+	s.lastRun = time.Now()
+	s.mu.Unlock()
+
+	before := time.Now().Add(-time.Duration(s.settings.GetInt(ctx, "metadata.refresh_hours")) * time.Hour)
+	items, err := s.repo.ListMetadataRefreshItems(ctx, before, metadataRefreshBatch)
+	if err != nil {
+		return 0, fmt.Errorf("list items to refresh: %w", err)
+	}
+
+	refreshed := 0
+	for _, item := range items {
+		if ctx.Err() != nil {
+			return refreshed, ctx.Err()
+		}
+		if _, err := s.refresh(ctx, item); err != nil {
+			s.logger.Warn().Err(err).Str("title", item.Title).Str("type", item.Type).Msg("Failed to refresh metadata")
+			if err := s.repo.SetMediaItemMetadataRefreshed(ctx, item.ID); err != nil {
+				return refreshed, fmt.Errorf("mark metadata refreshed: %w", err)
+			}
+			continue
+		}
+		refreshed++
+	}
+	if refreshed > 0 {
+		s.logger.Info().Int("refreshed", refreshed).Msg("Metadata refresh finished")
+	}
+	return refreshed, nil
+}
+
+// Refresh refreshes one library item from TMDB now.
+func (s *MetadataService) Refresh(ctx context.Context, mediaType model.MediaType, tmdbID int64) (model.MetadataRefresh, error) {
+	item, err := s.repo.GetMediaItemByTmdbIDAndType(ctx, tmdbID, string(mediaType))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return model.MetadataRefresh{}, ErrMetadataNotInLibrary
+	}
+	if err != nil {
+		return model.MetadataRefresh{}, fmt.Errorf("get media item: %w", err)
+	}
+	return s.refresh(ctx, item)
+}
+
+func (s *MetadataService) refresh(ctx context.Context, item dbgen.MediaItem) (model.MetadataRefresh, error) {
+	if item.TmdbID == nil {
+		return model.MetadataRefresh{}, fmt.Errorf("%s has no TMDB ID", item.Title)
+	}
+
+	s.items.Lock()
+	defer s.items.Unlock()
+
+	var result model.MetadataRefresh
+	var err error
+	if item.Type == string(model.MediaTypeSeries) {
+		result, err = s.refreshSeries(ctx, item)
+	} else {
+		result, err = s.refreshMovie(ctx, item)
+	}
+	if err != nil {
+		return model.MetadataRefresh{}, err
+	}
+	if err := s.repo.SetMediaItemMetadataRefreshed(ctx, item.ID); err != nil {
+		return model.MetadataRefresh{}, fmt.Errorf("mark metadata refreshed: %w", err)
+	}
+
+	if result.EpisodesAdded+result.EpisodesMoved+result.EpisodesMerged+result.EpisodesRemoved > 0 {
+		s.logger.Info().
+			Str("title", result.Title).
+			Int("added", result.EpisodesAdded).
+			Int("moved", result.EpisodesMoved).
+			Int("merged", result.EpisodesMerged).
+			Int("removed", result.EpisodesRemoved).
+			Int("kept", result.EpisodesKept).
+			Msg("Reconciled episodes with TMDB")
+	}
+	return result, nil
+}
+
+func (s *MetadataService) refreshMovie(ctx context.Context, item dbgen.MediaItem) (model.MetadataRefresh, error) {
+	details, err := s.tmdb.RefreshMovieDetails(ctx, *item.TmdbID)
+	if err != nil {
+		return model.MetadataRefresh{}, fmt.Errorf("get movie details: %w", err)
+	}
+	if item, err = s.updateTitle(ctx, item, details.Title, parseYear(details.ReleaseDate)); err != nil {
+		return model.MetadataRefresh{}, err
+	}
+
+	dates, err := s.tmdb.RefreshMovieReleaseDates(ctx, *item.TmdbID)
+	if err != nil {
+		return model.MetadataRefresh{}, fmt.Errorf("get release dates: %w", err)
+	}
+	theatrical, digital, physical := movieReleaseDates(dates)
+	if err := s.repo.SetMediaItemReleaseDates(ctx, item.ID, theatrical, digital, physical); err != nil {
+		return model.MetadataRefresh{}, fmt.Errorf("set release dates: %w", err)
+	}
+	return metadataRefreshFor(item), nil
+}
+
+// updateTitle stores a changed title or year. An empty title from TMDB is
+// ignored.
+func (s *MetadataService) updateTitle(ctx context.Context, item dbgen.MediaItem, title string, year *int32) (dbgen.MediaItem, error) {
+	if title == "" {
+		return item, nil
+	}
+	sameYear := (year == nil && item.Year == nil) || (year != nil && item.Year != nil && *year == *item.Year)
+	if title == item.Title && sameYear {
+		return item, nil
+	}
+	updated, err := s.repo.UpdateMediaItem(ctx, item.ID, title, year, item.TmdbID)
+	if err != nil {
+		return item, fmt.Errorf("update title: %w", err)
+	}
+	s.logger.Info().Str("from", item.Title).Str("to", title).Msg("Updated title from TMDB")
+	return updated, nil
+}
+
+// tmdbEpisode is an episode as TMDB numbers it.
+type tmdbEpisode struct {
+	season  int32
+	number  int32
+	tmdbID  int64
+	title   string
+	airDate pgtype.Date
+}
+
+// episodeSlot is a (season, episode) number pair.
+type episodeSlot struct {
+	season, number int32
+}
+
+func (s *MetadataService) refreshSeries(ctx context.Context, item dbgen.MediaItem) (model.MetadataRefresh, error) {
+	tmdbID := *item.TmdbID
+	details, err := s.tmdb.RefreshSeriesDetails(ctx, tmdbID)
+	if err != nil {
+		return model.MetadataRefresh{}, fmt.Errorf("get series details: %w", err)
+	}
+	if item, err = s.updateTitle(ctx, item, details.Name, parseYear(details.FirstAirDate)); err != nil {
+		return model.MetadataRefresh{}, err
+	}
+
+	seasons := make(map[int32]pgtype.Date, len(details.Seasons))
+	var episodes []tmdbEpisode
+	for _, info := range details.Seasons {
+		full, err := s.tmdb.RefreshTVSeasonDetails(ctx, tmdbID, info.SeasonNumber)
+		if err != nil {
+			return model.MetadataRefresh{}, fmt.Errorf("get season %d: %w", info.SeasonNumber, err)
+		}
+		seasons[int32(info.SeasonNumber)] = tmdbDate(info.AirDate)
+		for _, ep := range full.Episodes {
+			episodes = append(episodes, tmdbEpisode{
+				season:  int32(info.SeasonNumber),
+				number:  int32(ep.EpisodeNumber),
+				tmdbID:  ep.ID,
+				title:   ep.Name,
+				airDate: tmdbDate(ep.AirDate),
+			})
+		}
+	}
+
+	tvdbIDs, err := s.episodeTvdbIDs(ctx, item, episodes)
+	if err != nil {
+		return model.MetadataRefresh{}, err
+	}
+
+	tx, err := s.repo.Pool.Begin(ctx)
+	if err != nil {
+		return model.MetadataRefresh{}, fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+	txQueries := s.repo.Q.WithTx(tx)
+
+	result := metadataRefreshFor(item)
+	if err := reconcileEpisodes(ctx, txQueries, item, seasons, episodes, tvdbIDs, &result); err != nil {
+		return model.MetadataRefresh{}, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return model.MetadataRefresh{}, fmt.Errorf("commit transaction: %w", err)
+	}
+	return result, nil
+}
+
+// episodeTvdbIDs looks up the TVDB IDs of episodes that aren't stored with
+// one yet, by TMDB episode ID. Lookups that fail are skipped; the next
+// refresh tries again.
+func (s *MetadataService) episodeTvdbIDs(ctx context.Context, item dbgen.MediaItem, episodes []tmdbEpisode) (map[int64]int64, error) {
+	existing, err := s.repo.ListEpisodesForMedia(ctx, item.ID)
+	if err != nil {
+		return nil, fmt.Errorf("list episodes: %w", err)
+	}
+	known := make(map[int64]bool, len(existing))
+	for _, ep := range existing {
+		if ep.TmdbID != nil && ep.TvdbID != nil {
+			known[*ep.TmdbID] = true
+		}
+	}
+
+	ids := make(map[int64]int64)
+	for _, ep := range episodes {
+		if known[ep.tmdbID] {
+			continue
+		}
+		external, err := s.tmdb.GetTVEpisodeExternalIDs(ctx, *item.TmdbID, int(ep.season), int(ep.number))
+		if err != nil {
+			s.logger.Debug().Err(err).Str("title", item.Title).Int32("season", ep.season).Int32("episode", ep.number).Msg("Failed to get episode external IDs")
+			continue
+		}
+		if external.TVDBID != 0 {
+			ids[ep.tmdbID] = external.TVDBID
+		}
+	}
+	return ids, nil
+}
+
+// reconcileEpisodes makes the seasons and episodes of a series match TMDB.
+// Episode rows are matched to TMDB episodes by TMDB ID, so an episode TMDB
+// renumbered moves along with its files, downloads and imports. A row left in
+// a TMDB episode's place without its ID (created lazily, before TMDB was
+// asked) is taken over, or merged into the episode's row when it has one.
+// Episodes gone from TMDB are deleted unless something still points at them,
+// in which case they are kept, unmonitored, so no file loses its episode.
+func reconcileEpisodes(ctx context.Context, q *dbgen.Queries, item dbgen.MediaItem, seasons map[int32]pgtype.Date, episodes []tmdbEpisode, tvdbIDs map[int64]int64, result *model.MetadataRefresh) error {
+	existingSeasons, err := q.ListSeasonsForMedia(ctx, item.ID)
+	if err != nil {
+		return fmt.Errorf("list seasons: %w", err)
+	}
+	knownSeasons := make(map[int32]bool, len(existingSeasons))
+	for _, season := range existingSeasons {
+		knownSeasons[season.SeasonNumber] = true
+	}
+
+	seasonRows := make(map[int32]dbgen.MediaSeason, len(seasons))
+	for number, airDate := range seasons {
+		season, err := q.UpsertSeason(ctx, dbgen.UpsertSeasonParams{MediaItemID: item.ID, SeasonNumber: number, AirDate: airDate})
+		if err != nil {
+			return fmt.Errorf("upsert season %d: %w", number, err)
+		}
+		if !knownSeasons[number] && item.Monitored && item.MonitorNewSeasons && number > 0 {
+			if season, err = q.SetSeasonMonitored(ctx, dbgen.SetSeasonMonitoredParams{ID: season.ID, Monitored: true}); err != nil {
+				return fmt.Errorf("set season monitored: %w", err)
+			}
+		}
+		seasonRows[number] = season
+	}
+
+	rows, err := q.ListEpisodesForMedia(ctx, item.ID)
+	if err != nil {
+		return fmt.Errorf("list episodes: %w", err)
+	}
+	want := make(map[int64]episodeSlot, len(episodes))
+	for _, ep := range episodes {
+		want[ep.tmdbID] = episodeSlot{ep.season, ep.number}
+	}
+
+	// Match rows to TMDB episodes, preferring a row already in place. Other
+	// rows with the same TMDB ID, left behind by an earlier renumbering, are
+	// merged into it.
+	matched := make(map[int64]dbgen.ListEpisodesForMediaRow, len(rows))
+	for _, row := range rows {
+		if row.TmdbID == nil {
+			continue
+		}
+		if slot, ok := want[*row.TmdbID]; ok && slot == (episodeSlot{row.SeasonNumber, row.EpisodeNumber}) {
+			matched[*row.TmdbID] = row
+		}
+	}
+	unmatched := make(map[episodeSlot]dbgen.ListEpisodesForMediaRow)
+	for _, row := range rows {
+		if row.TmdbID == nil {
+			unmatched[episodeSlot{row.SeasonNumber, row.EpisodeNumber}] = row
+			continue
+		}
+		if _, ok := want[*row.TmdbID]; !ok {
+			unmatched[episodeSlot{row.SeasonNumber, row.EpisodeNumber}] = row
+			continue
+		}
+		keeper, ok := matched[*row.TmdbID]
+		if !ok {
+			matched[*row.TmdbID] = row
+			continue
+		}
+		if keeper.ID != row.ID {
+			if err := mergeEpisode(ctx, q, row.ID, keeper.ID); err != nil {
+				return err
+			}
+			result.EpisodesMerged++
+		}
+	}
+
+	// Rows that move are parked on negative episode numbers first, so
+	// renumbering can't trip over (season_id, episode_number) being unique.
+	parked := make(map[pgtype.UUID]bool)
+	for tmdbID, row := range matched {
+		if want[tmdbID] == (episodeSlot{row.SeasonNumber, row.EpisodeNumber}) {
+			continue
+		}
+		number := -int32(len(parked) + 1)
+		if err := q.MoveEpisode(ctx, dbgen.MoveEpisodeParams{SeasonID: row.SeasonID, EpisodeNumber: number, ID: row.ID}); err != nil {
+			return fmt.Errorf("park episode: %w", err)
+		}
+		parked[row.ID] = true
+	}
+
+	for _, ep := range episodes {
+		season := seasonRows[ep.season]
+		slot := episodeSlot{ep.season, ep.number}
+		row, hasRow := matched[ep.tmdbID]
+		occupant, occupied := unmatched[slot]
+		delete(unmatched, slot)
+
+		if hasRow && occupied {
+			if err := mergeEpisode(ctx, q, occupant.ID, row.ID); err != nil {
+				return err
+			}
+			result.EpisodesMerged++
+		}
+		if hasRow && parked[row.ID] {
+			if err := q.MoveEpisode(ctx, dbgen.MoveEpisodeParams{SeasonID: season.ID, EpisodeNumber: ep.number, ID: row.ID}); err != nil {
+				return fmt.Errorf("move episode to S%02dE%02d: %w", ep.season, ep.number, err)
+			}
+			result.EpisodesMoved++
+		}
+
+		var title *string
+		if ep.title != "" {
+			title = &ep.title
+		}
+		var tvdbID *int64
+		if id, ok := tvdbIDs[ep.tmdbID]; ok {
+			tvdbID = &id
+		}
+		tmdbID := ep.tmdbID
+		saved, err := q.UpsertEpisode(ctx, dbgen.UpsertEpisodeParams{
+			SeasonID:      season.ID,
+			EpisodeNumber: ep.number,
+			Title:         title,
+			AirDate:       ep.airDate,
+			TmdbID:        &tmdbID,
+			TvdbID:        tvdbID,
+		})
+		if err != nil {
+			return fmt.Errorf("upsert episode S%02dE%02d: %w", ep.season, ep.number, err)
+		}
+		if !hasRow && !occupied {
+			result.EpisodesAdded++
+			if season.Monitored {
+				if _, err := q.SetEpisodeMonitored(ctx, dbgen.SetEpisodeMonitoredParams{ID: saved.ID, Monitored: true}); err != nil {
+					return fmt.Errorf("set episode monitored: %w", err)
+				}
+			}
+		}
+	}
+
+	// An empty answer from TMDB is more likely a glitch than a series with
+	// every episode removed, so nothing is deleted on one.
+	if len(episodes) == 0 {
+		return nil
+	}
+
+	for _, row := range unmatched {
+		inUse, err := q.EpisodeInUse(ctx, row.ID)
+		if err != nil {
+			return fmt.Errorf("check episode use: %w", err)
+		}
+		if inUse {
+			if row.Monitored {
+				if _, err := q.SetEpisodeMonitored(ctx, dbgen.SetEpisodeMonitoredParams{ID: row.ID, Monitored: false}); err != nil {
+					return fmt.Errorf("unmonitor episode: %w", err)
+				}
+			}
+			result.EpisodesKept++
+			continue
+		}
+		if err := q.DeleteEpisode(ctx, row.ID); err != nil {
+			return fmt.Errorf("delete episode: %w", err)
+		}
+		result.EpisodesRemoved++
+	}
+
+	for _, season := range existingSeasons {
+		if _, ok := seasons[season.SeasonNumber]; ok {
+			continue
+		}
+		if err := q.DeleteSeasonIfUnused(ctx, season.ID); err != nil {
+			return fmt.Errorf("delete season %d: %w", season.SeasonNumber, err)
+		}
+	}
+
+	if err := q.RealignDownloadJobSeasons(ctx, item.ID); err != nil {
+		return fmt.Errorf("realign download job seasons: %w", err)
+	}
+	return nil
+}
+
+// mergeEpisode points everything referencing one episode row at another and
+// deletes the first.
+func mergeEpisode(ctx context.Context, q *dbgen.Queries, from, to pgtype.UUID) error {
+	if err := q.ReassignEpisodeFiles(ctx, dbgen.ReassignEpisodeFilesParams{ToEpisodeID: to, FromEpisodeID: from}); err != nil {
+		return fmt.Errorf("reassign episode files: %w", err)
+	}
+	if err := q.ReassignEpisodeDownloadJobs(ctx, dbgen.ReassignEpisodeDownloadJobsParams{ToEpisodeID: to, FromEpisodeID: from}); err != nil {
+		return fmt.Errorf("reassign episode download jobs: %w", err)
+	}
+	if err := q.ReassignEpisodeImportTasks(ctx, dbgen.ReassignEpisodeImportTasksParams{ToEpisodeID: to, FromEpisodeID: from}); err != nil {
+		return fmt.Errorf("reassign episode import tasks: %w", err)
+	}
+	if err := q.DeleteEpisode(ctx, from); err != nil {
+		return fmt.Errorf("delete merged episode: %w", err)
+	}
+	return nil
+}
+
+func metadataRefreshFor(item dbgen.MediaItem) model.MetadataRefresh {
+	return model.MetadataRefresh{
+		MediaItemID: item.ID.String(),
+		MediaType:   model.MediaType(item.Type),
+		TmdbID:      derefInt64(item.TmdbID),
+		Title:       item.Title,
+		Year:        int32ToIntPtr(item.Year),
+	}
+}
+
+// movieReleaseDates picks the theatrical, digital and physical release dates
+// of a movie: the first of the priority countries that has one, otherwise the
+// earliest anywhere. Limited releases count as theatrical when there is no
+// wide one.
+func movieReleaseDates(dates tmdb.MovieReleaseDates) (theatrical, digital, physical pgtype.Date) {
+	if dates.MovieReleaseDatesResults == nil {
+		return
+	}
+	pick := func(types ...int) pgtype.Date {
+		for _, t := range types {
+			var earliest pgtype.Date
+			for _, country := range []string{"US", "GB", "CA", "AU", ""} {
+				for _, result := range dates.Results {
+					if country != "" && result.Iso3166_1 != country {
+						continue
+					}
+					for _, rd := range result.ReleaseDates {
+						if rd.Type != t || len(rd.ReleaseDate) < len(time.DateOnly) {
+							continue
+						}
+						d := tmdbDate(rd.ReleaseDate[:len(time.DateOnly)])
+						if d.Valid && (!earliest.Valid || d.Time.Before(earliest.Time)) {
+							earliest = d
+						}
+					}
+				}
+				if earliest.Valid {
+					return earliest
+				}
+			}
+		}
+		return pgtype.Date{}
+	}
+	return pick(tmdbReleaseTheatrical, tmdbReleaseLimited), pick(tmdbReleaseDigital), pick(tmdbReleasePhysical)
+}
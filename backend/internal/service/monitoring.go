@@ -0,0 +1,445 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	dbgen "github.com/kyleaupton/arrflix/internal/db/sqlc"
+	"github.com/kyleaupton/arrflix/internal/logger"
+	"github.com/kyleaupton/arrflix/internal/model"
+	"github.com/kyleaupton/arrflix/internal/repo"
+)
+
+var (
+	ErrMonitorInvalid  = errors.New("invalid monitoring request")
+	ErrSeasonNotFound  = errors.New("season not found")
+	ErrEpisodeNotFound = errors.New("episode not found")
+)
+
+// MonitoringService decides which movies, series, seasons and episodes are
+// wanted. Monitoring a title creates its media_item, and for series the
+// seasons and episodes, from TMDB so missing items can be found before
+// anything is downloaded.
+type MonitoringService struct {
+	repo            *repo.Repository
+	logger          *logger.Logger
+	tmdb            *TmdbService
+	media           *MediaService
+	qualityProfiles *QualityProfilesService
+}
+
+func NewMonitoringService(r *repo.Repository, l *logger.Logger, tmdb *TmdbService, media *MediaService, qualityProfiles *QualityProfilesService) *MonitoringService {
+	return &MonitoringService{repo: r, logger: l, tmdb: tmdb, media: media, qualityProfiles: qualityProfiles}
+}
+
+// Get returns the monitoring state of a movie or series. Titles that aren't
+// in the library are reported as not monitored.
+func (s *MonitoringService) Get(ctx context.Context, mediaType model.MediaType, tmdbID int64) (model.Monitoring, error) {
+	item, err := s.repo.GetMediaItemByTmdbIDAndType(ctx, tmdbID, string(mediaType))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return model.Monitoring{Seasons: []model.SeasonMonitoring{}}, nil
+	}
+	if err != nil {
+		return model.Monitoring{}, err
+	}
+	return loadMonitoring(ctx, s.repo, item)
+}
+
+// Monitor sets the monitoring of a movie or series along with its target
+// library and quality profile. libraryID and qualityProfileID may be invalid
+// (NULL) to use the defaults.
+func (s *MonitoringService) Monitor(ctx context.Context, mediaType model.MediaType, tmdbID int64, req model.MonitorRequest, libraryID, qualityProfileID pgtype.UUID) (model.Monitoring, error) {
+	if err := s.validateTargets(ctx, mediaType, libraryID, qualityProfileID); err != nil {
+		return model.Monitoring{}, err
+	}
+	if mediaType == model.MediaTypeMovie && (req.MonitorNewSeasons || req.Seasons != nil) {
+		return model.Monitoring{}, fmt.Errorf("%w: seasons only apply to series", ErrMonitorInvalid)
+	}
+
+	if !req.Monitored {
+		// Unmonitoring doesn't add anything to the library.
+		if _, err := s.repo.GetMediaItemByTmdbIDAndType(ctx, tmdbID, string(mediaType)); errors.Is(err, pgx.ErrNoRows) {
+			return model.Monitoring{Seasons: []model.SeasonMonitoring{}}, nil
+		}
+	}
+
+	item, err := s.media.EnsureMediaItem(ctx, mediaType, tmdbID)
+	if err != nil {
+		return model.Monitoring{}, err
+	}
+	item, err = s.repo.SetMediaItemMonitoring(ctx, item.ID, req.Monitored, req.Monitored && req.MonitorNewSeasons, libraryID)
+	if err != nil {
+		return model.Monitoring{}, fmt.Errorf("set monitoring: %w", err)
+	}
+	if item, err = s.repo.SetMediaItemQualityProfile(ctx, item.ID, qualityProfileID); err != nil {
+		return model.Monitoring{}, fmt.Errorf("set quality profile: %w", err)
+	}
+
+	if mediaType == model.MediaTypeSeries {
+		if err := s.SyncSeries(ctx, item); err != nil {
+			return model.Monitoring{}, err
+		}
+		if req.Monitored {
+			if err := s.monitorSeasons(ctx, item, req.Seasons); err != nil {
+				return model.Monitoring{}, err
+			}
+		}
+	}
+
+	s.logger.Info().Str("type", string(mediaType)).Int64("tmdb_id", tmdbID).Bool("monitored", req.Monitored).Msg("Updated monitoring")
+	return loadMonitoring(ctx, s.repo, item)
+}
+
+// SetSeasonMonitored monitors or unmonitors a season and all of its episodes.
+// Monitoring a season of an unmonitored series monitors the series too.
+func (s *MonitoringService) SetSeasonMonitored(ctx context.Context, tmdbID int64, seasonNumber int, monitored bool) (model.Monitoring, error) {
+	item, err := s.seriesForChange(ctx, tmdbID, monitored)
+	if err != nil {
+		return model.Monitoring{}, err
+	}
+	season, err := s.repo.GetSeasonByNumber(ctx, item.ID, int32(seasonNumber))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return model.Monitoring{}, ErrSeasonNotFound
+	}
+	if err != nil {
+		return model.Monitoring{}, err
+	}
+	if err := s.setSeason(ctx, season.ID, monitored); err != nil {
+		return model.Monitoring{}, err
+	}
+	return loadMonitoring(ctx, s.repo, item)
+}
+
+// SetEpisodeMonitored monitors or unmonitors a single episode. Monitoring an
+// episode also monitors its season, without its other episodes, and series.
+func (s *MonitoringService) SetEpisodeMonitored(ctx context.Context, tmdbID int64, seasonNumber, episodeNumber int, monitored bool) (model.Monitoring, error) {
+	item, err := s.seriesForChange(ctx, tmdbID, monitored)
+	if err != nil {
+		return model.Monitoring{}, err
+	}
+	season, err := s.repo.GetSeasonByNumber(ctx, item.ID, int32(seasonNumber))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return model.Monitoring{}, ErrSeasonNotFound
+	}
+	if err != nil {
+		return model.Monitoring{}, err
+	}
+	episode, err := s.repo.GetEpisodeByNumber(ctx, season.ID, int32(episodeNumber))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return model.Monitoring{}, fmt.Errorf("%w: S%02dE%02d", ErrEpisodeNotFound, seasonNumber, episodeNumber)
+	}
+	if err != nil {
+		return model.Monitoring{}, err
+	}
+
+	if _, err := s.repo.SetEpisodeMonitored(ctx, episode.ID, monitored); err != nil {
+		return model.Monitoring{}, fmt.Errorf("set episode monitored: %w", err)
+	}
+	if monitored && !season.Monitored {
+		if _, err := s.repo.SetSeasonMonitored(ctx, season.ID, true); err != nil {
+			return model.Monitoring{}, fmt.Errorf("set season monitored: %w", err)
+		}
+	}
+	return loadMonitoring(ctx, s.repo, item)
+}
+
+// seriesForChange returns the series for a season or episode change, adding
+// it to the library when monitoring something of a series that isn't there
+// yet, and monitoring the series itself when monitored is set.
+func (s *MonitoringService) seriesForChange(ctx context.Context, tmdbID int64, monitored bool) (dbgen.MediaItem, error) {
+	item, err := s.repo.GetMediaItemByTmdbIDAndType(ctx, tmdbID, string(model.MediaTypeSeries))
+	if errors.Is(err, pgx.ErrNoRows) {
+		if !monitored {
+			return dbgen.MediaItem{}, ErrMediaNotInLibrary
+		}
+		item, err = s.media.EnsureMediaItem(ctx, model.MediaTypeSeries, tmdbID)
+		if err != nil {
+			return dbgen.MediaItem{}, err
+		}
+		if err := s.SyncSeries(ctx, item); err != nil {
+			return dbgen.MediaItem{}, err
+		}
+	} else if err != nil {
+		return dbgen.MediaItem{}, err
+	}
+
+	if monitored && !item.Monitored {
+		item, err = s.repo.SetMediaItemMonitoring(ctx, item.ID, true, item.MonitorNewSeasons, item.LibraryID)
+		if err != nil {
+			return dbgen.MediaItem{}, fmt.Errorf("set monitoring: %w", err)
+		}
+	}
+	return item, nil
+}
+
+// monitorSeasons monitors the given seasons of a series, or every season but
+// specials when seasons is nil, and unmonitors the rest.
+func (s *MonitoringService) monitorSeasons(ctx context.Context, item dbgen.MediaItem, seasons []int) error {
+	rows, err := s.repo.ListSeasonsForMedia(ctx, item.ID)
+	if err != nil {
+		return fmt.Errorf("list seasons: %w", err)
+	}
+	for _, n := range seasons {
+		if !slices.ContainsFunc(rows, func(r dbgen.MediaSeason) bool { return int(r.SeasonNumber) == n }) {
+			return fmt.Errorf("%w: %d", ErrSeasonNotFound, n)
+		}
+	}
+
+	for _, season := range rows {
+		want := season.SeasonNumber > 0
+		if seasons != nil {
+			want = slices.Contains(seasons, int(season.SeasonNumber))
+		}
+		if err := s.setSeason(ctx, season.ID, want); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *MonitoringService) setSeason(ctx context.Context, seasonID pgtype.UUID, monitored bool) error {
+	if _, err := s.repo.SetSeasonMonitored(ctx, seasonID, monitored); err != nil {
+		return fmt.Errorf("set season monitored: %w", err)
+	}
+	if err := s.repo.SetSeasonEpisodesMonitored(ctx, seasonID, monitored); err != nil {
+		return fmt.Errorf("set season episodes monitored: %w", err)
+	}
+	return nil
+}
+
+// SyncSeries creates the seasons and episodes of a series from TMDB. Seasons
+// that are new to a series monitoring new seasons are monitored, as are new
+// episodes of monitored seasons.
+func (s *MonitoringService) SyncSeries(ctx context.Context, item dbgen.MediaItem) error {
+	if item.TmdbID == nil {
+		return fmt.Errorf("series %s has no TMDB ID", item.Title)
+	}
+	details, err := s.tmdb.GetSeriesDetails(ctx, *item.TmdbID)
+	if err != nil {
+		return fmt.Errorf("get series details: %w", err)
+	}
+
+	existing, err := s.repo.ListSeasonsForMedia(ctx, item.ID)
+	if err != nil {
+		return fmt.Errorf("list seasons: %w", err)
+	}
+	known := make(map[int32]bool, len(existing))
+	for _, season := range existing {
+		known[season.SeasonNumber] = true
+	}
+
+	for _, info := range details.Seasons {
+		number := int32(info.SeasonNumber)
+		season, err := s.repo.UpsertSeason(ctx, item.ID, number, tmdbDate(info.AirDate))
+		if err != nil {
+			return fmt.Errorf("upsert season %d: %w", number, err)
+		}
+		if !known[number] && item.Monitored && item.MonitorNewSeasons && number > 0 {
+			if season, err = s.repo.SetSeasonMonitored(ctx, season.ID, true); err != nil {
+				return fmt.Errorf("set season monitored: %w", err)
+			}
+		}
+		if err := s.syncEpisodes(ctx, *item.TmdbID, season); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *MonitoringService) syncEpisodes(ctx context.Context, tmdbID int64, season dbgen.MediaSeason) error {
+	full, err := s.tmdb.GetTVSeasonDetails(ctx, tmdbID, int(season.SeasonNumber))
+	if err != nil {
+		return fmt.Errorf("get season %d: %w", season.SeasonNumber, err)
+	}
+
+	existing, err := s.repo.ListEpisodesForSeason(ctx, season.ID)
+	if err != nil {
+		return fmt.Errorf("list episodes: %w", err)
+	}
+	known := make(map[int32]bool, len(existing))
+	for _, ep := range existing {
+		known[ep.EpisodeNumber] = true
+	}
+
+	for _, info := range full.Episodes {
+		number := int32(info.EpisodeNumber)
+		title, id := info.Name, info.ID
+		ep, err := s.repo.UpsertEpisode(ctx, season.ID, number, &title, tmdbDate(info.AirDate), &id, nil)
+		if err != nil {
+			return fmt.Errorf("upsert episode S%02dE%02d: %w", season.SeasonNumber, number, err)
+		}
+		if !known[number] && season.Monitored {
+			if _, err := s.repo.SetEpisodeMonitored(ctx, ep.ID, true); err != nil {
+				return fmt.Errorf("set episode monitored: %w", err)
+			}
+		}
+	}
+	return nil
+}
+
+// validateTargets checks that the chosen library and quality profile exist
+// and that the library holds the media type.
+func (s *MonitoringService) validateTargets(ctx context.Context, mediaType model.MediaType, libraryID, qualityProfileID pgtype.UUID) error {
+	if libraryID.Valid {
+		library, err := s.repo.GetLibrary(ctx, libraryID)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return fmt.Errorf("%w: library not found", ErrMonitorInvalid)
+		}
+		if err != nil {
+			return err
+		}
+		if library.Type != string(mediaType) {
+			return fmt.Errorf("%w: library %s holds %s, not %s", ErrMonitorInvalid, library.Name, library.Type, mediaType)
+		}
+	}
+	if qualityProfileID.Valid {
+		if _, err := s.qualityProfiles.Get(ctx, qualityProfileID); errors.Is(err, pgx.ErrNoRows) {
+			return fmt.Errorf("%w: quality profile not found", ErrMonitorInvalid)
+		} else if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// loadMonitoring reads the monitoring state of a media item.
+func loadMonitoring(ctx context.Context, r *repo.Repository, item dbgen.MediaItem) (model.Monitoring, error) {
+	counts, err := r.ListMonitoringCounts(ctx, []pgtype.UUID{item.ID})
+	if err != nil {
+		return model.Monitoring{}, fmt.Errorf("count monitored episodes: %w", err)
+	}
+	var episodes []dbgen.ListEpisodeAvailabilityForSeriesRow
+	if item.Type == string(model.MediaTypeSeries) {
+		if episodes, err = r.ListEpisodeAvailabilityForSeries(ctx, item.ID); err != nil {
+			return model.Monitoring{}, fmt.Errorf("list episodes: %w", err)
+		}
+	}
+
+	m := buildMonitoring(item, countsFor(counts, item.ID), episodes)
+	if seasons, err := r.ListSeasonsForMedia(ctx, item.ID); err == nil {
+		m.Seasons = addEmptySeasons(m.Seasons, seasons)
+	}
+	return m, nil
+}
+
+func countsFor(rows []dbgen.ListMonitoringCountsRow, id pgtype.UUID) dbgen.ListMonitoringCountsRow {
+	for _, row := range rows {
+		if row.MediaItemID == id {
+			return row
+		}
+	}
+	return dbgen.ListMonitoringCountsRow{MediaItemID: id}
+}
+
+// buildMonitoring assembles the monitoring state of a media item from its
+// episode counts and, for series, its episodes.
+func buildMonitoring(item dbgen.MediaItem, counts dbgen.ListMonitoringCountsRow, episodes []dbgen.ListEpisodeAvailabilityForSeriesRow) model.Monitoring {
+	m := model.Monitoring{
+		Monitored:         item.Monitored,
+		MonitorNewSeasons: item.MonitorNewSeasons,
+		HasFile:           counts.HasFile,
+	}
+	if item.LibraryID.Valid {
+		id := item.LibraryID.String()
+		m.LibraryID = &id
+	}
+	if item.QualityProfileID.Valid {
+		id := item.QualityProfileID.String()
+		m.QualityProfileID = &id
+	}
+
+	if item.Type == string(model.MediaTypeMovie) {
+		m.Missing = item.Monitored && !counts.HasFile
+		return m
+	}
+
+	m.MonitoredEpisodes = int(counts.MonitoredEpisodes)
+	m.MissingEpisodes = int(counts.MissingEpisodes)
+	m.Missing = m.MissingEpisodes > 0
+	m.Seasons = seasonMonitoring(item, episodes)
+	return m
+}
+
+// seasonMonitoring rolls episode rows (one per episode file) up into seasons.
+func seasonMonitoring(item dbgen.MediaItem, rows []dbgen.ListEpisodeAvailabilityForSeriesRow) []model.SeasonMonitoring {
+	states := episodeStates(item, rows)
+	bySeason := make(map[int32]*model.SeasonMonitoring)
+	for _, row := range rows {
+		if _, ok := bySeason[row.SeasonNumber]; !ok {
+			bySeason[row.SeasonNumber] = &model.SeasonMonitoring{SeasonNumber: row.SeasonNumber, Monitored: row.SeasonMonitored}
+		}
+	}
+	for key, st := range states {
+		season := bySeason[key.season]
+		season.Episodes++
+		if st.monitored {
+			season.MonitoredEpisodes++
+		}
+		if st.missing {
+			season.MissingEpisodes++
+		}
+	}
+
+	out := make([]model.SeasonMonitoring, 0, len(bySeason))
+	for _, season := range bySeason {
+		out = append(out, *season)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].SeasonNumber < out[j].SeasonNumber })
+	return out
+}
+
+// addEmptySeasons adds seasons without episodes to the season list.
+func addEmptySeasons(out []model.SeasonMonitoring, seasons []dbgen.MediaSeason) []model.SeasonMonitoring {
+	for _, season := range seasons {
+		if !slices.ContainsFunc(out, func(m model.SeasonMonitoring) bool { return m.SeasonNumber == season.SeasonNumber }) {
+			out = append(out, model.SeasonMonitoring{SeasonNumber: season.SeasonNumber, Monitored: season.Monitored})
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].SeasonNumber < out[j].SeasonNumber })
+	if out == nil {
+		out = []model.SeasonMonitoring{}
+	}
+	return out
+}
+
+type episodeKey struct{ season, episode int32 }
+
+type episodeState struct {
+	monitored bool // series, season and episode are monitored
+	hasFile   bool
+	missing   bool // monitored, aired and without a file on disk
+}
+
+// episodeStates reduces episode availability rows, which repeat an episode
+// for each of its files, to one state per episode.
+func episodeStates(item dbgen.MediaItem, rows []dbgen.ListEpisodeAvailabilityForSeriesRow) map[episodeKey]episodeState {
+	today := time.Now()
+	states := make(map[episodeKey]episodeState, len(rows))
+	for _, row := range rows {
+		key := episodeKey{row.SeasonNumber, row.EpisodeNumber}
+		st := states[key]
+		st.monitored = item.Monitored && row.SeasonMonitored && row.Monitored
+		if row.FileID.Valid && (row.FileExists == nil || *row.FileExists) {
+			st.hasFile = true
+		}
+		aired := row.AirDate.Valid && !row.AirDate.Time.After(today)
+		st.missing = st.monitored && aired && !st.hasFile
+		states[key] = st
+	}
+	return states
+}
+
+// tmdbDate converts a TMDB "2006-01-02" date; empty or malformed dates are NULL.
+func tmdbDate(s string) pgtype.Date {
+	t, err := time.Parse(time.DateOnly, s)
+	if err != nil {
+		return pgtype.Date{}
+	}
+	return pgtype.Date{Time: t, Valid: true}
+}
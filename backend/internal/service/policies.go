@@ -3,6 +3,8 @@ package service
 import (
 	"context"
 	"errors"
+	"strconv"
+	"strings"
 
 	"github.com/jackc/pgx/v5/pgtype"
 	dbgen "github.com/kyleaupton/arrflix/internal/db/sqlc"
@@ -102,7 +104,7 @@ func (s *PoliciesService) GetAction(ctx context.Context, id pgtype.UUID) (dbgen.
 
 func (s *PoliciesService) CreateAction(ctx context.Context, policyID pgtype.UUID, actionType, value string, order int32) (dbgen.Action, error) {
 	// Validate action type
-	validTypes := []string{"set_downloader", "set_library", "set_name_template", "stop_processing"}
+	validTypes := []string{"set_downloader", "set_library", "set_name_template", "stop_processing", "add_score", "reject"}
 	valid := false
 	for _, t := range validTypes {
 		if actionType == t {
@@ -117,13 +119,18 @@ func (s *PoliciesService) CreateAction(ctx context.Context, policyID pgtype.UUID
 	if value == "" && actionType != "stop_processing" {
 		return dbgen.Action{}, errors.New("value required for action type")
 	}
+	if actionType == "add_score" {
+		if _, err := strconv.Atoi(strings.TrimSpace(value)); err != nil {
+			return dbgen.Action{}, errors.New("score must be an integer")
+		}
+	}
 
 	return s.repo.CreateAction(ctx, policyID, actionType, value, order)
 }
 
 func (s *PoliciesService) UpdateAction(ctx context.Context, id pgtype.UUID, actionType, value string, order int32) (dbgen.Action, error) {
 	// Validate action type
-	validTypes := []string{"set_downloader", "set_library", "set_name_template", "stop_processing"}
+	validTypes := []string{"set_downloader", "set_library", "set_name_template", "stop_processing", "add_score", "reject"}
 	valid := false
 	for _, t := range validTypes {
 		if actionType == t {
@@ -138,6 +145,11 @@ func (s *PoliciesService) UpdateAction(ctx context.Context, id pgtype.UUID, acti
 	if value == "" && actionType != "stop_processing" {
 		return dbgen.Action{}, errors.New("value required for action type")
 	}
+	if actionType == "add_score" {
+		if _, err := strconv.Atoi(strings.TrimSpace(value)); err != nil {
+			return dbgen.Action{}, errors.New("score must be an integer")
+		}
+	}
 
 	return s.repo.UpdateAction(ctx, id, actionType, value, order)
 }
@@ -0,0 +1,387 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	dbgen "github.com/kyleaupton/arrflix/internal/db/sqlc"
+	"github.com/kyleaupton/arrflix/internal/logger"
+	"github.com/kyleaupton/arrflix/internal/model"
+	"github.com/kyleaupton/arrflix/internal/qualityprofile"
+	"github.com/kyleaupton/arrflix/internal/release"
+	"github.com/kyleaupton/arrflix/internal/repo"
+)
+
+var (
+	ErrQualityProfileInvalid = errors.New("invalid quality profile")
+	ErrQualityProfileDefault = errors.New("the default quality profile cannot be deleted")
+	ErrMediaNotInLibrary     = errors.New("media is not in the library")
+)
+
+// Where the quality profile for a media item comes from
+const (
+	QualityProfileSourceMedia   = "media"
+	QualityProfileSourceLibrary = "library"
+	QualityProfileSourceDefault = "default"
+)
+
+type QualityProfilesService struct {
+	repo   *repo.Repository
+	logger *logger.Logger
+}
+
+func NewQualityProfilesService(r *repo.Repository, l *logger.Logger) *QualityProfilesService {
+	return &QualityProfilesService{repo: r, logger: l}
+}
+
+func (s *QualityProfilesService) List(ctx context.Context) ([]model.QualityProfile, error) {
+	rows, err := s.repo.ListQualityProfiles(ctx)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]model.QualityProfile, 0, len(rows))
+	for _, row := range rows {
+		p, err := qualityProfileToModel(row)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, p)
+	}
+	return out, nil
+}
+
+func (s *QualityProfilesService) Get(ctx context.Context, id pgtype.UUID) (model.QualityProfile, error) {
+	row, err := s.repo.GetQualityProfile(ctx, id)
+	if err != nil {
+		return model.QualityProfile{}, err
+	}
+	return qualityProfileToModel(row)
+}
+
+func (s *QualityProfilesService) Create(ctx context.Context, req model.QualityProfileRequest) (model.QualityProfile, error) {
+	items, err := validateQualityProfile(req)
+	if err != nil {
+		return model.QualityProfile{}, err
+	}
+	row, err := s.repo.CreateQualityProfile(ctx, req.Name, items, req.Cutoff, req.UpgradesAllowed, false)
+	if err != nil {
+		return model.QualityProfile{}, err
+	}
+	if req.Default {
+		if row, err = s.makeDefault(ctx, row); err != nil {
+			return model.QualityProfile{}, err
+		}
+	}
+	return qualityProfileToModel(row)
+}
+
+func (s *QualityProfilesService) Update(ctx context.Context, id pgtype.UUID, req model.QualityProfileRequest) (model.QualityProfile, error) {
+	items, err := validateQualityProfile(req)
+	if err != nil {
+		return model.QualityProfile{}, err
+	}
+	current, err := s.repo.GetQualityProfile(ctx, id)
+	if err != nil {
+		return model.QualityProfile{}, err
+	}
+	// The default moves by making another profile the default, never by unsetting it
+	row, err := s.repo.UpdateQualityProfile(ctx, id, req.Name, items, req.Cutoff, req.UpgradesAllowed, current.Default)
+	if err != nil {
+		return model.QualityProfile{}, err
+	}
+	if req.Default && !current.Default {
+		if row, err = s.makeDefault(ctx, row); err != nil {
+			return model.QualityProfile{}, err
+		}
+	}
+	return qualityProfileToModel(row)
+}
+
+// Delete removes a profile. Libraries and media items using it fall back to
+// the next profile in line.
+func (s *QualityProfilesService) Delete(ctx context.Context, id pgtype.UUID) error {
+	row, err := s.repo.GetQualityProfile(ctx, id)
+	if err != nil {
+		return err
+	}
+	if row.Default {
+		return ErrQualityProfileDefault
+	}
+	return s.repo.DeleteQualityProfile(ctx, id)
+}
+
+// makeDefault unsets the previous default before flagging row, since only
+// one profile can be the default.
+func (s *QualityProfilesService) makeDefault(ctx context.Context, row dbgen.QualityProfile) (dbgen.QualityProfile, error) {
+	if err := s.repo.UnsetDefaultQualityProfiles(ctx, row.ID); err != nil {
+		return dbgen.QualityProfile{}, err
+	}
+	return s.repo.UpdateQualityProfile(ctx, row.ID, row.Name, row.Items, row.Cutoff, row.UpgradesAllowed, true)
+}
+
+// SetForMedia assigns a profile to a movie or series in the library. An
+// invalid profileID clears the assignment.
+func (s *QualityProfilesService) SetForMedia(ctx context.Context, mediaType model.MediaType, tmdbID int64, profileID pgtype.UUID) (model.MediaQualityProfile, error) {
+	item, err := s.repo.GetMediaItemByTmdbIDAndType(ctx, tmdbID, string(mediaType))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return model.MediaQualityProfile{}, ErrMediaNotInLibrary
+		}
+		return model.MediaQualityProfile{}, err
+	}
+	if profileID.Valid {
+		if _, err := s.repo.GetQualityProfile(ctx, profileID); err != nil {
+			return model.MediaQualityProfile{}, err
+		}
+	}
+	if _, err := s.repo.SetMediaItemQualityProfile(ctx, item.ID, profileID); err != nil {
+		return model.MediaQualityProfile{}, err
+	}
+	return s.ForMedia(ctx, mediaType, tmdbID)
+}
+
+// ForMedia returns the profile that applies to a movie or series.
+func (s *QualityProfilesService) ForMedia(ctx context.Context, mediaType model.MediaType, tmdbID int64) (model.MediaQualityProfile, error) {
+	row, source, err := s.resolve(ctx, mediaType, tmdbID)
+	if err != nil {
+		return model.MediaQualityProfile{}, err
+	}
+	p, err := qualityProfileToModel(row)
+	if err != nil {
+		return model.MediaQualityProfile{}, err
+	}
+	return model.MediaQualityProfile{Profile: p, Source: source}, nil
+}
+
+// resolve finds the profile for a movie or series: its own, then that of the
+// library it is monitored into or that holds its files (or the default
+// library for its type), then the default profile.
+func (s *QualityProfilesService) resolve(ctx context.Context, mediaType model.MediaType, tmdbID int64) (dbgen.QualityProfile, string, error) {
+	item, err := s.repo.GetMediaItemByTmdbIDAndType(ctx, tmdbID, string(mediaType))
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return dbgen.QualityProfile{}, "", err
+	}
+	inLibrary := err == nil
+
+	if inLibrary && item.QualityProfileID.Valid {
+		if row, err := s.repo.GetQualityProfile(ctx, item.QualityProfileID); err == nil {
+			return row, QualityProfileSourceMedia, nil
+		}
+	}
+
+	var library dbgen.Library
+	libraryErr := pgx.ErrNoRows
+	if inLibrary && item.LibraryID.Valid {
+		library, libraryErr = s.repo.GetLibrary(ctx, item.LibraryID)
+	}
+	if inLibrary && libraryErr != nil {
+		if files, err := s.repo.ListMediaFilesForItem(ctx, item.ID); err == nil && len(files) > 0 {
+			library, libraryErr = s.repo.GetLibrary(ctx, files[0].LibraryID)
+		}
+	}
+	if libraryErr != nil {
+		library, libraryErr = s.repo.GetDefaultLibrary(ctx, string(mediaType))
+	}
+	if libraryErr == nil && library.QualityProfileID.Valid {
+		if row, err := s.repo.GetQualityProfile(ctx, library.QualityProfileID); err == nil {
+			return row, QualityProfileSourceLibrary, nil
+		}
+	}
+
+	row, err := s.repo.GetDefaultQualityProfile(ctx)
+	if err != nil {
+		return dbgen.QualityProfile{}, "", err
+	}
+	return row, QualityProfileSourceDefault, nil
+}
+
+// profileCheck decides on search results for one movie, season or episode:
+// the profile that applies and the quality of the file already in the library.
+type profileCheck struct {
+	profile  qualityprofile.Profile
+	existing *release.Quality
+	runtime  int // minutes for the whole target, zero when unknown
+}
+
+// checkFor builds a profileCheck for a movie (season and episode nil), a
+// series season, or an episode. It returns nil when no profile applies.
+// runtime is the expected runtime of the target in minutes, or zero.
+func (s *QualityProfilesService) checkFor(ctx context.Context, mediaType model.MediaType, tmdbID int64, season, episode *int, runtime int) *profileCheck {
+	row, _, err := s.resolve(ctx, mediaType, tmdbID)
+	if err != nil {
+		if !errors.Is(err, pgx.ErrNoRows) {
+			s.logger.Warn().Err(err).Int64("tmdb_id", tmdbID).Msg("Failed to resolve quality profile")
+		}
+		return nil
+	}
+	profile, err := profileFromRow(row)
+	if err != nil {
+		s.logger.Warn().Err(err).Str("profile", row.Name).Msg("Failed to decode quality profile")
+		return nil
+	}
+
+	check := &profileCheck{profile: profile, runtime: runtime}
+	if item, err := s.repo.GetMediaItemByTmdbIDAndType(ctx, tmdbID, string(mediaType)); err == nil {
+		check.existing = s.existingQuality(ctx, profile, item.ID, mediaType, season, episode)
+	}
+	return check
+}
+
+// belowCutoff reports whether a movie, season or episode has a file the
+// profile would upgrade: one whose quality is below the cutoff, in a profile
+// that allows upgrades.
+func (s *QualityProfilesService) belowCutoff(ctx context.Context, mediaType model.MediaType, tmdbID int64, season, episode *int) bool {
+	check := s.checkFor(ctx, mediaType, tmdbID, season, episode, 0)
+	return check != nil && check.existing != nil && check.profile.UpgradesAllowed && !check.profile.MeetsCutoff(*check.existing)
+}
+
+// existingQuality returns the quality the target already has in the library,
+// or nil when something is missing. A movie has the quality of its best file.
+// A season or series has the quality of its worst episode, and none while any
+// aired episode has no file, so a pack that fills the gaps is allowed.
+func (s *QualityProfilesService) existingQuality(ctx context.Context, profile qualityprofile.Profile, mediaItemID pgtype.UUID, mediaType model.MediaType, season, episode *int) *release.Quality {
+	files, err := s.repo.ListMediaFilesForItem(ctx, mediaItemID)
+	if err != nil {
+		s.logger.Warn().Err(err).Msg("Failed to list media files for quality profile check")
+		return nil
+	}
+
+	episodeQuality := make(map[episodeKey]release.Quality)
+	var best *release.Quality
+	for _, f := range files {
+		if f.FileExists != nil && !*f.FileExists {
+			continue
+		}
+		q := qualityprofile.ExistingQuality(f.Quality, f.Path)
+		if mediaType == model.MediaTypeMovie {
+			if best == nil || profile.Rank(q) > profile.Rank(*best) {
+				best = &q
+			}
+			continue
+		}
+		if f.SeasonNumber == nil || f.EpisodeNumber == nil {
+			continue
+		}
+		key := episodeKey{*f.SeasonNumber, *f.EpisodeNumber}
+		if prev, ok := episodeQuality[key]; !ok || profile.Rank(q) > profile.Rank(prev) {
+			episodeQuality[key] = q
+		}
+	}
+	if mediaType == model.MediaTypeMovie {
+		return best
+	}
+
+	episodes, err := s.repo.ListEpisodeAvailabilityForSeries(ctx, mediaItemID)
+	if err != nil {
+		s.logger.Warn().Err(err).Msg("Failed to list episodes for quality profile check")
+		return nil
+	}
+	now := time.Now()
+	var worst *release.Quality
+	for _, ep := range episodes {
+		if season != nil && int(ep.SeasonNumber) != *season {
+			continue
+		}
+		if season == nil && ep.SeasonNumber == 0 {
+			continue // specials aren't part of a series pack
+		}
+		if episode != nil && int(ep.EpisodeNumber) != *episode {
+			continue
+		}
+		q, ok := episodeQuality[episodeKey{ep.SeasonNumber, ep.EpisodeNumber}]
+		if !ok {
+			if ep.AirDate.Valid && ep.AirDate.Time.After(now) {
+				continue // not aired yet
+			}
+			return nil
+		}
+		if worst == nil || profile.Rank(q) < profile.Rank(*worst) {
+			worst = &q
+		}
+	}
+	return worst
+}
+
+// decide evaluates a candidate against the profile.
+func (c *profileCheck) decide(candidate model.DownloadCandidate) qualityprofile.Decision {
+	return c.profile.Evaluate(qualityprofile.Candidate{
+		Quality:        release.Parse(candidate.Title).Quality.Quality,
+		Size:           candidate.Size,
+		RuntimeMinutes: c.runtime,
+	}, c.existing)
+}
+
+// mark sets the profile verdict on a search result.
+func (c *profileCheck) mark(candidate *model.DownloadCandidate) {
+	d := c.decide(*candidate)
+	candidate.ProfileStatus = string(d.Status)
+	candidate.ProfileReason = d.Reason
+}
+
+// fields returns the profile.* policy fields for a candidate.
+func (c *profileCheck) fields(candidate model.DownloadCandidate) model.ProfileFields {
+	d := c.decide(candidate)
+	f := model.ProfileFields{
+		Name:        c.profile.Name,
+		Status:      string(d.Status),
+		Reason:      d.Reason,
+		Allowed:     d.Status != qualityprofile.StatusRejected,
+		IsUpgrade:   d.Status == qualityprofile.StatusUpgrade,
+		MeetsCutoff: d.MeetsCutoff,
+		Rank:        d.Rank,
+		Cutoff:      c.profile.Cutoff,
+		HasFile:     c.existing != nil,
+	}
+	if c.existing != nil {
+		f.ExistingQuality = c.existing.String()
+	}
+	return f
+}
+
+func validateQualityProfile(req model.QualityProfileRequest) ([]byte, error) {
+	p := qualityprofile.Profile{
+		Name:            req.Name,
+		Items:           req.Items,
+		Cutoff:          req.Cutoff,
+		UpgradesAllowed: req.UpgradesAllowed,
+	}
+	if err := p.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrQualityProfileInvalid, err)
+	}
+	return json.Marshal(req.Items)
+}
+
+func profileFromRow(row dbgen.QualityProfile) (qualityprofile.Profile, error) {
+	var items []qualityprofile.Item
+	if err := json.Unmarshal(row.Items, &items); err != nil {
+		return qualityprofile.Profile{}, fmt.Errorf("decode quality profile items: %w", err)
+	}
+	return qualityprofile.Profile{
+		Name:            row.Name,
+		Items:           items,
+		Cutoff:          row.Cutoff,
+		UpgradesAllowed: row.UpgradesAllowed,
+	}, nil
+}
+
+func qualityProfileToModel(row dbgen.QualityProfile) (model.QualityProfile, error) {
+	p, err := profileFromRow(row)
+	if err != nil {
+		return model.QualityProfile{}, err
+	}
+	return model.QualityProfile{
+		ID:              row.ID.String(),
+		Name:            row.Name,
+		Items:           p.Items,
+		Cutoff:          row.Cutoff,
+		UpgradesAllowed: row.UpgradesAllowed,
+		Default:         row.Default,
+		CreatedAt:       row.CreatedAt,
+		UpdatedAt:       row.UpdatedAt,
+	}, nil
+}
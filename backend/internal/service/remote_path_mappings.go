@@ -0,0 +1,205 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	dbgen "github.com/kyleaupton/arrflix/internal/db/sqlc"
+	"github.com/kyleaupton/arrflix/internal/downloader"
+	"github.com/kyleaupton/arrflix/internal/logger"
+	"github.com/kyleaupton/arrflix/internal/model"
+	"github.com/kyleaupton/arrflix/internal/pathmapping"
+	"github.com/kyleaupton/arrflix/internal/repo"
+)
+
+var (
+	ErrRemotePathMappingInvalid  = errors.New("invalid remote path mapping")
+	ErrRemotePathMappingNotFound = errors.New("remote path mapping not found")
+	ErrRemotePathCheck           = errors.New("can't check remote paths")
+)
+
+// RemotePathMappingsService manages the remote path mappings applied to
+// paths reported by downloaders, and checks them against a downloader.
+type RemotePathMappingsService struct {
+	repo   *repo.Repository
+	logger *logger.Logger
+	mapper *pathmapping.Mapper
+}
+
+// NewRemotePathMappingsService creates a new remote path mappings service
+func NewRemotePathMappingsService(r *repo.Repository, l *logger.Logger) *RemotePathMappingsService {
+	return &RemotePathMappingsService{repo: r, logger: l, mapper: pathmapping.New(r)}
+}
+
+// List lists the mappings of every downloader.
+func (s *RemotePathMappingsService) List(ctx context.Context) ([]model.RemotePathMapping, error) {
+	rows, err := s.repo.ListRemotePathMappings(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list remote path mappings: %w", err)
+	}
+	out := make([]model.RemotePathMapping, 0, len(rows))
+	for _, row := range rows {
+		m := remotePathMapping(dbgen.RemotePathMapping{
+			ID: row.ID, DownloaderID: row.DownloaderID, RemotePath: row.RemotePath, LocalPath: row.LocalPath,
+			CreatedAt: row.CreatedAt, UpdatedAt: row.UpdatedAt,
+		})
+		m.DownloaderName = row.DownloaderName
+		out = append(out, m)
+	}
+	return out, nil
+}
+
+// Get returns a mapping.
+func (s *RemotePathMappingsService) Get(ctx context.Context, id pgtype.UUID) (model.RemotePathMapping, error) {
+	row, err := s.repo.GetRemotePathMapping(ctx, id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return model.RemotePathMapping{}, ErrRemotePathMappingNotFound
+	}
+	if err != nil {
+		return model.RemotePathMapping{}, err
+	}
+	return remotePathMapping(row), nil
+}
+
+// Create adds a mapping to a downloader.
+func (s *RemotePathMappingsService) Create(ctx context.Context, req model.RemotePathMappingRequest) (model.RemotePathMapping, error) {
+	downloaderID, remotePath, localPath, err := s.validate(ctx, pgtype.UUID{}, req)
+	if err != nil {
+		return model.RemotePathMapping{}, err
+	}
+	row, err := s.repo.CreateRemotePathMapping(ctx, downloaderID, remotePath, localPath)
+	if err != nil {
+		return model.RemotePathMapping{}, fmt.Errorf("create remote path mapping: %w", err)
+	}
+	return remotePathMapping(row), nil
+}
+
+// Update replaces a mapping.
+func (s *RemotePathMappingsService) Update(ctx context.Context, id pgtype.UUID, req model.RemotePathMappingRequest) (model.RemotePathMapping, error) {
+	downloaderID, remotePath, localPath, err := s.validate(ctx, id, req)
+	if err != nil {
+		return model.RemotePathMapping{}, err
+	}
+	row, err := s.repo.UpdateRemotePathMapping(ctx, id, downloaderID, remotePath, localPath)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return model.RemotePathMapping{}, ErrRemotePathMappingNotFound
+	}
+	if err != nil {
+		return model.RemotePathMapping{}, fmt.Errorf("update remote path mapping: %w", err)
+	}
+	return remotePathMapping(row), nil
+}
+
+// Delete removes a mapping.
+func (s *RemotePathMappingsService) Delete(ctx context.Context, id pgtype.UUID) error {
+	return s.repo.DeleteRemotePathMapping(ctx, id)
+}
+
+// Check asks a downloader for the path of its most recently added item,
+// applies the downloader's mappings and reports whether Arrflix can see the
+// result.
+func (s *RemotePathMappingsService) Check(ctx context.Context, downloaderID pgtype.UUID, client downloader.Client) (model.RemotePathCheck, error) {
+	items, err := client.List(ctx)
+	if errors.Is(err, downloader.ErrUnsupported) {
+		return model.RemotePathCheck{}, fmt.Errorf("%w: the downloader can't list its items", ErrRemotePathCheck)
+	}
+	if err != nil {
+		return model.RemotePathCheck{}, fmt.Errorf("list downloader items: %w", err)
+	}
+
+	var latest *downloader.Item
+	for i, item := range items {
+		if item.ContentPath == "" && item.SavePath == "" {
+			continue
+		}
+		if latest == nil || item.AddedAt.After(latest.AddedAt) {
+			latest = &items[i]
+		}
+	}
+	if latest == nil {
+		return model.RemotePathCheck{}, fmt.Errorf("%w: the downloader has no items", ErrRemotePathCheck)
+	}
+
+	mappings, err := s.mapper.Mappings(ctx, downloaderID)
+	if err != nil {
+		return model.RemotePathCheck{}, fmt.Errorf("list remote path mappings: %w", err)
+	}
+	remotePath := latest.ContentPath
+	if remotePath == "" {
+		remotePath = latest.SavePath
+	}
+	localPath, mapping, mapped := pathmapping.Map(mappings, remotePath)
+
+	check := model.RemotePathCheck{
+		DownloaderID: downloaderID.String(),
+		ItemName:     latest.Name,
+		RemotePath:   remotePath,
+		LocalPath:    localPath,
+		Mapped:       mapped,
+	}
+	if mapped {
+		check.MappingID = mapping.ID.String()
+	}
+	_, err = os.Stat(localPath)
+	check.Exists = err == nil
+	switch {
+	case check.Exists:
+		check.Message = fmt.Sprintf("Arrflix can see %s", localPath)
+	case mapped:
+		check.Message = fmt.Sprintf("%s maps to %s, which Arrflix can't see. Check the mapping's local path and how the storage is mounted.", remotePath, localPath)
+	default:
+		check.Message = fmt.Sprintf("Arrflix can't see %s and no mapping matches it. Map the downloader's path to where Arrflix mounts the same storage.", remotePath)
+	}
+	return check, nil
+}
+
+// validate checks a mapping and returns its downloader and trimmed paths. The
+// local path must be absolute; a downloader maps each remote path once.
+func (s *RemotePathMappingsService) validate(ctx context.Context, id pgtype.UUID, req model.RemotePathMappingRequest) (pgtype.UUID, string, string, error) {
+	var downloaderID pgtype.UUID
+	if err := downloaderID.Scan(req.DownloaderID); err != nil {
+		return pgtype.UUID{}, "", "", fmt.Errorf("%w: invalid downloaderId", ErrRemotePathMappingInvalid)
+	}
+	if _, err := s.repo.GetDownloader(ctx, downloaderID); errors.Is(err, pgx.ErrNoRows) {
+		return pgtype.UUID{}, "", "", fmt.Errorf("%w: downloader not found", ErrRemotePathMappingInvalid)
+	} else if err != nil {
+		return pgtype.UUID{}, "", "", fmt.Errorf("get downloader: %w", err)
+	}
+
+	remotePath, localPath := strings.TrimSpace(req.RemotePath), strings.TrimSpace(req.LocalPath)
+	if remotePath == "" || localPath == "" {
+		return pgtype.UUID{}, "", "", fmt.Errorf("%w: remotePath and localPath are required", ErrRemotePathMappingInvalid)
+	}
+	if !filepath.IsAbs(localPath) {
+		return pgtype.UUID{}, "", "", fmt.Errorf("%w: localPath must be absolute", ErrRemotePathMappingInvalid)
+	}
+	localPath = filepath.Clean(localPath)
+
+	existing, err := s.repo.ListRemotePathMappingsForDownloader(ctx, downloaderID)
+	if err != nil {
+		return pgtype.UUID{}, "", "", fmt.Errorf("list remote path mappings: %w", err)
+	}
+	for _, m := range existing {
+		if m.ID != id && m.RemotePath == remotePath {
+			return pgtype.UUID{}, "", "", fmt.Errorf("%w: %s is already mapped for this downloader", ErrRemotePathMappingInvalid, remotePath)
+		}
+	}
+	return downloaderID, remotePath, localPath, nil
+}
+
+func remotePathMapping(row dbgen.RemotePathMapping) model.RemotePathMapping {
+	return model.RemotePathMapping{
+		ID:           row.ID.String(),
+		DownloaderID: row.DownloaderID.String(),
+		RemotePath:   row.RemotePath,
+		LocalPath:    row.LocalPath,
+		CreatedAt:    row.CreatedAt,
+		UpdatedAt:    row.UpdatedAt,
+	}
+}
@@ -0,0 +1,417 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	dbgen "github.com/kyleaupton/arrflix/internal/db/sqlc"
+	"github.com/kyleaupton/arrflix/internal/logger"
+	"github.com/kyleaupton/arrflix/internal/model"
+	"github.com/kyleaupton/arrflix/internal/policy"
+	"github.com/kyleaupton/arrflix/internal/repo"
+)
+
+var (
+	ErrRequestRuleInvalid  = errors.New("invalid request rule")
+	ErrRequestRuleNotFound = errors.New("request rule not found")
+	ErrRequestQuotaInvalid = errors.New("invalid request quota")
+	// ErrRequestRuleForbidden is returned when a user without requests.approve
+	// changes quotas or rules, or lists quotas other than their own.
+	ErrRequestRuleForbidden = errors.New("not allowed")
+)
+
+// defaultQuotaWindowDays is the window of quotas set without one, and the
+// window usage is reported over when there is no limit.
+const defaultQuotaWindowDays = 7
+
+// RequestRulesService manages request quotas and auto-approval rules, and
+// applies them to new requests.
+type RequestRulesService struct {
+	repo   *repo.Repository
+	logger *logger.Logger
+	engine *policy.Engine
+}
+
+// NewRequestRulesService creates a new request rules service
+func NewRequestRulesService(r *repo.Repository, l *logger.Logger, engine *policy.Engine) *RequestRulesService {
+	return &RequestRulesService{repo: r, logger: l, engine: engine}
+}
+
+// ListQuotas lists the quotas of every role and user. Users without
+// requests.approve only see the quotas that apply to them.
+func (s *RequestRulesService) ListQuotas(ctx context.Context, userID pgtype.UUID) ([]model.RequestQuota, error) {
+	manager, err := s.can(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	var rows []dbgen.RequestQuota
+	if manager {
+		rows, err = s.repo.ListRequestQuotas(ctx)
+	} else {
+		rows, err = s.repo.ListRequestQuotasForUser(ctx, userID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("list request quotas: %w", err)
+	}
+	out := make([]model.RequestQuota, 0, len(rows))
+	for _, row := range rows {
+		out = append(out, requestQuota(row))
+	}
+	return out, nil
+}
+
+// SetQuota sets the quota of a role or user.
+func (s *RequestRulesService) SetQuota(ctx context.Context, userID pgtype.UUID, subjectType string, subjectID pgtype.UUID, req model.RequestQuotaRequest) (model.RequestQuota, error) {
+	if err := s.require(ctx, userID); err != nil {
+		return model.RequestQuota{}, err
+	}
+	subject, err := s.subject(ctx, subjectType, subjectID)
+	if err != nil {
+		return model.RequestQuota{}, err
+	}
+	if (req.MovieLimit != nil && *req.MovieLimit < 0) || (req.SeasonLimit != nil && *req.SeasonLimit < 0) {
+		return model.RequestQuota{}, fmt.Errorf("%w: limits can't be negative", ErrRequestQuotaInvalid)
+	}
+	if req.WindowDays < 0 {
+		return model.RequestQuota{}, fmt.Errorf("%w: windowDays must be positive", ErrRequestQuotaInvalid)
+	}
+	window := req.WindowDays
+	if window == 0 {
+		window = defaultQuotaWindowDays
+	}
+
+	row, err := s.repo.UpsertRequestQuota(ctx, dbgen.UpsertRequestQuotaParams{
+		SubjectType: subject,
+		SubjectID:   subjectID,
+		MovieLimit:  intToInt32Ptr(req.MovieLimit),
+		SeasonLimit: intToInt32Ptr(req.SeasonLimit),
+		WindowDays:  int32(window),
+	})
+	if err != nil {
+		return model.RequestQuota{}, fmt.Errorf("set request quota: %w", err)
+	}
+	return requestQuota(row), nil
+}
+
+// DeleteQuota removes the quota of a role or user.
+func (s *RequestRulesService) DeleteQuota(ctx context.Context, userID pgtype.UUID, subjectType string, subjectID pgtype.UUID) error {
+	if err := s.require(ctx, userID); err != nil {
+		return err
+	}
+	subject, err := subjectTypeFromString(subjectType)
+	if err != nil {
+		return err
+	}
+	return s.repo.DeleteRequestQuota(ctx, subject, subjectID)
+}
+
+// Usage returns what a user has requested within their quota windows.
+func (s *RequestRulesService) Usage(ctx context.Context, userID pgtype.UUID) (model.RequestQuotaUsage, error) {
+	quota, err := s.quota(ctx, userID)
+	if err != nil {
+		return model.RequestQuotaUsage{}, err
+	}
+	now := time.Now()
+	row, err := s.repo.GetUserRequestUsage(ctx, userID,
+		now.AddDate(0, 0, -quota.movies.windowDays), now.AddDate(0, 0, -quota.seasons.windowDays))
+	if err != nil {
+		return model.RequestQuotaUsage{}, fmt.Errorf("get request usage: %w", err)
+	}
+	return model.RequestQuotaUsage{
+		Movies:  quota.movies.usage(int(row.Movies)),
+		Seasons: quota.seasons.usage(int(row.Seasons)),
+	}, nil
+}
+
+// ListRules lists the request rules of every role, highest priority first.
+func (s *RequestRulesService) ListRules(ctx context.Context) ([]model.RequestRule, error) {
+	rows, err := s.repo.ListRequestRules(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list request rules: %w", err)
+	}
+	out := make([]model.RequestRule, 0, len(rows))
+	for _, row := range rows {
+		rule := requestRule(dbgen.RequestRule{
+			ID: row.ID, RoleID: row.RoleID, Name: row.Name, Enabled: row.Enabled, Priority: row.Priority,
+			LeftOperand: row.LeftOperand, Operator: row.Operator, RightOperand: row.RightOperand,
+			Action: row.Action, CreatedAt: row.CreatedAt, UpdatedAt: row.UpdatedAt,
+		})
+		rule.RoleName = row.RoleName
+		out = append(out, rule)
+	}
+	return out, nil
+}
+
+// GetRule returns a request rule.
+func (s *RequestRulesService) GetRule(ctx context.Context, id pgtype.UUID) (model.RequestRule, error) {
+	row, err := s.repo.GetRequestRule(ctx, id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return model.RequestRule{}, ErrRequestRuleNotFound
+	}
+	if err != nil {
+		return model.RequestRule{}, err
+	}
+	return requestRule(row), nil
+}
+
+// CreateRule creates a request rule.
+func (s *RequestRulesService) CreateRule(ctx context.Context, userID pgtype.UUID, req model.RequestRuleRequest) (model.RequestRule, error) {
+	if err := s.require(ctx, userID); err != nil {
+		return model.RequestRule{}, err
+	}
+	params, err := s.ruleParams(ctx, req)
+	if err != nil {
+		return model.RequestRule{}, err
+	}
+	row, err := s.repo.CreateRequestRule(ctx, params)
+	if err != nil {
+		return model.RequestRule{}, fmt.Errorf("create request rule: %w", err)
+	}
+	return requestRule(row), nil
+}
+
+// UpdateRule replaces a request rule.
+func (s *RequestRulesService) UpdateRule(ctx context.Context, userID, id pgtype.UUID, req model.RequestRuleRequest) (model.RequestRule, error) {
+	if err := s.require(ctx, userID); err != nil {
+		return model.RequestRule{}, err
+	}
+	params, err := s.ruleParams(ctx, req)
+	if err != nil {
+		return model.RequestRule{}, err
+	}
+	row, err := s.repo.UpdateRequestRule(ctx, dbgen.UpdateRequestRuleParams{
+		RoleID:       params.RoleID,
+		Name:         params.Name,
+		Enabled:      params.Enabled,
+		Priority:     params.Priority,
+		LeftOperand:  params.LeftOperand,
+		Operator:     params.Operator,
+		RightOperand: params.RightOperand,
+		Action:       params.Action,
+		ID:           id,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return model.RequestRule{}, ErrRequestRuleNotFound
+	}
+	if err != nil {
+		return model.RequestRule{}, fmt.Errorf("update request rule: %w", err)
+	}
+	return requestRule(row), nil
+}
+
+// DeleteRule deletes a request rule.
+func (s *RequestRulesService) DeleteRule(ctx context.Context, userID, id pgtype.UUID) error {
+	if err := s.require(ctx, userID); err != nil {
+		return err
+	}
+	return s.repo.DeleteRequestRule(ctx, id)
+}
+
+// Evaluate decides whether a new request of the user is approved right away.
+func (s *RequestRulesService) Evaluate(ctx context.Context, userID pgtype.UUID, reqCtx model.RequestContext) (model.RequestEvaluation, error) {
+	return s.engine.EvaluateRequest(ctx, userID, reqCtx)
+}
+
+// GetFieldDefinitions returns the fields request rules can compare, from the
+// RequestContext struct tags.
+func (s *RequestRulesService) GetFieldDefinitions(ctx context.Context) ([]model.FieldDefinition, error) {
+	contextFields := model.ListRequestContextFields()
+	fields := make([]model.FieldDefinition, 0, len(contextFields))
+	for _, cf := range contextFields {
+		fields = append(fields, contextFieldToDefinition(cf))
+	}
+	return fields, nil
+}
+
+func (s *RequestRulesService) ruleParams(ctx context.Context, req model.RequestRuleRequest) (dbgen.CreateRequestRuleParams, error) {
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return dbgen.CreateRequestRuleParams{}, fmt.Errorf("%w: name required", ErrRequestRuleInvalid)
+	}
+	var roleID pgtype.UUID
+	if err := roleID.Scan(req.RoleID); err != nil {
+		return dbgen.CreateRequestRuleParams{}, fmt.Errorf("%w: invalid roleId", ErrRequestRuleInvalid)
+	}
+	if _, err := s.subject(ctx, string(dbgen.GrantSubjectRole), roleID); errors.Is(err, ErrRequestQuotaInvalid) {
+		return dbgen.CreateRequestRuleParams{}, fmt.Errorf("%w: role not found", ErrRequestRuleInvalid)
+	} else if err != nil {
+		return dbgen.CreateRequestRuleParams{}, err
+	}
+	switch req.Action {
+	case model.RequestRuleApprove, model.RequestRuleRequireApproval:
+	default:
+		return dbgen.CreateRequestRuleParams{}, fmt.Errorf("%w: action must be approve or require_approval", ErrRequestRuleInvalid)
+	}
+
+	params := dbgen.CreateRequestRuleParams{
+		RoleID:   roleID,
+		Name:     name,
+		Enabled:  req.Enabled == nil || *req.Enabled,
+		Priority: int32(req.Priority),
+		Action:   string(req.Action),
+	}
+	left, op, right := strings.TrimSpace(req.LeftOperand), strings.TrimSpace(req.Operator), strings.TrimSpace(req.RightOperand)
+	switch {
+	case left == "" && op == "" && right == "":
+		// Always matches
+	case left == "" || op == "" || right == "":
+		return dbgen.CreateRequestRuleParams{}, fmt.Errorf("%w: a condition needs leftOperand, operator and rightOperand", ErrRequestRuleInvalid)
+	default:
+		validOps := []string{"==", "!=", ">", ">=", "<", "<=", "contains", "in", "not in"}
+		if !slices.Contains(validOps, op) {
+			return dbgen.CreateRequestRuleParams{}, fmt.Errorf("%w: invalid operator", ErrRequestRuleInvalid)
+		}
+		params.LeftOperand, params.Operator, params.RightOperand = &left, &op, &right
+	}
+	return params, nil
+}
+
+// require fails with ErrRequestRuleForbidden unless the user has
+// requests.approve. Quotas and auto-approval rules decide what skips approval,
+// so they belong to the users who approve.
+func (s *RequestRulesService) require(ctx context.Context, userID pgtype.UUID) error {
+	ok, err := s.can(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return fmt.Errorf("%w: requires %s", ErrRequestRuleForbidden, permRequestsApprove)
+	}
+	return nil
+}
+
+func (s *RequestRulesService) can(ctx context.Context, userID pgtype.UUID) (bool, error) {
+	ok, err := s.repo.UserHasPermission(ctx, userID, permRequestsApprove)
+	if err != nil {
+		return false, fmt.Errorf("check permission: %w", err)
+	}
+	return ok, nil
+}
+
+// subject checks that a quota or rule subject exists.
+func (s *RequestRulesService) subject(ctx context.Context, subjectType string, subjectID pgtype.UUID) (dbgen.GrantSubject, error) {
+	subject, err := subjectTypeFromString(subjectType)
+	if err != nil {
+		return "", err
+	}
+	if subject == dbgen.GrantSubjectUser {
+		if _, err := s.repo.GetUserByID(ctx, subjectID); err != nil {
+			return "", fmt.Errorf("%w: user not found", ErrRequestQuotaInvalid)
+		}
+		return subject, nil
+	}
+
+	roles, err := s.repo.ListRoles(ctx)
+	if err != nil {
+		return "", fmt.Errorf("list roles: %w", err)
+	}
+	if !slices.ContainsFunc(roles, func(r dbgen.Role) bool { return r.ID == subjectID }) {
+		return "", fmt.Errorf("%w: role not found", ErrRequestQuotaInvalid)
+	}
+	return subject, nil
+}
+
+// quota returns the limits that apply to a user.
+func (s *RequestRulesService) quota(ctx context.Context, userID pgtype.UUID) (requestQuotaLimits, error) {
+	rows, err := s.repo.ListRequestQuotasForUser(ctx, userID)
+	if err != nil {
+		return requestQuotaLimits{}, fmt.Errorf("list request quotas: %w", err)
+	}
+	return mergeRequestQuotas(rows), nil
+}
+
+// quotaLimit is a limit on one kind of request; a nil limit is unlimited.
+type quotaLimit struct {
+	limit      *int
+	windowDays int
+}
+
+func (q quotaLimit) usage(used int) model.QuotaUsage {
+	u := model.QuotaUsage{Limit: q.limit, Used: used, WindowDays: q.windowDays}
+	if q.limit != nil {
+		remaining := max(*q.limit-used, 0)
+		u.Remaining = &remaining
+	}
+	return u
+}
+
+type requestQuotaLimits struct {
+	movies, seasons quotaLimit
+}
+
+// mergeRequestQuotas combines the quotas that apply to a user. The user's own
+// quota wins. Otherwise each limit is the most generous of the user's roles:
+// unlimited if any role is, else the highest, over the shortest window on a
+// tie. Without any quota there are no limits.
+func mergeRequestQuotas(rows []dbgen.RequestQuota) requestQuotaLimits {
+	unlimited := quotaLimit{windowDays: defaultQuotaWindowDays}
+	for _, row := range rows {
+		if row.SubjectType == dbgen.GrantSubjectUser {
+			return requestQuotaLimits{
+				movies:  quotaLimit{limit: int32ToIntPtr(row.MovieLimit), windowDays: int(row.WindowDays)},
+				seasons: quotaLimit{limit: int32ToIntPtr(row.SeasonLimit), windowDays: int(row.WindowDays)},
+			}
+		}
+	}
+	if len(rows) == 0 {
+		return requestQuotaLimits{movies: unlimited, seasons: unlimited}
+	}
+
+	generous := func(limit func(dbgen.RequestQuota) *int32) quotaLimit {
+		var best quotaLimit
+		for i, row := range rows {
+			l := limit(row)
+			if l == nil {
+				return unlimited
+			}
+			if i == 0 || int(*l) > *best.limit || (int(*l) == *best.limit && int(row.WindowDays) < best.windowDays) {
+				best = quotaLimit{limit: int32ToIntPtr(l), windowDays: int(row.WindowDays)}
+			}
+		}
+		return best
+	}
+	return requestQuotaLimits{
+		movies:  generous(func(r dbgen.RequestQuota) *int32 { return r.MovieLimit }),
+		seasons: generous(func(r dbgen.RequestQuota) *int32 { return r.SeasonLimit }),
+	}
+}
+
+func subjectTypeFromString(s string) (dbgen.GrantSubject, error) {
+	switch dbgen.GrantSubject(s) {
+	case dbgen.GrantSubjectRole, dbgen.GrantSubjectUser:
+		return dbgen.GrantSubject(s), nil
+	}
+	return "", fmt.Errorf("%w: subject type must be role or user", ErrRequestQuotaInvalid)
+}
+
+func requestQuota(row dbgen.RequestQuota) model.RequestQuota {
+	return model.RequestQuota{
+		SubjectType: string(row.SubjectType),
+		SubjectID:   row.SubjectID.String(),
+		MovieLimit:  int32ToIntPtr(row.MovieLimit),
+		SeasonLimit: int32ToIntPtr(row.SeasonLimit),
+		WindowDays:  int(row.WindowDays),
+	}
+}
+
+func requestRule(row dbgen.RequestRule) model.RequestRule {
+	return model.RequestRule{
+		ID:           row.ID.String(),
+		RoleID:       row.RoleID.String(),
+		Name:         row.Name,
+		Enabled:      row.Enabled,
+		Priority:     int(row.Priority),
+		LeftOperand:  coalesce(row.LeftOperand, ""),
+		Operator:     coalesce(row.Operator, ""),
+		RightOperand: coalesce(row.RightOperand, ""),
+		Action:       model.RequestRuleAction(row.Action),
+		CreatedAt:    row.CreatedAt,
+		UpdatedAt:    row.UpdatedAt,
+	}
+}
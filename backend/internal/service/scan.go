@@ -12,7 +12,9 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 	dbgen "github.com/kyleaupton/arrflix/internal/db/sqlc"
 	"github.com/kyleaupton/arrflix/internal/identity"
+	"github.com/kyleaupton/arrflix/internal/importer"
 	"github.com/kyleaupton/arrflix/internal/logger"
+	"github.com/kyleaupton/arrflix/internal/qualityprofile"
 	"github.com/kyleaupton/arrflix/internal/repo"
 )
 
@@ -49,6 +51,11 @@ func (s *ScannerService) StartScan(ctx context.Context, libraryID pgtype.UUID) (
 			return err // propagate permission/IO errors
 		}
 
+		// Files replaced by upgrades are kept aside, not part of the library
+		if d.IsDir() && d.Name() == importer.ReplacedDir && path != library.RootPath {
+			return filepath.SkipDir
+		}
+
 		if d.IsDir() || !isMediaFile(path) {
 			s.logger.Debug().Str("path", path).Msg("Skipping Directory or Non-Media File")
 			return nil
@@ -239,7 +246,7 @@ func (s *ScannerService) StartScan(ctx context.Context, libraryID pgtype.UUID) (
 		}
 
 		// create media_file (removed seasonId - derived from episode)
-		mf, err := s.repo.CreateMediaFile(ctx, library.ID, mediaItemId, episodeId, relPath)
+		mf, err := s.repo.CreateMediaFile(ctx, library.ID, mediaItemId, episodeId, relPath, qualityprofile.FileQuality(relPath))
 		if err != nil {
 			s.logger.Error().Err(err).Str("path", relPath).Msg("Failed to create media file")
 			return nil
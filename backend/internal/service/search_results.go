@@ -0,0 +1,236 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	dbgen "github.com/kyleaupton/arrflix/internal/db/sqlc"
+	"github.com/kyleaupton/arrflix/internal/indexer"
+	"github.com/kyleaupton/arrflix/internal/model"
+)
+
+var ErrSearchSessionNotFound = errors.New("search session not found")
+
+const maxListedSearchSessions = 20
+
+// saveSearchSession persists a search and its candidates so they can be
+// reopened and enqueued until the retention window passes.
+func (s *DownloadCandidatesService) saveSearchSession(ctx context.Context, query indexer.SearchQuery, candidates []model.DownloadCandidate, sources []model.SearchSourceReport) (model.SearchSession, error) {
+	// Expired sessions are removed opportunistically; results cascade.
+	if err := s.repo.DeleteExpiredSearchSessions(ctx); err != nil {
+		s.logger.Warn().Err(err).Msg("Failed to delete expired search sessions")
+	}
+
+	sourcesJSON, err := json.Marshal(sources)
+	if err != nil {
+		return model.SearchSession{}, fmt.Errorf("marshal sources: %w", err)
+	}
+
+	retention := time.Duration(s.settings.GetInt(ctx, "search.result_retention_hours")) * time.Hour
+	if retention <= 0 {
+		retention = time.Duration(Registry["search.result_retention_hours"].Default.(int64)) * time.Hour
+	}
+
+	params := dbgen.InsertSearchResultsParams{
+		Positions:  make([]int32, 0, len(candidates)),
+		IndexerIds: make([]int64, 0, len(candidates)),
+		Guids:      make([]string, 0, len(candidates)),
+		Titles:     make([]string, 0, len(candidates)),
+		Candidates: make([][]byte, 0, len(candidates)),
+	}
+	for i, c := range candidates {
+		data, err := json.Marshal(c)
+		if err != nil {
+			return model.SearchSession{}, fmt.Errorf("marshal candidate: %w", err)
+		}
+		params.Positions = append(params.Positions, int32(i))
+		params.IndexerIds = append(params.IndexerIds, c.IndexerID)
+		params.Guids = append(params.Guids, c.GUID)
+		params.Titles = append(params.Titles, c.Title)
+		params.Candidates = append(params.Candidates, data)
+	}
+
+	tx, err := s.repo.Pool.Begin(ctx)
+	if err != nil {
+		return model.SearchSession{}, err
+	}
+	defer tx.Rollback(ctx)
+	txQueries := s.repo.Q.WithTx(tx)
+
+	session, err := txQueries.CreateSearchSession(ctx, dbgen.CreateSearchSessionParams{
+		MediaType:     string(query.MediaType),
+		TmdbID:        query.TmdbID,
+		SeasonNumber:  intToInt32Ptr(query.Season),
+		EpisodeNumber: intToInt32Ptr(query.Episode),
+		Query:         query.Query,
+		Sources:       sourcesJSON,
+		ExpiresAt:     time.Now().Add(retention),
+	})
+	if err != nil {
+		return model.SearchSession{}, fmt.Errorf("create search session: %w", err)
+	}
+
+	params.SessionID = session.ID
+	if err := txQueries.InsertSearchResults(ctx, params); err != nil {
+		return model.SearchSession{}, fmt.Errorf("insert search results: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return model.SearchSession{}, err
+	}
+
+	return searchSessionToModel(session, int64(len(candidates))), nil
+}
+
+// lookupCandidate loads a persisted candidate. With a session ID the result
+// must belong to that search; without one the most recent search that
+// returned the release is used.
+func (s *DownloadCandidatesService) lookupCandidate(ctx context.Context, sessionID string, indexerID int64, guid string) (model.DownloadCandidate, error) {
+	var data []byte
+	var expiresAt time.Time
+
+	if sessionID != "" {
+		var id pgtype.UUID
+		if err := id.Scan(sessionID); err != nil {
+			return model.DownloadCandidate{}, ErrCandidateNotFound
+		}
+		row, err := s.repo.GetSearchResultInSession(ctx, id, indexerID, guid)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return model.DownloadCandidate{}, ErrCandidateNotFound
+			}
+			return model.DownloadCandidate{}, fmt.Errorf("get search result: %w", err)
+		}
+		data, expiresAt = row.Candidate, row.ExpiresAt
+	} else {
+		row, err := s.repo.GetLatestSearchResult(ctx, indexerID, guid)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return model.DownloadCandidate{}, ErrCandidateNotFound
+			}
+			return model.DownloadCandidate{}, fmt.Errorf("get search result: %w", err)
+		}
+		data, expiresAt = row.Candidate, row.ExpiresAt
+	}
+
+	if time.Now().After(expiresAt) {
+		return model.DownloadCandidate{}, ErrCandidateExpired
+	}
+
+	var candidate model.DownloadCandidate
+	if err := json.Unmarshal(data, &candidate); err != nil {
+		return model.DownloadCandidate{}, fmt.Errorf("decode search result: %w", err)
+	}
+
+	// The blocklist may have changed since the search; check it again.
+	flagged := []model.DownloadCandidate{candidate}
+	if err := s.blocklist.Flag(ctx, flagged); err != nil {
+		return model.DownloadCandidate{}, err
+	}
+	return flagged[0], nil
+}
+
+// ListSearchSessions returns the unexpired searches for a title, newest first.
+func (s *DownloadCandidatesService) ListSearchSessions(ctx context.Context, mediaType model.MediaType, tmdbID int64) ([]model.SearchSession, error) {
+	rows, err := s.repo.ListSearchSessions(ctx, string(mediaType), tmdbID, maxListedSearchSessions)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]model.SearchSession, 0, len(rows))
+	for _, row := range rows {
+		out = append(out, searchSessionToModel(dbgen.SearchSession{
+			ID:            row.ID,
+			MediaType:     row.MediaType,
+			TmdbID:        row.TmdbID,
+			SeasonNumber:  row.SeasonNumber,
+			EpisodeNumber: row.EpisodeNumber,
+			Query:         row.Query,
+			Sources:       row.Sources,
+			CreatedAt:     row.CreatedAt,
+			ExpiresAt:     row.ExpiresAt,
+		}, row.ResultCount))
+	}
+	return out, nil
+}
+
+// GetSearchSession reopens a persisted search with its candidates in their
+// original order.
+func (s *DownloadCandidatesService) GetSearchSession(ctx context.Context, sessionID pgtype.UUID) (model.DownloadCandidatesResponse, error) {
+	session, err := s.repo.GetSearchSession(ctx, sessionID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return model.DownloadCandidatesResponse{}, ErrSearchSessionNotFound
+		}
+		return model.DownloadCandidatesResponse{}, err
+	}
+	if time.Now().After(session.ExpiresAt) {
+		return model.DownloadCandidatesResponse{}, ErrCandidateExpired
+	}
+
+	rows, err := s.repo.ListSearchResults(ctx, sessionID)
+	if err != nil {
+		return model.DownloadCandidatesResponse{}, err
+	}
+
+	candidates := make([]model.DownloadCandidate, 0, len(rows))
+	for _, row := range rows {
+		var c model.DownloadCandidate
+		if err := json.Unmarshal(row.Candidate, &c); err != nil {
+			s.logger.Warn().Err(err).Str("guid", row.Guid).Msg("Failed to decode search result")
+			continue
+		}
+		candidates = append(candidates, c)
+	}
+	if err := s.blocklist.Flag(ctx, candidates); err != nil {
+		s.logger.Warn().Err(err).Msg("Failed to check candidates against blocklist")
+	}
+
+	var sources []model.SearchSourceReport
+	if len(session.Sources) > 0 {
+		if err := json.Unmarshal(session.Sources, &sources); err != nil {
+			s.logger.Warn().Err(err).Msg("Failed to decode search session sources")
+		}
+	}
+
+	return model.DownloadCandidatesResponse{
+		Session:    searchSessionToModel(session, int64(len(rows))),
+		Candidates: candidates,
+		Sources:    sources,
+	}, nil
+}
+
+func searchSessionToModel(session dbgen.SearchSession, resultCount int64) model.SearchSession {
+	return model.SearchSession{
+		ID:          session.ID.String(),
+		MediaType:   model.MediaType(session.MediaType),
+		TmdbID:      session.TmdbID,
+		Season:      int32ToIntPtr(session.SeasonNumber),
+		Episode:     int32ToIntPtr(session.EpisodeNumber),
+		Query:       session.Query,
+		ResultCount: resultCount,
+		CreatedAt:   session.CreatedAt,
+		ExpiresAt:   session.ExpiresAt,
+	}
+}
+
+func intToInt32Ptr(v *int) *int32 {
+	if v == nil {
+		return nil
+	}
+	i := int32(*v)
+	return &i
+}
+
+func int32ToIntPtr(v *int32) *int {
+	if v == nil {
+		return nil
+	}
+	i := int(*v)
+	return &i
+}
@@ -2,6 +2,7 @@ package service
 
 import (
 	"github.com/kyleaupton/arrflix/internal/config"
+	indexerpkg "github.com/kyleaupton/arrflix/internal/indexer"
 	prowlarradapter "github.com/kyleaupton/arrflix/internal/indexer/prowlarr"
 	"github.com/kyleaupton/arrflix/internal/logger"
 	"github.com/kyleaupton/arrflix/internal/policy"
@@ -10,6 +11,9 @@ import (
 
 type Services struct {
 	Auth               *AuthService
+	AutoSearch         *AutoSearchService
+	Blocklist          *BlocklistService
+	Calendar           *CalendarService
 	Downloaders        *DownloadersService
 	DownloadCandidates *DownloadCandidatesService
 	DownloadJobs       *DownloadJobsService
@@ -18,17 +22,26 @@ type Services struct {
 	Import             *ImportService
 	ImportTasks        *ImportTasksService
 	Indexer            *IndexerService
+	IndexerHealth      *IndexerHealthService
 	Libraries          *LibrariesService
 	Media              *MediaService
+	MediaRequests      *MediaRequestsService
+	Metadata           *MetadataService
+	Monitoring         *MonitoringService
 	NameTemplates      *NameTemplatesService
 	Policies           *PoliciesService
+	QualityProfiles    *QualityProfilesService
+	RemotePathMappings *RemotePathMappingsService
+	RequestRules       *RequestRulesService
 	Scanner            *ScannerService
 	Settings           *SettingsService
 	Setup              *SetupService
+	TitleAliases       *TitleAliasesService
 	Tmdb               *TmdbService
 	UnmatchedFiles     *UnmatchedFilesService
 	Users              *UsersService
 	Version            *VersionService
+	Wanted             *WantedService
 }
 
 func New(r *repo.Repository, l *logger.Logger, c *config.Config, opts ...Option) *Services {
@@ -39,35 +52,57 @@ func New(r *repo.Repository, l *logger.Logger, c *config.Config, opts ...Option)
 
 	tmdb := NewTmdbService(r, l)
 	indexer := NewIndexerService(r, l, c)
-	indexerSource := prowlarradapter.New(indexer.Client(), l)
+	indexerSource := indexerpkg.NewComposite(l, indexerpkg.DefaultSourceTimeout,
+		indexerpkg.NamedSource{Name: "prowlarr", Source: prowlarradapter.New(indexer.Client(), l)},
+	)
+	indexerHealth := NewIndexerHealthService(r, l, indexerSource)
 	settings := NewSettingsService(r)
+	blocklist := NewBlocklistService(r, l)
 	media := NewMediaService(r, l, tmdb, settings)
 	policies := NewPoliciesService(r, l)
 	policyEngine := policy.NewEngine(r, l)
+	titleAliases := NewTitleAliasesService(r, l, tmdb, media, settings)
+	qualityProfiles := NewQualityProfilesService(r, l)
 	users := NewUsersService(r)
 	invites := NewInvitesService(r)
+	downloadCandidates := NewDownloadCandidatesService(r, l, indexerSource, media, titleAliases, settings, indexerHealth, blocklist, qualityProfiles, policyEngine)
+	monitoring := NewMonitoringService(r, l, tmdb, media, qualityProfiles)
+	autoSearch := NewAutoSearchService(r, l, settings, downloadCandidates)
+	requestRules := NewRequestRulesService(r, l, policyEngine)
 
 	return &Services{
 		Auth:               NewAuthService(r, cfg, settings, invites),
+		AutoSearch:         autoSearch,
+		Blocklist:          blocklist,
+		Calendar:           NewCalendarService(r, l, settings),
 		Downloaders:        NewDownloadersService(r),
-		DownloadCandidates: NewDownloadCandidatesService(r, l, indexerSource, media, policyEngine),
+		DownloadCandidates: downloadCandidates,
 		DownloadJobs:       NewDownloadJobsService(r),
 		Invites:            invites,
 		Feed:               NewFeedService(r, l, tmdb),
 		Import:             NewImportService(r, l),
 		ImportTasks:        NewImportTasksService(r),
 		Indexer:            indexer,
+		IndexerHealth:      indexerHealth,
 		Libraries:          NewLibrariesService(r),
 		Media:              media,
+		MediaRequests:      NewMediaRequestsService(r, l, settings, tmdb, monitoring, autoSearch, qualityProfiles, requestRules),
+		Metadata:           NewMetadataService(r, l, settings, tmdb),
+		Monitoring:         monitoring,
 		NameTemplates:      NewNameTemplatesService(r),
 		Policies:           policies,
+		QualityProfiles:    qualityProfiles,
+		RemotePathMappings: NewRemotePathMappingsService(r, l),
+		RequestRules:       requestRules,
 		Scanner:            NewScannerService(r, l, tmdb),
 		Settings:           settings,
 		Setup:              NewSetupService(r, users),
+		TitleAliases:       titleAliases,
 		Tmdb:               tmdb,
 		UnmatchedFiles:     NewUnmatchedFilesService(r, l, tmdb),
 		Users:              users,
 		Version:            NewVersionService(r, l),
+		Wanted:             NewWantedService(r, l, autoSearch),
 	}
 }
 
@@ -61,6 +61,51 @@ func (s *SettingsService) GetAll(ctx context.Context) (map[string]any, error) {
 	return out, nil
 }
 
+// GetInt returns an int setting, falling back to the registry default.
+func (s *SettingsService) GetInt(ctx context.Context, key string) int64 {
+	if all, err := s.GetAll(ctx); err == nil {
+		if v, ok := all[key].(int64); ok {
+			return v
+		}
+	}
+	if spec, ok := Registry[key]; ok {
+		if v, ok := spec.Default.(int64); ok {
+			return v
+		}
+	}
+	return 0
+}
+
+// GetBool returns a bool setting, falling back to the registry default.
+func (s *SettingsService) GetBool(ctx context.Context, key string) bool {
+	if all, err := s.GetAll(ctx); err == nil {
+		if v, ok := all[key].(bool); ok {
+			return v
+		}
+	}
+	if spec, ok := Registry[key]; ok {
+		if v, ok := spec.Default.(bool); ok {
+			return v
+		}
+	}
+	return false
+}
+
+// GetText returns a text setting, falling back to the registry default.
+func (s *SettingsService) GetText(ctx context.Context, key string) string {
+	if all, err := s.GetAll(ctx); err == nil {
+		if v, ok := all[key].(string); ok {
+			return v
+		}
+	}
+	if spec, ok := Registry[key]; ok {
+		if v, ok := spec.Default.(string); ok {
+			return v
+		}
+	}
+	return ""
+}
+
 // Set validates and persists a single key/value according to the registry.
 // GetUserRegion returns the user's region code for watch provider lookups.
 // TODO: Make this configurable via user settings.
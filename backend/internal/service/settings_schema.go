@@ -21,4 +21,47 @@ var Registry = map[string]SettingSpec{
 	"site.title":            {Key: "site.title", Type: SettingText, Default: "Arrflix"},
 	"auth.signup_strategy":  {Key: "auth.signup_strategy", Type: SettingText, Default: "invite_only"},
 	"requests.max_per_user": {Key: "requests.max_per_user", Type: SettingInt, Default: int64(5)},
+
+	// Maximum number of distinct TMDB titles (primary, original, alternative, translated)
+	// queried per candidate search. Custom aliases are always searched.
+	"search.max_title_aliases": {Key: "search.max_title_aliases", Type: SettingInt, Default: int64(3)},
+
+	// How long candidate search results are kept so a search can be reopened and enqueued later.
+	"search.result_retention_hours": {Key: "search.result_retention_hours", Type: SettingInt, Default: int64(72)},
+
+	// Automatic search for monitored movies and aired episodes without a file.
+	// A pass runs every interval_minutes and performs at most max_searches_per_run
+	// searches, search_delay_seconds apart. A title that was searched without a
+	// grab isn't searched again for retry_hours. With upgrades on, searches left
+	// over go to files below their quality profile's cutoff.
+	"autosearch.enabled":              {Key: "autosearch.enabled", Type: SettingBool, Default: true},
+	"autosearch.interval_minutes":     {Key: "autosearch.interval_minutes", Type: SettingInt, Default: int64(60)},
+	"autosearch.max_searches_per_run": {Key: "autosearch.max_searches_per_run", Type: SettingInt, Default: int64(10)},
+	"autosearch.search_delay_seconds": {Key: "autosearch.search_delay_seconds", Type: SettingInt, Default: int64(5)},
+	"autosearch.retry_hours":          {Key: "autosearch.retry_hours", Type: SettingInt, Default: int64(12)},
+	"autosearch.upgrades":             {Key: "autosearch.upgrades", Type: SettingBool, Default: true},
+
+	// Metadata refresh. Library items are refreshed from TMDB (titles, release
+	// dates, seasons and episodes) once their metadata is older than refresh_hours.
+	"metadata.refresh_hours": {Key: "metadata.refresh_hours", Type: SettingInt, Default: int64(24)},
+
+	// Packed releases are extracted into a staging dir under extract_path, or
+	// next to the download when it is empty. Staged files are removed once
+	// imported.
+	"import.extract_path": {Key: "import.extract_path", Type: SettingText, Default: ""},
+
+	// Imported videos are checked against their release: the file must parse,
+	// run for at least half its TMDB runtime and not fall short of the
+	// release's resolution. Copies are compared with their source by size,
+	// and by SHA-256 with verify_checksum. A file that fails is not imported
+	// and its release is blocklisted; search_on_verify_failure then searches
+	// for another.
+	"import.verify":                   {Key: "import.verify", Type: SettingBool, Default: true},
+	"import.verify_checksum":          {Key: "import.verify_checksum", Type: SettingBool, Default: false},
+	"import.search_on_verify_failure": {Key: "import.search_on_verify_failure", Type: SettingBool, Default: false},
+
+	// Library files replaced by an upgrade, and their extra files, are kept in
+	// the library's .replaced dir for replaced_retention_days, then removed.
+	// Zero keeps them forever.
+	"import.replaced_retention_days": {Key: "import.replaced_retention_days", Type: SettingInt, Default: int64(30)},
 }
@@ -0,0 +1,307 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	dbgen "github.com/kyleaupton/arrflix/internal/db/sqlc"
+	"github.com/kyleaupton/arrflix/internal/indexer"
+	"github.com/kyleaupton/arrflix/internal/logger"
+	"github.com/kyleaupton/arrflix/internal/model"
+	"github.com/kyleaupton/arrflix/internal/repo"
+)
+
+var ErrTitleAliasInvalid = errors.New("alias title is required")
+
+// TitleAliasesService gathers the titles a movie or series is searched under:
+// the TMDB title, original title, alternative titles, translations and
+// user-defined custom aliases.
+type TitleAliasesService struct {
+	repo     *repo.Repository
+	logger   *logger.Logger
+	tmdb     *TmdbService
+	media    *MediaService
+	settings *SettingsService
+}
+
+func NewTitleAliasesService(r *repo.Repository, l *logger.Logger, tmdb *TmdbService, media *MediaService, settings *SettingsService) *TitleAliasesService {
+	return &TitleAliasesService{repo: r, logger: l, tmdb: tmdb, media: media, settings: settings}
+}
+
+// List returns every known alias for a title, including excluded ones, with
+// Searched set on those used for candidate searches.
+func (s *TitleAliasesService) List(ctx context.Context, mediaType model.MediaType, tmdbID int64) ([]model.TitleAlias, error) {
+	titles, err := s.tmdbTitles(ctx, mediaType, tmdbID)
+	if err != nil {
+		return nil, err
+	}
+	custom, err := s.customAliases(ctx, mediaType, tmdbID)
+	if err != nil {
+		return nil, err
+	}
+	return s.merge(ctx, titles, custom), nil
+}
+
+// SearchTitles returns the aliases that should be queried for a title, with
+// the primary TMDB title first, and every title a release of it may be named
+// after. The latter also has the alternative titles and translations that
+// aren't searched.
+func (s *TitleAliasesService) SearchTitles(ctx context.Context, mediaType model.MediaType, tmdbID int64) ([]model.TitleAlias, []string, error) {
+	titles, err := s.tmdbTitles(ctx, mediaType, tmdbID)
+	if err != nil {
+		return nil, nil, err
+	}
+	custom, err := s.customAliases(ctx, mediaType, tmdbID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var searched []model.TitleAlias
+	for _, a := range s.merge(ctx, titles, custom) {
+		if a.Searched {
+			searched = append(searched, a)
+		}
+	}
+	return searched, matchTitles(titles.aliases, custom), nil
+}
+
+// merge limits TMDB titles to the user's region and merges them with custom
+// aliases.
+func (s *TitleAliasesService) merge(ctx context.Context, titles tmdbTitles, custom []dbgen.MediaItemAlias) []model.TitleAlias {
+	regional := regionalAliases(titles.aliases, s.settings.GetUserRegion(ctx), titles.originCountries)
+	maxTitles := int(s.settings.GetInt(ctx, "search.max_title_aliases"))
+	return mergeTitleAliases(regional, custom, titles.originalLanguage, maxTitles)
+}
+
+// customAliases returns the user-defined aliases of a title, none when it
+// isn't a media item yet.
+func (s *TitleAliasesService) customAliases(ctx context.Context, mediaType model.MediaType, tmdbID int64) ([]dbgen.MediaItemAlias, error) {
+	mi, err := s.repo.GetMediaItemByTmdbIDAndType(ctx, tmdbID, string(mediaType))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get media item: %w", err)
+	}
+	custom, err := s.repo.ListMediaItemAliases(ctx, mi.ID)
+	if err != nil {
+		return nil, fmt.Errorf("list custom aliases: %w", err)
+	}
+	return custom, nil
+}
+
+// Add creates or updates a custom alias. Excluded aliases hide a matching
+// TMDB title from searches instead of adding a new one.
+func (s *TitleAliasesService) Add(ctx context.Context, mediaType model.MediaType, tmdbID int64, title string, excluded bool) (dbgen.MediaItemAlias, error) {
+	title = strings.TrimSpace(title)
+	if title == "" {
+		return dbgen.MediaItemAlias{}, ErrTitleAliasInvalid
+	}
+
+	mi, err := s.media.EnsureMediaItem(ctx, mediaType, tmdbID)
+	if err != nil {
+		return dbgen.MediaItemAlias{}, err
+	}
+
+	return s.repo.UpsertMediaItemAlias(ctx, mi.ID, title, excluded)
+}
+
+// Delete removes a custom alias.
+func (s *TitleAliasesService) Delete(ctx context.Context, mediaType model.MediaType, tmdbID int64, aliasID pgtype.UUID) error {
+	mi, err := s.repo.GetMediaItemByTmdbIDAndType(ctx, tmdbID, string(mediaType))
+	if err != nil {
+		return err
+	}
+	return s.repo.DeleteMediaItemAlias(ctx, aliasID, mi.ID)
+}
+
+// tmdbTitles is every title TMDB has for a movie or series.
+type tmdbTitles struct {
+	// aliases are in preference order: primary, original, alternative
+	// titles, translations
+	aliases          []model.TitleAlias
+	originalLanguage string
+	originCountries  []string
+}
+
+// tmdbTitles returns the TMDB-provided titles of a movie or series.
+func (s *TitleAliasesService) tmdbTitles(ctx context.Context, mediaType model.MediaType, tmdbID int64) (tmdbTitles, error) {
+	var out []model.TitleAlias
+	var originalLanguage string
+	var originCountries []string
+
+	switch mediaType {
+	case model.MediaTypeMovie:
+		details, err := s.tmdb.GetMovieDetails(ctx, tmdbID)
+		if err != nil {
+			return tmdbTitles{}, fmt.Errorf("get movie details: %w", err)
+		}
+		originalLanguage, originCountries = details.OriginalLanguage, details.OriginCountry
+		out = append(out,
+			model.TitleAlias{Title: details.Title, Source: model.TitleAliasSourcePrimary},
+			model.TitleAlias{Title: details.OriginalTitle, Source: model.TitleAliasSourceOriginal, Language: details.OriginalLanguage},
+		)
+
+		if alt, err := s.tmdb.GetMovieAlternativeTitles(ctx, tmdbID); err == nil {
+			for _, t := range alt.Titles {
+				out = append(out, model.TitleAlias{Title: t.Title, Source: model.TitleAliasSourceAlternative, Country: t.Iso3166_1})
+			}
+		} else {
+			s.logger.Warn().Err(err).Int64("tmdb_id", tmdbID).Msg("Failed to get alternative titles")
+		}
+
+		if tr, err := s.tmdb.GetMovieTranslations(ctx, tmdbID); err == nil {
+			for _, t := range tr.Translations {
+				out = append(out, model.TitleAlias{Title: t.Data.Title, Source: model.TitleAliasSourceTranslation, Country: t.Iso3166_1, Language: t.Iso639_1})
+			}
+		} else {
+			s.logger.Warn().Err(err).Int64("tmdb_id", tmdbID).Msg("Failed to get translations")
+		}
+
+	case model.MediaTypeSeries:
+		details, err := s.tmdb.GetSeriesDetails(ctx, tmdbID)
+		if err != nil {
+			return tmdbTitles{}, fmt.Errorf("get series details: %w", err)
+		}
+		originalLanguage, originCountries = details.OriginalLanguage, details.OriginCountry
+		out = append(out,
+			model.TitleAlias{Title: details.Name, Source: model.TitleAliasSourcePrimary},
+			model.TitleAlias{Title: details.OriginalName, Source: model.TitleAliasSourceOriginal, Language: details.OriginalLanguage},
+		)
+
+		if alt, err := s.tmdb.GetSeriesAlternativeTitles(ctx, tmdbID); err == nil {
+			for _, t := range alt.Results {
+				out = append(out, model.TitleAlias{Title: t.Title, Source: model.TitleAliasSourceAlternative, Country: t.Iso3166_1})
+			}
+		} else {
+			s.logger.Warn().Err(err).Int64("tmdb_id", tmdbID).Msg("Failed to get alternative titles")
+		}
+
+		if tr, err := s.tmdb.GetSeriesTranslations(ctx, tmdbID); err == nil {
+			for _, t := range tr.Translations {
+				out = append(out, model.TitleAlias{Title: t.Data.Name, Source: model.TitleAliasSourceTranslation, Country: t.Iso3166_1, Language: t.Iso639_1})
+			}
+		} else {
+			s.logger.Warn().Err(err).Int64("tmdb_id", tmdbID).Msg("Failed to get translations")
+		}
+
+	default:
+		return tmdbTitles{}, fmt.Errorf("unsupported media type %q", mediaType)
+	}
+
+	return tmdbTitles{aliases: out, originalLanguage: originalLanguage, originCountries: originCountries}, nil
+}
+
+// regionalAliases drops alternative titles from countries other than the
+// origin countries and the user's region; releases rarely use them.
+func regionalAliases(aliases []model.TitleAlias, region string, originCountries []string) []model.TitleAlias {
+	countries := map[string]bool{region: true}
+	for _, c := range originCountries {
+		countries[c] = true
+	}
+	out := make([]model.TitleAlias, 0, len(aliases))
+	for _, a := range aliases {
+		if a.Source == model.TitleAliasSourceAlternative && a.Country != "" && !countries[a.Country] {
+			continue
+		}
+		out = append(out, a)
+	}
+	return out
+}
+
+// matchTitles returns every distinct TMDB and custom title a release may be
+// named after, leaving out excluded ones.
+func matchTitles(aliases []model.TitleAlias, custom []dbgen.MediaItemAlias) []string {
+	excluded := make(map[string]bool)
+	for _, c := range custom {
+		if c.Excluded {
+			excluded[indexer.NormalizeTitle(c.Title)] = true
+		}
+	}
+
+	seen := make(map[string]bool)
+	var out []string
+	add := func(title string) {
+		key := indexer.NormalizeTitle(title)
+		if key == "" || seen[key] || excluded[key] {
+			return
+		}
+		seen[key] = true
+		out = append(out, title)
+	}
+	for _, a := range aliases {
+		add(a.Title)
+	}
+	for _, c := range custom {
+		add(c.Title)
+	}
+	return out
+}
+
+// mergeTitleAliases dedupes TMDB and custom aliases by normalized title and
+// marks which ones are searched. Primary and custom aliases are always
+// searched; other TMDB titles fill the remaining slots up to maxTitles.
+func mergeTitleAliases(tmdbAliases []model.TitleAlias, custom []dbgen.MediaItemAlias, originalLanguage string, maxTitles int) []model.TitleAlias {
+	excluded := make(map[string]bool)
+	for _, c := range custom {
+		if c.Excluded {
+			excluded[indexer.NormalizeTitle(c.Title)] = true
+		}
+	}
+
+	seen := make(map[string]bool)
+	var out []model.TitleAlias
+	add := func(a model.TitleAlias) {
+		key := indexer.NormalizeTitle(a.Title)
+		if key == "" || seen[key] {
+			return
+		}
+		seen[key] = true
+		out = append(out, a)
+	}
+
+	tmdbSearched := 0
+	for _, a := range tmdbAliases {
+		if a.Source != model.TitleAliasSourcePrimary {
+			continue
+		}
+		a.Searched = true
+		tmdbSearched++
+		add(a)
+	}
+
+	for _, c := range custom {
+		id := c.ID.String()
+		add(model.TitleAlias{
+			ID:       &id,
+			Title:    c.Title,
+			Source:   model.TitleAliasSourceCustom,
+			Excluded: c.Excluded,
+			Searched: !c.Excluded,
+		})
+	}
+
+	// Translations other than English and the original language rarely show up in release names
+	for _, a := range tmdbAliases {
+		if a.Source == model.TitleAliasSourcePrimary {
+			continue
+		}
+		if a.Source == model.TitleAliasSourceTranslation && a.Language != originalLanguage && a.Language != "en" {
+			continue
+		}
+		key := indexer.NormalizeTitle(a.Title)
+		if excluded[key] {
+			a.Excluded = true
+		} else if tmdbSearched < maxTitles && !seen[key] {
+			a.Searched = true
+			tmdbSearched++
+		}
+		add(a)
+	}
+
+	return out
+}
@@ -0,0 +1,166 @@
+package service
+
+import (
+	"reflect"
+	"testing"
+
+	dbgen "github.com/kyleaupton/arrflix/internal/db/sqlc"
+	"github.com/kyleaupton/arrflix/internal/model"
+)
+
+// describeAliases renders aliases as "Title (source)", with "*" for searched
+// and "-" for excluded ones, so failures read like the expected lists.
+func describeAliases(aliases []model.TitleAlias) []string {
+	out := make([]string, 0, len(aliases))
+	for _, a := range aliases {
+		s := a.Title + " (" + string(a.Source) + ")"
+		if a.Searched {
+			s += " *"
+		}
+		if a.Excluded {
+			s += " -"
+		}
+		out = append(out, s)
+	}
+	return out
+}
+
+func TestMergeTitleAliases(t *testing.T) {
+	primary := func(title string) model.TitleAlias {
+		return model.TitleAlias{Title: title, Source: model.TitleAliasSourcePrimary}
+	}
+	original := func(title, lang string) model.TitleAlias {
+		return model.TitleAlias{Title: title, Source: model.TitleAliasSourceOriginal, Language: lang}
+	}
+	alternative := func(title, country string) model.TitleAlias {
+		return model.TitleAlias{Title: title, Source: model.TitleAliasSourceAlternative, Country: country}
+	}
+	translation := func(title, lang string) model.TitleAlias {
+		return model.TitleAlias{Title: title, Source: model.TitleAliasSourceTranslation, Language: lang}
+	}
+	custom := func(title string, excluded bool) dbgen.MediaItemAlias {
+		return dbgen.MediaItemAlias{Title: title, Excluded: excluded}
+	}
+
+	tests := []struct {
+		name             string
+		tmdb             []model.TitleAlias
+		custom           []dbgen.MediaItemAlias
+		originalLanguage string
+		maxTitles        int
+		want             []string
+	}{
+		{
+			name:             "dedupes normalized titles",
+			tmdb:             []model.TitleAlias{primary("The Matrix"), original("The Matrix", "en"), alternative("the matrix", "US"), alternative("Matrix", "US")},
+			originalLanguage: "en",
+			maxTitles:        3,
+			want:             []string{"The Matrix (primary) *", "Matrix (alternative) *"},
+		},
+		{
+			name: "searches in preference order up to the limit, folding accents",
+			tmdb: []model.TitleAlias{
+				primary("Amélie"),
+				original("Le Fabuleux Destin d'Amélie Poulain", "fr"),
+				alternative("Amelie", "US"),
+				alternative("Amelie from Montmartre", "US"),
+				translation("Die fabelhafte Welt der Amélie", "de"),
+				translation("Amélie", "en"),
+				translation("Le fabuleux destin d'Amélie Poulain", "fr"),
+			},
+			originalLanguage: "fr",
+			maxTitles:        2,
+			want:             []string{"Amélie (primary) *", "Le Fabuleux Destin d'Amélie Poulain (original) *", "Amelie from Montmartre (alternative)"},
+		},
+		{
+			name: "keeps English and original language translations",
+			tmdb: []model.TitleAlias{
+				primary("Spirited Away"),
+				translation("El viaje de Chihiro", "es"),
+				translation("Sen to Chihiro no Kamikakushi", "ja"),
+				translation("Spirited Away: The Movie", "en"),
+			},
+			originalLanguage: "ja",
+			maxTitles:        3,
+			want:             []string{"Spirited Away (primary) *", "Sen to Chihiro no Kamikakushi (translation) *", "Spirited Away: The Movie (translation) *"},
+		},
+		{
+			name:             "custom aliases take precedence",
+			tmdb:             []model.TitleAlias{primary("Money Heist"), alternative("La Casa de Papel", "ES"), alternative("Haus des Geldes", "DE")},
+			custom:           []dbgen.MediaItemAlias{custom("La Casa de Papel", false), custom("Haus des Geldes", true)},
+			originalLanguage: "es",
+			maxTitles:        1,
+			want:             []string{"Money Heist (primary) *", "La Casa de Papel (custom) *", "Haus des Geldes (custom) -"},
+		},
+		{
+			name:             "excluded title is listed but not searched",
+			tmdb:             []model.TitleAlias{primary("Dark"), alternative("Dark Netflix", "US"), alternative("Dark (2017)", "US")},
+			custom:           []dbgen.MediaItemAlias{custom("dark.netflix", true)},
+			originalLanguage: "de",
+			maxTitles:        3,
+			want:             []string{"Dark (primary) *", "dark.netflix (custom) -", "Dark (2017) (alternative) *"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := describeAliases(mergeTitleAliases(tt.tmdb, tt.custom, tt.originalLanguage, tt.maxTitles))
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("mergeTitleAliases() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRegionalAliases(t *testing.T) {
+	aliases := []model.TitleAlias{
+		{Title: "Money Heist", Source: model.TitleAliasSourcePrimary},
+		{Title: "La Casa de Papel", Source: model.TitleAliasSourceAlternative, Country: "ES"},
+		{Title: "Money Heist (US)", Source: model.TitleAliasSourceAlternative, Country: "US"},
+		{Title: "La Maison de Papier", Source: model.TitleAliasSourceAlternative, Country: "FR"},
+		{Title: "Papel", Source: model.TitleAliasSourceAlternative},
+		{Title: "Haus des Geldes", Source: model.TitleAliasSourceTranslation, Country: "DE", Language: "de"},
+	}
+
+	tests := []struct {
+		name            string
+		region          string
+		originCountries []string
+		want            []string
+	}{
+		{"origin and region", "US", []string{"ES"}, []string{"Money Heist", "La Casa de Papel", "Money Heist (US)", "Papel", "Haus des Geldes"}},
+		{"origin only", "", []string{"ES"}, []string{"Money Heist", "La Casa de Papel", "Papel", "Haus des Geldes"}},
+		{"region elsewhere", "FR", nil, []string{"Money Heist", "La Maison de Papier", "Papel", "Haus des Geldes"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, a := range regionalAliases(aliases, tt.region, tt.originCountries) {
+				got = append(got, a.Title)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("regionalAliases() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMatchTitles(t *testing.T) {
+	aliases := []model.TitleAlias{
+		{Title: "Money Heist", Source: model.TitleAliasSourcePrimary},
+		{Title: "La Casa de Papel", Source: model.TitleAliasSourceOriginal},
+		{Title: "La casa de papel", Source: model.TitleAliasSourceAlternative, Country: "MX"},
+		{Title: "La Maison de Papier", Source: model.TitleAliasSourceAlternative, Country: "FR"},
+		{Title: "Haus des Geldes", Source: model.TitleAliasSourceTranslation, Language: "de"},
+	}
+	custom := []dbgen.MediaItemAlias{
+		{Title: "Casa de Papel"},
+		{Title: "Haus des Geldes", Excluded: true},
+	}
+
+	want := []string{"Money Heist", "La Casa de Papel", "La Maison de Papier", "Casa de Papel"}
+	if got := matchTitles(aliases, custom); !reflect.DeepEqual(got, want) {
+		t.Errorf("matchTitles() = %q, want %q", got, want)
+	}
+}
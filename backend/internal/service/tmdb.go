@@ -53,6 +53,14 @@ func (s *TmdbService) GetMovieDetails(ctx context.Context, id int64) (tmdb.Movie
 	}, STATIC_TTL)
 }
 
+// RefreshMovieDetails is GetMovieDetails bypassing the cache, which it updates.
+func (s *TmdbService) RefreshMovieDetails(ctx context.Context, id int64) (tmdb.MovieDetails, error) {
+	cacheKey := fmt.Sprintf("tmdb_movie_details_%d", id)
+	return fetchIntoCache(ctx, s.repo, s.logger, cacheKey, func() (*tmdb.MovieDetails, error) {
+		return s.client.GetMovieDetails(int(id), map[string]string{})
+	}, STATIC_TTL)
+}
+
 // GetMovieDetailsWithExtras fetches movie details with appended release dates and watch providers.
 // Uses DYNAMIC_TTL (1 hour) since watch providers can change frequently.
 func (s *TmdbService) GetMovieDetailsWithExtras(ctx context.Context, id int64) (tmdb.MovieDetails, error) {
@@ -64,6 +72,48 @@ func (s *TmdbService) GetMovieDetailsWithExtras(ctx context.Context, id int64) (
 	}, DYNAMIC_TTL)
 }
 
+func (s *TmdbService) GetMovieExternalIDs(ctx context.Context, id int64) (tmdb.MovieExternalIDs, error) {
+	cacheKey := fmt.Sprintf("tmdb_movie_external_ids_%d", id)
+	return getOrFetchFromCache(ctx, s.repo, s.logger, cacheKey, func() (*tmdb.MovieExternalIDs, error) {
+		return s.client.GetMovieExternalIDs(int(id), map[string]string{})
+	}, STATIC_TTL)
+}
+
+func (s *TmdbService) GetSeriesExternalIDs(ctx context.Context, id int64) (tmdb.TVExternalIDs, error) {
+	cacheKey := fmt.Sprintf("tmdb_series_external_ids_%d", id)
+	return getOrFetchFromCache(ctx, s.repo, s.logger, cacheKey, func() (*tmdb.TVExternalIDs, error) {
+		return s.client.GetTVExternalIDs(int(id), map[string]string{})
+	}, STATIC_TTL)
+}
+
+func (s *TmdbService) GetMovieAlternativeTitles(ctx context.Context, id int64) (tmdb.MovieAlternativeTitles, error) {
+	cacheKey := fmt.Sprintf("tmdb_movie_alternative_titles_%d", id)
+	return getOrFetchFromCache(ctx, s.repo, s.logger, cacheKey, func() (*tmdb.MovieAlternativeTitles, error) {
+		return s.client.GetMovieAlternativeTitles(int(id), map[string]string{})
+	}, STATIC_TTL)
+}
+
+func (s *TmdbService) GetMovieTranslations(ctx context.Context, id int64) (tmdb.MovieTranslations, error) {
+	cacheKey := fmt.Sprintf("tmdb_movie_translations_%d", id)
+	return getOrFetchFromCache(ctx, s.repo, s.logger, cacheKey, func() (*tmdb.MovieTranslations, error) {
+		return s.client.GetMovieTranslations(int(id), map[string]string{})
+	}, STATIC_TTL)
+}
+
+func (s *TmdbService) GetSeriesAlternativeTitles(ctx context.Context, id int64) (tmdb.TVAlternativeTitles, error) {
+	cacheKey := fmt.Sprintf("tmdb_series_alternative_titles_%d", id)
+	return getOrFetchFromCache(ctx, s.repo, s.logger, cacheKey, func() (*tmdb.TVAlternativeTitles, error) {
+		return s.client.GetTVAlternativeTitles(int(id), map[string]string{})
+	}, STATIC_TTL)
+}
+
+func (s *TmdbService) GetSeriesTranslations(ctx context.Context, id int64) (tmdb.TVTranslations, error) {
+	cacheKey := fmt.Sprintf("tmdb_series_translations_%d", id)
+	return getOrFetchFromCache(ctx, s.repo, s.logger, cacheKey, func() (*tmdb.TVTranslations, error) {
+		return s.client.GetTVTranslations(int(id), map[string]string{})
+	}, STATIC_TTL)
+}
+
 func (s *TmdbService) GetSeriesDetails(ctx context.Context, id int64) (tmdb.TVDetails, error) {
 	cacheKey := fmt.Sprintf("tmdb_series_details_%d", id)
 	return getOrFetchFromCache(ctx, s.repo, s.logger, cacheKey, func() (*tmdb.TVDetails, error) {
@@ -71,6 +121,14 @@ func (s *TmdbService) GetSeriesDetails(ctx context.Context, id int64) (tmdb.TVDe
 	}, STATIC_TTL)
 }
 
+// RefreshSeriesDetails is GetSeriesDetails bypassing the cache, which it updates.
+func (s *TmdbService) RefreshSeriesDetails(ctx context.Context, id int64) (tmdb.TVDetails, error) {
+	cacheKey := fmt.Sprintf("tmdb_series_details_%d", id)
+	return fetchIntoCache(ctx, s.repo, s.logger, cacheKey, func() (*tmdb.TVDetails, error) {
+		return s.client.GetTVDetails(int(id), map[string]string{})
+	}, STATIC_TTL)
+}
+
 // GetSeriesDetailsWithExtras fetches series details with appended content ratings and watch providers.
 // Uses DYNAMIC_TTL (1 hour) since watch providers can change frequently.
 func (s *TmdbService) GetSeriesDetailsWithExtras(ctx context.Context, id int64) (tmdb.TVDetails, error) {
@@ -89,6 +147,37 @@ func (s *TmdbService) GetTVSeasonDetails(ctx context.Context, id int64, seasonNu
 	}, STATIC_TTL)
 }
 
+// RefreshTVSeasonDetails is GetTVSeasonDetails bypassing the cache, which it updates.
+func (s *TmdbService) RefreshTVSeasonDetails(ctx context.Context, id int64, seasonNumber int) (tmdb.TVSeasonDetails, error) {
+	cacheKey := fmt.Sprintf("tmdb_tv_season_details_%d_%d", id, seasonNumber)
+	return fetchIntoCache(ctx, s.repo, s.logger, cacheKey, func() (*tmdb.TVSeasonDetails, error) {
+		return s.client.GetTVSeasonDetails(int(id), seasonNumber, map[string]string{})
+	}, STATIC_TTL)
+}
+
+// GetTVEpisodeExternalIDs returns the IMDb and TVDB IDs of an episode.
+func (s *TmdbService) GetTVEpisodeExternalIDs(ctx context.Context, id int64, seasonNumber, episodeNumber int) (tmdb.TVEpisodeExternalIDs, error) {
+	cacheKey := fmt.Sprintf("tmdb_tv_episode_external_ids_%d_%d_%d", id, seasonNumber, episodeNumber)
+	return getOrFetchFromCache(ctx, s.repo, s.logger, cacheKey, func() (*tmdb.TVEpisodeExternalIDs, error) {
+		return s.client.GetTVEpisodeExternalIDs(int(id), seasonNumber, episodeNumber)
+	}, STATIC_TTL)
+}
+
+// GetSeriesEpisodeGroups lists the alternative episode orderings of a series (absolute, DVD, story arc, ...).
+func (s *TmdbService) GetSeriesEpisodeGroups(ctx context.Context, id int64) (tmdb.TVEpisodeGroups, error) {
+	cacheKey := fmt.Sprintf("tmdb_series_episode_groups_%d", id)
+	return getOrFetchFromCache(ctx, s.repo, s.logger, cacheKey, func() (*tmdb.TVEpisodeGroups, error) {
+		return s.client.GetTVEpisodeGroups(int(id), map[string]string{})
+	}, STATIC_TTL)
+}
+
+func (s *TmdbService) GetEpisodeGroupDetails(ctx context.Context, groupID string) (tmdb.TVEpisodeGroupsDetails, error) {
+	cacheKey := fmt.Sprintf("tmdb_episode_group_details_%s", groupID)
+	return getOrFetchFromCache(ctx, s.repo, s.logger, cacheKey, func() (*tmdb.TVEpisodeGroupsDetails, error) {
+		return s.client.GetTVEpisodeGroupsDetails(groupID, map[string]string{})
+	}, STATIC_TTL)
+}
+
 func (s *TmdbService) GetEpisodeDetails(ctx context.Context, id int64, season int64, episode int64) (tmdb.TVEpisodeDetails, error) {
 	cacheKey := fmt.Sprintf("tmdb_episode_details_%d_%d_%d", id, season, episode)
 	return getOrFetchFromCache(ctx, s.repo, s.logger, cacheKey, func() (*tmdb.TVEpisodeDetails, error) {
@@ -217,6 +306,14 @@ func (s *TmdbService) GetMovieReleaseDates(ctx context.Context, id int64) (tmdb.
 	}, STATIC_TTL)
 }
 
+// RefreshMovieReleaseDates is GetMovieReleaseDates bypassing the cache, which it updates.
+func (s *TmdbService) RefreshMovieReleaseDates(ctx context.Context, id int64) (tmdb.MovieReleaseDates, error) {
+	cacheKey := fmt.Sprintf("tmdb_movie_release_dates_%d", id)
+	return fetchIntoCache(ctx, s.repo, s.logger, cacheKey, func() (*tmdb.MovieReleaseDates, error) {
+		return s.client.GetMovieReleaseDates(int(id))
+	}, STATIC_TTL)
+}
+
 func (s *TmdbService) GetTVContentRatings(ctx context.Context, id int64) (tmdb.TVContentRatings, error) {
 	cacheKey := fmt.Sprintf("tmdb_tv_content_ratings_%d", id)
 	return getOrFetchFromCache(ctx, s.repo, s.logger, cacheKey, func() (*tmdb.TVContentRatings, error) {
@@ -238,29 +335,7 @@ func getOrFetchFromCache[T any](ctx context.Context, r *repo.Repository, l *logg
 
 	if !found {
 		l.Debug().Str("cache_key", cacheKey).Msg("Cache miss, fetching from API")
-		res, err := fetch()
-		if err != nil {
-			var zero T
-			return zero, err
-		}
-
-		category := "tmdb"
-		contentType := "application/json"
-
-		// Convert the result to json to be stored in the cache
-		jsonRes, err := json.Marshal(res)
-		if err != nil {
-			var zero T
-			return zero, err
-		}
-
-		// Note: pass nil for headers so the DB receives NULL (valid for jsonb)
-		if err := r.UpsertApiCache(ctx, cacheKey, &category, jsonRes, 200, &contentType, nil, ttl); err != nil {
-			l.Error().Err(err).Str("cache_key", cacheKey).Msg("Failed upserting api cache")
-		}
-
-		// Return the result
-		return *res, nil
+		return fetchIntoCache(ctx, r, l, cacheKey, fetch, ttl)
 	}
 
 	var out T
@@ -271,3 +346,31 @@ func getOrFetchFromCache[T any](ctx context.Context, r *repo.Repository, l *logg
 	}
 	return out, nil
 }
+
+// fetchIntoCache calls the fetch function and stores the response in the
+// cache, replacing any cached entry.
+func fetchIntoCache[T any](ctx context.Context, r *repo.Repository, l *logger.Logger, cacheKey string, fetch func() (*T, error), ttl time.Duration) (T, error) {
+	res, err := fetch()
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+
+	category := "tmdb"
+	contentType := "application/json"
+
+	// Convert the result to json to be stored in the cache
+	jsonRes, err := json.Marshal(res)
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+
+	// Note: pass nil for headers so the DB receives NULL (valid for jsonb)
+	if err := r.UpsertApiCache(ctx, cacheKey, &category, jsonRes, 200, &contentType, nil, ttl); err != nil {
+		l.Error().Err(err).Str("cache_key", cacheKey).Msg("Failed upserting api cache")
+	}
+
+	// Return the result
+	return *res, nil
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 	dbgen "github.com/kyleaupton/arrflix/internal/db/sqlc"
 	"github.com/kyleaupton/arrflix/internal/logger"
+	"github.com/kyleaupton/arrflix/internal/qualityprofile"
 	"github.com/kyleaupton/arrflix/internal/repo"
 )
 
@@ -177,7 +178,7 @@ func (s *UnmatchedFilesService) Match(ctx context.Context, id pgtype.UUID, req M
 	}
 
 	// Create media file
-	mediaFile, err := s.repo.CreateMediaFile(ctx, unmatched.LibraryID, mediaItem.ID, episodeID, unmatched.Path)
+	mediaFile, err := s.repo.CreateMediaFile(ctx, unmatched.LibraryID, mediaItem.ID, episodeID, unmatched.Path, qualityprofile.FileQuality(unmatched.Path))
 	if err != nil {
 		return dbgen.MediaFile{}, fmt.Errorf("create media file: %w", err)
 	}
@@ -0,0 +1,253 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	dbgen "github.com/kyleaupton/arrflix/internal/db/sqlc"
+	"github.com/kyleaupton/arrflix/internal/logger"
+	"github.com/kyleaupton/arrflix/internal/model"
+	"github.com/kyleaupton/arrflix/internal/qualityprofile"
+	"github.com/kyleaupton/arrflix/internal/release"
+	"github.com/kyleaupton/arrflix/internal/repo"
+)
+
+var (
+	ErrWantedSearchInvalid = errors.New("each item needs a TMDB ID and a media type of movie or series, and series need a season")
+	ErrWantedSearchTooMany = fmt.Errorf("at most %d items can be searched at once", maxWantedSearchItems)
+)
+
+// maxWantedSearchItems caps the items of one bulk search.
+const maxWantedSearchItems = 500
+
+// WantedFilter narrows the wanted lists. Zero values don't filter, except
+// that unmonitored items are left out unless IncludeUnmonitored is set.
+type WantedFilter struct {
+	MediaType          *model.MediaType
+	LibraryID          pgtype.UUID
+	SeriesTmdbID       *int64
+	IncludeUnmonitored bool
+	Aired              *bool // missing only: aired (true) or not yet aired (false)
+}
+
+// WantedService lists movies and episodes that are missing their file or
+// whose file is below the quality profile's cutoff, and searches for them.
+type WantedService struct {
+	repo       *repo.Repository
+	logger     *logger.Logger
+	autoSearch *AutoSearchService
+}
+
+// NewWantedService creates a new wanted service
+func NewWantedService(r *repo.Repository, l *logger.Logger, autoSearch *AutoSearchService) *WantedService {
+	return &WantedService{repo: r, logger: l, autoSearch: autoSearch}
+}
+
+// Missing lists movies and episodes with no file on disk, most recently
+// aired first.
+func (s *WantedService) Missing(ctx context.Context, filter WantedFilter, page, pageSize int) (model.PaginatedWantedResponse, error) {
+	var mediaType *string
+	if filter.MediaType != nil {
+		t := string(*filter.MediaType)
+		mediaType = &t
+	}
+
+	total, err := s.repo.CountWantedMissing(ctx, dbgen.CountWantedMissingParams{
+		MediaType:          mediaType,
+		LibraryID:          filter.LibraryID,
+		SeriesTmdbID:       filter.SeriesTmdbID,
+		IncludeUnmonitored: filter.IncludeUnmonitored,
+		Aired:              filter.Aired,
+	})
+	if err != nil {
+		return model.PaginatedWantedResponse{}, fmt.Errorf("count missing: %w", err)
+	}
+	rows, err := s.repo.ListWantedMissing(ctx, dbgen.ListWantedMissingParams{
+		MediaType:          mediaType,
+		LibraryID:          filter.LibraryID,
+		SeriesTmdbID:       filter.SeriesTmdbID,
+		IncludeUnmonitored: filter.IncludeUnmonitored,
+		Aired:              filter.Aired,
+		PageSize:           int32(pageSize),
+		OffsetVal:          int32((page - 1) * pageSize),
+	})
+	if err != nil {
+		return model.PaginatedWantedResponse{}, fmt.Errorf("list missing: %w", err)
+	}
+
+	items := make([]model.WantedItem, 0, len(rows))
+	for _, row := range rows {
+		items = append(items, wantedItem(row))
+	}
+	return paginatedWanted(items, total, page, pageSize), nil
+}
+
+// Cutoff lists movies and episodes whose best file on disk is below the
+// cutoff of a quality profile that allows upgrades, by title.
+func (s *WantedService) Cutoff(ctx context.Context, filter WantedFilter, page, pageSize int) (model.PaginatedWantedResponse, error) {
+	var mediaType *string
+	if filter.MediaType != nil {
+		t := string(*filter.MediaType)
+		mediaType = &t
+	}
+	files, err := s.repo.ListWantedCutoffFiles(ctx, dbgen.ListWantedCutoffFilesParams{
+		MediaType:          mediaType,
+		LibraryID:          filter.LibraryID,
+		SeriesTmdbID:       filter.SeriesTmdbID,
+		IncludeUnmonitored: filter.IncludeUnmonitored,
+	})
+	if err != nil {
+		return model.PaginatedWantedResponse{}, fmt.Errorf("list files: %w", err)
+	}
+
+	// A movie or episode with several files has the quality of its best one.
+	// Profiles are loaded once each; the zero ID is the default profile.
+	type best struct {
+		row     dbgen.ListWantedCutoffFilesRow
+		quality release.Quality
+		profile *qualityprofile.Profile
+	}
+	profiles := make(map[pgtype.UUID]*qualityprofile.Profile)
+	var order []string
+	bests := make(map[string]*best)
+	for _, f := range files {
+		if f.TmdbID == nil {
+			continue
+		}
+		profile, ok := profiles[f.QualityProfileID]
+		if !ok {
+			profile = s.loadProfile(ctx, f.QualityProfileID)
+			profiles[f.QualityProfileID] = profile
+		}
+		if profile == nil || !profile.UpgradesAllowed {
+			continue
+		}
+
+		key := f.MediaItemID.String() + "/" + f.EpisodeID.String()
+		q := qualityprofile.ExistingQuality(f.Quality, f.Path)
+		b, ok := bests[key]
+		if !ok {
+			bests[key] = &best{row: f, quality: q, profile: profile}
+			order = append(order, key)
+			continue
+		}
+		if b.profile.Rank(q) > b.profile.Rank(b.quality) {
+			b.row, b.quality = f, q
+		}
+	}
+
+	var unmet []model.WantedItem
+	for _, key := range order {
+		b := bests[key]
+		if b.profile.MeetsCutoff(b.quality) {
+			continue
+		}
+		item := wantedItem(dbgen.ListWantedMissingRow{
+			MediaType:      b.row.MediaType,
+			MediaItemID:    b.row.MediaItemID,
+			EpisodeID:      b.row.EpisodeID,
+			TmdbID:         b.row.TmdbID,
+			Title:          b.row.Title,
+			Year:           b.row.Year,
+			LibraryID:      b.row.LibraryID,
+			SeasonNumber:   b.row.SeasonNumber,
+			EpisodeNumber:  b.row.EpisodeNumber,
+			EpisodeTitle:   b.row.EpisodeTitle,
+			AirDate:        b.row.AirDate,
+			Monitored:      b.row.Monitored,
+			LastSearchedAt: b.row.LastSearchedAt,
+		})
+		item.Quality = b.quality.String()
+		item.Cutoff = b.profile.Cutoff
+		unmet = append(unmet, item)
+	}
+
+	total := len(unmet)
+	start := min((page-1)*pageSize, total)
+	end := min(start+pageSize, total)
+	items := append([]model.WantedItem{}, unmet[start:end]...)
+	return paginatedWanted(items, int64(total), page, pageSize), nil
+}
+
+// loadProfile returns the quality profile with the given ID, or the default
+// profile for the zero ID. It returns nil when there is none.
+func (s *WantedService) loadProfile(ctx context.Context, id pgtype.UUID) *qualityprofile.Profile {
+	var row dbgen.QualityProfile
+	var err error
+	if id.Valid {
+		row, err = s.repo.GetQualityProfile(ctx, id)
+	} else {
+		row, err = s.repo.GetDefaultQualityProfile(ctx)
+	}
+	if err != nil {
+		s.logger.Warn().Err(err).Str("profile_id", id.String()).Msg("Failed to load quality profile")
+		return nil
+	}
+	profile, err := profileFromRow(row)
+	if err != nil {
+		s.logger.Warn().Err(err).Str("profile", row.Name).Msg("Failed to decode quality profile")
+		return nil
+	}
+	return &profile
+}
+
+// Search queues a search for each item, recorded as a manual automatic
+// search decision.
+func (s *WantedService) Search(items []model.WantedSearchItem) (model.WantedSearchQueued, error) {
+	if len(items) > maxWantedSearchItems {
+		return model.WantedSearchQueued{}, ErrWantedSearchTooMany
+	}
+	for _, item := range items {
+		if item.TmdbID <= 0 {
+			return model.WantedSearchQueued{}, ErrWantedSearchInvalid
+		}
+		switch item.MediaType {
+		case model.MediaTypeMovie:
+		case model.MediaTypeSeries:
+			if item.Season == nil {
+				return model.WantedSearchQueued{}, ErrWantedSearchInvalid
+			}
+		default:
+			return model.WantedSearchQueued{}, ErrWantedSearchInvalid
+		}
+	}
+	return model.WantedSearchQueued{Queued: s.autoSearch.Queue(items)}, nil
+}
+
+func wantedItem(row dbgen.ListWantedMissingRow) model.WantedItem {
+	item := model.WantedItem{
+		MediaItemID:    row.MediaItemID.String(),
+		MediaType:      model.MediaType(row.MediaType),
+		TmdbID:         derefInt64(row.TmdbID),
+		Title:          row.Title,
+		Year:           int32ToIntPtr(row.Year),
+		Season:         int32ToIntPtr(row.SeasonNumber),
+		Episode:        int32ToIntPtr(row.EpisodeNumber),
+		EpisodeTitle:   coalesce(row.EpisodeTitle, ""),
+		Monitored:      row.Monitored,
+		LastSearchedAt: timestamptzPtr(row.LastSearchedAt),
+	}
+	if row.EpisodeID.Valid {
+		item.EpisodeID = row.EpisodeID.String()
+	}
+	if row.AirDate.Valid {
+		item.AirDate = row.AirDate.Time.Format(time.DateOnly)
+	}
+	return item
+}
+
+func paginatedWanted(items []model.WantedItem, total int64, page, pageSize int) model.PaginatedWantedResponse {
+	return model.PaginatedWantedResponse{
+		Data: items,
+		Pagination: model.Pagination{
+			Total:      total,
+			Page:       page,
+			PageSize:   pageSize,
+			TotalPages: int(math.Ceil(float64(total) / float64(pageSize))),
+		},
+	}
+}
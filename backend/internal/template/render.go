@@ -15,6 +15,7 @@ func Render(tmplStr string, data any) (string, error) {
 	funcMap := template.FuncMap{
 		"sanitize": sanitizeValue,
 		"clean":    cleanQualityValue,
+		"join":     joinValues,
 	}
 
 	t, err := template.New("naming").Funcs(funcMap).Parse(tmplStr)
@@ -62,3 +63,15 @@ func cleanQualityValue(v any) string {
 	}
 	return sanitizeValue(s)
 }
+
+// joinValues joins a list (e.g. .Release.Languages) with sep, dropping
+// "Unknown" and empty entries, and sanitizes the result.
+func joinValues(values []string, sep string) string {
+	kept := make([]string, 0, len(values))
+	for _, v := range values {
+		if cleanQualityValue(v) != "" {
+			kept = append(kept, v)
+		}
+	}
+	return sanitizeValue(strings.Join(kept, sep))
+}
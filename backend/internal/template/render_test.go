@@ -19,6 +19,10 @@ func TestRender(t *testing.T) {
 	ctx := map[string]any{
 		"Media":   media,
 		"Quality": result.Quality,
+		"Release": map[string]any{
+			"Languages": []string{"French", "English"},
+			"Unknown":   []string{"Unknown"},
+		},
 	}
 
 	tests := []struct {
@@ -56,6 +60,16 @@ func TestRender(t *testing.T) {
 			template: "{{.Media.CleanTitle}} ({{.Media.Year}}) [{{.Quality.Full}}]",
 			want:     "21 Jump Street (2012) [Bluray-2160p Remux]",
 		},
+		{
+			name:     "With joined languages",
+			template: "{{.Media.CleanTitle}} [{{join .Release.Languages \"+\"}}]",
+			want:     "21 Jump Street [French+English]",
+		},
+		{
+			name:     "Join drops unknown",
+			template: "{{join .Release.Unknown \"+\"}}",
+			want:     "",
+		},
 	}
 
 	for _, tt := range tests {
@@ -699,6 +699,12 @@ export type ModelDownloadCandidate = {
     title: string;
 };
 
+export type ModelDownloadCandidatesResponse = {
+    candidates?: Array<ModelDownloadCandidate>;
+    session?: ModelSearchSession;
+    sources?: Array<ModelSearchSourceReport>;
+};
+
 export type ModelEnumValue = {
     label: string;
     value: string;
@@ -1094,6 +1100,25 @@ export type ModelRuleInfo = {
     rightResolvedValue?: unknown;
 };
 
+export type ModelSearchSession = {
+    createdAt?: string;
+    episode?: number;
+    expiresAt?: string;
+    id?: string;
+    mediaType?: ModelMediaType;
+    query?: string;
+    resultCount?: number;
+    season?: number;
+    tmdbId?: number;
+};
+
+export type ModelSearchSourceReport = {
+    durationMs?: number;
+    error?: string;
+    name?: string;
+    resultCount?: number;
+};
+
 export type ModelSearchResponse = {
     query: string;
     results: Array<ModelSearchResult>;
@@ -2781,7 +2806,7 @@ export type GetV1MovieByIdCandidatesResponses = {
     /**
      * OK
      */
-    200: Array<ModelDownloadCandidate>;
+    200: ModelDownloadCandidatesResponse;
 };
 
 export type GetV1MovieByIdCandidatesResponse = GetV1MovieByIdCandidatesResponses[keyof GetV1MovieByIdCandidatesResponses];
@@ -3593,7 +3618,7 @@ export type GetV1SeriesByIdCandidatesResponses = {
     /**
      * OK
      */
-    200: Array<ModelDownloadCandidate>;
+    200: ModelDownloadCandidatesResponse;
 };
 
 export type GetV1SeriesByIdCandidatesResponse = GetV1SeriesByIdCandidatesResponses[keyof GetV1SeriesByIdCandidatesResponses];